@@ -1,6 +1,7 @@
 package ast
 
 type Node interface {
+	Spanned
 	TokenLiteral() string
 }
 
@@ -16,30 +17,35 @@ type Expression interface {
 
 // VariableDeclaration est une instruction de type const/let/var
 type VariableDeclaration struct {
+	Span
 	IsConst bool
+	IsVar   bool // var : portée de la fonction, hissée en tête ; let sinon
 	Name    string
 	Type    string
 	Value   Expression
 }
 
-func (vd *VariableDeclaration) statementNode()      {}
+func (vd *VariableDeclaration) statementNode()       {}
 func (vd *VariableDeclaration) TokenLiteral() string { return "var" }
 
 type StringLiteral struct {
+	Span
 	Value string
 }
 
-func (s *StringLiteral) expressionNode() {}
+func (s *StringLiteral) expressionNode()      {}
 func (s *StringLiteral) TokenLiteral() string { return s.Value }
 
 type NumberLiteral struct {
+	Span
 	Value string
 }
 
-func (n *NumberLiteral) expressionNode() {}
+func (n *NumberLiteral) expressionNode()      {}
 func (n *NumberLiteral) TokenLiteral() string { return n.Value }
 
 type BooleanLiteral struct {
+	Span
 	Value bool
 }
 
@@ -53,198 +59,600 @@ func (b *BooleanLiteral) TokenLiteral() string {
 
 // TypeAlias pour les alias de types comme type TaskStatus = 'pending' | 'in_progress' | 'done'
 type TypeAlias struct {
-	Name string
-	Type string
+	Span
+	Name           string
+	TypeParameters []TypeParameter
+	Type           string
+	Literals       []string // valeurs d'une union de littéraux chaîne, nil sinon
 }
 
-func (ta *TypeAlias) statementNode() {}
+func (ta *TypeAlias) statementNode()       {}
 func (ta *TypeAlias) TokenLiteral() string { return "type" }
 
 // Interface pour les interfaces TypeScript
 type Interface struct {
-	Name   string
-	Fields []InterfaceField
+	Span
+	Name           string
+	TypeParameters []TypeParameter
+	Extends        []string // interface Pet extends Animal, Named
+	Fields         []InterfaceField
 }
 
-func (i *Interface) statementNode() {}
+func (i *Interface) statementNode()       {}
 func (i *Interface) TokenLiteral() string { return "interface" }
 
+// InterfaceField est une propriété (name?: T) ou une signature de méthode
+// (find<K>(id: K): T), auquel cas Type est vide
 type InterfaceField struct {
-	Name string
-	Type string
+	Span
+	Name     string
+	Type     string
+	Optional bool
+
+	IsMethod       bool
+	TypeParameters []TypeParameter
+	Parameters     []Parameter
+	ReturnType     string
 }
 
-// ClassDeclaration pour les classes
-type ClassDeclaration struct {
+func (f *InterfaceField) TokenLiteral() string { return f.Name }
+
+// TypeParameter pour <T extends Base = Default>
+type TypeParameter struct {
+	Span
+	Name       string
+	Constraint string // type après extends, vide si absent
+	Default    string // type par défaut, vide si absent
+}
+
+func (tp *TypeParameter) TokenLiteral() string { return tp.Name }
+
+// EnumDeclaration pour enum Status { Pending, Done = "done" } et const enum
+type EnumDeclaration struct {
+	Span
 	Name    string
-	Fields  []ClassField
-	Methods []ClassMethod
+	IsConst bool // const enum : les accès aux membres sont remplacés par leur valeur
+	Members []EnumMember
+}
+
+func (ed *EnumDeclaration) statementNode()       {}
+func (ed *EnumDeclaration) TokenLiteral() string { return "enum" }
+
+type EnumMember struct {
+	Span
+	Name  string
+	Value Expression // nil si la valeur est auto-incrémentée
 }
 
-func (cd *ClassDeclaration) statementNode() {}
+func (em *EnumMember) TokenLiteral() string { return em.Name }
+
+// Decorator représente un décorateur : @log, @core.Component, @Injectable({ ... })
+type Decorator struct {
+	Span
+	Name      string // nom, éventuellement qualifié : core.Component
+	IsCall    bool   // @Injectable() est appelé, @log ne l'est pas
+	Arguments []Expression
+}
+
+func (d *Decorator) TokenLiteral() string { return "@" }
+
+// ClassDeclaration pour les classes
+type ClassDeclaration struct {
+	Span
+	Name           string
+	Decorators     []Decorator
+	TypeParameters []TypeParameter
+	IsAbstract     bool     // abstract class Shape
+	SuperClass     string   // type après extends, avec ses arguments : Base<T>
+	Implements     []string // interfaces après implements
+	Fields         []ClassField
+	Methods        []ClassMethod
+	StaticBlocks   []StaticBlock
+}
+
+func (cd *ClassDeclaration) statementNode()       {}
 func (cd *ClassDeclaration) TokenLiteral() string { return "class" }
 
 type ClassField struct {
-	Name       string
-	Type       string
-	IsPrivate  bool
-	IsStatic   bool
-	HasDefault bool
-	Default    Expression
+	Span
+	Name          string
+	Decorators    []Decorator
+	Type          string
+	IsPrivate     bool
+	IsPrivateName bool // #secret : Name est sans #, IsPrivate est aussi vrai
+	IsProtected   bool // protected : visible des sous-classes
+	IsStatic      bool
+	IsReadonly    bool // readonly : affecté à la déclaration ou dans le constructeur
+	HasDefault    bool
+	Default       Expression
 }
 
+func (cf *ClassField) TokenLiteral() string { return cf.Name }
+
+// StaticBlock pour static { ... }, exécuté à la définition de la classe
+type StaticBlock struct {
+	Span
+	Body []Statement
+}
+
+func (sb *StaticBlock) TokenLiteral() string { return "static" }
+
+// MethodKind distingue les méthodes, les accesseurs get/set et le constructeur
+type MethodKind int
+
+const (
+	RegularMethod MethodKind = iota
+	Getter                   // get total(): number { ... }
+	Setter                   // set total(value: number) { ... }
+	Constructor
+)
+
 type ClassMethod struct {
+	Span
+	Name           string
+	Decorators     []Decorator
+	Kind           MethodKind
+	TypeParameters []TypeParameter
+	Parameters     []Parameter
+	ReturnType     string
+	IsAsync        bool
+	IsGenerator    bool // *entries() { ... }
+	IsAbstract     bool // abstract area(): number; sans corps
+	IsPrivate      bool
+	IsPrivateName  bool // #helper() : Name est sans #, IsPrivate est aussi vrai
+	IsProtected    bool // protected : visible des sous-classes
+	IsStatic       bool
+	Body           []Statement
+}
+
+func (cm *ClassMethod) TokenLiteral() string { return cm.Name }
+
+type Parameter struct {
+	Span
 	Name       string
-	Parameters []Parameter
-	ReturnType string
-	IsAsync    bool
-	IsPrivate  bool
-	Body       []Statement
+	Decorators []Decorator // @Inject(TOKEN) repo: Repo
+	Type       string
+	IsRest     bool       // ...args: number[]
+	Optional   bool       // x?: T
+	Default    Expression // pas: number = 1
+
+	// Propriété de paramètre : constructor(private readonly repo: Repo)
+	// déclare et initialise implicitement le champ repo
+	IsProperty  bool
+	IsPrivate   bool
+	IsProtected bool
+	IsReadonly  bool
 }
 
-type Parameter struct {
-	Name string
-	Type string
+func (p *Parameter) TokenLiteral() string { return p.Name }
+
+// CanBeOmitted indique si l'argument peut être omis à l'appel
+func (p Parameter) CanBeOmitted() bool {
+	return p.Optional || p.Default != nil
 }
 
 // FunctionDeclaration pour les fonctions
 type FunctionDeclaration struct {
-	Name       string
-	Parameters []Parameter
-	ReturnType string
-	IsAsync    bool
-	Body       []Statement
+	Span
+	Name           string
+	TypeParameters []TypeParameter
+	Parameters     []Parameter
+	ReturnType     string
+	IsAsync        bool
+	IsGenerator    bool        // function* range() { ... }
+	Body           []Statement // nil pour une signature sans corps
+
+	// Signatures de surcharge qui précèdent l'implémentation :
+	// function f(x: string): string; function f(x: number): number;
+	Overloads []*FunctionDeclaration
 }
 
-func (fd *FunctionDeclaration) statementNode() {}
+func (fd *FunctionDeclaration) statementNode()       {}
 func (fd *FunctionDeclaration) TokenLiteral() string { return "function" }
 
 // ArrayLiteral pour les tableaux
 type ArrayLiteral struct {
+	Span
 	Elements []Expression
 }
 
-func (al *ArrayLiteral) expressionNode() {}
+func (al *ArrayLiteral) expressionNode()      {}
 func (al *ArrayLiteral) TokenLiteral() string { return "[" }
 
 // ObjectLiteral pour les objets
 type ObjectLiteral struct {
+	Span
 	Properties []ObjectProperty
 }
 
-func (ol *ObjectLiteral) expressionNode() {}
+func (ol *ObjectLiteral) expressionNode()      {}
 func (ol *ObjectLiteral) TokenLiteral() string { return "{" }
 
+// ObjectProperty représente une paire clé/valeur. Pour un spread ({ ...other })
+// la clé est vide et Value est un *SpreadElement.
 type ObjectProperty struct {
+	Span
 	Key   string
 	Value Expression
 }
 
+func (op *ObjectProperty) TokenLiteral() string { return op.Key }
+
 // Statements pour le contrôle de flux
 type IfStatement struct {
-	Condition Expression
+	Span
+	Condition  Expression
 	ThenBranch Statement
 	ElseBranch Statement
 }
 
-func (is *IfStatement) statementNode() {}
+func (is *IfStatement) statementNode()       {}
 func (is *IfStatement) TokenLiteral() string { return "if" }
 
 type ForStatement struct {
+	Span
 	Init      Statement
 	Condition Expression
 	Update    Statement
 	Body      Statement
 }
 
-func (fs *ForStatement) statementNode() {}
+func (fs *ForStatement) statementNode()       {}
 func (fs *ForStatement) TokenLiteral() string { return "for" }
 
 type WhileStatement struct {
+	Span
 	Condition Expression
 	Body      Statement
 }
 
-func (ws *WhileStatement) statementNode() {}
+func (ws *WhileStatement) statementNode()       {}
 func (ws *WhileStatement) TokenLiteral() string { return "while" }
 
 type BlockStatement struct {
+	Span
 	Statements []Statement
 }
 
-func (bs *BlockStatement) statementNode() {}
+func (bs *BlockStatement) statementNode()       {}
 func (bs *BlockStatement) TokenLiteral() string { return "{" }
 
 type ExpressionStatement struct {
+	Span
 	Expression Expression
 }
 
-func (es *ExpressionStatement) statementNode() {}
+func (es *ExpressionStatement) statementNode()       {}
 func (es *ExpressionStatement) TokenLiteral() string { return es.Expression.TokenLiteral() }
 
 type ReturnStatement struct {
+	Span
 	Value Expression
 }
 
-func (rs *ReturnStatement) statementNode() {}
+func (rs *ReturnStatement) statementNode()       {}
 func (rs *ReturnStatement) TokenLiteral() string { return "return" }
 
 // Expressions
 type Identifier struct {
+	Span
 	Value string
 }
 
-func (i *Identifier) expressionNode() {}
+func (i *Identifier) expressionNode()      {}
 func (i *Identifier) TokenLiteral() string { return i.Value }
 
 type CallExpression struct {
-	Function  Expression
-	Arguments []Expression
+	Span
+	Function      Expression
+	TypeArguments []string // identity<number>(5)
+	Arguments     []Expression
 }
 
-func (ce *CallExpression) expressionNode() {}
+func (ce *CallExpression) expressionNode()      {}
 func (ce *CallExpression) TokenLiteral() string { return "(" }
 
+// NewExpression pour new Box<string>(value)
+type NewExpression struct {
+	Span
+	Class         Expression
+	TypeArguments []string
+	Arguments     []Expression
+}
+
+func (ne *NewExpression) expressionNode()      {}
+func (ne *NewExpression) TokenLiteral() string { return "new" }
+
 type InfixExpression struct {
+	Span
 	Left     Expression
 	Operator string
 	Right    Expression
 }
 
-func (ie *InfixExpression) expressionNode() {}
+func (ie *InfixExpression) expressionNode()      {}
 func (ie *InfixExpression) TokenLiteral() string { return ie.Operator }
 
+// precedences donne la priorité des opérateurs binaires, du plus lâche au
+// plus serré
+var precedences = map[string]int{
+	"||": 1, "??": 1,
+	"&&": 2,
+	"|":  3, "^": 4, "&": 5,
+	"==": 6, "!=": 6, "===": 6, "!==": 6,
+	"<": 7, ">": 7, "<=": 7, ">=": 7, "instanceof": 7, "in": 7,
+	"<<": 8, ">>": 8, ">>>": 8,
+	"+": 9, "-": 9,
+	"*": 10, "/": 10, "%": 10,
+	"**": 11,
+}
+
+// Precedence renvoie la priorité d'un opérateur binaire, 0 pour un opérateur
+// qui n'en est pas un : a + b * c se lit a + (b * c)
+func Precedence(operator string) int {
+	return precedences[operator]
+}
+
+// ParenthesizedExpression pour une expression entre parenthèses : (a + b) * 2.
+// Autour d'une assertion ou d'un élément JSX, (value as Shape).area(), les
+// parenthèses ne sont pas gardées.
+type ParenthesizedExpression struct {
+	Span
+	Expression Expression
+}
+
+func (pe *ParenthesizedExpression) expressionNode()      {}
+func (pe *ParenthesizedExpression) TokenLiteral() string { return "(" }
+
 // Template literals pour les backticks
 type TemplateLiteral struct {
+	Span
 	Parts []Expression // Alternance de texte et expressions
 }
 
-func (tl *TemplateLiteral) expressionNode() {}
+func (tl *TemplateLiteral) expressionNode()      {}
 func (tl *TemplateLiteral) TokenLiteral() string { return "`" }
 
 // Index access pour arr[0] ou obj.prop
 type IndexExpression struct {
+	Span
 	Left  Expression
 	Index Expression
 }
 
-func (ie *IndexExpression) expressionNode() {}
+func (ie *IndexExpression) expressionNode()      {}
 func (ie *IndexExpression) TokenLiteral() string { return "[" }
 
 type DotExpression struct {
+	Span
 	Object   Expression
 	Property string
 }
 
-func (de *DotExpression) expressionNode() {}
+func (de *DotExpression) expressionNode()      {}
 func (de *DotExpression) TokenLiteral() string { return "." }
 
 // Assignment pour depart--
 type AssignmentExpression struct {
+	Span
 	Left     Expression
 	Operator string // =, +=, -=, ++, --
 	Right    Expression
 }
 
-func (ae *AssignmentExpression) expressionNode() {}
+func (ae *AssignmentExpression) expressionNode()      {}
 func (ae *AssignmentExpression) TokenLiteral() string { return ae.Operator }
+
+// SpreadElement pour ...expr dans les tableaux, objets et arguments d'appel
+type SpreadElement struct {
+	Span
+	Argument Expression
+}
+
+func (se *SpreadElement) expressionNode()      {}
+func (se *SpreadElement) TokenLiteral() string { return "..." }
+
+// ImportSpecifier est un nom importé ou exporté : { a as b, type T }
+type ImportSpecifier struct {
+	Span
+	Name   string // nom dans le module d'origine
+	Alias  string // nom local (ou exporté), vide si identique
+	IsType bool   // { type T } : effacé à la compilation
+}
+
+func (is *ImportSpecifier) TokenLiteral() string { return is.Name }
+
+// LocalName renvoie le nom sous lequel le symbole est visible
+func (is ImportSpecifier) LocalName() string {
+	if is.Alias != "" {
+		return is.Alias
+	}
+	return is.Name
+}
+
+// ImportDeclaration pour import d, { a as b } from "./m", import * as ns from "./m"
+// et import "./m"
+type ImportDeclaration struct {
+	Span
+	Source     string
+	Default    string // import d from "./m"
+	Namespace  string // import * as ns from "./m"
+	Specifiers []ImportSpecifier
+	IsTypeOnly bool // import type { T } from "./m"
+}
+
+func (id *ImportDeclaration) statementNode()       {}
+func (id *ImportDeclaration) TokenLiteral() string { return "import" }
+
+// ExportDeclaration couvre les formes d'export :
+//
+//	export <déclaration>            Declaration
+//	export default <décl. ou expr.> IsDefault, Declaration (ExpressionStatement pour une expression)
+//	export { a as b } [from "./m"]  Specifiers, Source
+//	export * [as ns] from "./m"     IsWildcard, Namespace, Source
+type ExportDeclaration struct {
+	Span
+	Declaration Statement
+	IsDefault   bool
+	Specifiers  []ImportSpecifier
+	IsWildcard  bool
+	Namespace   string
+	Source      string // ré-export, vide sinon
+	IsTypeOnly  bool   // export type { T }
+}
+
+func (ed *ExportDeclaration) statementNode()       {}
+func (ed *ExportDeclaration) TokenLiteral() string { return "export" }
+
+// NamespaceDeclaration pour namespace Geometry { ... } ; namespace A.B { }
+// devient deux namespaces imbriqués. Pour declare module "x" { ... },
+// IsModule est vrai et Name est le nom du module ; declare global { ... }
+// s'appelle global.
+type NamespaceDeclaration struct {
+	Span
+	Name     string
+	Body     []Statement // nil pour declare module "x";
+	IsModule bool
+}
+
+func (nd *NamespaceDeclaration) statementNode()       {}
+func (nd *NamespaceDeclaration) TokenLiteral() string { return "namespace" }
+
+// AmbientDeclaration pour declare const VERSION: string, declare function
+// f(): void, declare module "x" { ... } : la déclaration ne décrit que des
+// types, son implémentation existe ailleurs
+type AmbientDeclaration struct {
+	Span
+	Declaration Statement
+}
+
+func (ad *AmbientDeclaration) statementNode()       {}
+func (ad *AmbientDeclaration) TokenLiteral() string { return "declare" }
+
+// AwaitExpression pour await promesse
+type AwaitExpression struct {
+	Span
+	Argument Expression
+}
+
+func (ae *AwaitExpression) expressionNode()      {}
+func (ae *AwaitExpression) TokenLiteral() string { return "await" }
+
+// AsExpression pour les assertions de type valeur as Type et <Type>valeur ;
+// Type vaut "const" pour as const
+type AsExpression struct {
+	Span
+	Expression   Expression
+	Type         string
+	AngleBracket bool // écrite <Type>valeur
+}
+
+func (ae *AsExpression) expressionNode()      {}
+func (ae *AsExpression) TokenLiteral() string { return "as" }
+
+// NonNullExpression pour l'assertion non nulle valeur!
+type NonNullExpression struct {
+	Span
+	Expression Expression
+}
+
+func (ne *NonNullExpression) expressionNode()      {}
+func (ne *NonNullExpression) TokenLiteral() string { return "!" }
+
+// SatisfiesExpression pour valeur satisfies Type : le type est vérifié sans
+// changer celui de la valeur
+type SatisfiesExpression struct {
+	Span
+	Expression Expression
+	Type       string
+}
+
+func (se *SatisfiesExpression) expressionNode()      {}
+func (se *SatisfiesExpression) TokenLiteral() string { return "satisfies" }
+
+// ArrowFunction pour (a: number): number => a * 2 et async x => { ... } ;
+// le corps est soit une expression, soit un bloc
+type ArrowFunction struct {
+	Span
+	Parameters     []Parameter
+	ReturnType     string
+	IsAsync        bool
+	ExpressionBody Expression  // x => x * 2
+	Body           []Statement // x => { return x * 2 }
+}
+
+func (af *ArrowFunction) expressionNode()      {}
+func (af *ArrowFunction) TokenLiteral() string { return "=>" }
+
+// ClassExpression pour const Point = class { ... } ; le nom de la classe
+// est vide quand elle est anonyme
+type ClassExpression struct {
+	Span
+	Class *ClassDeclaration
+}
+
+func (ce *ClassExpression) expressionNode()      {}
+func (ce *ClassExpression) TokenLiteral() string { return "class" }
+
+// YieldExpression pour yield valeur et yield* itérable (Delegate)
+type YieldExpression struct {
+	Span
+	Argument Expression // nil pour un yield sans valeur
+	Delegate bool
+}
+
+func (ye *YieldExpression) expressionNode()      {}
+func (ye *YieldExpression) TokenLiteral() string { return "yield" }
+
+// JSXElement pour un élément JSX <Button kind="primary">Envoyer</Button> ;
+// un nom en minuscules désigne une balise HTML, les autres un composant
+type JSXElement struct {
+	Span
+	Name        string // div, Button, Menu.Item
+	Attributes  []JSXAttribute
+	Children    []Expression // JSXText, JSXElement, JSXFragment, JSXExpressionContainer
+	SelfClosing bool         // <br />
+}
+
+func (je *JSXElement) expressionNode()      {}
+func (je *JSXElement) TokenLiteral() string { return "<" + je.Name + ">" }
+
+// JSXAttribute pour name="valeur", name={expression}, name seul (true) et
+// l'attribut étalé {...props}
+type JSXAttribute struct {
+	Span
+	Name     string
+	Value    Expression // StringLiteral ou JSXExpressionContainer ; nil pour un attribut seul
+	IsSpread bool       // {...props} : Value est l'objet étalé
+}
+
+func (ja *JSXAttribute) TokenLiteral() string { return ja.Name }
+
+// JSXFragment pour <>enfants</>
+type JSXFragment struct {
+	Span
+	Children []Expression
+}
+
+func (jf *JSXFragment) expressionNode()      {}
+func (jf *JSXFragment) TokenLiteral() string { return "<>" }
+
+// JSXExpressionContainer pour une expression {valeur} entre balises ou en
+// valeur d'attribut
+type JSXExpressionContainer struct {
+	Span
+	Expression Expression
+}
+
+func (jc *JSXExpressionContainer) expressionNode()      {}
+func (jc *JSXExpressionContainer) TokenLiteral() string { return "{" }
+
+// JSXText pour le texte entre deux balises, blancs déjà normalisés comme le
+// fait React : les retours à la ligne et l'indentation disparaissent
+type JSXText struct {
+	Span
+	Value string
+}
+
+func (jt *JSXText) expressionNode()      {}
+func (jt *JSXText) TokenLiteral() string { return jt.Value }
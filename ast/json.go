@@ -0,0 +1,310 @@
+package ast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSONVersion est la version du format JSON de l'arbre. Elle change dès qu'un
+// nœud ou un champ est renommé ou retiré ; un ajout de champ la garde.
+const JSONVersion = 2
+
+// Chaque nœud s'écrit comme un objet dont le champ kind donne le type Go
+// (VariableDeclaration, ClassField...), suivi de son étendue et de ses champs
+// en camelCase ; les champs à leur valeur zéro sont omis.
+var nodeKinds = map[string]reflect.Type{}
+
+func init() {
+	for _, node := range []any{
+		Program{}, Comment{},
+		VariableDeclaration{}, StringLiteral{}, NumberLiteral{}, BooleanLiteral{},
+		TypeAlias{}, Interface{}, InterfaceField{}, TypeParameter{},
+		EnumDeclaration{}, EnumMember{}, Decorator{},
+		ClassDeclaration{}, ClassField{}, StaticBlock{}, ClassMethod{}, Parameter{}, FunctionDeclaration{},
+		ArrayLiteral{}, ObjectLiteral{}, ObjectProperty{},
+		IfStatement{}, ForStatement{}, WhileStatement{}, BlockStatement{}, ExpressionStatement{}, ReturnStatement{},
+		Identifier{}, CallExpression{}, NewExpression{}, InfixExpression{}, ParenthesizedExpression{}, TemplateLiteral{},
+		IndexExpression{}, DotExpression{}, AssignmentExpression{}, SpreadElement{},
+		ImportSpecifier{}, ImportDeclaration{}, ExportDeclaration{}, NamespaceDeclaration{}, AmbientDeclaration{},
+		AwaitExpression{}, AsExpression{}, NonNullExpression{}, SatisfiesExpression{},
+		ArrowFunction{}, ClassExpression{}, YieldExpression{},
+		JSXElement{}, JSXAttribute{}, JSXFragment{}, JSXExpressionContainer{}, JSXText{},
+	} {
+		t := reflect.TypeOf(node)
+		nodeKinds[t.Name()] = t
+	}
+}
+
+var methodKindNames = []string{"method", "getter", "setter", "constructor"}
+
+// requiredFields liste les enfants qu'un nœud a toujours : absents ou null, le
+// JSON est refusé plutôt que de laisser un arbre que personne ne sait parcourir
+var requiredFields = map[string][]string{
+	"ExpressionStatement":     {"Expression"},
+	"IfStatement":             {"Condition", "ThenBranch"},
+	"ForStatement":            {"Body"},
+	"WhileStatement":          {"Condition", "Body"},
+	"AmbientDeclaration":      {"Declaration"},
+	"CallExpression":          {"Function"},
+	"NewExpression":           {"Class"},
+	"InfixExpression":         {"Left"},
+	"ParenthesizedExpression": {"Expression"},
+	"IndexExpression":         {"Left", "Index"},
+	"DotExpression":           {"Object"},
+	"AssignmentExpression":    {"Left"},
+	"SpreadElement":           {"Argument"},
+	"AwaitExpression":         {"Argument"},
+	"AsExpression":            {"Expression"},
+	"NonNullExpression":       {"Expression"},
+	"SatisfiesExpression":     {"Expression"},
+	"ClassExpression":         {"Class"},
+}
+
+var (
+	spanType       = reflect.TypeOf(Span{})
+	methodKindType = reflect.TypeOf(MethodKind(0))
+	nodeType       = reflect.TypeOf((*Node)(nil)).Elem()
+)
+
+// EncodeJSON écrit un programme dans le format JSON versionné, indenté
+func EncodeJSON(program *Program) ([]byte, error) {
+	envelope := jsonObject{
+		{"version", JSONVersion},
+		{"program", encodeValue(reflect.ValueOf(program))},
+	}
+	return json.MarshalIndent(envelope, "", "  ")
+}
+
+// DecodeJSON reconstruit l'arbre typé écrit par EncodeJSON ; la table des
+// lignes est recalculée depuis le source
+func DecodeJSON(data []byte) (*Program, error) {
+	var envelope struct {
+		Version int             `json:"version"`
+		Program json.RawMessage `json:"program"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, err
+	}
+	if envelope.Version != JSONVersion {
+		return nil, fmt.Errorf("ast : version JSON %d non prise en charge (attendue : %d)", envelope.Version, JSONVersion)
+	}
+
+	program := &Program{}
+	if err := decodeStruct(envelope.Program, reflect.ValueOf(program).Elem()); err != nil {
+		return nil, err
+	}
+	program.Lines = NewLineTable(program.Source)
+	return program, nil
+}
+
+// jsonObject garde l'ordre de ses champs, que encoding/json trierait dans une
+// map : kind en tête, puis les champs dans l'ordre de la structure
+type jsonObject []jsonField
+
+type jsonField struct {
+	key   string
+	value any
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field.key)
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func encodeValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return encodeValue(v.Elem())
+	case reflect.Slice:
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = encodeValue(v.Index(i))
+		}
+		return items
+	case reflect.Struct:
+		if v.Type() == spanType {
+			return encodeSpan(v.Interface().(Span))
+		}
+		object := jsonObject{{"kind", v.Type().Name()}}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() || field.Type == reflect.TypeOf(LineTable(nil)) || v.Field(i).IsZero() {
+				continue
+			}
+			object = append(object, jsonField{jsonName(field), encodeValue(v.Field(i))})
+		}
+		return object
+	}
+	if v.Type() == methodKindType {
+		return methodKindNames[v.Int()]
+	}
+	return v.Interface()
+}
+
+func encodeSpan(span Span) jsonObject {
+	location := func(l Location) jsonObject {
+		return jsonObject{{"offset", l.Offset}, {"line", l.Line}, {"column", l.Column}}
+	}
+	return jsonObject{{"pos", location(span.Pos)}, {"end", location(span.End)}}
+}
+
+// jsonName met en minuscule l'initiale d'un nom de champ : TypeParameters ->
+// typeParameters. Kind, réservé au type du nœud, devient methodKind.
+func jsonName(field reflect.StructField) string {
+	switch field.Type {
+	case spanType:
+		return "span"
+	case methodKindType:
+		return "methodKind"
+	}
+	return strings.ToLower(field.Name[:1]) + field.Name[1:]
+}
+
+func decodeStruct(data json.RawMessage, v reflect.Value) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if kind, ok := fields["kind"]; ok {
+		var name string
+		if err := json.Unmarshal(kind, &name); err != nil {
+			return err
+		}
+		if name != v.Type().Name() {
+			return fmt.Errorf("ast : nœud %s là où %s est attendu", name, v.Type().Name())
+		}
+	}
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		raw, ok := fields[jsonName(field)]
+		if !ok {
+			continue
+		}
+		if err := decodeValue(raw, v.Field(i)); err != nil {
+			return fmt.Errorf("%s.%s : %w", v.Type().Name(), field.Name, err)
+		}
+	}
+	for _, name := range requiredFields[v.Type().Name()] {
+		if v.FieldByName(name).IsNil() {
+			return fmt.Errorf("ast : %s sans %s", v.Type().Name(), name)
+		}
+	}
+	return nil
+}
+
+func decodeValue(data json.RawMessage, v reflect.Value) error {
+	if string(data) == "null" {
+		return nil
+	}
+	switch {
+	case v.Type() == spanType:
+		return decodeSpan(data, v.Addr().Interface().(*Span))
+	case v.Type() == methodKindType:
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return err
+		}
+		for kind, known := range methodKindNames {
+			if known == name {
+				v.SetInt(int64(kind))
+				return nil
+			}
+		}
+		return fmt.Errorf("ast : sorte de méthode inconnue %q", name)
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		node, err := decodeNode(data)
+		if err != nil {
+			return err
+		}
+		if !reflect.TypeOf(node).AssignableTo(v.Type()) {
+			return fmt.Errorf("ast : %T ne peut pas occuper une place de %s", node, v.Type().Name())
+		}
+		v.Set(reflect.ValueOf(node))
+	case reflect.Pointer:
+		target := reflect.New(v.Type().Elem())
+		if err := decodeStruct(data, target.Elem()); err != nil {
+			return err
+		}
+		v.Set(target)
+	case reflect.Slice:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		list := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if string(item) == "null" && (list.Index(i).Kind() == reflect.Interface || list.Index(i).Kind() == reflect.Pointer) {
+				// Une liste d'instructions ou d'expressions n'a pas de trou
+				return fmt.Errorf("ast : null ne peut pas occuper une place de %s", v.Type().Elem().Name())
+			}
+			if err := decodeValue(item, list.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(list)
+	case reflect.Struct:
+		return decodeStruct(data, v)
+	default:
+		return json.Unmarshal(data, v.Addr().Interface())
+	}
+	return nil
+}
+
+// decodeNode lit un nœud d'une place typée par interface (Expression,
+// Statement) : son kind choisit le type à construire
+func decodeNode(data json.RawMessage) (Node, error) {
+	var header struct {
+		Kind string `json:"kind"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	t, ok := nodeKinds[header.Kind]
+	if !ok || !reflect.PointerTo(t).Implements(nodeType) {
+		return nil, fmt.Errorf("ast : sorte de nœud inconnue %q", header.Kind)
+	}
+	node := reflect.New(t)
+	if err := decodeStruct(data, node.Elem()); err != nil {
+		return nil, err
+	}
+	return node.Interface().(Node), nil
+}
+
+func decodeSpan(data json.RawMessage, span *Span) error {
+	var raw struct {
+		Pos, End struct{ Offset, Line, Column int }
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	span.Pos = Location(raw.Pos)
+	span.End = Location(raw.End)
+	return nil
+}
@@ -0,0 +1,125 @@
+package ast_test
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/lexer"
+	"ProjetGo/parser"
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Sources couvrant chaque sorte de nœud
+var jsonSources = map[string]string{
+	"déclarations": `"use strict";
+// Statut
+type Status = "on" | "off";
+enum Color { Red = 1, Green }
+interface Named<T = string> { name: T; greet?(other: Named): void; }
+const tags: string[] = ["a", "b"];
+let user = { name: "Ada", ...defaults };
+`,
+	"classes": `@sealed
+abstract class Shape<T extends object> implements Named {
+  static readonly count: number = 0;
+  #secret: string = "";
+  static {
+    Shape.registry = [];
+  }
+  constructor(private readonly repo: Repo, ...rest: number[]) {
+    super();
+  }
+  get area(): number {
+    return this.#secret.length;
+  }
+  abstract describe(): string;
+}
+const Point = class { x: number = 0; };
+`,
+	"instructions": `import { readFile as read } from "fs";
+import * as path from "path";
+namespace Geometry { export const origin = 0; }
+declare const VERSION: string;
+async function load(file?: string): Promise<string> {
+  for (let i = 0; i < 3; i++) {
+    if (i == 1) {
+      continue;
+    } else {
+      console.log(` + "`step ${i}`" + `);
+    }
+  }
+  while (ready) { ready = false; }
+  const data = await read(file!) as string;
+  const size = <number>data.length;
+  const checked = data satisfies string;
+  const double = (x: number) => x * 2;
+  return data[0];
+}
+function* ids() { yield* [1, 2]; }
+export { load };
+export default load;
+`,
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for name, source := range jsonSources {
+		t.Run(name, func(t *testing.T) {
+			program := parser.New(lexer.New(source)).ParseProgram()
+			checkJSONRoundTrip(t, program)
+		})
+	}
+}
+
+func TestJSONRoundTripJSX(t *testing.T) {
+	source := `const view = <div className="box" {...props}>Hello {name}<><br /></></div>;`
+	program := parser.New(lexer.NewJSX(source)).ParseProgram()
+	checkJSONRoundTrip(t, program)
+}
+
+// checkJSONRoundTrip vérifie qu'un arbre relu depuis son JSON est identique à
+// l'original et se réécrit octet pour octet
+func checkJSONRoundTrip(t *testing.T, program *ast.Program) {
+	t.Helper()
+	encoded, err := ast.EncodeJSON(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ast.DecodeJSON(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(program, decoded) {
+		t.Errorf("l'arbre relu diffère de l'original")
+	}
+	again, err := ast.EncodeJSON(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, again) {
+		t.Errorf("le JSON réécrit diffère :\n%s\n---\n%s", encoded, again)
+	}
+}
+
+func TestDecodeJSONErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"version", `{"version": 99, "program": {"kind": "Program"}}`, "version JSON 99"},
+		{"sorte inconnue", `{"version": 2, "program": {"kind": "Program", "statements": [{"kind": "GotoStatement"}]}}`, "sorte de nœud inconnue"},
+		{"place d'expression", `{"version": 2, "program": {"kind": "Program", "statements": [{"kind": "Identifier", "value": "x"}]}}`, "ne peut pas occuper"},
+		{"instruction nulle", `{"version": 2, "program": {"kind": "Program", "statements": [null]}}`, "null ne peut pas occuper une place de Statement"},
+		{"enfant manquant", `{"version": 2, "program": {"kind": "Program", "statements": [{"kind": "ExpressionStatement", "expression": null}]}}`, "ExpressionStatement sans Expression"},
+		{"sorte de méthode", `{"version": 2, "program": {"kind": "Program", "statements": [{"kind": "ClassDeclaration", "name": "A", "methods": [{"kind": "ClassMethod", "name": "m", "methodKind": "operator"}]}]}}`, "sorte de méthode inconnue"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ast.DecodeJSON([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("erreur %v, attendu %q", err, tt.want)
+			}
+		})
+	}
+}
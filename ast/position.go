@@ -0,0 +1,39 @@
+package ast
+
+// Location repère un point du source : décalage en octets depuis le début,
+// ligne et colonne comptées à partir de 1
+type Location struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// IsValid indique si la position a été renseignée par le parser
+func (l Location) IsValid() bool {
+	return l.Line > 0
+}
+
+// Span est l'étendue d'un nœud dans le source, de son premier caractère (Pos)
+// au caractère qui suit le dernier (End). Chaque nœud l'embarque ; elle reste
+// nulle pour un nœud construit hors du parser.
+type Span struct {
+	Pos Location
+	End Location
+}
+
+func (s Span) nodeSpan() Span { return s }
+
+// Spanned est implémentée par les nœuds et par les éléments qui les composent
+// (membres de classe, paramètres, propriétés d'objet...)
+type Spanned interface {
+	nodeSpan() Span
+}
+
+// Position renvoie l'étendue d'un nœud, pour situer un diagnostic ou une
+// correspondance de source map
+func Position(node Spanned) Span {
+	if node == nil {
+		return Span{}
+	}
+	return node.nodeSpan()
+}
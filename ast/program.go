@@ -0,0 +1,50 @@
+package ast
+
+import "sort"
+
+// Program est la racine de l'arbre d'un fichier source : ses instructions et
+// ce que le parser sait du fichier lui-même
+type Program struct {
+	Span
+	FileName   string // vide pour un source qui ne vient pas d'un fichier
+	Source     string
+	Statements []Statement
+	Comments   []Comment
+	Directives []string  // prologue "use strict" ; retiré des instructions
+	Lines      LineTable // début de chaque ligne, pour passer d'un décalage à une ligne
+	IsModule   bool      // le fichier importe ou exporte : c'est un module ES, pas un script
+}
+
+func (p *Program) TokenLiteral() string { return p.FileName }
+
+// Comment est un commentaire // du source, texte compris
+type Comment struct {
+	Span
+	Text string
+}
+
+// LineTable donne le décalage du premier caractère de chaque ligne
+type LineTable []int
+
+// NewLineTable calcule la table des lignes d'un source
+func NewLineTable(source string) LineTable {
+	lines := LineTable{0}
+	for i := 0; i < len(source); i++ {
+		if source[i] == '\n' {
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
+
+// Location convertit un décalage en octets en position ligne/colonne
+func (lt LineTable) Location(offset int) Location {
+	if len(lt) == 0 {
+		return Location{}
+	}
+	line := sort.Search(len(lt), func(i int) bool { return lt[i] > offset }) - 1
+	if line < 0 {
+		line = 0
+	}
+	return Location{Offset: offset, Line: line + 1, Column: offset - lt[line] + 1}
+}
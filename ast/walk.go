@@ -0,0 +1,505 @@
+package ast
+
+import "fmt"
+
+// Visitor est appelé par Walk sur chaque nœud. Si Visit renvoie un visiteur w
+// non nil, Walk visite les enfants du nœud avec w puis appelle w.Visit(nil).
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+// Walk parcourt l'arbre en profondeur, dans l'ordre du source : il appelle
+// v.Visit(node), puis visite les enfants de node avec le visiteur renvoyé.
+// Les membres de classe, paramètres, propriétés d'objet et autres éléments
+// stockés par valeur sont visités par pointeur (*ClassField, *Parameter...).
+func Walk(v Visitor, node Node) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	// Littéraux et feuilles
+	case *StringLiteral, *NumberLiteral, *BooleanLiteral, *Identifier, *JSXText,
+		*TypeParameter, *ImportSpecifier:
+		// pas d'enfants
+
+	case *Program:
+		walkList(v, n.Statements)
+
+	// Déclarations
+	case *VariableDeclaration:
+		walkNode(v, n.Value)
+	case *TypeAlias:
+		walkValues(v, n.TypeParameters)
+	case *Interface:
+		walkValues(v, n.TypeParameters)
+		walkValues(v, n.Fields)
+	case *InterfaceField:
+		walkValues(v, n.TypeParameters)
+		walkValues(v, n.Parameters)
+	case *EnumDeclaration:
+		walkValues(v, n.Members)
+	case *EnumMember:
+		walkNode(v, n.Value)
+	case *Decorator:
+		walkList(v, n.Arguments)
+	case *ClassDeclaration:
+		walkValues(v, n.Decorators)
+		walkValues(v, n.TypeParameters)
+		walkValues(v, n.Fields)
+		walkValues(v, n.Methods)
+		walkValues(v, n.StaticBlocks)
+	case *ClassField:
+		walkValues(v, n.Decorators)
+		walkNode(v, n.Default)
+	case *StaticBlock:
+		walkList(v, n.Body)
+	case *ClassMethod:
+		walkValues(v, n.Decorators)
+		walkValues(v, n.TypeParameters)
+		walkValues(v, n.Parameters)
+		walkList(v, n.Body)
+	case *Parameter:
+		walkValues(v, n.Decorators)
+		walkNode(v, n.Default)
+	case *FunctionDeclaration:
+		walkList(v, n.Overloads)
+		walkValues(v, n.TypeParameters)
+		walkValues(v, n.Parameters)
+		walkList(v, n.Body)
+	case *ImportDeclaration:
+		walkValues(v, n.Specifiers)
+	case *ExportDeclaration:
+		walkNode(v, n.Declaration)
+		walkValues(v, n.Specifiers)
+	case *NamespaceDeclaration:
+		walkList(v, n.Body)
+	case *AmbientDeclaration:
+		walkNode(v, n.Declaration)
+
+	// Instructions
+	case *IfStatement:
+		walkNode(v, n.Condition)
+		walkNode(v, n.ThenBranch)
+		walkNode(v, n.ElseBranch)
+	case *ForStatement:
+		walkNode(v, n.Init)
+		walkNode(v, n.Condition)
+		walkNode(v, n.Update)
+		walkNode(v, n.Body)
+	case *WhileStatement:
+		walkNode(v, n.Condition)
+		walkNode(v, n.Body)
+	case *BlockStatement:
+		walkList(v, n.Statements)
+	case *ExpressionStatement:
+		walkNode(v, n.Expression)
+	case *ReturnStatement:
+		walkNode(v, n.Value)
+
+	// Expressions
+	case *ArrayLiteral:
+		walkList(v, n.Elements)
+	case *ObjectLiteral:
+		walkValues(v, n.Properties)
+	case *ObjectProperty:
+		walkNode(v, n.Value)
+	case *CallExpression:
+		walkNode(v, n.Function)
+		walkList(v, n.Arguments)
+	case *NewExpression:
+		walkNode(v, n.Class)
+		walkList(v, n.Arguments)
+	case *InfixExpression:
+		walkNode(v, n.Left)
+		walkNode(v, n.Right)
+	case *ParenthesizedExpression:
+		walkNode(v, n.Expression)
+	case *TemplateLiteral:
+		walkList(v, n.Parts)
+	case *IndexExpression:
+		walkNode(v, n.Left)
+		walkNode(v, n.Index)
+	case *DotExpression:
+		walkNode(v, n.Object)
+	case *AssignmentExpression:
+		walkNode(v, n.Left)
+		walkNode(v, n.Right)
+	case *SpreadElement:
+		walkNode(v, n.Argument)
+	case *AwaitExpression:
+		walkNode(v, n.Argument)
+	case *AsExpression:
+		walkNode(v, n.Expression)
+	case *NonNullExpression:
+		walkNode(v, n.Expression)
+	case *SatisfiesExpression:
+		walkNode(v, n.Expression)
+	case *ArrowFunction:
+		walkValues(v, n.Parameters)
+		walkNode(v, n.ExpressionBody)
+		walkList(v, n.Body)
+	case *ClassExpression:
+		if n.Class != nil {
+			Walk(v, n.Class)
+		}
+	case *YieldExpression:
+		walkNode(v, n.Argument)
+
+	// JSX
+	case *JSXElement:
+		walkValues(v, n.Attributes)
+		walkList(v, n.Children)
+	case *JSXAttribute:
+		walkNode(v, n.Value)
+	case *JSXFragment:
+		walkList(v, n.Children)
+	case *JSXExpressionContainer:
+		walkNode(v, n.Expression)
+
+	default:
+		panic(fmt.Sprintf("ast.Walk : type de nœud inattendu %T", n))
+	}
+
+	v.Visit(nil)
+}
+
+// walkNode visite un enfant facultatif : une instruction ou une expression
+// absente vaut nil
+func walkNode[T Node](v Visitor, node T) {
+	if Node(node) != nil {
+		Walk(v, node)
+	}
+}
+
+func walkList[T Node](v Visitor, list []T) {
+	for _, node := range list {
+		walkNode(v, node)
+	}
+}
+
+// walkValues visite les éléments d'une liste stockée par valeur
+func walkValues[T any, P interface {
+	*T
+	Node
+}](v Visitor, list []T) {
+	for i := range list {
+		Walk(v, P(&list[i]))
+	}
+}
+
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect parcourt l'arbre dans l'ordre de Walk et appelle f(node) sur chaque
+// nœud ; les enfants d'un nœud ne sont visités que si f renvoie true. Après
+// les enfants, f est appelée avec nil.
+func Inspect(node Node, f func(Node) bool) {
+	Walk(inspector(f), node)
+}
+
+// Rewrite transforme l'arbre en profondeur, dans l'ordre postfixe : les
+// enfants d'un nœud sont transformés avant que f ne soit appelée sur le nœud
+// lui-même. Le résultat de f remplace le nœud : le nœud lui-même pour le
+// garder, un autre nœud de la même catégorie (instruction, expression) pour
+// le changer, ou nil pour le retirer d'une liste ou vider un enfant
+// facultatif. Rewrite renvoie le résultat de f pour la racine ; l'arbre
+// d'origine n'est pas modifié : f reçoit une copie de chaque nœud qui a des
+// enfants, les feuilles (identifiants, littéraux) lui sont passées telles
+// quelles.
+func Rewrite(node Node, f func(Node) Node) Node {
+	if node == nil {
+		return nil
+	}
+
+	switch n := node.(type) {
+	case *StringLiteral, *NumberLiteral, *BooleanLiteral, *Identifier, *JSXText,
+		*TypeParameter, *ImportSpecifier:
+		// pas d'enfants
+
+	case *Program:
+		c := *n
+		c.Statements = rewriteList(n.Statements, f)
+		node = &c
+	case *VariableDeclaration:
+		c := *n
+		c.Value = rewriteNode(n.Value, f)
+		node = &c
+	case *TypeAlias:
+		c := *n
+		c.TypeParameters = rewriteValues(n.TypeParameters, f)
+		node = &c
+	case *Interface:
+		c := *n
+		c.TypeParameters = rewriteValues(n.TypeParameters, f)
+		c.Fields = rewriteValues(n.Fields, f)
+		node = &c
+	case *InterfaceField:
+		c := *n
+		c.TypeParameters = rewriteValues(n.TypeParameters, f)
+		c.Parameters = rewriteValues(n.Parameters, f)
+		node = &c
+	case *EnumDeclaration:
+		c := *n
+		c.Members = rewriteValues(n.Members, f)
+		node = &c
+	case *EnumMember:
+		c := *n
+		c.Value = rewriteNode(n.Value, f)
+		node = &c
+	case *Decorator:
+		c := *n
+		c.Arguments = rewriteList(n.Arguments, f)
+		node = &c
+	case *ClassDeclaration:
+		c := *n
+		c.Decorators = rewriteValues(n.Decorators, f)
+		c.TypeParameters = rewriteValues(n.TypeParameters, f)
+		c.Fields = rewriteValues(n.Fields, f)
+		c.Methods = rewriteValues(n.Methods, f)
+		c.StaticBlocks = rewriteValues(n.StaticBlocks, f)
+		node = &c
+	case *ClassField:
+		c := *n
+		c.Decorators = rewriteValues(n.Decorators, f)
+		c.Default = rewriteNode(n.Default, f)
+		node = &c
+	case *StaticBlock:
+		c := *n
+		c.Body = rewriteList(n.Body, f)
+		node = &c
+	case *ClassMethod:
+		c := *n
+		c.Decorators = rewriteValues(n.Decorators, f)
+		c.TypeParameters = rewriteValues(n.TypeParameters, f)
+		c.Parameters = rewriteValues(n.Parameters, f)
+		c.Body = rewriteList(n.Body, f)
+		node = &c
+	case *Parameter:
+		c := *n
+		c.Decorators = rewriteValues(n.Decorators, f)
+		c.Default = rewriteNode(n.Default, f)
+		node = &c
+	case *FunctionDeclaration:
+		c := *n
+		c.Overloads = rewriteList(n.Overloads, f)
+		c.TypeParameters = rewriteValues(n.TypeParameters, f)
+		c.Parameters = rewriteValues(n.Parameters, f)
+		c.Body = rewriteList(n.Body, f)
+		node = &c
+	case *ImportDeclaration:
+		c := *n
+		c.Specifiers = rewriteValues(n.Specifiers, f)
+		node = &c
+	case *ExportDeclaration:
+		c := *n
+		c.Declaration = rewriteNode(n.Declaration, f)
+		c.Specifiers = rewriteValues(n.Specifiers, f)
+		node = &c
+	case *NamespaceDeclaration:
+		c := *n
+		c.Body = rewriteList(n.Body, f)
+		node = &c
+	case *AmbientDeclaration:
+		c := *n
+		c.Declaration = rewriteNode(n.Declaration, f)
+		node = &c
+
+	case *IfStatement:
+		c := *n
+		c.Condition = rewriteNode(n.Condition, f)
+		c.ThenBranch = rewriteNode(n.ThenBranch, f)
+		c.ElseBranch = rewriteNode(n.ElseBranch, f)
+		node = &c
+	case *ForStatement:
+		c := *n
+		c.Init = rewriteNode(n.Init, f)
+		c.Condition = rewriteNode(n.Condition, f)
+		c.Update = rewriteNode(n.Update, f)
+		c.Body = rewriteNode(n.Body, f)
+		node = &c
+	case *WhileStatement:
+		c := *n
+		c.Condition = rewriteNode(n.Condition, f)
+		c.Body = rewriteNode(n.Body, f)
+		node = &c
+	case *BlockStatement:
+		c := *n
+		c.Statements = rewriteList(n.Statements, f)
+		node = &c
+	case *ExpressionStatement:
+		c := *n
+		c.Expression = rewriteNode(n.Expression, f)
+		node = &c
+	case *ReturnStatement:
+		c := *n
+		c.Value = rewriteNode(n.Value, f)
+		node = &c
+
+	case *ArrayLiteral:
+		c := *n
+		c.Elements = rewriteList(n.Elements, f)
+		node = &c
+	case *ObjectLiteral:
+		c := *n
+		c.Properties = rewriteValues(n.Properties, f)
+		node = &c
+	case *ObjectProperty:
+		c := *n
+		c.Value = rewriteNode(n.Value, f)
+		node = &c
+	case *CallExpression:
+		c := *n
+		c.Function = rewriteNode(n.Function, f)
+		c.Arguments = rewriteList(n.Arguments, f)
+		node = &c
+	case *NewExpression:
+		c := *n
+		c.Class = rewriteNode(n.Class, f)
+		c.Arguments = rewriteList(n.Arguments, f)
+		node = &c
+	case *InfixExpression:
+		c := *n
+		c.Left = rewriteNode(n.Left, f)
+		c.Right = rewriteNode(n.Right, f)
+		node = &c
+	case *ParenthesizedExpression:
+		c := *n
+		c.Expression = rewriteNode(n.Expression, f)
+		node = &c
+	case *TemplateLiteral:
+		c := *n
+		c.Parts = rewriteList(n.Parts, f)
+		node = &c
+	case *IndexExpression:
+		c := *n
+		c.Left = rewriteNode(n.Left, f)
+		c.Index = rewriteNode(n.Index, f)
+		node = &c
+	case *DotExpression:
+		c := *n
+		c.Object = rewriteNode(n.Object, f)
+		node = &c
+	case *AssignmentExpression:
+		c := *n
+		c.Left = rewriteNode(n.Left, f)
+		c.Right = rewriteNode(n.Right, f)
+		node = &c
+	case *SpreadElement:
+		c := *n
+		c.Argument = rewriteNode(n.Argument, f)
+		node = &c
+	case *AwaitExpression:
+		c := *n
+		c.Argument = rewriteNode(n.Argument, f)
+		node = &c
+	case *AsExpression:
+		c := *n
+		c.Expression = rewriteNode(n.Expression, f)
+		node = &c
+	case *NonNullExpression:
+		c := *n
+		c.Expression = rewriteNode(n.Expression, f)
+		node = &c
+	case *SatisfiesExpression:
+		c := *n
+		c.Expression = rewriteNode(n.Expression, f)
+		node = &c
+	case *ArrowFunction:
+		c := *n
+		c.Parameters = rewriteValues(n.Parameters, f)
+		c.ExpressionBody = rewriteNode(n.ExpressionBody, f)
+		c.Body = rewriteList(n.Body, f)
+		node = &c
+	case *ClassExpression:
+		c := *n
+		if n.Class != nil {
+			c.Class = rewriteNode(n.Class, f)
+		}
+		node = &c
+	case *YieldExpression:
+		c := *n
+		c.Argument = rewriteNode(n.Argument, f)
+		node = &c
+
+	case *JSXElement:
+		c := *n
+		c.Attributes = rewriteValues(n.Attributes, f)
+		c.Children = rewriteList(n.Children, f)
+		node = &c
+	case *JSXAttribute:
+		c := *n
+		c.Value = rewriteNode(n.Value, f)
+		node = &c
+	case *JSXFragment:
+		c := *n
+		c.Children = rewriteList(n.Children, f)
+		node = &c
+	case *JSXExpressionContainer:
+		c := *n
+		c.Expression = rewriteNode(n.Expression, f)
+		node = &c
+
+	default:
+		panic(fmt.Sprintf("ast.Rewrite : type de nœud inattendu %T", n))
+	}
+
+	return f(node)
+}
+
+// rewriteNode transforme un enfant et vérifie que son remplaçant peut occuper
+// sa place : une expression ne remplace pas une instruction
+func rewriteNode[T Node](node T, f func(Node) Node) T {
+	var zero T
+	if Node(node) == nil {
+		return zero
+	}
+	result := Rewrite(node, f)
+	if result == nil {
+		return zero
+	}
+	replaced, ok := result.(T)
+	if !ok {
+		panic(fmt.Sprintf("ast.Rewrite : %T ne peut pas remplacer %T", result, node))
+	}
+	return replaced
+}
+
+// rewriteList transforme une liste et en retire les nœuds pour lesquels f
+// renvoie nil
+func rewriteList[T Node](list []T, f func(Node) Node) []T {
+	if list == nil {
+		return nil
+	}
+	kept := make([]T, 0, len(list))
+	for _, node := range list {
+		if replaced := rewriteNode(node, f); Node(replaced) != nil {
+			kept = append(kept, replaced)
+		}
+	}
+	return kept
+}
+
+// rewriteValues transforme une liste stockée par valeur : f reçoit un
+// pointeur sur une copie de chaque élément et renvoie un pointeur du même type
+func rewriteValues[T any, P interface {
+	*T
+	Node
+}](list []T, f func(Node) Node) []T {
+	if list == nil {
+		return nil
+	}
+	kept := make([]T, 0, len(list))
+	for _, item := range list {
+		if replaced := rewriteNode(P(&item), f); replaced != nil {
+			kept = append(kept, *replaced)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,171 @@
+package ast_test
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/lexer"
+	"ProjetGo/parser"
+	"ProjetGo/printer"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func parse(source string) *ast.Program {
+	return parser.New(lexer.New(source)).ParseProgram()
+}
+
+func TestInspectOrder(t *testing.T) {
+	program := parse("function f(a: number) { return a + b * c; }")
+	var names []string
+	ast.Inspect(program, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.Identifier:
+			names = append(names, n.Value)
+		case *ast.Parameter:
+			names = append(names, "param "+n.Name)
+		}
+		return true
+	})
+	if got, want := strings.Join(names, " "), "param a a b c"; got != want {
+		t.Errorf("ordre de visite %q, attendu %q", got, want)
+	}
+}
+
+func TestInspectSkipsChildren(t *testing.T) {
+	program := parse("const x = f(a); function g() { return b; }")
+	var names []string
+	ast.Inspect(program, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Identifier); ok {
+			names = append(names, id.Value)
+		}
+		_, isFunction := node.(*ast.FunctionDeclaration)
+		return !isFunction
+	})
+	if got, want := strings.Join(names, " "), "f a"; got != want {
+		t.Errorf("identifiants visités %q, attendu %q", got, want)
+	}
+}
+
+func TestInspectStaticBlocks(t *testing.T) {
+	program := parse("class A {\n  static {}\n  static {\n    A.n = 1;\n  }\n}")
+	var lines []int
+	ast.Inspect(program, func(node ast.Node) bool {
+		if block, ok := node.(*ast.StaticBlock); ok {
+			lines = append(lines, block.Pos.Line)
+		}
+		return true
+	})
+	if len(lines) != 2 || lines[0] != 2 || lines[1] != 3 {
+		t.Errorf("blocs static visités aux lignes %v, attendu [2 3]", lines)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		f      func(ast.Node) ast.Node
+		want   string
+	}{
+		{
+			name:   "renomme un identifiant",
+			source: "let total = count + count;",
+			f: func(node ast.Node) ast.Node {
+				if id, ok := node.(*ast.Identifier); ok && id.Value == "count" {
+					return &ast.Identifier{Value: "n"}
+				}
+				return node
+			},
+			want: "let total = n + n;\n",
+		},
+		{
+			name:   "replie les constantes de bas en haut",
+			source: "const x = 1 + 2 + 3 * 4;",
+			f: func(node ast.Node) ast.Node {
+				infix, ok := node.(*ast.InfixExpression)
+				if !ok {
+					return node
+				}
+				left, lok := infix.Left.(*ast.NumberLiteral)
+				right, rok := infix.Right.(*ast.NumberLiteral)
+				if !lok || !rok {
+					return node
+				}
+				a, _ := strconv.Atoi(left.Value)
+				b, _ := strconv.Atoi(right.Value)
+				switch infix.Operator {
+				case "+":
+					return &ast.NumberLiteral{Value: strconv.Itoa(a + b)}
+				case "*":
+					return &ast.NumberLiteral{Value: strconv.Itoa(a * b)}
+				}
+				return node
+			},
+			want: "const x = 15;\n",
+		},
+		{
+			name:   "retire des instructions",
+			source: "console.log(1);\nlet a = 1;\nconsole.log(a);",
+			f: func(node ast.Node) ast.Node {
+				if es, ok := node.(*ast.ExpressionStatement); ok {
+					if call, ok := es.Expression.(*ast.CallExpression); ok {
+						if dot, ok := call.Function.(*ast.DotExpression); ok && dot.Property == "log" {
+							return nil
+						}
+					}
+				}
+				return node
+			},
+			want: "let a = 1;\n",
+		},
+		{
+			name:   "remplace un paramètre stocké par valeur",
+			source: "function f(a: number) {\n  return a;\n}",
+			f: func(node ast.Node) ast.Node {
+				if param, ok := node.(*ast.Parameter); ok {
+					param.Name = "b"
+				}
+				return node
+			},
+			want: "function f(b: number) {\n  return a;\n}\n",
+		},
+		{
+			name:   "vide un enfant facultatif",
+			source: "function f() {\n  return 1;\n}",
+			f: func(node ast.Node) ast.Node {
+				if _, ok := node.(*ast.NumberLiteral); ok {
+					return nil
+				}
+				return node
+			},
+			want: "function f() {\n  return;\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parse(tt.source)
+			before := printer.Print(program)
+			rewritten := ast.Rewrite(program, tt.f).(*ast.Program)
+			if got := printer.Print(rewritten); got != tt.want {
+				t.Errorf("Rewrite donne\n%s\nattendu\n%s", got, tt.want)
+			}
+			if after := printer.Print(program); after != before {
+				t.Errorf("l'arbre d'origine a changé :\n%s", after)
+			}
+		})
+	}
+}
+
+func TestRewriteRejectsMismatchedReplacement(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("une expression a remplacé une instruction sans panique")
+		}
+	}()
+	ast.Rewrite(parse("let a = 1;"), func(node ast.Node) ast.Node {
+		if _, ok := node.(*ast.VariableDeclaration); ok {
+			return &ast.Identifier{Value: "a"}
+		}
+		return node
+	})
+}
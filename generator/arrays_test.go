@@ -0,0 +1,31 @@
+package generator
+
+import "testing"
+
+func TestMixedArrays(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "éléments de types différents",
+			source: "const mixed = [1, \"a\", true];",
+			want: map[TargetLanguage][]string{
+				CSharp: {"var mixed = new object[] { 1.0, \"a\", true };"},
+				Rust:   {"let mixed = vec![Box::new(1.0) as Box<dyn std::any::Any>, Box::new(\"a\".to_string()), Box::new(true)];"},
+			},
+			absent: map[TargetLanguage][]string{
+				CSharp: {"new[] {"},
+			},
+		},
+		{
+			name:   "éléments d'un même type",
+			source: "const names = [\"a\", \"b\"];",
+			want: map[TargetLanguage][]string{
+				CSharp: {"string[] names = new string[] { \"a\", \"b\" };"},
+				Rust:   {"let names = vec![\"a\".to_string(), \"b\".to_string()];"},
+			},
+			absent: map[TargetLanguage][]string{
+				CSharp: {"object[]"},
+				Rust:   {"Box<dyn std::any::Any>"},
+			},
+		},
+	})
+}
@@ -0,0 +1,69 @@
+package generator
+
+import "ProjetGo/ast"
+
+// castsTo indique si une assertion de type vers t se traduit par une
+// conversion : as any, as unknown et as const ne changent que la vérification
+// faite par TypeScript
+func castsTo(t string) bool {
+	switch t {
+	case "any", "unknown", "const", "":
+		return false
+	}
+	return true
+}
+
+// assertedOperand génère la valeur d'une assertion effacée (as, satisfies, !),
+// entre parenthèses si c'est une opération : (a + b as number) * 2 doit rester
+// (a + b) * 2 une fois l'assertion retirée
+func assertedOperand(expr ast.Expression, generate func(ast.Expression) string) string {
+	switch expr.(type) {
+	case *ast.InfixExpression, *ast.AssignmentExpression:
+		return "(" + generate(expr) + ")"
+	}
+	return generate(expr)
+}
+
+// isRustPrimitive indique si un type Rust est un type primitif, seul admis
+// par une conversion as
+func isRustPrimitive(t string) bool {
+	switch t {
+	case "i32", "i64", "f32", "f64", "u8", "u32", "u64", "usize", "bool", "char":
+		return true
+	}
+	return false
+}
+
+// withoutErasedAssertion renvoie la déclaration dont la valeur n'est plus
+// enveloppée d'une assertion sans conversion (as const, as any, satisfies),
+// pour que son type soit déduit de la valeur elle-même : const tags = ["a"]
+// as const reste un tableau ; la déclaration d'origine n'est pas modifiée
+func withoutErasedAssertion(vd *ast.VariableDeclaration) *ast.VariableDeclaration {
+	var inner ast.Expression
+	switch value := vd.Value.(type) {
+	case *ast.AsExpression:
+		if castsTo(value.Type) {
+			return vd
+		}
+		inner = value.Expression
+	case *ast.SatisfiesExpression:
+		inner = value.Expression
+	default:
+		return vd
+	}
+	unwrapped := *vd
+	unwrapped.Value = inner
+	return withoutErasedAssertion(&unwrapped)
+}
+
+// hasConcreteType indique si une expression a forcément un type concret
+// (littéral, instanciation, opération), sur lequel une assertion de type Go
+// value.(T) n'est pas permise
+func hasConcreteType(expr ast.Expression) bool {
+	switch expr.(type) {
+	case *ast.StringLiteral, *ast.NumberLiteral, *ast.BooleanLiteral, *ast.TemplateLiteral,
+		*ast.ArrayLiteral, *ast.ObjectLiteral, *ast.NewExpression, *ast.InfixExpression:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package generator
+
+import "testing"
+
+func TestAnyAssertions(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "nombre et chaîne dans un any",
+			source: "let v: any = 5;\nlet n = v as number;\nlet s: any = \"hi\";\nlet t = s as string;",
+			want: map[TargetLanguage][]string{
+				Java:  {"Object v = 5.0;"},
+				Go:    {"var v interface{} = 5.0", "var n float64 = v.(float64)", "var s interface{} = \"hi\"", "s.(string)"},
+				Rust:  {"let v: Box<dyn std::any::Any> = Box::new(5.0);", "*v.downcast_ref::<f64>().unwrap()", "let s: Box<dyn std::any::Any> = Box::new(\"hi\".to_string());", "s.downcast_ref::<String>().unwrap().clone()"},
+				Swift: {"var v: Any = 5.0", "var s: Any = \"hi\""},
+			},
+		},
+	})
+}
+
+func TestNonNullAssertions(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "optionnel et valeur certaine",
+			source: "function label(prefix?: string): string {\n  return prefix!;\n}\nlet name: string = \"a\";\nlet m = name!;",
+			want: map[TargetLanguage][]string{
+				Rust:  {"return prefix.unwrap();", "let m = name;"},
+				Swift: {"return prefix!", "var m: String = name\n"},
+			},
+			absent: map[TargetLanguage][]string{
+				Rust:  {"name.unwrap()"},
+				Swift: {"name!"},
+			},
+		},
+	})
+}
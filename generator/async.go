@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// unwrapPromise renvoie T pour Promise<T> et indique si le type était une
+// promesse ; les langages cibles expriment l'asynchronisme par leur propre
+// type de retour (CompletableFuture<T>, Task<T>, async fn -> T...)
+func unwrapPromise(t string) (string, bool) {
+	if strings.HasPrefix(t, "Promise<") && strings.HasSuffix(t, ">") {
+		return strings.TrimSpace(t[len("Promise<") : len(t)-1]), true
+	}
+	return t, false
+}
+
+// containsAwait indique si des instructions contiennent un await, sans
+// descendre dans les fonctions imbriquées
+func containsAwait(statements []ast.Statement) bool {
+	found := false
+	inspectStatements(statements, func(node ast.Node) bool {
+		if _, ok := node.(*ast.AwaitExpression); ok {
+			found = true
+		}
+		return !found && !isFunctionScope(node)
+	})
+	return found
+}
+
+// arrowBody renvoie le corps d'une fonction fléchée sous forme
+// d'instructions : x => x * 2 devient { return x * 2 }
+func arrowBody(af *ast.ArrowFunction) []ast.Statement {
+	if af.ExpressionBody != nil {
+		return []ast.Statement{&ast.ReturnStatement{Value: af.ExpressionBody}}
+	}
+	return af.Body
+}
+
+// returnsValue indique si un corps de fonction renvoie une valeur
+func returnsValue(body []ast.Statement) bool {
+	found := false
+	inspectStatements(body, func(node ast.Node) bool {
+		if rs, ok := node.(*ast.ReturnStatement); ok && rs.Value != nil {
+			found = true
+		}
+		return !found && !isFunctionScope(node)
+	})
+	return found
+}
+
+// asyncPrefix renvoie le mot-clé async des langages qui le placent devant la
+// déclaration (Rust async fn, C# async Task)
+func asyncPrefix(isAsync bool) string {
+	if isAsync {
+		return "async "
+	}
+	return ""
+}
@@ -0,0 +1,461 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// findConstructor renvoie le constructeur d'une classe, ou nil s'il n'est pas déclaré
+func findConstructor(cd *ast.ClassDeclaration) *ast.ClassMethod {
+	for i := range cd.Methods {
+		if cd.Methods[i].Kind == ast.Constructor {
+			return &cd.Methods[i]
+		}
+	}
+	return nil
+}
+
+// regularMethods renvoie les méthodes d'une classe hors constructeur et accesseurs
+func regularMethods(cd *ast.ClassDeclaration) []ast.ClassMethod {
+	var methods []ast.ClassMethod
+	for _, method := range cd.Methods {
+		if method.Kind == ast.RegularMethod {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// classProperty regroupe les accesseurs get et set d'une même propriété
+type classProperty struct {
+	Name   string
+	Getter *ast.ClassMethod // nil pour une propriété en écriture seule
+	Setter *ast.ClassMethod // nil pour une propriété en lecture seule
+}
+
+// Type renvoie le type de la propriété : celui renvoyé par le get, sinon celui
+// du paramètre du set
+func (cp classProperty) Type() string {
+	if cp.Getter != nil && cp.Getter.ReturnType != "" {
+		return cp.Getter.ReturnType
+	}
+	if cp.Setter != nil && len(cp.Setter.Parameters) > 0 {
+		return cp.Setter.Parameters[0].Type
+	}
+	return ""
+}
+
+// accessor renvoie l'accesseur qui porte les modificateurs de la propriété
+func (cp classProperty) accessor() *ast.ClassMethod {
+	if cp.Getter != nil {
+		return cp.Getter
+	}
+	return cp.Setter
+}
+
+func (cp classProperty) IsStatic() bool { return cp.accessor().IsStatic }
+
+// Access renvoie le modificateur d'accès de la propriété en Java, C# et PHP
+func (cp classProperty) Access() string {
+	return memberAccess(cp.accessor().IsPrivate, cp.accessor().IsProtected)
+}
+
+// memberAccess renvoie le modificateur d'accès d'un membre en Java, C# et
+// PHP, qui ont les trois de TypeScript
+func memberAccess(isPrivate, isProtected bool) string {
+	switch {
+	case isPrivate:
+		return "private"
+	case isProtected:
+		return "protected"
+	}
+	return "public"
+}
+
+// swiftAccess renvoie le modificateur d'accès d'un membre en Swift, suivi
+// d'une espace : Swift n'a pas protected, internal le rend visible du module
+// et donc des sous-classes
+func swiftAccess(isPrivate, isProtected bool) string {
+	switch {
+	case isPrivate:
+		return "private "
+	case isProtected:
+		return "internal "
+	}
+	return ""
+}
+
+// classProperties renvoie les propriétés d'une classe déclarées par get/set,
+// dans l'ordre de leur premier accesseur
+func classProperties(cd *ast.ClassDeclaration) []classProperty {
+	var properties []classProperty
+	index := map[string]int{}
+	for i := range cd.Methods {
+		method := &cd.Methods[i]
+		if method.Kind != ast.Getter && method.Kind != ast.Setter {
+			continue
+		}
+		if _, ok := index[method.Name]; !ok {
+			index[method.Name] = len(properties)
+			properties = append(properties, classProperty{Name: method.Name})
+		}
+		if method.Kind == ast.Getter {
+			properties[index[method.Name]].Getter = method
+		} else {
+			properties[index[method.Name]].Setter = method
+		}
+	}
+	return properties
+}
+
+// accessorMethods renvoie les accesseurs get/set d'une classe sous forme de
+// méthodes ordinaires, pour les langages sans propriétés calculées ;
+// getterName et setterName dérivent leur nom de celui de la propriété
+func accessorMethods(cd *ast.ClassDeclaration, getterName, setterName func(string) string) []ast.ClassMethod {
+	var methods []ast.ClassMethod
+	for _, method := range cd.Methods {
+		switch method.Kind {
+		case ast.Getter:
+			method.Name = getterName(method.Name)
+		case ast.Setter:
+			method.Name = setterName(method.Name)
+		default:
+			continue
+		}
+		method.Kind = ast.RegularMethod
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+// getterName et setterName nomment les accesseurs à la manière de Java et PHP :
+// getTotal, setTotal
+func getterName(name string) string { return "get" + capitalize(name) }
+func setterName(name string) string { return "set" + capitalize(name) }
+
+// collectAccessors renvoie les noms des propriétés get/set des classes du
+// programme, sauf ceux qui nomment aussi un champ : dans les langages sans
+// propriétés calculées, un accès obj.total y devient un appel d'accesseur
+func collectAccessors(statements []ast.Statement) map[string]bool {
+	accessors := map[string]bool{}
+	fields := map[string]bool{}
+	for _, stmt := range declaredStatements(statements) {
+		cd, ok := stmt.(*ast.ClassDeclaration)
+		if !ok {
+			continue
+		}
+		cd = withParameterProperties(cd)
+		for _, property := range classProperties(cd) {
+			accessors[property.Name] = true
+		}
+		for _, field := range cd.Fields {
+			fields[field.Name] = true
+		}
+	}
+	for name := range fields {
+		delete(accessors, name)
+	}
+	return accessors
+}
+
+// accessorAssignment décompose une affectation obj.total = v (ou +=, ++...)
+// d'une propriété accesseur : elle renvoie l'accès obj.total et la valeur à
+// passer au set, ou nil si l'affectation ne vise pas une telle propriété
+func accessorAssignment(ae *ast.AssignmentExpression, accessors map[string]bool) (*ast.DotExpression, ast.Expression) {
+	de, ok := ae.Left.(*ast.DotExpression)
+	if !ok || !accessors[de.Property] {
+		return nil, nil
+	}
+	switch ae.Operator {
+	case "=":
+		return de, ae.Right
+	case "++":
+		return de, &ast.InfixExpression{Left: de, Operator: "+", Right: &ast.NumberLiteral{Value: "1"}}
+	case "--":
+		return de, &ast.InfixExpression{Left: de, Operator: "-", Right: &ast.NumberLiteral{Value: "1"}}
+	}
+	// x.total += v -> x.setTotal(x.getTotal() + v) ; x.total -= a + b garde
+	// ses parenthèses
+	right := ae.Right
+	switch right.(type) {
+	case *ast.InfixExpression, *ast.AssignmentExpression:
+		right = &ast.ParenthesizedExpression{Span: ast.Position(right), Expression: right}
+	}
+	return de, &ast.InfixExpression{Left: de, Operator: strings.TrimSuffix(ae.Operator, "="), Right: right}
+}
+
+// withParameterProperties renvoie la classe dans laquelle les propriétés de
+// paramètres du constructeur (constructor(private repo: Repo)) sont devenues
+// des champs, initialisés en tête du constructeur ; la classe d'origine n'est
+// pas modifiée
+func withParameterProperties(cd *ast.ClassDeclaration) *ast.ClassDeclaration {
+	constructor := findConstructor(cd)
+	if constructor == nil {
+		return cd
+	}
+	var fields []ast.ClassField
+	var assignments []ast.Statement
+	for _, param := range constructor.Parameters {
+		if !param.IsProperty {
+			continue
+		}
+		fields = append(fields, ast.ClassField{Name: param.Name, Type: param.Type, IsPrivate: param.IsPrivate, IsProtected: param.IsProtected, IsReadonly: param.IsReadonly})
+		assignments = append(assignments, &ast.ExpressionStatement{Expression: &ast.AssignmentExpression{
+			Left:     &ast.DotExpression{Object: &ast.Identifier{Value: "this"}, Property: param.Name},
+			Operator: "=",
+			Right:    &ast.Identifier{Value: param.Name},
+		}})
+	}
+	if len(fields) == 0 {
+		return cd
+	}
+
+	expanded := *cd
+	expanded.Fields = append(append([]ast.ClassField{}, cd.Fields...), fields...)
+	expanded.Methods = append([]ast.ClassMethod{}, cd.Methods...)
+	for i := range expanded.Methods {
+		if expanded.Methods[i].Kind == ast.Constructor {
+			expanded.Methods[i].Body = afterSuperCall(expanded.Methods[i].Body, assignments)
+		}
+	}
+	return &expanded
+}
+
+// constructorBody renvoie le corps du constructeur dans lequel les
+// initialisations des champs d'instance (private value = 0 -> this.value = 0)
+// suivent l'appel super(...), pour les langages où un champ ne peut pas être
+// initialisé à sa déclaration
+func constructorBody(cd *ast.ClassDeclaration) []ast.Statement {
+	var initializers []ast.Statement
+	for _, field := range cd.Fields {
+		if field.HasDefault && !field.IsStatic {
+			initializers = append(initializers, fieldInitializer(field))
+		}
+	}
+	var body []ast.Statement
+	if constructor := findConstructor(cd); constructor != nil {
+		body = constructor.Body
+	}
+	return afterSuperCall(body, initializers)
+}
+
+// fieldInitializer construit l'instruction this.champ = défaut d'un champ,
+// this.#champ pour un nom privé
+func fieldInitializer(field ast.ClassField) ast.Statement {
+	property := field.Name
+	if field.IsPrivateName {
+		property = "#" + property
+	}
+	return &ast.ExpressionStatement{Expression: &ast.AssignmentExpression{
+		Left:     &ast.DotExpression{Object: &ast.Identifier{Value: "this"}, Property: property},
+		Operator: "=",
+		Right:    field.Default,
+	}}
+}
+
+// needsConstructor indique si une classe doit avoir un constructeur : déclaré,
+// ou implicite pour initialiser des champs d'instance
+func needsConstructor(cd *ast.ClassDeclaration) bool {
+	return findConstructor(cd) != nil || len(constructorBody(cd)) > 0
+}
+
+// constructorParameters renvoie les paramètres du constructeur, s'il existe
+func constructorParameters(cd *ast.ClassDeclaration) []ast.Parameter {
+	if constructor := findConstructor(cd); constructor != nil {
+		return constructor.Parameters
+	}
+	return nil
+}
+
+// receiverName choisit le nom du receveur d'une classe dans les langages sans
+// this (Go) : l'initiale de la classe, ou self si un paramètre, une variable
+// locale ou un identifiant des méthodes porte déjà ce nom, ou si la classe est
+// anonyme
+func receiverName(cd *ast.ClassDeclaration) string {
+	used := map[string]bool{}
+	ast.Inspect(cd, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.Identifier:
+			used[n.Value] = true
+		case *ast.Parameter:
+			used[n.Name] = true
+		case *ast.VariableDeclaration:
+			used[n.Name] = true
+		case *ast.FunctionDeclaration:
+			used[n.Name] = true
+		}
+		return true
+	})
+	name := "self"
+	if cd.Name != "" && !used[strings.ToLower(cd.Name[:1])] {
+		name = strings.ToLower(cd.Name[:1])
+	}
+	for used[name] {
+		name += "_"
+	}
+	return name
+}
+
+// typeParameterList génère <T, U> pour désigner une classe générique instanciée
+// avec ses propres paramètres de type
+func typeParameterList(params []ast.TypeParameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var names []string
+	for _, param := range params {
+		names = append(names, param.Name)
+	}
+	return "<" + strings.Join(names, ", ") + ">"
+}
+
+// capitalize met en majuscule la première lettre d'un nom : count -> Count
+func capitalize(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// mutatesThis indique si un corps de méthode affecte un champ de this, pour les
+// langages qui distinguent les receveurs mutables (Rust &mut self)
+func mutatesThis(body []ast.Statement) bool {
+	found := false
+	inspectStatements(body, func(node ast.Node) bool {
+		if ae, ok := node.(*ast.AssignmentExpression); ok && isThisMember(ae.Left) {
+			found = true
+		}
+		return !found && !bindsThis(node)
+	})
+	return found
+}
+
+// isThisMember indique si une expression désigne this.x (ou this.x[i], this.x.y)
+func isThisMember(expr ast.Expression) bool {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		return e.Value == "this"
+	case *ast.DotExpression:
+		return isThisMember(e.Object)
+	case *ast.IndexExpression:
+		return isThisMember(e.Left)
+	}
+	return false
+}
+
+// thisFieldAssignment renvoie le champ et la valeur d'une instruction
+// this.field = valeur, ou "" si l'instruction n'est pas de cette forme
+func thisFieldAssignment(stmt ast.Statement) (string, ast.Expression) {
+	es, ok := stmt.(*ast.ExpressionStatement)
+	if !ok {
+		return "", nil
+	}
+	ae, ok := es.Expression.(*ast.AssignmentExpression)
+	if !ok || ae.Operator != "=" {
+		return "", nil
+	}
+	de, ok := ae.Left.(*ast.DotExpression)
+	if !ok {
+		return "", nil
+	}
+	if ident, ok := de.Object.(*ast.Identifier); ok && ident.Value == "this" {
+		return strings.TrimPrefix(de.Property, "#"), ae.Right
+	}
+	return "", nil
+}
+
+// privateAccess renvoie l'accès obj.#secret sous le nom que lui donne un
+// langage sans noms privés ECMAScript : rename reçoit le nom sans # (__secret
+// en Python, secret ailleurs). Les autres accès sont renvoyés tels quels.
+func privateAccess(de *ast.DotExpression, rename func(string) string) *ast.DotExpression {
+	if !strings.HasPrefix(de.Property, "#") {
+		return de
+	}
+	renamed := *de
+	renamed.Property = rename(de.Property[1:])
+	return &renamed
+}
+
+// bareName garde le nom d'un membre privé sans son # : la visibilité passe
+// par le modificateur private du langage
+func bareName(name string) string { return name }
+
+// unexported met en minuscule la première lettre d'un nom : en Go, c'est ce
+// qui rend un champ ou une méthode privé au paquet
+func unexported(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+// defaultClassName nomme la classe anonyme de export default class { ... }
+// dans les langages où toute classe a un nom
+const defaultClassName = "DefaultClass"
+
+// hoistClassExpressions remplace const Point = class { ... } par la
+// déclaration de classe Point, pour les langages sans expressions de classe ;
+// la classe prend le nom de la variable. La classe anonyme d'un export
+// default prend le nom defaultClassName.
+func hoistClassExpressions(statements []ast.Statement) []ast.Statement {
+	var result []ast.Statement
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *ast.VariableDeclaration:
+			if ce, ok := s.Value.(*ast.ClassExpression); ok {
+				class := *ce.Class
+				class.Name = s.Name
+				stmt = &class
+			}
+		case *ast.ExportDeclaration:
+			switch d := s.Declaration.(type) {
+			case *ast.VariableDeclaration:
+				hoisted := *s
+				hoisted.Declaration = hoistClassExpressions([]ast.Statement{d})[0]
+				stmt = &hoisted
+			case *ast.ClassDeclaration:
+				if d.Name == "" {
+					class := *d
+					class.Name = defaultClassName
+					named := *s
+					named.Declaration = &class
+					stmt = &named
+				}
+			}
+		}
+		result = append(result, stmt)
+	}
+	return result
+}
+
+// staticBlockStatements renvoie les instructions des blocs static { ... }
+// d'une classe, pour les langages qui les exécutent hors de la classe : this
+// y désigne la classe et devient son nom (this.count -> Counter.count)
+func staticBlockStatements(cd *ast.ClassDeclaration) []ast.Statement {
+	var statements []ast.Statement
+	for _, block := range cd.StaticBlocks {
+		for _, stmt := range block.Body {
+			statements = append(statements, replaceThis(stmt, cd.Name))
+		}
+	}
+	return statements
+}
+
+// replaceThis remplace this par l'identifiant name dans une instruction ; les
+// fonctions fléchées, qui gardent le this englobant, sont parcourues, pas les
+// fonctions et classes imbriquées
+func replaceThis(stmt ast.Statement, name string) ast.Statement {
+	bound := map[*ast.Identifier]bool{}
+	ast.Inspect(stmt, func(node ast.Node) bool {
+		if ident, ok := node.(*ast.Identifier); ok && ident.Value == "this" {
+			bound[ident] = true
+		}
+		return !bindsThis(node)
+	})
+	return ast.Rewrite(stmt, func(node ast.Node) ast.Node {
+		if ident, ok := node.(*ast.Identifier); ok && bound[ident] {
+			return &ast.Identifier{Span: ident.Span, Value: name}
+		}
+		return node
+	}).(ast.Statement)
+}
@@ -0,0 +1,134 @@
+package generator
+
+import "testing"
+
+const accessSource = `class Base {
+  protected count: number = 0;
+  private secret: string = "s";
+  constructor(protected readonly name: string) {}
+  protected bump(): void {
+    this.count = this.count + 1;
+  }
+  protected get total(): number {
+    return this.count;
+  }
+}`
+
+func TestAccessModifiers(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "protected",
+			source: accessSource,
+			want: map[TargetLanguage][]string{
+				Java:   {"protected double count = 0;", "private String secret", "protected final String name;", "protected void bump()", "protected double getTotal()"},
+				CSharp: {"protected double count = 0;", "private string secret", "protected string name;", "protected void bump()", "protected double total"},
+				PHP:    {"protected $count = 0;", "private $secret", "protected $name;", "protected function bump()", "protected function getTotal()"},
+				Swift:  {"internal var count: Double", "private var secret: String", "internal var name: String", "internal func bump()", "internal var total: Double"},
+			},
+			absent: map[TargetLanguage][]string{
+				Java:   {"private double count", "private void bump"},
+				CSharp: {"private double count", "private void bump"},
+				PHP:    {"private $count", "private function bump"},
+				Swift:  {"private var count", "private func bump"},
+			},
+		},
+	})
+}
+
+func TestRustMutableBindings(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name: "accesseur, méthode qui modifie this, push",
+			source: `class Thing {
+  private _v: number = 0;
+  set v(value: number) {
+    this._v = value;
+  }
+  bump(): void {
+    this._v = this._v + 1;
+  }
+  read(): number {
+    return this._v;
+  }
+}
+const t = new Thing();
+t.v = 2;
+const u = new Thing();
+u.bump();
+const w = new Thing();
+console.log(w.read());
+const xs = [1];
+xs.push(2);`,
+			want: map[TargetLanguage][]string{
+				Rust: {"let mut t = Thing::new();", "t.set_v(2.0);", "let mut u = Thing::new();", "let w = Thing::new();", "let mut xs = vec![1.0];"},
+			},
+		},
+		{
+			name: "redéfinition qui modifie this",
+			source: `class Base {
+  n: number = 0;
+  step(): void {}
+}
+class Sub extends Base {
+  step(): void {
+    this.n = this.n + 1;
+  }
+}
+const b = new Base();
+b.step();`,
+			want: map[TargetLanguage][]string{
+				Rust: {"let mut b = Base::new();"},
+			},
+		},
+	})
+}
+
+func TestAnonymousDefaultClass(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "export default class",
+			source: "export default class {\n  n: number = 1;\n  get(): number {\n    return this.n;\n  }\n}",
+			want: map[TargetLanguage][]string{
+				JavaScript: {"export default class {"},
+				Java:       {"static class DefaultClass {"},
+				Python:     {"class DefaultClass:", "default = DefaultClass"},
+				CSharp:     {"class DefaultClass"},
+				Go:         {"type DefaultClass struct {", "func (d *DefaultClass) get() float64 {"},
+				Rust:       {"pub use self::DefaultClass as default;", "pub struct DefaultClass {"},
+				Swift:      {"class DefaultClass {"},
+				PHP:        {"class DefaultClass {"},
+			},
+		},
+	})
+}
+
+func TestRustInheritedAccessors(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name: "accesseurs d'une sous-classe",
+			source: `class Shape {
+  area(): number {
+    return 0;
+  }
+}
+class Circle extends Shape {
+  private _radius: number = 1;
+  get radius(): number {
+    return this._radius;
+  }
+  set radius(value: number) {
+    this._radius = value;
+  }
+  area(): number {
+    return 3.14 * this._radius * this._radius;
+  }
+}
+const c = new Circle();
+c.radius = 3;
+console.log(c.radius);`,
+			want: map[TargetLanguage][]string{
+				Rust: {"impl Circle {", "fn radius(&self) -> f64 {", "fn set_radius(&mut self, value: f64) {", "c.set_radius(3.0);", "impl ShapeMethods for Circle {"},
+			},
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// DecoratorFormat choisit la forme des décorateurs JavaScript générés
+type DecoratorFormat string
+
+const (
+	TC39Decorators   DecoratorFormat = "tc39"   // @Injectable() class X, syntaxe standard
+	LegacyDecorators DecoratorFormat = "legacy" // appels __decorate, comme tsc avec experimentalDecorators
+)
+
+// DecoratorMapping traduit un décorateur TypeScript dans les langages qui ont
+// leur propre mécanisme : annotation Java, décorateur Python, attribut C#.
+// $args y est remplacé par les arguments du décorateur ; un langage sans
+// traduction garde le décorateur en commentaire.
+type DecoratorMapping struct {
+	Java   string `json:"java"`   // @Deprecated
+	Python string `json:"python"` // @functools.cache
+	CSharp string `json:"csharp"` // [Obsolete($args)]
+}
+
+// DefaultDecoratorMappings traduit les décorateurs courants ; les services
+// Angular deviennent des beans Spring
+var DefaultDecoratorMappings = map[string]DecoratorMapping{
+	"Injectable":   {Java: "@org.springframework.stereotype.Service"},
+	"Component":    {Java: "@org.springframework.stereotype.Component"},
+	"Deprecated":   {Java: "@Deprecated", CSharp: "[Obsolete($args)]"},
+	"deprecated":   {Java: "@Deprecated", CSharp: "[Obsolete($args)]"},
+	"Serializable": {CSharp: "[Serializable]"},
+}
+
+// decoratorTable extrait la traduction d'un langage des correspondances par
+// défaut complétées (ou remplacées) par celles des options
+func decoratorTable(custom map[string]DecoratorMapping, target func(DecoratorMapping) string) map[string]string {
+	table := map[string]string{}
+	for _, mappings := range []map[string]DecoratorMapping{DefaultDecoratorMappings, custom} {
+		for name, mapping := range mappings {
+			if translation := target(mapping); translation != "" {
+				table[name] = translation
+			} else {
+				delete(table, name)
+			}
+		}
+	}
+	return table
+}
+
+// decoratorExpression reconstruit l'expression d'un décorateur :
+// @core.Component({ ... }) -> core.Component({ ... })
+func decoratorExpression(d ast.Decorator) ast.Expression {
+	parts := strings.Split(d.Name, ".")
+	var expr ast.Expression = &ast.Identifier{Value: parts[0]}
+	for _, part := range parts[1:] {
+		expr = &ast.DotExpression{Object: expr, Property: part}
+	}
+	if d.IsCall {
+		expr = &ast.CallExpression{Function: expr, Arguments: d.Arguments}
+	}
+	return expr
+}
+
+// decoratorSource redonne le décorateur tel qu'écrit en TypeScript, pour le
+// garder en commentaire dans les langages qui ne le traduisent pas
+func decoratorSource(d ast.Decorator) string {
+	lines := strings.Split((&JavaScriptGenerator{}).GenerateExpression(decoratorExpression(d)), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return "@" + strings.Join(lines, " ")
+}
+
+// translateDecorator renvoie la traduction d'un décorateur, cherchée par son nom
+// complet puis par son dernier segment (core.Component -> Component)
+func translateDecorator(d ast.Decorator, table map[string]string, generate func(ast.Expression) string) (string, bool) {
+	translation, ok := table[d.Name]
+	if !ok {
+		translation, ok = table[d.Name[strings.LastIndex(d.Name, ".")+1:]]
+	}
+	if !ok {
+		return "", false
+	}
+	if len(d.Arguments) == 0 {
+		// [Obsolete($args)] sans argument -> [Obsolete]
+		translation = strings.ReplaceAll(translation, "($args)", "")
+	}
+	var args []string
+	for _, arg := range d.Arguments {
+		args = append(args, generate(arg))
+	}
+	return strings.ReplaceAll(translation, "$args", strings.Join(args, ", ")), true
+}
+
+// decoratorLines génère une ligne par décorateur, traduit ou en commentaire,
+// au-dessus d'une classe ou d'un membre
+func decoratorLines(decorators []ast.Decorator, table map[string]string, generate func(ast.Expression) string, comment, indent string) string {
+	var sb strings.Builder
+	for _, d := range decorators {
+		if translation, ok := translateDecorator(d, table, generate); ok {
+			sb.WriteString(indent + translation + "\n")
+		} else {
+			sb.WriteString(indent + comment + " " + decoratorSource(d) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// inlineDecorators génère les décorateurs d'un paramètre devant son nom ; ceux
+// sans traduction restent en commentaire /* @Inject(TOKEN) */
+func inlineDecorators(decorators []ast.Decorator, table map[string]string, generate func(ast.Expression) string) string {
+	var sb strings.Builder
+	for _, d := range decorators {
+		if translation, ok := translateDecorator(d, table, generate); ok {
+			sb.WriteString(translation + " ")
+		} else {
+			sb.WriteString("/* " + decoratorSource(d) + " */ ")
+		}
+	}
+	return sb.String()
+}
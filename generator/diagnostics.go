@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Diagnostic signale une construction du source que la cible ne sait pas
+// exprimer : le code est généré au plus près et la construction écartée y
+// reste en commentaire
+type Diagnostic struct {
+	ast.Span
+	Target  TargetLanguage
+	Message string
+}
+
+// String écrit le diagnostic sous la forme ligne:colonne: [cible] message
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: [%s] %s", d.Pos.Line, d.Pos.Column, d.Target, d.Message)
+}
+
+// Raisons pour lesquelles une cible n'exprime pas un héritage multiple : une
+// interface qui étend une classe, une classe qui implémente une classe. Une
+// cible absente l'exprime (JavaScript efface les interfaces, un protocole
+// Swift peut exiger une classe, Python type les objets par leur forme).
+var (
+	interfaceExtendsClass = map[TargetLanguage]string{
+		Java:   "une interface Java ne peut pas étendre une classe",
+		CSharp: "une interface C# ne peut pas étendre une classe",
+		Python: "un Protocol ne peut pas hériter d'une classe",
+		Go:     "une interface Go ne peut pas embarquer une struct",
+		Rust:   "un trait ne peut pas étendre une struct",
+		PHP:    "une interface PHP ne peut pas étendre une classe",
+	}
+	classImplementsClass = map[TargetLanguage]string{
+		Java:   "une classe Java n'implémente que des interfaces",
+		CSharp: "une classe C# n'a qu'une classe parente et n'implémente que des interfaces",
+		Go:     "une struct Go ne satisfait que des interfaces",
+		Rust:   "une struct n'implémente que des traits",
+		Swift:  "une classe Swift n'a qu'une classe parente et n'adopte que des protocoles",
+		PHP:    "une classe PHP n'implémente que des interfaces",
+	}
+)
+
+// Diagnose renvoie, dans l'ordre du source, les diagnostics de la génération
+// d'un programme vers une cible : les héritages multiples qu'elle n'exprime
+// pas, et les types de namespace dont le nom est repris ailleurs quand elle
+// aplatit les namespaces. Les interfaces Go sont des structs sauf si une
+// classe les implémente ; une struct peut embarquer une classe.
+func Diagnose(program *ast.Program, targetLang TargetLanguage) []Diagnostic {
+	statements := hoistClassExpressions(flattenNamespaces(program.Statements))
+	classes := collectClasses(statements)
+	implemented := implementedByClasses(classes, collectInterfaces(statements))
+
+	var diagnostics []Diagnostic
+	report := func(node ast.Node, format string, args ...interface{}) {
+		diagnostics = append(diagnostics, Diagnostic{Span: ast.Position(node), Target: targetLang, Message: fmt.Sprintf(format, args...)})
+	}
+	for _, stmt := range declaredStatements(statements) {
+		switch s := stmt.(type) {
+		case *ast.Interface:
+			reason := interfaceExtendsClass[targetLang]
+			if reason == "" || targetLang == Go && !implemented[s.Name] {
+				continue
+			}
+			_, rejected := classBases(s.Extends, classes)
+			for _, class := range rejected {
+				report(s, "%s étend la classe %s : %s", s.Name, class, reason)
+			}
+		case *ast.ClassDeclaration:
+			reason := classImplementsClass[targetLang]
+			if reason == "" {
+				continue
+			}
+			_, rejected := classBases(s.Implements, classes)
+			for _, class := range rejected {
+				report(s, "%s implémente la classe %s : %s", s.Name, class, reason)
+			}
+		}
+	}
+	if flattensNamespaces[targetLang] {
+		for _, c := range namespaceCollisions(program.Statements) {
+			switch c.Declaration.(type) {
+			case *ast.FunctionDeclaration, *ast.VariableDeclaration:
+				// Renommées par prefixNamespaceCollisions
+				continue
+			}
+			report(c.Declaration, "%s de %s est aussi déclaré hors de ce namespace : les namespaces aplatis confondent les deux types", c.Name, c.Path)
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Pos.Offset < diagnostics[j].Pos.Offset
+	})
+	return diagnostics
+}
+
+// implementedClassComments laisse dans la sortie, au-dessus d'une classe, les
+// classes de sa clause implements que la cible a dû écarter
+func implementedClassComments(name string, rejected []string, targetLang TargetLanguage, indent string) string {
+	var sb strings.Builder
+	for _, class := range rejected {
+		sb.WriteString(indent + "// " + name + " implémente la classe " + class + " : " + classImplementsClass[targetLang] + "\n")
+	}
+	return sb.String()
+}
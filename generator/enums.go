@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// resolveEnumMembers renvoie les membres d'un enum avec leur valeur calculée :
+// comme en TypeScript, un membre sans initialiseur vaut le précédent + 1
+// (0 pour le premier)
+func resolveEnumMembers(ed *ast.EnumDeclaration) []ast.EnumMember {
+	var members []ast.EnumMember
+	next := 0.0
+
+	for _, member := range ed.Members {
+		value := member.Value
+		if value == nil {
+			value = &ast.NumberLiteral{Value: strconv.FormatFloat(next, 'f', -1, 64)}
+		}
+		if nl, ok := value.(*ast.NumberLiteral); ok {
+			if n, err := strconv.ParseFloat(nl.Value, 64); err == nil {
+				next = n + 1
+			}
+		}
+		members = append(members, ast.EnumMember{Name: member.Name, Value: value})
+	}
+
+	return members
+}
+
+// isStringEnum indique si au moins un membre de l'enum a une valeur chaîne
+func isStringEnum(members []ast.EnumMember) bool {
+	for _, member := range members {
+		if _, ok := member.Value.(*ast.StringLiteral); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isAutoIncrementedEnum indique si aucun membre n'a de valeur explicite
+func isAutoIncrementedEnum(ed *ast.EnumDeclaration) bool {
+	for _, member := range ed.Members {
+		if member.Value != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// enumValueString renvoie la valeur d'un membre sous forme de chaîne, pour les
+// enums mixtes représentés par des chaînes dans les langages cibles
+func enumValueString(value ast.Expression) string {
+	switch v := value.(type) {
+	case *ast.StringLiteral:
+		return v.Value
+	case *ast.NumberLiteral:
+		return v.Value
+	}
+	return ""
+}
+
+// collectEnums indexe les enums déclarés dans le programme par nom, y compris
+// dans les namespaces et les déclarations ambiantes
+func collectEnums(statements []ast.Statement) map[string]*ast.EnumDeclaration {
+	enums := map[string]*ast.EnumDeclaration{}
+	for _, stmt := range declaredStatements(statements) {
+		if ed, ok := stmt.(*ast.EnumDeclaration); ok {
+			enums[ed.Name] = ed
+		}
+	}
+	return enums
+}
+
+// enumMemberAccess renvoie l'enum et le membre désignés par Status.Pending,
+// ou nil si l'expression n'est pas un accès à un membre d'enum connu
+func enumMemberAccess(enums map[string]*ast.EnumDeclaration, de *ast.DotExpression) (*ast.EnumDeclaration, *ast.EnumMember) {
+	ident, ok := de.Object.(*ast.Identifier)
+	if !ok {
+		return nil, nil
+	}
+	ed, ok := enums[ident.Value]
+	if !ok {
+		return nil, nil
+	}
+	for _, member := range resolveEnumMembers(ed) {
+		if member.Name == de.Property {
+			return ed, &member
+		}
+	}
+	return ed, nil
+}
+
+// literalUnionEnum convertit un alias d'union de littéraux chaîne en enum
+// chaîne équivalent : type TaskStatus = 'pending' | 'in_progress' devient
+// enum TaskStatus { Pending = "pending", InProgress = "in_progress" }.
+// Renvoie nil si l'alias n'est pas une union de littéraux.
+func literalUnionEnum(ta *ast.TypeAlias) *ast.EnumDeclaration {
+	if len(ta.Literals) == 0 {
+		return nil
+	}
+	ed := &ast.EnumDeclaration{Name: ta.Name}
+	for _, value := range ta.Literals {
+		ed.Members = append(ed.Members, ast.EnumMember{
+			Name:  enumMemberName(value),
+			Value: &ast.StringLiteral{Value: value},
+		})
+	}
+	return ed
+}
+
+// enumMemberName dérive un identifiant PascalCase d'une valeur littérale :
+// in_progress -> InProgress, "in progress" -> InProgress, 2fa -> _2fa
+func enumMemberName(value string) string {
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var sb strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		sb.WriteString(string(unicode.ToUpper(runes[0])) + string(runes[1:]))
+	}
+	name := sb.String()
+	if name == "" {
+		return "Empty"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		return "_" + name
+	}
+	return name
+}
@@ -0,0 +1,40 @@
+package generator
+
+import "testing"
+
+func TestComputedDefaults(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "défaut lisant un paramètre précédent",
+			source: "function g(a: number, b: number = a * 2): number {\n  return a + b;\n}",
+			want: map[TargetLanguage][]string{
+				Python: {"def g(a, b=None):", "    if b is None:\n        b = a * 2"},
+				CSharp: {"static double g(double a, double? bOpt = null)", "var b = bOpt ?? a * 2;"},
+				Swift:  {"func g(_ a: Double, _ b: Double? = nil) -> Double {", "let b = b ?? a * 2.0"},
+			},
+			absent: map[TargetLanguage][]string{
+				Python: {"b=a * 2"},
+				CSharp: {"b ??="},
+				Swift:  {"= a * 2.0)"},
+			},
+		},
+		{
+			name:   "défaut de méthode",
+			source: "class Scale {\n  factor = 2;\n  apply(x: number, y: number = x + this.factor): number {\n    return y;\n  }\n}",
+			want: map[TargetLanguage][]string{
+				Python: {"def apply(self, x, y=None):", "if y is None:"},
+				CSharp: {"double? yOpt = null", "var y = yOpt ?? x + this.factor;"},
+				Swift:  {"_ y: Double? = nil", "let y = y ?? x + Double(self.factor)"},
+			},
+		},
+		{
+			name:   "défaut constant",
+			source: "function k(x: number = 3): number {\n  return x;\n}",
+			want: map[TargetLanguage][]string{
+				Python: {"def k(x=3):"},
+				CSharp: {"static double k(double x = 3)"},
+				Swift:  {"func k(_ x: Double = 3.0) -> Double {"},
+			},
+		},
+	})
+}
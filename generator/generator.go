@@ -2,6 +2,8 @@ package generator
 
 import (
 	"ProjetGo/ast"
+	"ProjetGo/semantic"
+	"strconv"
 	"strings"
 )
 
@@ -24,68 +26,405 @@ type CodeGenerator interface {
 	Generate(statements []ast.Statement) string
 }
 
+// ModuleFormat choisit la syntaxe des modules JavaScript générés
+type ModuleFormat string
+
+const (
+	ESModule ModuleFormat = "esm"      // import / export
+	CommonJS ModuleFormat = "commonjs" // require / exports
+)
+
+// Options règle la génération ; la valeur zéro correspond aux défauts
+type Options struct {
+	Modules    ModuleFormat    // JavaScript : ESModule par défaut
+	Decorators DecoratorFormat // JavaScript : syntaxe TC39 par défaut
+	JSX        JSXRuntime      // JavaScript : React.createElement par défaut
+
+	// DecoratorMappings complète DefaultDecoratorMappings ; une traduction vide
+	// retire celle par défaut
+	DecoratorMappings map[string]DecoratorMapping
+}
+
 // Generate génère du code dans le langage cible spécifié
-func Generate(statements []ast.Statement, targetLang TargetLanguage) string {
+func Generate(program *ast.Program, targetLang TargetLanguage) string {
+	return GenerateWithOptions(program, targetLang, Options{})
+}
+
+// GenerateWithOptions génère du code dans le langage cible avec des options
+func GenerateWithOptions(program *ast.Program, targetLang TargetLanguage, options Options) string {
 	var generator CodeGenerator
+	if flattensNamespaces[targetLang] {
+		program = prefixNamespaceCollisions(program)
+	}
+	statements := program.Statements
 
 	switch targetLang {
 	case JavaScript:
-		generator = &JavaScriptGenerator{}
+		generator = &JavaScriptGenerator{Modules: options.Modules, Decorators: options.Decorators, JSX: options.JSX, Directives: program.Directives}
 	case Java:
-		generator = &JavaGenerator{}
+		generator = &JavaGenerator{Decorators: decoratorTable(options.DecoratorMappings, func(m DecoratorMapping) string { return m.Java }), types: infer(program)}
 	case Python:
-		generator = &PythonGenerator{}
+		generator = &PythonGenerator{Decorators: decoratorTable(options.DecoratorMappings, func(m DecoratorMapping) string { return m.Python })}
 	case CSharp:
-		generator = &CSharpGenerator{}
+		generator = &CSharpGenerator{Decorators: decoratorTable(options.DecoratorMappings, func(m DecoratorMapping) string { return m.CSharp }), types: infer(program)}
 	case Go:
-		generator = &GoGenerator{}
+		generator = &GoGenerator{types: infer(program)}
 	case Rust:
-		generator = &RustGenerator{}
+		generator = &RustGenerator{types: infer(program)}
 	case Swift:
-		generator = &SwiftGenerator{}
+		generator = &SwiftGenerator{types: infer(program)}
 	case PHP:
 		generator = &PHPGenerator{}
 	default:
-		generator = &JavaScriptGenerator{} // défaut
+		generator = &JavaScriptGenerator{Modules: options.Modules, Decorators: options.Decorators, JSX: options.JSX, Directives: program.Directives} // défaut
+	}
+
+	// Le JSX n'a de sens qu'avec React, en JavaScript
+	if _, ok := generator.(*JavaScriptGenerator); !ok {
+		if node := findJSX(statements); node != nil {
+			return jsxDiagnostic(targetLang, node)
+		}
+		// Seul JavaScript a des expressions de classe
+		statements = hoistClassExpressions(statements)
 	}
 
 	return generator.Generate(statements)
 }
 
 // JavaScriptGenerator génère du code JavaScript
-type JavaScriptGenerator struct{}
+type JavaScriptGenerator struct {
+	Modules    ModuleFormat                    // ESModule (défaut) ou CommonJS
+	Decorators DecoratorFormat                 // TC39Decorators (défaut) ou LegacyDecorators
+	JSX        JSXRuntime                      // ClassicJSX (défaut) ou AutomaticJSX
+	Directives []string                        // prologue du fichier : "use strict"
+	constEnums map[string]*ast.EnumDeclaration // const enum dont les accès sont inlinés
+
+	usesDecorate bool // décorateurs legacy : helper __decorate
+	usesParam    bool // décorateurs de paramètres legacy : helper __param
+
+	namespaceDepth int // namespaces englobants : var au niveau du programme, let au-dessous
+
+	jsxImports map[string]bool // fonctions de react/jsx-runtime utilisées : jsx, jsxs, Fragment
+}
 
 func (jsg *JavaScriptGenerator) Generate(statements []ast.Statement) string {
 	var sb strings.Builder
 
+	jsg.constEnums = map[string]*ast.EnumDeclaration{}
+	jsg.jsxImports = map[string]bool{}
+	for name, ed := range collectEnums(statements) {
+		if ed.IsConst {
+			jsg.constEnums[name] = ed
+		}
+	}
+
 	for _, stmt := range statements {
 		switch s := stmt.(type) {
-		case *ast.VariableDeclaration:
-			sb.WriteString(jsg.GenerateVariableDeclaration(s))
-		case *ast.FunctionDeclaration:
-			sb.WriteString(jsg.GenerateFunction(s))
-		case *ast.IfStatement:
-			sb.WriteString(jsg.GenerateIfStatement(s))
-		case *ast.ForStatement:
-			sb.WriteString(jsg.GenerateForStatement(s))
-		case *ast.WhileStatement:
-			sb.WriteString(jsg.GenerateWhileStatement(s))
-		case *ast.ReturnStatement:
-			sb.WriteString(jsg.GenerateReturnStatement(s))
-		case *ast.ExpressionStatement:
-			sb.WriteString(jsg.GenerateExpressionStatement(s))
-		case *ast.TypeAlias:
-			sb.WriteString(jsg.GenerateTypeAlias(s))
-		case *ast.Interface:
-			sb.WriteString(jsg.GenerateInterface(s))
-		case *ast.ClassDeclaration:
-			sb.WriteString(jsg.GenerateClass(s))
+		case *ast.ImportDeclaration:
+			sb.WriteString(jsg.GenerateImport(s))
+		case *ast.ExportDeclaration:
+			sb.WriteString(jsg.GenerateExport(s))
+		default:
+			sb.WriteString(jsg.generateTopLevel(stmt))
+		}
+	}
+
+	// Helpers des décorateurs legacy, repris de ceux qu'émet tsc
+	var helpers strings.Builder
+	if jsg.usesDecorate {
+		helpers.WriteString("var __decorate = (this && this.__decorate) || function (decorators, target, key, desc) {\n")
+		helpers.WriteString("    var c = arguments.length, r = c < 3 ? target : desc === null ? desc = Object.getOwnPropertyDescriptor(target, key) : desc, d;\n")
+		helpers.WriteString("    for (var i = decorators.length - 1; i >= 0; i--) if (d = decorators[i]) r = (c < 3 ? d(r) : c > 3 ? d(target, key, r) : d(target, key)) || r;\n")
+		helpers.WriteString("    return c > 3 && r && Object.defineProperty(target, key, r), r;\n")
+		helpers.WriteString("};\n")
+	}
+	if jsg.usesParam {
+		helpers.WriteString("var __param = (this && this.__param) || function (paramIndex, decorator) {\n")
+		helpers.WriteString("    return function (target, key) { decorator(target, key, paramIndex); }\n")
+		helpers.WriteString("};\n")
+	}
+	// Les directives doivent rester en tête du fichier
+	var prologue strings.Builder
+	for _, directive := range jsg.Directives {
+		prologue.WriteString("\"" + directive + "\";\n")
+	}
+	if helpers.Len() > 0 {
+		return prologue.String() + jsg.generateJSXImport() + helpers.String() + "\n" + sb.String()
+	}
+	return prologue.String() + jsg.generateJSXImport() + sb.String()
+}
+
+func (jsg *JavaScriptGenerator) generateTopLevel(stmt ast.Statement) string {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		return jsg.GenerateVariableDeclaration(s)
+	case *ast.FunctionDeclaration:
+		return jsg.GenerateFunction(s)
+	case *ast.IfStatement:
+		return jsg.GenerateIfStatement(s)
+	case *ast.ForStatement:
+		return jsg.GenerateForStatement(s)
+	case *ast.WhileStatement:
+		return jsg.GenerateWhileStatement(s)
+	case *ast.ReturnStatement:
+		return jsg.GenerateReturnStatement(s)
+	case *ast.ExpressionStatement:
+		return jsg.GenerateExpressionStatement(s)
+	case *ast.TypeAlias:
+		return jsg.GenerateTypeAlias(s)
+	case *ast.Interface:
+		return jsg.GenerateInterface(s)
+	case *ast.ClassDeclaration:
+		return jsg.GenerateClass(s)
+	case *ast.EnumDeclaration:
+		return jsg.GenerateEnum(s)
+	case *ast.NamespaceDeclaration:
+		return jsg.GenerateNamespace(s, "")
+	case *ast.AmbientDeclaration:
+		// Implémentée ailleurs : seul son type compte
+		return "// Ambient declaration: " + declarationName(s.Declaration) + "\n"
+	}
+	return ""
+}
+
+// GenerateNamespace génère un namespace comme tsc : une IIFE qui reçoit
+// l'objet du namespace et y range ses exports. parent est l'objet du
+// namespace englobant qui exporte celui-ci, vide sinon.
+//
+//	var Geometry;
+//	(function (Geometry) {
+//	    function area(r) { ... }
+//	    Geometry.area = area;
+//	})(Geometry || (Geometry = {}));
+func (jsg *JavaScriptGenerator) GenerateNamespace(nd *ast.NamespaceDeclaration, parent string) string {
+	if !isInstantiated(nd) {
+		return "// Namespace: " + nd.Name + "\n"
+	}
+
+	keyword := "var"
+	if jsg.namespaceDepth > 0 {
+		keyword = "let"
+	}
+	jsg.namespaceDepth++
+	defer func() { jsg.namespaceDepth-- }()
+
+	var body strings.Builder
+	for _, stmt := range nd.Body {
+		ed, ok := stmt.(*ast.ExportDeclaration)
+		switch {
+		case !ok:
+			if inner, ok := stmt.(*ast.NamespaceDeclaration); ok {
+				body.WriteString(jsg.GenerateNamespace(inner, ""))
+			} else {
+				body.WriteString(jsg.generateTopLevel(stmt))
+			}
+		case ed.Declaration != nil:
+			if inner, ok := ed.Declaration.(*ast.NamespaceDeclaration); ok {
+				body.WriteString(jsg.GenerateNamespace(inner, nd.Name))
+				continue
+			}
+			code := jsg.generateTopLevel(ed.Declaration)
+			if name := declarationName(ed.Declaration); name != "" && !isTypeDeclaration(ed.Declaration) {
+				// L'export suit la déclaration, avant sa ligne vide éventuelle
+				declaration := strings.TrimRight(code, "\n")
+				code = declaration + "\n" + nd.Name + "." + name + " = " + name + ";\n" + code[len(declaration)+1:]
+			}
+			body.WriteString(code)
+		default:
+			// export { a as b } : les ré-exports n'existent pas dans un namespace
+			for _, spec := range ed.Specifiers {
+				if !spec.IsType {
+					body.WriteString(nd.Name + "." + spec.LocalName() + " = " + spec.Name + ";\n")
+				}
+			}
+		}
+	}
+
+	var sb strings.Builder
+	object := nd.Name + " || (" + nd.Name + " = {})"
+	if parent != "" {
+		// Namespace exporté par son parent : (Inner = Geometry.Inner || (Geometry.Inner = {}))
+		member := parent + "." + nd.Name
+		object = nd.Name + " = " + member + " || (" + member + " = {})"
+	}
+	sb.WriteString(keyword + " " + nd.Name + ";\n")
+	sb.WriteString("(function (" + nd.Name + ") {\n")
+	sb.WriteString(indentLines(body.String(), "    "))
+	sb.WriteString("})(" + object + ");\n")
+	return sb.String()
+}
+
+// GenerateImport génère un import ESM ou un require CommonJS ; comme tsc, les
+// imports de types sont effacés, et un import qui n'importe que des types
+// disparaît
+func (jsg *JavaScriptGenerator) GenerateImport(id *ast.ImportDeclaration) string {
+	specs := valueSpecifiers(id)
+	source := "\"" + id.Source + "\""
+	if id.IsTypeOnly || (id.Default == "" && id.Namespace == "" && len(specs) == 0 && len(id.Specifiers) > 0) {
+		return ""
+	}
+
+	if jsg.Modules == CommonJS {
+		require := "require(" + source + ")"
+		var sb strings.Builder
+		if id.Default == "" && id.Namespace == "" && len(specs) == 0 {
+			sb.WriteString(require + ";\n")
+		}
+		if id.Default != "" {
+			sb.WriteString("const " + id.Default + " = " + require + ".default;\n")
+		}
+		if id.Namespace != "" {
+			sb.WriteString("const " + id.Namespace + " = " + require + ";\n")
+		}
+		if len(specs) > 0 {
+			var names []string
+			for _, spec := range specs {
+				if spec.Alias != "" {
+					names = append(names, spec.Name+": "+spec.Alias)
+				} else {
+					names = append(names, spec.Name)
+				}
+			}
+			sb.WriteString("const { " + strings.Join(names, ", ") + " } = " + require + ";\n")
+		}
+		return sb.String()
+	}
+
+	var clauses []string
+	if id.Default != "" {
+		clauses = append(clauses, id.Default)
+	}
+	if id.Namespace != "" {
+		clauses = append(clauses, "* as "+id.Namespace)
+	}
+	if len(specs) > 0 {
+		clauses = append(clauses, "{ "+jsg.moduleSpecifiers(specs)+" }")
+	}
+	if len(clauses) == 0 {
+		return "import " + source + ";\n"
+	}
+	return "import " + strings.Join(clauses, ", ") + " from " + source + ";\n"
+}
+
+// GenerateExport génère un export ESM ou des affectations exports.x CommonJS
+func (jsg *JavaScriptGenerator) GenerateExport(ed *ast.ExportDeclaration) string {
+	if ed.IsTypeOnly || isTypeDeclaration(ed.Declaration) {
+		if ed.Declaration != nil {
+			return jsg.generateTopLevel(ed.Declaration)
+		}
+		return ""
+	}
+
+	// export default <expression>
+	if isDefaultExpression(ed) {
+		value := jsg.GenerateExpression(ed.Declaration.(*ast.ExpressionStatement).Expression)
+		if jsg.Modules == CommonJS {
+			return "exports.default = " + value + ";\n"
+		}
+		return "export default " + value + ";\n"
+	}
+
+	// export <déclaration>
+	if ed.Declaration != nil {
+		code := jsg.generateTopLevel(ed.Declaration)
+		name := declarationName(ed.Declaration)
+		if cd, ok := ed.Declaration.(*ast.ClassDeclaration); ok && ed.IsDefault && jsg.Modules != CommonJS && jsg.rebindsClass(cd) {
+			// let X = class X {} ne peut pas suivre export default
+			return code + "export default " + name + ";\n"
+		}
+		if jsg.Modules == CommonJS {
+			exported := name
+			if ed.IsDefault {
+				exported = "default"
+			}
+			return code + "exports." + exported + " = " + name + ";\n"
+		}
+		if ed.IsDefault {
+			return "export default " + code
+		}
+		return "export " + code
+	}
+
+	var specs []ast.ImportSpecifier
+	for _, spec := range ed.Specifiers {
+		if !spec.IsType {
+			specs = append(specs, spec)
+		}
+	}
+	source := "\"" + ed.Source + "\""
+
+	if jsg.Modules == CommonJS {
+		var sb strings.Builder
+		switch {
+		case ed.IsWildcard && ed.Namespace != "":
+			sb.WriteString("exports." + ed.Namespace + " = require(" + source + ");\n")
+		case ed.IsWildcard:
+			sb.WriteString("Object.assign(exports, require(" + source + "));\n")
+		default:
+			for _, spec := range specs {
+				value := spec.Name
+				if ed.Source != "" {
+					value = "require(" + source + ")." + spec.Name
+				}
+				sb.WriteString("exports." + spec.LocalName() + " = " + value + ";\n")
+			}
 		}
+		return sb.String()
 	}
 
+	var sb strings.Builder
+	switch {
+	case ed.IsWildcard && ed.Namespace != "":
+		sb.WriteString("export * as " + ed.Namespace)
+	case ed.IsWildcard:
+		sb.WriteString("export *")
+	case len(specs) == 0 && len(ed.Specifiers) > 0:
+		return "" // n'exportait que des types
+	default:
+		sb.WriteString("export { " + jsg.moduleSpecifiers(specs) + " }")
+	}
+	if ed.Source != "" {
+		sb.WriteString(" from " + source)
+	}
+	sb.WriteString(";\n")
 	return sb.String()
 }
 
+// moduleSpecifiers génère a, b as c
+func (jsg *JavaScriptGenerator) moduleSpecifiers(specs []ast.ImportSpecifier) string {
+	var names []string
+	for _, spec := range specs {
+		if spec.Alias != "" {
+			names = append(names, spec.Name+" as "+spec.Alias)
+		} else {
+			names = append(names, spec.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// GenerateEnum génère un objet gelé avec, comme TypeScript, la correspondance
+// inverse valeur -> nom pour les membres numériques. Un const enum ne produit
+// aucun code : ses accès sont remplacés par la valeur du membre.
+func (jsg *JavaScriptGenerator) GenerateEnum(ed *ast.EnumDeclaration) string {
+	if ed.IsConst {
+		return "// const enum " + ed.Name + " (inliné)\n"
+	}
+
+	members := resolveEnumMembers(ed)
+	var entries []string
+	for _, member := range members {
+		entries = append(entries, "  "+member.Name+": "+jsg.GenerateExpression(member.Value))
+	}
+	for _, member := range members {
+		if nl, ok := member.Value.(*ast.NumberLiteral); ok {
+			entries = append(entries, "  \""+nl.Value+"\": \""+member.Name+"\"")
+		}
+	}
+
+	return "const " + ed.Name + " = Object.freeze({\n" + strings.Join(entries, ",\n") + "\n});\n"
+}
+
 func (jsg *JavaScriptGenerator) GenerateIfStatement(is *ast.IfStatement) string {
 	var sb strings.Builder
 	sb.WriteString("if (")
@@ -104,7 +443,7 @@ func (jsg *JavaScriptGenerator) GenerateForStatement(fs *ast.ForStatement) strin
 	var sb strings.Builder
 	sb.WriteString("for (")
 	if fs.Init != nil {
-		sb.WriteString(strings.TrimSuffix(jsg.GenerateStatement(fs.Init), "\n"))
+		sb.WriteString(strings.TrimSuffix(jsg.GenerateStatement(fs.Init), ";\n"))
 	}
 	sb.WriteString("; ")
 	if fs.Condition != nil {
@@ -112,7 +451,7 @@ func (jsg *JavaScriptGenerator) GenerateForStatement(fs *ast.ForStatement) strin
 	}
 	sb.WriteString("; ")
 	if fs.Update != nil {
-		sb.WriteString(strings.TrimSuffix(jsg.GenerateStatement(fs.Update), "\n"))
+		sb.WriteString(strings.TrimSuffix(jsg.GenerateStatement(fs.Update), ";\n"))
 	}
 	sb.WriteString(") ")
 	sb.WriteString(jsg.GenerateStatement(fs.Body))
@@ -155,6 +494,12 @@ func (jsg *JavaScriptGenerator) GenerateStatement(stmt ast.Statement) string {
 		return jsg.GenerateExpressionStatement(s)
 	case *ast.ReturnStatement:
 		return jsg.GenerateReturnStatement(s)
+	case *ast.IfStatement:
+		return jsg.GenerateIfStatement(s)
+	case *ast.ForStatement:
+		return jsg.GenerateForStatement(s)
+	case *ast.WhileStatement:
+		return jsg.GenerateWhileStatement(s)
 	}
 	return ""
 }
@@ -163,8 +508,7 @@ func (jsg *JavaScriptGenerator) GenerateBlockStatement(bs *ast.BlockStatement) s
 	var sb strings.Builder
 	sb.WriteString("{\n")
 	for _, stmt := range bs.Statements {
-		sb.WriteString("    ")
-		sb.WriteString(jsg.GenerateStatement(stmt))
+		sb.WriteString(indentLines(jsg.GenerateStatement(stmt), "    "))
 	}
 	sb.WriteString("}")
 	return sb.String()
@@ -182,6 +526,8 @@ func (jsg *JavaScriptGenerator) GenerateExpression(expr ast.Expression) string {
 		return jsg.GenerateTemplateLiteral(e)
 	case *ast.Identifier:
 		return e.Value
+	case *ast.ParenthesizedExpression:
+		return "(" + jsg.GenerateExpression(e.Expression) + ")"
 	case *ast.InfixExpression:
 		return jsg.GenerateExpression(e.Left) + " " + e.Operator + " " + jsg.GenerateExpression(e.Right)
 	case *ast.ArrayLiteral:
@@ -194,10 +540,191 @@ func (jsg *JavaScriptGenerator) GenerateExpression(expr ast.Expression) string {
 		return jsg.GenerateIndexExpression(e)
 	case *ast.DotExpression:
 		return jsg.GenerateDotExpression(e)
+	case *ast.SpreadElement:
+		return "..." + jsg.GenerateExpression(e.Argument)
+	case *ast.AssignmentExpression:
+		return jsg.GenerateAssignmentExpression(e)
+	case *ast.NewExpression:
+		return "new " + jsg.GenerateExpression(e.Class) + "(" + jsg.generateArguments(e.Arguments) + ")"
+	case *ast.AwaitExpression:
+		return "await " + jsg.GenerateExpression(e.Argument)
+	case *ast.YieldExpression:
+		yield := "yield"
+		if e.Delegate {
+			yield += "*"
+		}
+		if e.Argument != nil {
+			yield += " " + jsg.GenerateExpression(e.Argument)
+		}
+		return yield
+	case *ast.AsExpression:
+		return assertedOperand(e.Expression, jsg.GenerateExpression)
+	case *ast.SatisfiesExpression:
+		return assertedOperand(e.Expression, jsg.GenerateExpression)
+	case *ast.NonNullExpression:
+		return assertedOperand(e.Expression, jsg.GenerateExpression)
+	case *ast.ArrowFunction:
+		return jsg.GenerateArrowFunction(e)
+	case *ast.ClassExpression:
+		return strings.TrimSuffix(jsg.GenerateClass(e.Class), "\n\n")
+	case *ast.JSXElement:
+		return jsg.GenerateJSX(e.Name, e.Attributes, e.Children)
+	case *ast.JSXFragment:
+		return jsg.GenerateJSX("", nil, e.Children)
+	case *ast.JSXExpressionContainer:
+		return jsg.GenerateExpression(e.Expression)
+	case *ast.JSXText:
+		return jsxString(e.Value)
 	}
 	return ""
 }
 
+// GenerateJSX génère un élément JSX, ou un fragment quand name est vide :
+// React.createElement avec le runtime classique, _jsx et _jsxs de
+// react/jsx-runtime avec le runtime automatique, comme tsc avec jsx: react
+// et jsx: react-jsx
+func (jsg *JavaScriptGenerator) GenerateJSX(name string, attributes []ast.JSXAttribute, children []ast.Expression) string {
+	if jsg.JSX == AutomaticJSX {
+		return jsg.generateAutomaticJSX(name, attributes, children)
+	}
+
+	tag := "React.Fragment"
+	if isIntrinsicElement(name) {
+		tag = jsxString(name)
+	} else if name != "" {
+		tag = name
+	}
+	props := "null"
+	if len(attributes) > 0 {
+		props = "{ " + strings.Join(jsg.jsxProperties(attributes), ", ") + " }"
+	}
+
+	call := "React.createElement(" + tag + ", " + props
+	if len(children) == 1 && !isJSXElement(children[0]) {
+		return call + ", " + jsg.GenerateExpression(children[0]) + ")"
+	}
+	// Comme tsc, un enfant par ligne dès qu'il y a des éléments imbriqués
+	for _, child := range children {
+		call += ",\n" + indentLines(jsg.GenerateExpression(child), "    ")
+	}
+	return call + ")"
+}
+
+// generateAutomaticJSX génère _jsx(tag, { ...props, children }, key) ; un
+// élément à plusieurs enfants passe par _jsxs et un tableau
+func (jsg *JavaScriptGenerator) generateAutomaticJSX(name string, attributes []ast.JSXAttribute, children []ast.Expression) string {
+	tag := "_Fragment"
+	if isIntrinsicElement(name) {
+		tag = jsxString(name)
+	} else if name != "" {
+		tag = name
+	} else {
+		jsg.jsxImports["Fragment"] = true
+	}
+
+	// key n'est pas une prop : elle devient le troisième argument
+	var key string
+	var props []ast.JSXAttribute
+	for _, attribute := range attributes {
+		if attribute.Name == "key" && attribute.Value != nil {
+			key = jsg.GenerateExpression(attribute.Value)
+		} else {
+			props = append(props, attribute)
+		}
+	}
+	properties := jsg.jsxProperties(props)
+
+	function := "jsx"
+	switch {
+	case len(children) == 1:
+		properties = append(properties, "children: "+jsg.GenerateExpression(children[0]))
+	case len(children) > 1:
+		function = "jsxs"
+		var elements []string
+		for _, child := range children {
+			elements = append(elements, indentLines(jsg.GenerateExpression(child), "    "))
+		}
+		properties = append(properties, "children: [\n"+strings.Join(elements, ",\n")+"\n]")
+	}
+	jsg.jsxImports[function] = true
+
+	object := "{}"
+	if len(properties) > 0 {
+		object = "{ " + strings.Join(properties, ", ") + " }"
+	}
+	call := "_" + function + "(" + tag + ", " + object
+	if key != "" {
+		call += ", " + key
+	}
+	return call + ")"
+}
+
+// jsxProperties génère les propriétés de l'objet props d'un élément :
+// className: "card", "aria-label": label, disabled: true, ...rest
+func (jsg *JavaScriptGenerator) jsxProperties(attributes []ast.JSXAttribute) []string {
+	var properties []string
+	for _, attribute := range attributes {
+		switch value := attribute.Value.(type) {
+		case nil:
+			properties = append(properties, jsxPropertyKey(attribute.Name)+": true")
+		case *ast.StringLiteral:
+			// Une chaîne d'attribut JSX n'a pas de séquences d'échappement
+			properties = append(properties, jsxPropertyKey(attribute.Name)+": "+jsxString(value.Value))
+		default:
+			if attribute.IsSpread {
+				properties = append(properties, "..."+jsg.GenerateExpression(value))
+			} else {
+				properties = append(properties, jsxPropertyKey(attribute.Name)+": "+jsg.GenerateExpression(value))
+			}
+		}
+	}
+	return properties
+}
+
+// generateJSXImport importe les fonctions de react/jsx-runtime utilisées par
+// le runtime automatique
+func (jsg *JavaScriptGenerator) generateJSXImport() string {
+	var specifiers []ast.ImportSpecifier
+	for _, name := range []string{"jsx", "jsxs", "Fragment"} {
+		if jsg.jsxImports[name] {
+			specifiers = append(specifiers, ast.ImportSpecifier{Name: name, Alias: "_" + name})
+		}
+	}
+	if len(specifiers) == 0 {
+		return ""
+	}
+	return jsg.GenerateImport(&ast.ImportDeclaration{Source: "react/jsx-runtime", Specifiers: specifiers})
+}
+
+// GenerateArrowFunction génère une fonction fléchée sans ses annotations de type
+func (jsg *JavaScriptGenerator) GenerateArrowFunction(af *ast.ArrowFunction) string {
+	prefix := "(" + jsg.generateParameters(af.Parameters) + ") => "
+	if af.IsAsync {
+		prefix = "async " + prefix
+	}
+	if af.ExpressionBody != nil {
+		body := jsg.GenerateExpression(af.ExpressionBody)
+		// Un objet littéral serait lu comme un bloc
+		if _, ok := af.ExpressionBody.(*ast.ObjectLiteral); ok {
+			body = "(" + body + ")"
+		}
+		return prefix + body
+	}
+	var body strings.Builder
+	for _, stmt := range af.Body {
+		body.WriteString(jsg.GenerateStatement(stmt))
+	}
+	return prefix + "{\n" + indentLines(body.String(), "    ") + "}"
+}
+
+func (jsg *JavaScriptGenerator) GenerateAssignmentExpression(ae *ast.AssignmentExpression) string {
+	if ae.Right == nil {
+		// ++ / -- postfixés
+		return jsg.GenerateExpression(ae.Left) + ae.Operator
+	}
+	return jsg.GenerateExpression(ae.Left) + " " + ae.Operator + " " + jsg.GenerateExpression(ae.Right)
+}
+
 func (jsg *JavaScriptGenerator) GenerateTemplateLiteral(tl *ast.TemplateLiteral) string {
 	if len(tl.Parts) > 0 {
 		return "`" + tl.Parts[0].TokenLiteral() + "`"
@@ -226,8 +753,10 @@ func (jsg *JavaScriptGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) str
 			sb.WriteString(",\n")
 		}
 		sb.WriteString("  ")
-		sb.WriteString(prop.Key)
-		sb.WriteString(": ")
+		if _, ok := prop.Value.(*ast.SpreadElement); !ok {
+			sb.WriteString(prop.Key)
+			sb.WriteString(": ")
+		}
 		sb.WriteString(jsg.GenerateExpression(prop.Value))
 	}
 	sb.WriteString("\n}")
@@ -235,17 +764,16 @@ func (jsg *JavaScriptGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) str
 }
 
 func (jsg *JavaScriptGenerator) GenerateCallExpression(ce *ast.CallExpression) string {
-	var sb strings.Builder
-	sb.WriteString(jsg.GenerateExpression(ce.Function))
-	sb.WriteString("(")
-	for i, arg := range ce.Arguments {
-		if i > 0 {
-			sb.WriteString(", ")
-		}
-		sb.WriteString(jsg.GenerateExpression(arg))
+	// Les arguments de type sont effacés
+	return jsg.GenerateExpression(ce.Function) + "(" + jsg.generateArguments(ce.Arguments) + ")"
+}
+
+func (jsg *JavaScriptGenerator) generateArguments(arguments []ast.Expression) string {
+	var args []string
+	for _, arg := range arguments {
+		args = append(args, jsg.GenerateExpression(arg))
 	}
-	sb.WriteString(")")
-	return sb.String()
+	return strings.Join(args, ", ")
 }
 
 func (jsg *JavaScriptGenerator) GenerateIndexExpression(ie *ast.IndexExpression) string {
@@ -253,6 +781,9 @@ func (jsg *JavaScriptGenerator) GenerateIndexExpression(ie *ast.IndexExpression)
 }
 
 func (jsg *JavaScriptGenerator) GenerateDotExpression(de *ast.DotExpression) string {
+	if _, member := enumMemberAccess(jsg.constEnums, de); member != nil {
+		return jsg.GenerateExpression(member.Value) + " /* " + jsg.GenerateExpression(de.Object) + "." + de.Property + " */"
+	}
 	return jsg.GenerateExpression(de.Object) + "." + de.Property
 }
 
@@ -264,89 +795,290 @@ func (jsg *JavaScriptGenerator) GenerateInterface(i *ast.Interface) string {
 	return "// Interface: " + i.Name + "\n"
 }
 
+// GenerateClass génère une classe ES2015 ; les champs d'instance initialisés
+// sont affectés dans le constructeur, les paramètres de type sont effacés
 func (jsg *JavaScriptGenerator) GenerateClass(cd *ast.ClassDeclaration) string {
-	var sb strings.Builder
-	sb.WriteString("class ")
-	sb.WriteString(cd.Name)
-	sb.WriteString(" {\n")
-	sb.WriteString("    // TODO: Implement class body\n")
-	sb.WriteString("}\n\n")
-	return sb.String()
-}
+	cd = withParameterProperties(cd)
+	legacy := jsg.Decorators == LegacyDecorators
 
-func (jsg *JavaScriptGenerator) GenerateFunction(fd *ast.FunctionDeclaration) string {
 	var sb strings.Builder
-
-	if fd.IsAsync {
-		sb.WriteString("async ")
+	if jsg.rebindsClass(cd) {
+		// Le décorateur de classe legacy remplace la classe : let X = class X {}
+		sb.WriteString("let " + cd.Name + " = ")
+	} else if !legacy {
+		sb.WriteString(jsg.decoratorLines(cd.Decorators, ""))
 	}
-	sb.WriteString("function ")
-	sb.WriteString(fd.Name)
-	sb.WriteString("(")
+	sb.WriteString("class")
+	if cd.Name != "" {
+		sb.WriteString(" " + cd.Name)
+	}
+	if cd.SuperClass != "" {
+		sb.WriteString(" extends " + superClassName(cd))
+	}
+	sb.WriteString(" {\n")
 
-	// Paramètres
-	for i, param := range fd.Parameters {
-		if i > 0 {
-			sb.WriteString(", ")
+	hasStatics := false
+	for _, field := range cd.Fields {
+		if field.IsStatic || field.IsPrivateName || (!legacy && len(field.Decorators) > 0) {
+			// Un champ d'instance n'est déclaré que pour porter ses décorateurs
+			// TC39, ou parce qu'un nom privé doit l'être
+			if !legacy {
+				sb.WriteString(jsg.decoratorLines(field.Decorators, "    "))
+			}
+			sb.WriteString("    ")
+			if field.IsStatic {
+				sb.WriteString("static ")
+			}
+			if field.IsPrivateName {
+				sb.WriteString("#")
+			}
+			sb.WriteString(field.Name)
+			if field.HasDefault && field.IsStatic {
+				sb.WriteString(" = " + jsg.GenerateExpression(field.Default))
+			}
+			sb.WriteString(";\n")
+			hasStatics = true
 		}
-		sb.WriteString(param.Name)
 	}
 
-	sb.WriteString(") {\n")
-
-	// Corps de la fonction
-	for _, stmt := range fd.Body {
-		sb.WriteString("    ")
-		sb.WriteString(jsg.GenerateStatement(stmt))
+	if hasStatics {
+		sb.WriteString("\n")
 	}
 
-	sb.WriteString("}\n\n")
-	return sb.String()
-}
-
-func (jsg *JavaScriptGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
-	var sb strings.Builder
-
-	if vd.IsConst {
-		sb.WriteString("const ")
-	} else {
-		sb.WriteString("let ")
+	var members []string
+	for _, block := range cd.StaticBlocks {
+		members = append(members, jsg.generateStaticBlock(block.Body))
 	}
-	sb.WriteString(vd.Name)
-	sb.WriteString(" = ")
-
-	if vd.Value != nil {
-		switch val := vd.Value.(type) {
-		case *ast.StringLiteral:
-			sb.WriteString(jsg.GenerateStringLiteral(val))
-		case *ast.NumberLiteral:
-			sb.WriteString(jsg.GenerateNumberLiteral(val))
-		case *ast.BooleanLiteral:
-			sb.WriteString(jsg.GenerateBooleanLiteral(val))
-		case *ast.ArrayLiteral:
-			sb.WriteString(jsg.GenerateArrayLiteral(val))
-		case *ast.ObjectLiteral:
-			sb.WriteString(jsg.GenerateObjectLiteral(val))
-		case *ast.TemplateLiteral:
-			sb.WriteString(jsg.GenerateTemplateLiteral(val))
-		default:
-			sb.WriteString(jsg.GenerateExpression(val))
+	if needsConstructor(cd) {
+		members = append(members, jsg.generateMethod("constructor", constructorParameters(cd), constructorBody(cd), ""))
+	}
+	for _, method := range cd.Methods {
+		// Une méthode abstraite n'a pas d'équivalent : les sous-classes la définissent
+		if method.Kind == ast.Constructor || method.IsAbstract {
+			continue
+		}
+		decorators := ""
+		if !legacy {
+			decorators = jsg.decoratorLines(method.Decorators, "    ")
+		}
+		prefix := ""
+		if method.IsStatic {
+			prefix += "static "
+		}
+		switch method.Kind {
+		case ast.Getter:
+			prefix += "get "
+		case ast.Setter:
+			prefix += "set "
 		}
+		if method.IsAsync {
+			prefix += "async "
+		}
+		if method.IsGenerator {
+			prefix += "*"
+		}
+		if method.IsPrivateName {
+			prefix += "#"
+		}
+		members = append(members, decorators+jsg.generateMethod(method.Name, method.Parameters, method.Body, prefix))
 	}
+	sb.WriteString(strings.Join(members, "\n"))
 
-	sb.WriteString(";\n")
+	if jsg.rebindsClass(cd) {
+		sb.WriteString("};\n")
+	} else {
+		sb.WriteString("}\n")
+	}
+	if legacy {
+		sb.WriteString(jsg.generateDecorateCalls(cd))
+	}
+	sb.WriteString("\n")
 	return sb.String()
 }
 
-func (jsg *JavaScriptGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
-	return "\"" + sl.Value + "\""
+// decoratorLines génère les décorateurs TC39 d'une classe ou d'un membre
+func (jsg *JavaScriptGenerator) decoratorLines(decorators []ast.Decorator, indent string) string {
+	var sb strings.Builder
+	for _, d := range decorators {
+		sb.WriteString(indent + "@" + jsg.GenerateExpression(decoratorExpression(d)) + "\n")
+	}
+	return sb.String()
 }
 
-func (jsg *JavaScriptGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
-	return nl.Value
+// rebindsClass indique si des décorateurs legacy remplacent la classe elle-même
+// (décorateurs de classe ou de paramètres du constructeur)
+func (jsg *JavaScriptGenerator) rebindsClass(cd *ast.ClassDeclaration) bool {
+	if jsg.Decorators != LegacyDecorators {
+		return false
+	}
+	if len(cd.Decorators) > 0 {
+		return true
+	}
+	for _, param := range constructorParameters(cd) {
+		if len(param.Decorators) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
-func (jsg *JavaScriptGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
+// generateDecorateCalls applique les décorateurs legacy après la classe, comme
+// tsc avec experimentalDecorators : ceux des membres sur le prototype (ou la
+// classe pour un membre statique), puis ceux de la classe qui la remplacent
+func (jsg *JavaScriptGenerator) generateDecorateCalls(cd *ast.ClassDeclaration) string {
+	var sb strings.Builder
+	decorate := func(decorators []ast.Decorator, params []ast.Parameter) []string {
+		var list []string
+		for _, d := range decorators {
+			list = append(list, jsg.GenerateExpression(decoratorExpression(d)))
+		}
+		for i, param := range params {
+			for _, d := range param.Decorators {
+				jsg.usesParam = true
+				list = append(list, "__param("+strconv.Itoa(i)+", "+jsg.GenerateExpression(decoratorExpression(d))+")")
+			}
+		}
+		if len(list) > 0 {
+			jsg.usesDecorate = true
+		}
+		return list
+	}
+	target := func(isStatic bool) string {
+		if isStatic {
+			return cd.Name
+		}
+		return cd.Name + ".prototype"
+	}
+
+	for _, field := range cd.Fields {
+		if list := decorate(field.Decorators, nil); len(list) > 0 {
+			sb.WriteString("__decorate([" + strings.Join(list, ", ") + "], " + target(field.IsStatic) + ", \"" + field.Name + "\", void 0);\n")
+		}
+	}
+	for _, method := range cd.Methods {
+		if method.Kind == ast.Constructor || method.IsAbstract {
+			continue
+		}
+		if list := decorate(method.Decorators, method.Parameters); len(list) > 0 {
+			sb.WriteString("__decorate([" + strings.Join(list, ", ") + "], " + target(method.IsStatic) + ", \"" + method.Name + "\", null);\n")
+		}
+	}
+	if list := decorate(cd.Decorators, constructorParameters(cd)); len(list) > 0 {
+		sb.WriteString(cd.Name + " = __decorate([" + strings.Join(list, ", ") + "], " + cd.Name + ");\n")
+	}
+	return sb.String()
+}
+
+func (jsg *JavaScriptGenerator) generateMethod(name string, params []ast.Parameter, body []ast.Statement, prefix string) string {
+	var sb strings.Builder
+	sb.WriteString("    " + prefix + name + "(" + jsg.generateParameters(params) + ") {\n")
+	for _, stmt := range body {
+		sb.WriteString(indentLines(jsg.GenerateStatement(stmt), "        "))
+	}
+	sb.WriteString("    }\n")
+	return sb.String()
+}
+
+// generateStaticBlock génère un bloc static { ... }, exécuté à la définition
+// de la classe
+func (jsg *JavaScriptGenerator) generateStaticBlock(body []ast.Statement) string {
+	var sb strings.Builder
+	sb.WriteString("    static {\n")
+	for _, stmt := range body {
+		sb.WriteString(indentLines(jsg.GenerateStatement(stmt), "        "))
+	}
+	sb.WriteString("    }\n")
+	return sb.String()
+}
+
+// generateParameters génère la liste des paramètres sans leurs types ; un
+// décorateur de paramètre n'existe pas en TC39 et reste en commentaire
+func (jsg *JavaScriptGenerator) generateParameters(params []ast.Parameter) string {
+	var parts []string
+	for _, param := range params {
+		part := param.Name
+		if jsg.Decorators != LegacyDecorators {
+			part = inlineDecorators(param.Decorators, nil, jsg.GenerateExpression) + part
+		}
+		if param.IsRest {
+			part = "..." + part
+		}
+		if param.Default != nil {
+			part += " = " + jsg.GenerateExpression(param.Default)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (jsg *JavaScriptGenerator) GenerateFunction(fd *ast.FunctionDeclaration) string {
+	var sb strings.Builder
+
+	if fd.IsAsync {
+		sb.WriteString("async ")
+	}
+	sb.WriteString("function")
+	if fd.IsGenerator {
+		sb.WriteString("*")
+	}
+	sb.WriteString(" ")
+	sb.WriteString(fd.Name)
+	sb.WriteString("(")
+	sb.WriteString(jsg.generateParameters(fd.Parameters))
+	sb.WriteString(") {\n")
+
+	// Corps de la fonction
+	for _, stmt := range fd.Body {
+		sb.WriteString(indentLines(jsg.GenerateStatement(stmt), "    "))
+	}
+
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+func (jsg *JavaScriptGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+	var sb strings.Builder
+
+	if vd.IsConst {
+		sb.WriteString("const ")
+	} else {
+		sb.WriteString("let ")
+	}
+	sb.WriteString(vd.Name)
+	sb.WriteString(" = ")
+
+	if vd.Value != nil {
+		switch val := vd.Value.(type) {
+		case *ast.StringLiteral:
+			sb.WriteString(jsg.GenerateStringLiteral(val))
+		case *ast.NumberLiteral:
+			sb.WriteString(jsg.GenerateNumberLiteral(val))
+		case *ast.BooleanLiteral:
+			sb.WriteString(jsg.GenerateBooleanLiteral(val))
+		case *ast.ArrayLiteral:
+			sb.WriteString(jsg.GenerateArrayLiteral(val))
+		case *ast.ObjectLiteral:
+			sb.WriteString(jsg.GenerateObjectLiteral(val))
+		case *ast.TemplateLiteral:
+			sb.WriteString(jsg.GenerateTemplateLiteral(val))
+		default:
+			sb.WriteString(jsg.GenerateExpression(val))
+		}
+	}
+
+	sb.WriteString(";\n")
+	return sb.String()
+}
+
+func (jsg *JavaScriptGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
+	return "\"" + sl.Value + "\""
+}
+
+func (jsg *JavaScriptGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
+	return nl.Value
+}
+
+func (jsg *JavaScriptGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
 	if bl.Value {
 		return "true"
 	}
@@ -354,34 +1086,99 @@ func (jsg *JavaScriptGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) s
 }
 
 // JavaGenerator génère du code Java
-type JavaGenerator struct{}
+type JavaGenerator struct {
+	Decorators map[string]string // décorateur -> annotation : Injectable -> @Service
+
+	needsMergeHelper bool // un spread d'objet nécessite le helper mergeMaps
+
+	typeNames map[string]bool               // classes, interfaces et paramètres de type connus
+	types     *semantic.Inference           // types déduits, pour les tableaux et les spreads
+	literals  map[*ast.StringLiteral]string // chaînes écrites comme membres d'enum : 'done' -> TaskStatus.Done
+	arrays    map[*ast.ArrayLiteral]string  // type des éléments attendu : this.items = [] dans un number[]
+	rests     map[string]restParameter      // fonctions à varargs : f(1, ...xs) regroupe ses arguments
+	lambdas   map[string]string             // variable lambda -> méthode de son interface fonctionnelle
+	outerThis string                        // this d'une méthode génératrice vu depuis son itérateur : Tree.this
+	accessors map[string]bool               // propriétés get/set, accédées par getX() et setX()
+
+	classes    map[string]*ast.ClassDeclaration // hiérarchie des classes
+	interfaces map[string]*ast.Interface        // propriétés à implémenter par des accesseurs
+
+	namespaces map[string]map[string]namespaceMember // membres des namespaces
+	namespace  string                                // namespace dont une classe est générée
+}
 
 func (jg *JavaGenerator) Generate(statements []ast.Statement) string {
 	var sb strings.Builder
 
+	jg.literals = enumLiterals(jg.types, statements)
+	jg.arrays = arrayElements(jg.types, statements)
+	jg.lambdas = map[string]string{}
+
+	// Tout est public dans GeneratedCode : les exports n'ont pas d'équivalent
+	statements, imports, _ := splitModuleStatements(statements)
+	jg.typeNames = collectTypeNames(statements)
+	for name := range importedTypes(imports) {
+		jg.typeNames[name] = true
+	}
+	jg.rests = collectRestParameters(statements)
+	jg.accessors = collectAccessors(statements)
+	jg.classes = collectClasses(statements)
+	jg.interfaces = collectInterfaces(statements)
+	jg.namespaces = collectNamespaces(statements)
+
+	sb.WriteString(jg.generateImports(imports))
 	sb.WriteString("public class GeneratedCode {\n")
 
 	// Séparer les variables et les fonctions
 	var variables []ast.Statement
 	var functions []ast.Statement
 	var expressions []ast.Statement
+	var enums []*ast.EnumDeclaration
+	var types []ast.Statement
 
 	for _, stmt := range statements {
-		switch stmt.(type) {
+		switch s := stmt.(type) {
 		case *ast.VariableDeclaration:
 			variables = append(variables, stmt)
 		case *ast.FunctionDeclaration:
 			functions = append(functions, stmt)
+		case *ast.EnumDeclaration:
+			enums = append(enums, s)
+		case *ast.TypeAlias:
+			// Une union de littéraux devient un enum
+			if ed := literalUnionEnum(s); ed != nil {
+				enums = append(enums, ed)
+			}
+		case *ast.ClassDeclaration, *ast.Interface, *ast.NamespaceDeclaration:
+			types = append(types, stmt)
+		case *ast.AmbientDeclaration:
+			// Implémentée ailleurs : seuls ses types sont repris (typeNames)
 		default:
 			expressions = append(expressions, stmt)
 		}
 	}
 
+	// Les enums, classes, interfaces et namespaces deviennent des types imbriqués
+	for _, ed := range enums {
+		sb.WriteString(jg.GenerateEnum(ed))
+	}
+	for _, stmt := range types {
+		switch s := stmt.(type) {
+		case *ast.ClassDeclaration:
+			sb.WriteString(jg.GenerateClass(s))
+		case *ast.Interface:
+			sb.WriteString(jg.GenerateInterface(s))
+		case *ast.NamespaceDeclaration:
+			sb.WriteString(jg.GenerateNamespace(s))
+		}
+	}
+
 	// Générer les fonctions d'abord
 	for _, stmt := range functions {
 		if s, ok := stmt.(*ast.FunctionDeclaration); ok {
 			sb.WriteString("    ")
 			sb.WriteString(jg.GenerateJavaFunction(s))
+			sb.WriteString(jg.GenerateJavaOverloads(s))
 		}
 	}
 
@@ -391,172 +1188,794 @@ func (jg *JavaGenerator) Generate(statements []ast.Statement) string {
 	// Variables dans main
 	for _, stmt := range variables {
 		if s, ok := stmt.(*ast.VariableDeclaration); ok {
-			sb.WriteString("        ")
-			sb.WriteString(jg.GenerateVariableDeclaration(s))
+			sb.WriteString(indentLines(jg.GenerateVariableDeclaration(s), "        "))
 		}
 	}
 
-	// Expressions/appels dans main
+	// Expressions/appels et boucles dans main
 	for _, stmt := range expressions {
-		if s, ok := stmt.(*ast.ExpressionStatement); ok {
-			sb.WriteString("        ")
-			sb.WriteString(jg.GenerateJavaExpressionStatement(s))
-		}
+		sb.WriteString(indentLines(jg.GenerateJavaStatement(stmt), "        "))
 	}
 
 	sb.WriteString("    }\n")
+
+	if jg.needsMergeHelper {
+		sb.WriteString("\n")
+		sb.WriteString("    @SafeVarargs\n")
+		sb.WriteString("    static java.util.HashMap<String, Object> mergeMaps(java.util.Map<String, Object>... maps) {\n")
+		sb.WriteString("        java.util.HashMap<String, Object> result = new java.util.HashMap<>();\n")
+		sb.WriteString("        for (java.util.Map<String, Object> m : maps) {\n")
+		sb.WriteString("            result.putAll(m);\n")
+		sb.WriteString("        }\n")
+		sb.WriteString("        return result;\n")
+		sb.WriteString("    }\n")
+	}
+
 	sb.WriteString("}\n")
 	return sb.String()
 }
 
-func (jg *JavaGenerator) GenerateJavaFunction(fd *ast.FunctionDeclaration) string {
-	var sb strings.Builder
-
-	sb.WriteString("public static ")
+// GenerateNamespace génère un namespace comme classe statique imbriquée :
+// Geometry.area(1) s'écrit alors de la même façon. Ses variables deviennent
+// des champs statiques et ses instructions un bloc d'initialisation statique.
+func (jg *JavaGenerator) GenerateNamespace(nd *ast.NamespaceDeclaration) string {
+	var members, initializer strings.Builder
+	for _, stmt := range namespaceBody(nd) {
+		switch s := stmt.(type) {
+		case *ast.EnumDeclaration:
+			members.WriteString(jg.GenerateEnum(s))
+		case *ast.TypeAlias:
+			if ed := literalUnionEnum(s); ed != nil {
+				members.WriteString(jg.GenerateEnum(ed))
+			}
+		case *ast.ClassDeclaration:
+			// area(r) dans une classe qui a elle-même une méthode area désigne
+			// celle-ci en Java : les membres du namespace y sont qualifiés
+			outer := jg.namespace
+			jg.namespace = nd.Name
+			members.WriteString(jg.GenerateClass(s))
+			jg.namespace = outer
+		case *ast.Interface:
+			members.WriteString(jg.GenerateInterface(s))
+		case *ast.NamespaceDeclaration:
+			members.WriteString(jg.GenerateNamespace(s))
+		case *ast.FunctionDeclaration:
+			members.WriteString("    " + jg.GenerateJavaFunction(s) + jg.GenerateJavaOverloads(s))
+		case *ast.VariableDeclaration:
+			members.WriteString("    static " + jg.staticField(s) + "\n")
+		case *ast.AmbientDeclaration:
+		default:
+			initializer.WriteString(jg.GenerateJavaStatement(stmt))
+		}
+	}
+	if initializer.Len() > 0 {
+		members.WriteString("    static {\n" + indentLines(initializer.String(), "        ") + "    }\n")
+	}
 
-	// Type de retour
-	if fd.ReturnType == "void" || fd.ReturnType == "" {
-		sb.WriteString("void ")
-	} else if fd.ReturnType == "string" {
-		sb.WriteString("String ")
-	} else if fd.ReturnType == "number" {
-		sb.WriteString("int ")
-	} else if fd.ReturnType == "boolean" {
-		sb.WriteString("boolean ")
-	} else {
-		sb.WriteString("Object ")
+	var sb strings.Builder
+	sb.WriteString("    static class " + nd.Name + " {\n")
+	if members.Len() > 0 {
+		sb.WriteString(indentLines(strings.TrimRight(members.String(), "\n")+"\n", "    "))
 	}
+	sb.WriteString("    }\n\n")
+	return sb.String()
+}
 
-	sb.WriteString(fd.Name)
-	sb.WriteString("(")
+// staticField génère une variable de namespace comme champ ; un champ ne peut
+// pas être déclaré var : il prend le type de la classe instanciée
+func (jg *JavaGenerator) staticField(vd *ast.VariableDeclaration) string {
+	code := jg.GenerateVariableDeclaration(vd)
+	if ne, ok := withoutErasedAssertion(vd).Value.(*ast.NewExpression); ok && strings.Contains(code, "var "+vd.Name+" = ") {
+		code = strings.Replace(code, "var ", jg.GenerateExpression(ne.Class)+jg.typeArguments(ne.TypeArguments)+" ", 1)
+	}
+	return code
+}
 
-	// Paramètres
-	for i, param := range fd.Parameters {
-		if i > 0 {
-			sb.WriteString(", ")
+// generateImports génère les imports Java : un module devient un package
+// ("./models/user" -> models.user), un type y est importé directement et une
+// fonction par import statique de sa classe GeneratedCode. Java n'a pas
+// d'alias : le nom d'origine est importé.
+func (jg *JavaGenerator) generateImports(imports []*ast.ImportDeclaration) string {
+	var lines []string
+	seen := map[string]bool{}
+	add := func(line string) {
+		if !seen[line] {
+			seen[line] = true
+			lines = append(lines, line)
 		}
+	}
 
-		// Type du paramètre
-		if param.Type == "string" {
-			sb.WriteString("String ")
-		} else if param.Type == "number" {
-			sb.WriteString("int ")
-		} else if param.Type == "boolean" {
-			sb.WriteString("boolean ")
-		} else {
-			sb.WriteString("Object ")
+	for _, id := range imports {
+		_, segments := modulePath(id.Source)
+		pkg := strings.ToLower(strings.Join(segments, "."))
+		if id.Namespace != "" {
+			add("import " + pkg + ".*;")
+		}
+		names := []string{}
+		if id.Default != "" {
+			names = append(names, id.Default)
+		}
+		for _, spec := range id.Specifiers {
+			names = append(names, spec.Name)
+		}
+		for _, name := range names {
+			if isTypeName(name) {
+				add("import " + pkg + "." + name + ";")
+			} else {
+				add("import static " + pkg + ".GeneratedCode." + name + ";")
+			}
 		}
+	}
 
-		sb.WriteString(param.Name)
+	if len(lines) == 0 {
+		return ""
 	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
 
-	sb.WriteString(") {\n")
+// GenerateEnum génère un enum Java dont chaque constante porte sa valeur TypeScript
+func (jg *JavaGenerator) GenerateEnum(ed *ast.EnumDeclaration) string {
+	members := resolveEnumMembers(ed)
 
-	// Corps de la fonction
-	for _, stmt := range fd.Body {
-		sb.WriteString("        ")
-		sb.WriteString(jg.GenerateJavaStatement(stmt))
+	valueType := "int"
+	if isStringEnum(members) {
+		valueType = "String"
 	}
 
+	var constants []string
+	for _, member := range members {
+		value := jg.GenerateExpression(member.Value)
+		if valueType == "String" {
+			value = "\"" + enumValueString(member.Value) + "\""
+		}
+		constants = append(constants, "        "+member.Name+"("+value+")")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("    enum " + ed.Name + " {\n")
+	sb.WriteString(strings.Join(constants, ",\n") + ";\n\n")
+	sb.WriteString("        private final " + valueType + " value;\n\n")
+	sb.WriteString("        " + ed.Name + "(" + valueType + " value) {\n")
+	sb.WriteString("            this.value = value;\n")
+	sb.WriteString("        }\n\n")
+	sb.WriteString("        public " + valueType + " getValue() {\n")
+	sb.WriteString("            return value;\n")
+	sb.WriteString("        }\n")
 	sb.WriteString("    }\n\n")
 	return sb.String()
 }
 
-func (jg *JavaGenerator) GenerateJavaStatement(stmt ast.Statement) string {
-	switch s := stmt.(type) {
-	case *ast.ReturnStatement:
-		if s.Value != nil {
-			return "return " + jg.GenerateExpression(s.Value) + ";\n"
+// mapType convertit un type TypeScript en type Java
+func (jg *JavaGenerator) mapType(t string) string {
+	if strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "Array<") {
+		return jg.mapType(elementType(t)) + "[]"
+	}
+	if inner, ok := unwrapPromise(t); ok {
+		return "java.util.concurrent.CompletableFuture<" + jg.boxedType(inner) + ">"
+	}
+	if inner, ok := yieldedType(t); ok {
+		return "Iterable<" + jg.boxedType(inner) + ">"
+	}
+	switch t {
+	case "void":
+		return "Void"
+	case "string":
+		return "String"
+	case "number":
+		return "double"
+	case integerType:
+		return "int"
+	case "boolean":
+		return "boolean"
+	}
+	if name, args := splitTypeArguments(t); name == "Map" && len(args) == 2 && !jg.typeNames[name] {
+		return "java.util.Map<" + jg.boxedType(args[0]) + ", " + jg.boxedType(args[1]) + ">"
+	}
+
+	// Types déclarés et paramètres de type, avec leurs arguments : Box<T>
+	if name, args := splitTypeArguments(t); jg.typeNames[name] {
+		if len(args) == 0 {
+			return name
 		}
-		return "return;\n"
-	case *ast.IfStatement:
-		return jg.GenerateJavaIfStatement(s)
-	case *ast.VariableDeclaration:
-		return jg.GenerateVariableDeclaration(s)
+		var mapped []string
+		for _, arg := range args {
+			mapped = append(mapped, jg.boxedType(arg))
+		}
+		return name + "<" + strings.Join(mapped, ", ") + ">"
 	}
-	return ""
+	return "Object"
 }
 
-func (jg *JavaGenerator) GenerateJavaIfStatement(is *ast.IfStatement) string {
-	var sb strings.Builder
-	sb.WriteString("if (")
-	sb.WriteString(jg.GenerateExpression(is.Condition))
-	sb.WriteString(") {\n")
+// typeParameters génère <T extends Base, U> ; Java n'a pas de type par défaut
+func (jg *JavaGenerator) typeParameters(params []ast.TypeParameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, param := range params {
+		part := param.Name
+		if bound := jg.boxedType(param.Constraint); param.Constraint != "" && bound != "Object" {
+			part += " extends " + bound
+		}
+		parts = append(parts, part)
+	}
+	return "<" + strings.Join(parts, ", ") + ">"
+}
+
+// typeArguments génère <Integer, String> pour un appel ou un new générique
+func (jg *JavaGenerator) typeArguments(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	var mapped []string
+	for _, arg := range args {
+		mapped = append(mapped, jg.boxedType(arg))
+	}
+	return "<" + strings.Join(mapped, ", ") + ">"
+}
+
+// GenerateClass génère une classe imbriquée statique
+func (jg *JavaGenerator) GenerateClass(cd *ast.ClassDeclaration) string {
+	defer declareTypeParameters(jg.typeNames, cd.TypeParameters)()
+	cd = withParameterProperties(withImplicitConstructor(cd, jg.classes))
 
-	if blockStmt, ok := is.ThenBranch.(*ast.BlockStatement); ok {
-		for _, stmt := range blockStmt.Statements {
-			sb.WriteString("            ")
-			sb.WriteString(jg.GenerateJavaStatement(stmt))
+	implemented, rejected := classBases(cd.Implements, jg.classes)
+
+	var sb strings.Builder
+	sb.WriteString(implementedClassComments(cd.Name, rejected, Java, "    "))
+	sb.WriteString(jg.annotations(cd.Decorators, "    "))
+	sb.WriteString("    ")
+	if cd.IsAbstract {
+		sb.WriteString("abstract ")
+	}
+	sb.WriteString("static class " + cd.Name + jg.typeParameters(cd.TypeParameters))
+	if cd.SuperClass != "" {
+		sb.WriteString(" extends " + jg.mapType(cd.SuperClass))
+	}
+	if len(implemented) > 0 {
+		var interfaces []string
+		for _, i := range implemented {
+			interfaces = append(interfaces, jg.mapType(i))
 		}
+		sb.WriteString(" implements " + strings.Join(interfaces, ", "))
 	}
+	sb.WriteString(" {\n")
 
-	sb.WriteString("        }")
+	for _, field := range cd.Fields {
+		sb.WriteString(jg.annotations(field.Decorators, "        "))
+		modifiers := jg.memberModifiers(memberAccess(field.IsPrivate, field.IsProtected), field.IsStatic)
+		if field.IsReadonly {
+			modifiers += "final "
+		}
+		sb.WriteString("        " + modifiers + jg.fieldType(field) + " " + field.Name)
+		if field.HasDefault {
+			sb.WriteString(" = " + jg.GenerateExpression(field.Default))
+		}
+		sb.WriteString(";\n")
+	}
+	if len(cd.Fields) > 0 && (len(cd.Methods) > 0 || len(cd.StaticBlocks) > 0) {
+		sb.WriteString("\n")
+	}
 
-	if is.ElseBranch != nil {
-		sb.WriteString(" else {\n")
-		if blockStmt, ok := is.ElseBranch.(*ast.BlockStatement); ok {
-			for _, stmt := range blockStmt.Statements {
-				sb.WriteString("            ")
-				sb.WriteString(jg.GenerateJavaStatement(stmt))
-			}
+	var members []string
+	if statics := staticBlockStatements(cd); len(statics) > 0 {
+		members = append(members, "        static {\n"+jg.generateBody(statics, false, "            ")+"        }\n")
+	}
+	if constructor := findConstructor(cd); constructor != nil {
+		members = append(members, jg.annotations(constructor.Decorators, "        ")+jg.generateMethod("public "+cd.Name, *constructor))
+	}
+	// Les accesseurs get/set deviennent des méthodes getX() et setX(value)
+	for _, method := range append(accessorMethods(cd, getterName, setterName), regularMethods(cd)...) {
+		restore := declareTypeParameters(jg.typeNames, method.TypeParameters)
+		signature := jg.memberModifiers(memberAccess(method.IsPrivate, method.IsProtected), method.IsStatic)
+		if method.IsAbstract {
+			signature += "abstract "
+		}
+		if len(method.TypeParameters) > 0 {
+			signature += jg.typeParameters(method.TypeParameters) + " "
+		}
+		signature += jg.returnType(typedReturn(jg.types, &method, method.ReturnType, method.IsGenerator), method.IsGenerator) + " " + method.Name
+		if method.IsGenerator && !method.IsStatic {
+			jg.outerThis = cd.Name + ".this"
 		}
-		sb.WriteString("        }")
+		if method.IsAbstract {
+			members = append(members, jg.annotations(method.Decorators, "        ")+"        "+signature+"("+jg.generateParameters(method.Parameters)+");\n")
+			restore()
+			continue
+		}
+		members = append(members, jg.annotations(method.Decorators, "        ")+jg.generateMethod(signature, method))
+		jg.outerThis = ""
+		restore()
 	}
+	members = append(members, jg.generateInterfaceAccessors(cd)...)
+	sb.WriteString(strings.Join(members, "\n"))
 
-	sb.WriteString("\n")
+	sb.WriteString("    }\n\n")
 	return sb.String()
 }
 
-func (jg *JavaGenerator) GenerateJavaExpressionStatement(es *ast.ExpressionStatement) string {
-	// Convertir console.log en System.out.println
-	if callExpr, ok := es.Expression.(*ast.CallExpression); ok {
-		if dotExpr, ok := callExpr.Function.(*ast.DotExpression); ok {
-			if ident, ok := dotExpr.Object.(*ast.Identifier); ok {
-				if ident.Value == "console" && dotExpr.Property == "log" {
-					var sb strings.Builder
-					sb.WriteString("System.out.println(")
-					for i, arg := range callExpr.Arguments {
-						if i > 0 {
-							sb.WriteString(" + \", \" + ")
-						}
-						sb.WriteString(jg.GenerateExpression(arg))
-					}
-					sb.WriteString(");\n")
-					return sb.String()
-				}
-			}
-		}
+// generateInterfaceAccessors génère les accesseurs name() qui implémentent les
+// propriétés des interfaces de la classe à partir de ses champs
+func (jg *JavaGenerator) generateInterfaceAccessors(cd *ast.ClassDeclaration) []string {
+	var accessors []string
+	for _, property := range backedInterfaceProperties(cd, jg.classes, jg.interfaces) {
+		accessors = append(accessors, "        public "+jg.mapType(property.Type)+" "+property.Name+"() {\n            return this."+property.Name+";\n        }\n")
 	}
-
-	return jg.GenerateExpression(es.Expression) + ";\n"
+	return accessors
 }
 
-func (jg *JavaGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+func (jg *JavaGenerator) generateMethod(signature string, method ast.ClassMethod) string {
 	var sb strings.Builder
+	sb.WriteString("        " + signature + "(" + jg.generateParameters(method.Parameters) + ") {\n")
+	if method.IsGenerator {
+		sb.WriteString(jg.generateIterator(method.Body, method.Parameters, method.ReturnType, "            "))
+	} else {
+		sb.WriteString(jg.generateBody(method.Body, method.IsAsync, "            "))
+	}
+	sb.WriteString("        }\n")
+	return sb.String()
+}
 
-	// En Java, tout est final ou pas, pas de distinction const/let comme JS
-	if vd.IsConst {
-		sb.WriteString("final ")
+// generateBody génère le corps d'une fonction ; celui d'une fonction async
+// s'exécute dans CompletableFuture.supplyAsync (runAsync sans valeur de retour)
+func (jg *JavaGenerator) generateBody(body []ast.Statement, isAsync bool, indent string) string {
+	var sb strings.Builder
+	for _, stmt := range body {
+		sb.WriteString(jg.GenerateJavaStatement(stmt))
+	}
+	if !isAsync {
+		return indentLines(sb.String(), indent)
+	}
+	launcher := "runAsync"
+	if returnsValue(body) {
+		launcher = "supplyAsync"
 	}
+	return indent + "return java.util.concurrent.CompletableFuture." + launcher + "(() -> {\n" +
+		indentLines(sb.String(), indent+"    ") + indent + "});\n"
+}
 
-	// Déterminer le type Java
-	switch vd.Value.(type) {
-	case *ast.StringLiteral:
-		sb.WriteString("String ")
-	case *ast.NumberLiteral:
-		sb.WriteString("int ") // Simplifié, pourrait être double/long
+// GenerateInterface génère une interface ; les propriétés deviennent des
+// accesseurs, comme les composants d'un record
+func (jg *JavaGenerator) GenerateInterface(i *ast.Interface) string {
+	defer declareTypeParameters(jg.typeNames, i.TypeParameters)()
+
+	var sb strings.Builder
+	bases, rejected := classBases(i.Extends, jg.classes)
+	for _, class := range rejected {
+		sb.WriteString("    // " + i.Name + " étend la classe " + class + " : " + interfaceExtendsClass[Java] + "\n")
+	}
+	sb.WriteString("    interface " + i.Name + jg.typeParameters(i.TypeParameters))
+	if len(bases) > 0 {
+		var extends []string
+		for _, base := range bases {
+			extends = append(extends, jg.mapType(base))
+		}
+		sb.WriteString(" extends " + strings.Join(extends, ", "))
+	}
+	sb.WriteString(" {\n")
+	for _, field := range i.Fields {
+		if field.IsMethod {
+			restore := declareTypeParameters(jg.typeNames, field.TypeParameters)
+			sb.WriteString("        ")
+			if len(field.TypeParameters) > 0 {
+				sb.WriteString(jg.typeParameters(field.TypeParameters) + " ")
+			}
+			sb.WriteString(jg.returnType(field.ReturnType, false) + " " + field.Name + "(" + jg.generateParameters(field.Parameters) + ");\n")
+			restore()
+		} else if field.Optional {
+			sb.WriteString("        " + jg.boxedType(field.Type) + " " + field.Name + "();\n")
+		} else {
+			sb.WriteString("        " + jg.mapType(field.Type) + " " + field.Name + "();\n")
+		}
+	}
+	sb.WriteString("    }\n\n")
+	return sb.String()
+}
+
+func (jg *JavaGenerator) memberModifiers(access string, isStatic bool) string {
+	modifiers := access + " "
+	if isStatic {
+		modifiers += "static "
+	}
+	return modifiers
+}
+
+// fieldType renvoie le type Java d'un champ, déduit de sa valeur s'il n'est pas annoté
+func (jg *JavaGenerator) fieldType(field ast.ClassField) string {
+	if field.Type != "" {
+		return jg.mapType(field.Type)
+	}
+	switch field.Default.(type) {
+	case *ast.StringLiteral, *ast.TemplateLiteral:
+		return "String"
+	case *ast.NumberLiteral:
+		return jg.mapType(refineNumber("number", jg.types != nil && jg.types.Integral(&field)))
 	case *ast.BooleanLiteral:
-		sb.WriteString("boolean ")
-	case *ast.ArrayLiteral:
-		sb.WriteString("int[] ") // Simplifié pour les arrays de nombres
-	case *ast.ObjectLiteral:
-		sb.WriteString("java.util.HashMap<String, Object> ")
-	case *ast.TemplateLiteral:
-		sb.WriteString("String ")
+		return "boolean"
+	}
+	return "Object"
+}
+
+func (jg *JavaGenerator) returnType(t string, isGenerator bool) string {
+	if _, ok := yieldedType(t); isGenerator && !ok {
+		return "Iterable<Object>"
+	}
+	if t == "void" || t == "" {
+		return "void"
+	}
+	return jg.mapType(t)
+}
+
+// iteratorElement renvoie le type objet des valeurs produites par un générateur
+func (jg *JavaGenerator) iteratorElement(t string) string {
+	if inner, ok := yieldedType(t); ok {
+		return jg.boxedType(inner)
+	}
+	return "Object"
+}
+
+// generateIterator génère le corps d'un générateur. Java n'a pas de yield : le
+// corps découpé en états (voir lowerGenerator) devient la méthode advance d'un
+// Iterator anonyme, dont les variables locales sont des champs. L'Iterable
+// renvoyé rejoue le générateur à chaque parcours.
+func (jg *JavaGenerator) generateIterator(body []ast.Statement, params []ast.Parameter, returnType, indent string) string {
+	m := lowerGenerator(body)
+	element := jg.iteratorElement(returnType)
+
+	var sb strings.Builder
+	sb.WriteString("return () -> new java.util.Iterator<" + element + ">() {\n")
+	sb.WriteString("    private int state = 0;\n")
+	sb.WriteString("    private boolean ready = false;\n")
+	sb.WriteString("    private " + element + " current;\n")
+	for _, local := range m.Locals {
+		sb.WriteString("    private " + jg.localType(local, params) + " " + local.Name + ";\n")
+	}
+	for i := 0; i < m.Delegates; i++ {
+		sb.WriteString("    private java.util.Iterator<? extends " + element + "> delegate" + strconv.Itoa(i) + ";\n")
+	}
+
+	sb.WriteString("\n    private boolean advance() {\n")
+	sb.WriteString("        while (true) {\n")
+	sb.WriteString("            switch (state) {\n")
+	reachable := m.reachable()
+	for i, state := range m.States {
+		if !reachable[i] {
+			continue
+		}
+		var code strings.Builder
+		for _, stmt := range state.Statements {
+			code.WriteString(jg.GenerateJavaStatement(stmt))
+		}
+		code.WriteString(jg.generateExit(state.Exit, element))
+		sb.WriteString("                case " + strconv.Itoa(i) + ":\n")
+		sb.WriteString(indentLines(code.String(), "                    "))
+	}
+	sb.WriteString("                default:\n")
+	sb.WriteString("                    return false;\n")
+	sb.WriteString("            }\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("    }\n\n")
+
+	sb.WriteString("    @Override\n")
+	sb.WriteString("    public boolean hasNext() {\n")
+	sb.WriteString("        if (!ready) {\n")
+	sb.WriteString("            ready = advance();\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("        return ready;\n")
+	sb.WriteString("    }\n\n")
+	sb.WriteString("    @Override\n")
+	sb.WriteString("    public " + element + " next() {\n")
+	sb.WriteString("        if (!hasNext()) {\n")
+	sb.WriteString("            throw new java.util.NoSuchElementException();\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("        ready = false;\n")
+	sb.WriteString("        return current;\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("};\n")
+	return indentLines(sb.String(), indent)
+}
+
+// localType renvoie le type du champ qui porte une variable locale d'un
+// générateur ; une variable initialisée par un paramètre en prend le type
+func (jg *JavaGenerator) localType(vd *ast.VariableDeclaration, params []ast.Parameter) string {
+	if ident, ok := vd.Value.(*ast.Identifier); ok && vd.Type == "" {
+		for _, param := range params {
+			if param.Name == ident.Value && param.Type != "" {
+				return jg.mapType(param.Type)
+			}
+		}
+	}
+	if t := jg.variableType(vd); t != "var" {
+		return t
+	}
+	return "Object"
+}
+
+// generateExit génère la sortie d'un état de la machine d'un générateur ; un
+// entier produit dans un Double est converti : 1 s'écrit 1.0
+func (jg *JavaGenerator) generateExit(exit generatorExit, element string) string {
+	target := strconv.Itoa(exit.Target)
+	delegate := "delegate" + strconv.Itoa(exit.Delegate)
+	switch exit.Kind {
+	case exitJump:
+		return "state = " + target + ";\nbreak;\n"
+	case exitBranch:
+		return "state = " + jg.GenerateExpression(exit.Condition) + " ? " + target + " : " + strconv.Itoa(exit.Else) + ";\nbreak;\n"
+	case exitYield:
+		value := "null"
+		if nl, ok := exit.Value.(*ast.NumberLiteral); ok {
+			value = jg.boxedNumber(nl, element)
+		} else if exit.Value != nil {
+			value = jg.GenerateExpression(exit.Value)
+			if element == "Double" && isInteger(jg.types, exit.Value) {
+				value = "(double) " + castOperand(exit.Value, value)
+			}
+		}
+		return "current = " + value + ";\nstate = " + target + ";\nreturn true;\n"
+	case exitDelegate:
+		return delegate + " = " + jg.GenerateExpression(exit.Value) + ".iterator();\nstate = " + target + ";\nbreak;\n"
+	case exitDrain:
+		return "if (" + delegate + ".hasNext()) {\n    current = " + delegate + ".next();\n    return true;\n}\nstate = " + target + ";\nbreak;\n"
+	}
+	return "state = -1;\nreturn false;\n"
+}
+
+func (jg *JavaGenerator) generateParameters(params []ast.Parameter) string {
+	var parts []string
+	for _, param := range params {
+		parts = append(parts, inlineDecorators(param.Decorators, jg.Decorators, jg.GenerateExpression)+jg.parameterType(param)+" "+param.Name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// annotations traduit les décorateurs d'une classe ou d'un membre en
+// annotations ; ceux sans traduction restent en commentaire
+func (jg *JavaGenerator) annotations(decorators []ast.Decorator, indent string) string {
+	return decoratorLines(decorators, jg.Decorators, jg.GenerateExpression, "//", indent)
+}
+
+// boxedType renvoie le type objet Java correspondant, pour accepter null
+func (jg *JavaGenerator) boxedType(t string) string {
+	switch javaType := jg.mapType(t); javaType {
+	case "int":
+		return "Integer"
+	case "double":
+		return "Double"
+	case "boolean":
+		return "Boolean"
+	default:
+		return javaType
+	}
+}
+
+// GenerateJavaOverloads génère une surcharge par paramètre omissible : Java n'a
+// pas de valeurs par défaut, chaque surcharge délègue à la version complète
+// en passant la valeur par défaut (ou null pour un paramètre optionnel).
+// Les signatures de surcharge TypeScript deviennent elles aussi des méthodes
+// typées qui délèguent à l'implémentation.
+func (jg *JavaGenerator) GenerateJavaOverloads(fd *ast.FunctionDeclaration) string {
+	defer declareTypeParameters(jg.typeNames, fd.TypeParameters)()
+
+	returnType := jg.returnType(fd.ReturnType, fd.IsGenerator)
+	typeParams := ""
+	if len(fd.TypeParameters) > 0 {
+		typeParams = jg.typeParameters(fd.TypeParameters) + " "
+	}
+
+	var sb strings.Builder
+	first := firstOmittableParameter(fd.Parameters)
+	for n := first; first >= 0 && n < len(fd.Parameters); n++ {
+		var params, args []string
+		for _, param := range fd.Parameters[:n] {
+			params = append(params, jg.parameterType(param)+" "+param.Name)
+			args = append(args, param.Name)
+		}
+		for _, param := range fd.Parameters[n:] {
+			if al, ok := param.Default.(*ast.ArrayLiteral); ok && !hasSpread(al.Elements) {
+				// Un initialiseur {..} n'est valide qu'en déclaration
+				args = append(args, "new "+jg.mapType(param.Type)+" "+jg.GenerateArrayLiteral(al))
+			} else if param.Default != nil {
+				args = append(args, jg.GenerateExpression(param.Default))
+			} else {
+				args = append(args, "null")
+			}
+		}
+
+		sb.WriteString("    public static " + typeParams + returnType + " " + fd.Name + "(" + strings.Join(params, ", ") + ") {\n")
+		call := fd.Name + "(" + strings.Join(args, ", ") + ");\n"
+		if returnType == "void" {
+			sb.WriteString("        " + call)
+		} else {
+			sb.WriteString("        return " + call)
+		}
+		sb.WriteString("    }\n\n")
+	}
+
+	for _, signature := range overloadSignatures(fd, jg.parameterType) {
+		sb.WriteString(jg.generateSignature(signature, fd))
+	}
+	return sb.String()
+}
+
+// generateSignature génère une signature de surcharge : une méthode typée qui
+// convertit ses arguments pour appeler l'implémentation, puis son résultat
+func (jg *JavaGenerator) generateSignature(signature, fd *ast.FunctionDeclaration) string {
+	defer declareTypeParameters(jg.typeNames, signature.TypeParameters)()
+
+	typeParams := ""
+	if len(signature.TypeParameters) > 0 {
+		typeParams = jg.typeParameters(signature.TypeParameters) + " "
+	}
+	returnType := jg.returnType(signature.ReturnType, fd.IsGenerator)
+	cast := func(value, from, to string) string {
+		// Un tableau de primitifs n'est pas un Object[] : ses éléments sont emballés
+		if element := strings.TrimSuffix(from, "[]"); element != from && (element == "int" || element == "double") {
+			return "java.util.Arrays.stream(" + value + ").boxed().toArray(" + to + "::new)"
+		}
+		return "(" + to + ") " + value
+	}
+
+	var sb strings.Builder
+	sb.WriteString("    public static " + typeParams + returnType + " " + fd.Name + "(" + jg.generateParameters(signature.Parameters) + ") {\n")
+	call := fd.Name + "(" + strings.Join(overloadArguments(signature, fd, jg.parameterType, cast), ", ") + ")"
+	switch {
+	case returnType == "void":
+		sb.WriteString("        " + call + ";\n")
+	case returnType != jg.returnType(fd.ReturnType, fd.IsGenerator):
+		sb.WriteString("        return (" + returnType + ") " + call + ";\n")
 	default:
-		sb.WriteString("Object ")
+		sb.WriteString("        return " + call + ";\n")
+	}
+	sb.WriteString("    }\n\n")
+	return sb.String()
+}
+
+// parameterType renvoie le type Java d'un paramètre de fonction
+func (jg *JavaGenerator) parameterType(param ast.Parameter) string {
+	if param.IsRest {
+		return jg.mapType(elementType(param.Type)) + "..."
+	}
+	if param.Optional && param.Default == nil {
+		return jg.boxedType(param.Type)
+	}
+	return jg.mapType(param.Type)
+}
+
+func (jg *JavaGenerator) GenerateJavaFunction(fd *ast.FunctionDeclaration) string {
+	var sb strings.Builder
+
+	defer declareTypeParameters(jg.typeNames, fd.TypeParameters)()
+
+	sb.WriteString("public static ")
+	if len(fd.TypeParameters) > 0 {
+		sb.WriteString(jg.typeParameters(fd.TypeParameters) + " ")
+	}
+
+	// Type de retour
+	sb.WriteString(jg.returnType(typedReturn(jg.types, fd, fd.ReturnType, fd.IsGenerator), fd.IsGenerator) + " ")
+
+	sb.WriteString(fd.Name)
+	sb.WriteString("(")
+
+	// Paramètres ; un paramètre rest devient un varargs
+	sb.WriteString(jg.generateParameters(fd.Parameters))
+
+	sb.WriteString(") {\n")
+
+	// Corps de la fonction
+	if fd.IsGenerator {
+		sb.WriteString(jg.generateIterator(fd.Body, fd.Parameters, fd.ReturnType, "        "))
+	} else {
+		sb.WriteString(jg.generateBody(fd.Body, fd.IsAsync, "        "))
+	}
+
+	sb.WriteString("    }\n\n")
+	return sb.String()
+}
+
+func (jg *JavaGenerator) GenerateJavaStatement(stmt ast.Statement) string {
+	switch s := stmt.(type) {
+	case *ast.ReturnStatement:
+		if s.Value != nil {
+			return "return " + jg.GenerateExpression(s.Value) + ";\n"
+		}
+		return "return;\n"
+	case *ast.IfStatement:
+		return jg.GenerateJavaIfStatement(s)
+	case *ast.ForStatement:
+		return jg.GenerateJavaForStatement(s)
+	case *ast.WhileStatement:
+		return "while (" + jg.GenerateExpression(s.Condition) + ") {\n" + jg.generateBlock(s.Body) + "}\n"
+	case *ast.VariableDeclaration:
+		return jg.GenerateVariableDeclaration(s)
+	case *ast.ExpressionStatement:
+		return jg.GenerateJavaExpressionStatement(s)
+	}
+	return ""
+}
+
+// generateBlock génère les instructions d'un bloc, indentées d'un niveau
+func (jg *JavaGenerator) generateBlock(stmt ast.Statement) string {
+	var sb strings.Builder
+	for _, inner := range blockStatements(stmt) {
+		sb.WriteString(indentLines(jg.GenerateJavaStatement(inner), "    "))
+	}
+	return sb.String()
+}
+
+func (jg *JavaGenerator) GenerateJavaIfStatement(is *ast.IfStatement) string {
+	var sb strings.Builder
+	sb.WriteString("if (")
+	sb.WriteString(jg.GenerateExpression(is.Condition))
+	sb.WriteString(") {\n")
+	sb.WriteString(jg.generateBlock(is.ThenBranch))
+	sb.WriteString("}")
+
+	if is.ElseBranch != nil {
+		sb.WriteString(" else {\n")
+		sb.WriteString(jg.generateBlock(is.ElseBranch))
+		sb.WriteString("}")
+	}
+
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func (jg *JavaGenerator) GenerateJavaForStatement(fs *ast.ForStatement) string {
+	var header []string
+	if fs.Init != nil {
+		header = append(header, strings.TrimPrefix(strings.TrimSuffix(jg.GenerateJavaStatement(fs.Init), ";\n"), "final "))
+	} else {
+		header = append(header, "")
+	}
+	if fs.Condition != nil {
+		header = append(header, jg.GenerateExpression(fs.Condition))
+	} else {
+		header = append(header, "")
+	}
+	if es, ok := fs.Update.(*ast.ExpressionStatement); ok {
+		header = append(header, jg.GenerateExpression(es.Expression))
+	} else {
+		header = append(header, "")
+	}
+	return "for (" + strings.Join(header, "; ") + ") {\n" + jg.generateBlock(fs.Body) + "}\n"
+}
+
+func (jg *JavaGenerator) GenerateJavaExpressionStatement(es *ast.ExpressionStatement) string {
+	// Convertir console.log en System.out.println
+	if callExpr, ok := es.Expression.(*ast.CallExpression); ok {
+		if dotExpr, ok := callExpr.Function.(*ast.DotExpression); ok {
+			if ident, ok := dotExpr.Object.(*ast.Identifier); ok {
+				if ident.Value == "console" && dotExpr.Property == "log" {
+					var sb strings.Builder
+					sb.WriteString("System.out.println(")
+					for i, arg := range callExpr.Arguments {
+						if i > 0 {
+							sb.WriteString(" + \", \" + ")
+						}
+						sb.WriteString(jg.GenerateExpression(arg))
+					}
+					sb.WriteString(");\n")
+					return sb.String()
+				}
+			}
+		}
+	}
+
+	return jg.GenerateExpression(es.Expression) + ";\n"
+}
+
+func (jg *JavaGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+	vd = withoutErasedAssertion(vd)
+	var sb strings.Builder
+
+	// En Java, tout est final ou pas, pas de distinction const/let comme JS
+	if vd.IsConst {
+		sb.WriteString("final ")
 	}
 
+	javaType := jg.variableType(vd)
+	sb.WriteString(javaType + " ")
 	sb.WriteString(vd.Name)
 	sb.WriteString(" = ")
 
@@ -565,7 +1984,7 @@ func (jg *JavaGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration
 		case *ast.StringLiteral:
 			sb.WriteString(jg.GenerateStringLiteral(val))
 		case *ast.NumberLiteral:
-			sb.WriteString(jg.GenerateNumberLiteral(val))
+			sb.WriteString(jg.boxedNumber(val, javaType))
 		case *ast.BooleanLiteral:
 			sb.WriteString(jg.GenerateBooleanLiteral(val))
 		case *ast.ArrayLiteral:
@@ -583,28 +2002,131 @@ func (jg *JavaGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration
 	return sb.String()
 }
 
+// boxedNumber génère un nombre littéral rangé dans un type objet : un entier
+// y serait un Integer, que (double) value ou un Double refusent, 1 s'écrit 1.0
+func (jg *JavaGenerator) boxedNumber(nl *ast.NumberLiteral, javaType string) string {
+	switch javaType {
+	case "Object", "Double":
+		if decimalInteger(nl.Value) {
+			return jg.GenerateNumberLiteral(nl) + ".0"
+		}
+	}
+	return jg.GenerateNumberLiteral(nl)
+}
+
+// variableType détermine le type Java d'une variable d'après sa valeur
+func (jg *JavaGenerator) variableType(vd *ast.VariableDeclaration) string {
+	if alias := variableAlias(jg.types, vd); alias != "" {
+		return alias
+	}
+	switch value := vd.Value.(type) {
+	case *ast.StringLiteral:
+		return "String"
+	case *ast.NumberLiteral:
+		return jg.mapType(variableType(jg.types, vd))
+	case *ast.BooleanLiteral:
+		return "boolean"
+	case *ast.ArrayLiteral:
+		if vd.Type != "" {
+			return jg.mapType(vd.Type)
+		}
+		if elem := elementText(jg.types, value); elem != "" {
+			return jg.mapType(elem) + "[]"
+		}
+		return "Object[]"
+	case *ast.ObjectLiteral:
+		return "java.util.HashMap<String, Object>"
+	case *ast.TemplateLiteral:
+		return "String"
+	case *ast.NewExpression:
+		return "var"
+	case *ast.AwaitExpression:
+		// const r = await fetchData(id) : le type de la promesse attendue
+		if t := typed(jg.types, vd, vd.Type); t != "" {
+			return jg.mapType(t)
+		}
+	case *ast.AsExpression:
+		if castsTo(value.Type) {
+			return jg.mapType(value.Type)
+		}
+	case *ast.ArrowFunction:
+		functional := jg.functionalType(value)
+		jg.lambdas[vd.Name] = functionalMethod(functional)
+		return functional
+	}
+	if t := typed(jg.types, vd, vd.Type); t != "" {
+		return jg.mapType(t)
+	}
+	return "Object"
+}
+
 func (jg *JavaGenerator) GenerateCallExpression(ce *ast.CallExpression) string {
 	var sb strings.Builder
-	sb.WriteString(jg.GenerateExpression(ce.Function))
-	sb.WriteString("(")
-	for i, arg := range ce.Arguments {
-		if i > 0 {
-			sb.WriteString(", ")
+	if len(ce.TypeArguments) > 0 {
+		// Les arguments de type explicites exigent un receveur : GeneratedCode.<Integer>identity(5)
+		switch fn := ce.Function.(type) {
+		case *ast.Identifier:
+			sb.WriteString("GeneratedCode." + jg.typeArguments(ce.TypeArguments) + fn.Value)
+		case *ast.DotExpression:
+			sb.WriteString(jg.GenerateExpression(fn.Object) + "." + jg.typeArguments(ce.TypeArguments) + privateAccess(fn, bareName).Property)
+		default:
+			sb.WriteString(jg.GenerateExpression(ce.Function))
 		}
-		sb.WriteString(jg.GenerateExpression(arg))
+	} else if ident, ok := ce.Function.(*ast.Identifier); ok && jg.lambdas[ident.Value] != "" {
+		// Une lambda s'appelle par la méthode de son interface : f.apply(x)
+		sb.WriteString(ident.Value + "." + jg.lambdas[ident.Value])
+	} else {
+		sb.WriteString(jg.GenerateExpression(ce.Function))
 	}
-	sb.WriteString(")")
+	var args []string
+	if rest, restArgs, ok := restCall(jg.rests, ce); ok {
+		for _, arg := range ce.Arguments[:rest.index] {
+			args = append(args, jg.GenerateExpression(arg))
+		}
+		args = append(args, jg.restArguments(restArgs, elementType(rest.param.Type))...)
+	} else {
+		for _, arg := range ce.Arguments {
+			args = append(args, jg.GenerateExpression(arg))
+		}
+	}
+	sb.WriteString("(" + strings.Join(args, ", ") + ")")
 	return sb.String()
 }
 
+// restArguments génère les arguments reçus par un varargs : un seul ...xs
+// passe le tableau, un mélange d'éléments et de spreads est concaténé
+func (jg *JavaGenerator) restArguments(args []ast.Expression, elem string) []string {
+	if array, ok := soleSpread(args); ok {
+		return []string{jg.GenerateExpression(array)}
+	}
+	if hasSpread(args) {
+		return []string{jg.GenerateSpreadArray(args, elem)}
+	}
+	var result []string
+	for _, arg := range args {
+		result = append(result, jg.GenerateExpression(arg))
+	}
+	return result
+}
+
 func (jg *JavaGenerator) GenerateIndexExpression(ie *ast.IndexExpression) string {
-	return jg.GenerateExpression(ie.Left) + "[" + jg.GenerateExpression(ie.Index) + "]"
+	index := jg.GenerateExpression(ie.Index)
+	if isFloat(jg.types, ie.Index) {
+		// Un indice de tableau est un int
+		index = "(int) " + castOperand(ie.Index, index)
+	}
+	return jg.GenerateExpression(ie.Left) + "[" + index + "]"
 }
 
 func (jg *JavaGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
+	elements := flattenSpreads(al.Elements)
+	if hasSpread(elements) {
+		return jg.GenerateSpreadArray(elements, elementText(jg.types, al))
+	}
+
 	var sb strings.Builder
 	sb.WriteString("{")
-	for i, element := range al.Elements {
+	for i, element := range elements {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
@@ -614,7 +2136,75 @@ func (jg *JavaGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
 	return sb.String()
 }
 
+// arrayType renvoie le type Java d'un tableau littéral : celui qu'attend son
+// contexte, sinon celui de ses éléments, Object[] s'il n'est pas connu
+func (jg *JavaGenerator) arrayType(al *ast.ArrayLiteral) string {
+	elem, ok := jg.arrays[al]
+	if !ok {
+		elem = elementText(jg.types, al)
+	}
+	if elem == "" {
+		return "Object[]"
+	}
+	return jg.mapType(elem) + "[]"
+}
+
+// GenerateSpreadArray concatène les segments d'un tableau contenant des spreads
+// ([...a, 1, ...b]) avec le stream qui correspond au type des éléments :
+// IntStream pour int[], DoubleStream pour double[], Stream<T> sinon
+func (jg *JavaGenerator) GenerateSpreadArray(elements []ast.Expression, elem string) string {
+	javaType := "Object"
+	if elem != "" {
+		javaType = jg.mapType(elem)
+	}
+	stream, toArray := "java.util.stream.Stream", ".toArray("+javaType+"[]::new)"
+	switch javaType {
+	case "int":
+		stream, toArray = "java.util.stream.IntStream", ".toArray()"
+	case "double":
+		stream, toArray = "java.util.stream.DoubleStream", ".toArray()"
+	}
+
+	var segments []string
+	var pending []string
+	flush := func() {
+		if len(pending) > 0 {
+			segments = append(segments, stream+".of("+strings.Join(pending, ", ")+")")
+			pending = nil
+		}
+	}
+	for _, element := range elements {
+		if spread, ok := element.(*ast.SpreadElement); ok {
+			flush()
+			segments = append(segments, "java.util.Arrays.stream("+jg.GenerateExpression(spread.Argument)+")")
+		} else {
+			pending = append(pending, jg.GenerateExpression(element))
+		}
+	}
+	flush()
+
+	result := segments[0]
+	for _, segment := range segments[1:] {
+		result = stream + ".concat(" + result + ", " + segment + ")"
+	}
+	return result + toArray
+}
+
 func (jg *JavaGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) string {
+	if hasSpreadProperty(ol.Properties) {
+		// Java n'a pas de spread d'objet : fusion explicite via mergeMaps
+		jg.needsMergeHelper = true
+		var parts []string
+		for _, group := range splitSpreadProperties(ol.Properties) {
+			if spread, ok := group[0].Value.(*ast.SpreadElement); ok {
+				parts = append(parts, jg.GenerateExpression(spread.Argument))
+			} else {
+				parts = append(parts, jg.GenerateObjectLiteral(&ast.ObjectLiteral{Properties: group}))
+			}
+		}
+		return "mergeMaps(" + strings.Join(parts, ", ") + ")"
+	}
+
 	// En Java, on va créer un HashMap ou une classe anonyme
 	var sb strings.Builder
 	sb.WriteString("new java.util.HashMap<String, Object>() {{")
@@ -643,21 +2233,158 @@ func (jg *JavaGenerator) GenerateExpression(expr ast.Expression) string {
 	case *ast.TemplateLiteral:
 		return jg.GenerateTemplateLiteral(e)
 	case *ast.ArrayLiteral:
-		return jg.GenerateArrayLiteral(e)
+		if hasSpread(flattenSpreads(e.Elements)) {
+			return jg.GenerateArrayLiteral(e)
+		}
+		// Hors d'une déclaration, l'initialiseur {1, 2} s'écrit new int[] {1, 2}
+		return "new " + jg.arrayType(e) + " " + jg.GenerateArrayLiteral(e)
 	case *ast.ObjectLiteral:
 		return jg.GenerateObjectLiteral(e)
 	case *ast.CallExpression:
 		return jg.GenerateCallExpression(e)
 	case *ast.IndexExpression:
 		return jg.GenerateIndexExpression(e)
+	case *ast.DotExpression:
+		// this.#count : le champ est private
+		e = privateAccess(e, bareName)
+		if jg.accessors[e.Property] {
+			return jg.GenerateExpression(e.Object) + ".get" + capitalize(e.Property) + "()"
+		}
+		return jg.GenerateExpression(e.Object) + "." + e.Property
 	case *ast.Identifier:
+		if e.Value == "this" && jg.outerThis != "" {
+			return jg.outerThis
+		}
+		if member, ok := jg.namespaces[jg.namespace][e.Value]; ok && member.Value {
+			return jg.namespace + "." + e.Value
+		}
 		return e.Value
+	case *ast.ParenthesizedExpression:
+		return "(" + jg.GenerateExpression(e.Expression) + ")"
 	case *ast.InfixExpression:
+		left := jg.GenerateExpression(e.Left)
+		if e.Operator == "/" && isInteger(jg.types, e.Left) && isInteger(jg.types, e.Right) {
+			// La division de deux entiers donne un nombre à virgule en TypeScript
+			left = "(double) " + castOperand(e.Left, left)
+		}
+		return left + " " + e.Operator + " " + jg.GenerateExpression(e.Right)
+	case *ast.SpreadElement:
+		// Un tableau passé à un varargs Java est déjà « étalé »
+		return jg.GenerateExpression(e.Argument)
+	case *ast.AssignmentExpression:
+		if property, value := accessorAssignment(e, jg.accessors); property != nil {
+			return jg.GenerateExpression(property.Object) + ".set" + capitalize(property.Property) + "(" + jg.GenerateExpression(value) + ")"
+		}
+		if e.Right == nil {
+			return jg.GenerateExpression(e.Left) + e.Operator
+		}
 		return jg.GenerateExpression(e.Left) + " " + e.Operator + " " + jg.GenerateExpression(e.Right)
+	case *ast.NewExpression:
+		var params []ast.Parameter
+		var typeParams []ast.TypeParameter
+		if ident, ok := e.Class.(*ast.Identifier); ok && jg.classes[ident.Value] != nil {
+			typeParams = jg.classes[ident.Value].TypeParameters
+			if ctor := findConstructor(jg.classes[ident.Value]); ctor != nil {
+				params = ctor.Parameters
+			}
+		}
+		var args []string
+		for i, arg := range e.Arguments {
+			nl, ok := arg.(*ast.NumberLiteral)
+			if ok && i < len(params) && isTypeParameter(params[i].Type, typeParams) {
+				// new Box<number>(3) : T est un Double, que l'Integer 3 ne remplit pas
+				args = append(args, jg.boxedNumber(nl, "Double"))
+				continue
+			}
+			args = append(args, jg.GenerateExpression(arg))
+		}
+		class := jg.GenerateExpression(e.Class)
+		if class == "Map" && !jg.typeNames[class] {
+			// new Map<K, V>() -> new java.util.HashMap<K, V>()
+			class = "java.util.HashMap"
+		}
+		return "new " + class + jg.typeArguments(e.TypeArguments) + "(" + strings.Join(args, ", ") + ")"
+	case *ast.AwaitExpression:
+		// Attente bloquante du résultat
+		return jg.GenerateExpression(e.Argument) + ".join()"
+	case *ast.AsExpression:
+		if !castsTo(e.Type) {
+			return assertedOperand(e.Expression, jg.GenerateExpression)
+		}
+		// value as Shape -> ((Shape) value)
+		return "((" + jg.mapType(e.Type) + ") " + assertedOperand(e.Expression, jg.GenerateExpression) + ")"
+	case *ast.SatisfiesExpression:
+		return assertedOperand(e.Expression, jg.GenerateExpression)
+	case *ast.NonNullExpression:
+		return assertedOperand(e.Expression, jg.GenerateExpression)
+	case *ast.ArrowFunction:
+		return jg.GenerateArrowFunction(e)
 	}
 	return ""
 }
 
+// GenerateArrowFunction génère une lambda ; une lambda async renvoie un
+// CompletableFuture
+func (jg *JavaGenerator) GenerateArrowFunction(af *ast.ArrowFunction) string {
+	var names []string
+	for _, param := range af.Parameters {
+		names = append(names, param.Name)
+	}
+	prefix := "(" + strings.Join(names, ", ") + ") -> "
+
+	if af.IsAsync {
+		return prefix + "{\n" + jg.generateBody(arrowBody(af), true, "    ") + "}"
+	}
+	if af.ExpressionBody != nil {
+		return prefix + jg.GenerateExpression(af.ExpressionBody)
+	}
+	return prefix + "{\n" + jg.generateBody(af.Body, false, "    ") + "}"
+}
+
+// functionalType choisit l'interface fonctionnelle d'une lambda selon son
+// nombre de paramètres et son type de retour
+func (jg *JavaGenerator) functionalType(af *ast.ArrowFunction) string {
+	var types []string
+	for _, param := range af.Parameters {
+		types = append(types, jg.boxedType(param.Type))
+	}
+	returnType := af.ReturnType
+	if af.IsAsync && returnType == "" {
+		returnType = "Promise<void>"
+	}
+	returns := returnType != "void" && (returnType != "" || returnsValue(arrowBody(af)))
+
+	pkg := "java.util.function."
+	switch {
+	case len(types) == 0 && !returns:
+		return "Runnable"
+	case len(types) == 0:
+		return pkg + "Supplier<" + jg.boxedType(returnType) + ">"
+	case len(types) == 1 && !returns:
+		return pkg + "Consumer<" + types[0] + ">"
+	case len(types) == 1:
+		return pkg + "Function<" + types[0] + ", " + jg.boxedType(returnType) + ">"
+	case len(types) == 2 && !returns:
+		return pkg + "BiConsumer<" + types[0] + ", " + types[1] + ">"
+	case len(types) == 2:
+		return pkg + "BiFunction<" + types[0] + ", " + types[1] + ", " + jg.boxedType(returnType) + ">"
+	}
+	return "Object"
+}
+
+// functionalMethod renvoie la méthode abstraite d'une interface fonctionnelle
+func functionalMethod(functional string) string {
+	switch {
+	case functional == "Runnable":
+		return "run"
+	case strings.Contains(functional, "Supplier<"):
+		return "get"
+	case strings.Contains(functional, "Consumer<"):
+		return "accept"
+	}
+	return "apply"
+}
+
 func (jg *JavaGenerator) GenerateTemplateLiteral(tl *ast.TemplateLiteral) string {
 	// En Java, convertir les template literals en String.format ou concaténation simple
 	if len(tl.Parts) > 0 {
@@ -669,6 +2396,10 @@ func (jg *JavaGenerator) GenerateTemplateLiteral(tl *ast.TemplateLiteral) string
 }
 
 func (jg *JavaGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
+	if alias, ok := jg.literals[sl]; ok {
+		member := enumMemberName(sl.Value)
+		return alias + "." + member
+	}
 	return "\"" + sl.Value + "\""
 }
 
@@ -684,27 +2415,114 @@ func (jg *JavaGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
 }
 
 // PythonGenerator génère du code Python
-type PythonGenerator struct{}
+type PythonGenerator struct {
+	Decorators map[string]string // décorateur TypeScript -> décorateur Python
+
+	typeNames map[string]bool // classes, interfaces et paramètres de type connus
+	depth     int             // profondeur de fonctions : un await global passe par asyncio.run
+
+	classes map[string]*ast.ClassDeclaration // une interface ne peut pas étendre une classe
+
+	namespace bool // corps d'un namespace, généré dans une fonction
+}
 
 func (pg *PythonGenerator) Generate(statements []ast.Statement) string {
 	var sb strings.Builder
 
+	statements, imports, exports := splitModuleStatements(statements)
+	pg.typeNames = collectTypeNames(statements)
+	pg.classes = collectClasses(statements)
+
 	// Séparer les variables et les fonctions
 	var variables []ast.Statement
 	var functions []ast.Statement
 	var expressions []ast.Statement
+	var enums []*ast.EnumDeclaration
+	var literalAliases []*ast.TypeAlias
+	var types []ast.Statement
+	var namespaces []*ast.NamespaceDeclaration
 
 	for _, stmt := range statements {
-		switch stmt.(type) {
+		switch s := stmt.(type) {
 		case *ast.VariableDeclaration:
 			variables = append(variables, stmt)
 		case *ast.FunctionDeclaration:
 			functions = append(functions, stmt)
+		case *ast.EnumDeclaration:
+			enums = append(enums, s)
+		case *ast.TypeAlias:
+			if len(s.Literals) > 0 {
+				literalAliases = append(literalAliases, s)
+			}
+		case *ast.ClassDeclaration, *ast.Interface:
+			types = append(types, stmt)
+		case *ast.NamespaceDeclaration:
+			if isInstantiated(s) {
+				namespaces = append(namespaces, s)
+			}
+		case *ast.AmbientDeclaration:
+			// Implémentée ailleurs : seuls ses types sont repris (typeNames)
 		default:
 			expressions = append(expressions, stmt)
 		}
 	}
 
+	// Annotations évaluées paresseusement : une classe peut se nommer elle-même.
+	// L'import doit ouvrir le fichier : celui d'un namespace est fait ici.
+	typeVars := pg.generateTypeVars(statements)
+	if (typeVars != "" || len(types) > 0 || len(namespaces) > 0) && !pg.namespace {
+		sb.WriteString("from __future__ import annotations\n\n")
+	}
+	if len(namespaces) > 0 && !pg.namespace {
+		sb.WriteString("import types\n\n")
+	}
+	for _, stmt := range types {
+		if cd, ok := stmt.(*ast.ClassDeclaration); ok && cd.IsAbstract {
+			sb.WriteString("from abc import ABC, abstractmethod\n\n")
+			break
+		}
+	}
+	if imports := pg.typingImports(literalAliases, types, functions, typeVars != ""); len(imports) > 0 {
+		sb.WriteString("from typing import " + strings.Join(imports, ", ") + "\n\n")
+	}
+
+	// Un await hors fonction est exécuté par asyncio.run
+	if containsAwait(variables) || containsAwait(expressions) {
+		sb.WriteString("import asyncio\n\n")
+	}
+
+	// Imports et ré-exports du module
+	sb.WriteString(pg.generateImports(imports, exports))
+
+	// Enums : classes enum.Enum
+	if len(enums) > 0 {
+		sb.WriteString("from enum import Enum\n\n")
+		for _, ed := range enums {
+			sb.WriteString(pg.GenerateEnum(ed))
+		}
+	}
+
+	// Unions de littéraux : annotations typing.Literal
+	if len(literalAliases) > 0 {
+		for _, ta := range literalAliases {
+			sb.WriteString(pg.GenerateTypeAlias(ta))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Paramètres de type : TypeVar au niveau du module
+	sb.WriteString(typeVars)
+
+	// Interfaces (Protocol) et classes
+	for _, stmt := range types {
+		switch s := stmt.(type) {
+		case *ast.ClassDeclaration:
+			sb.WriteString(pg.GenerateClass(s))
+		case *ast.Interface:
+			sb.WriteString(pg.GenerateInterface(s))
+		}
+	}
+
 	// Générer les fonctions d'abord
 	for _, stmt := range functions {
 		if s, ok := stmt.(*ast.FunctionDeclaration); ok {
@@ -712,6 +2530,10 @@ func (pg *PythonGenerator) Generate(statements []ast.Statement) string {
 		}
 	}
 
+	for _, nd := range namespaces {
+		sb.WriteString(pg.GenerateNamespace(nd))
+	}
+
 	// Variables globales
 	for _, stmt := range variables {
 		if s, ok := stmt.(*ast.VariableDeclaration); ok {
@@ -719,6 +2541,26 @@ func (pg *PythonGenerator) Generate(statements []ast.Statement) string {
 		}
 	}
 
+	// Export par défaut : Python n'en a pas, il devient le nom default ;
+	// export { a as b } devient un alias au niveau du module
+	for _, ed := range exports {
+		if !ed.IsDefault {
+			if ed.Source == "" {
+				for _, spec := range ed.Specifiers {
+					if spec.Alias != "" {
+						sb.WriteString(spec.Alias + " = " + spec.Name + "\n")
+					}
+				}
+			}
+			continue
+		}
+		if isDefaultExpression(ed) {
+			sb.WriteString("default = " + pg.GeneratePythonExpression(ed.Declaration.(*ast.ExpressionStatement).Expression) + "\n")
+		} else {
+			sb.WriteString("default = " + declarationName(ed.Declaration) + "\n")
+		}
+	}
+
 	// Main execution
 	if len(expressions) > 0 {
 		sb.WriteString("\n# Main execution\n")
@@ -732,544 +2574,6511 @@ func (pg *PythonGenerator) Generate(statements []ast.Statement) string {
 	return sb.String()
 }
 
-func (pg *PythonGenerator) GeneratePythonFunction(fd *ast.FunctionDeclaration) string {
+// generateImports génère les imports, les ré-exports et la liste __all__ des
+// noms exportés. Un chemin relatif devient un import relatif de paquet :
+// "../models/user" -> from ..models.user import User
+func (pg *PythonGenerator) generateImports(imports []*ast.ImportDeclaration, exports []*ast.ExportDeclaration) string {
 	var sb strings.Builder
 
-	sb.WriteString("def ")
-	sb.WriteString(fd.Name)
-	sb.WriteString("(")
-
-	// Paramètres
-	for i, param := range fd.Parameters {
-		if i > 0 {
-			sb.WriteString(", ")
+	for _, id := range imports {
+		// Les annotations sont paresseuses, les imports de types restent utiles
+		var names []string
+		if id.Default != "" {
+			names = append(names, "default as "+id.Default)
+		}
+		for _, spec := range id.Specifiers {
+			names = append(names, pg.importName(spec))
+		}
+		if len(names) > 0 {
+			sb.WriteString("from " + pg.moduleName(id.Source) + " import " + strings.Join(names, ", ") + "\n")
+		}
+		if id.Namespace != "" {
+			sb.WriteString(pg.importModule(id.Source, id.Namespace))
+		}
+		if id.Default == "" && id.Namespace == "" && len(id.Specifiers) == 0 {
+			sb.WriteString(pg.importModule(id.Source, ""))
 		}
-		sb.WriteString(param.Name)
 	}
 
-	sb.WriteString("):\n")
-
-	// Corps de la fonction
-	if len(fd.Body) == 0 {
-		sb.WriteString("    pass\n")
-	} else {
-		for _, stmt := range fd.Body {
-			sb.WriteString("    ")
-			sb.WriteString(pg.GeneratePythonStatement(stmt))
+	var all []string
+	for _, ed := range exports {
+		switch {
+		case ed.Source != "" && ed.IsWildcard && ed.Namespace != "":
+			sb.WriteString(pg.importModule(ed.Source, ed.Namespace))
+			all = append(all, ed.Namespace)
+		case ed.Source != "" && ed.IsWildcard:
+			sb.WriteString("from " + pg.moduleName(ed.Source) + " import *\n")
+		case ed.Source != "":
+			var names []string
+			for _, spec := range ed.Specifiers {
+				names = append(names, pg.importName(spec))
+				all = append(all, spec.LocalName())
+			}
+			sb.WriteString("from " + pg.moduleName(ed.Source) + " import " + strings.Join(names, ", ") + "\n")
+		case ed.IsDefault:
+			all = append(all, "default")
+		case ed.Declaration != nil:
+			all = append(all, declarationName(ed.Declaration))
+		default:
+			for _, spec := range ed.Specifiers {
+				all = append(all, spec.LocalName())
+			}
 		}
 	}
 
-	sb.WriteString("\n")
+	// Les exports d'un namespace sont les attributs de son module (GenerateNamespace)
+	if len(all) > 0 && !pg.namespace {
+		var quoted []string
+		for _, name := range all {
+			quoted = append(quoted, "\""+name+"\"")
+		}
+		sb.WriteString("\n__all__ = [" + strings.Join(quoted, ", ") + "]\n")
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
 	return sb.String()
 }
 
-func (pg *PythonGenerator) GeneratePythonStatement(stmt ast.Statement) string {
-	switch s := stmt.(type) {
-	case *ast.ReturnStatement:
-		if s.Value != nil {
-			return "return " + pg.GeneratePythonExpression(s.Value) + "\n"
-		}
-		return "return\n"
-	case *ast.IfStatement:
-		return pg.GeneratePythonIfStatement(s)
-	case *ast.VariableDeclaration:
-		return pg.GenerateVariableDeclaration(s)
+// moduleName convertit un chemin de module en nom Python : "./utils/logger"
+// -> .utils.logger, "../config" -> ..config
+func (pg *PythonGenerator) moduleName(source string) string {
+	parents, segments := modulePath(source)
+	if !isRelativeModule(source) {
+		return strings.Join(segments, ".")
 	}
-	return ""
+	return strings.Repeat(".", parents+1) + strings.Join(segments, ".")
 }
 
-func (pg *PythonGenerator) GeneratePythonIfStatement(is *ast.IfStatement) string {
-	var sb strings.Builder
-	sb.WriteString("if ")
-	sb.WriteString(pg.GeneratePythonExpression(is.Condition))
-	sb.WriteString(":\n")
-
-	if blockStmt, ok := is.ThenBranch.(*ast.BlockStatement); ok {
-		for _, stmt := range blockStmt.Statements {
-			sb.WriteString("        ")
-			sb.WriteString(pg.GeneratePythonStatement(stmt))
-		}
+// importModule importe un module entier, sous un alias si non vide
+func (pg *PythonGenerator) importModule(source, alias string) string {
+	parents, segments := modulePath(source)
+	if len(segments) == 0 {
+		return ""
 	}
-
-	if is.ElseBranch != nil {
-		sb.WriteString("    else:\n")
-		if blockStmt, ok := is.ElseBranch.(*ast.BlockStatement); ok {
-			for _, stmt := range blockStmt.Statements {
-				sb.WriteString("        ")
-				sb.WriteString(pg.GeneratePythonStatement(stmt))
-			}
+	if !isRelativeModule(source) {
+		if alias == "" || alias == segments[len(segments)-1] && len(segments) == 1 {
+			return "import " + strings.Join(segments, ".") + "\n"
 		}
+		return "import " + strings.Join(segments, ".") + " as " + alias + "\n"
 	}
-
-	return sb.String()
+	// Import relatif : from <paquet> import <module>
+	pkg := strings.Repeat(".", parents+1) + strings.Join(segments[:len(segments)-1], ".")
+	module := segments[len(segments)-1]
+	if alias == "" || alias == module {
+		return "from " + pkg + " import " + module + "\n"
+	}
+	return "from " + pkg + " import " + module + " as " + alias + "\n"
 }
 
-func (pg *PythonGenerator) GeneratePythonExpression(expr ast.Expression) string {
-	switch e := expr.(type) {
-	case *ast.StringLiteral:
-		return pg.GenerateStringLiteral(e)
-	case *ast.NumberLiteral:
-		return pg.GenerateNumberLiteral(e)
-	case *ast.BooleanLiteral:
-		if e.Value {
-			return "True"
-		}
-		return "False"
-	case *ast.TemplateLiteral:
-		return pg.GenerateTemplateLiteral(e)
-	case *ast.ArrayLiteral:
-		return pg.GenerateArrayLiteral(e)
-	case *ast.ObjectLiteral:
-		return pg.GenerateObjectLiteral(e)
-	case *ast.CallExpression:
-		return pg.GenerateCallExpression(e)
-	case *ast.IndexExpression:
-		return pg.GenerateIndexExpression(e)
-	case *ast.Identifier:
-		return e.Value
-	case *ast.InfixExpression:
-		return pg.GeneratePythonExpression(e.Left) + " " + e.Operator + " " + pg.GeneratePythonExpression(e.Right)
+func (pg *PythonGenerator) importName(spec ast.ImportSpecifier) string {
+	if spec.Alias != "" {
+		return spec.Name + " as " + spec.Alias
 	}
-	return ""
+	return spec.Name
 }
 
-func (pg *PythonGenerator) GeneratePythonExpressionStatement(es *ast.ExpressionStatement) string {
-	// Convertir console.log en print
-	if callExpr, ok := es.Expression.(*ast.CallExpression); ok {
-		if dotExpr, ok := callExpr.Function.(*ast.DotExpression); ok {
-			if ident, ok := dotExpr.Object.(*ast.Identifier); ok {
-				if ident.Value == "console" && dotExpr.Property == "log" {
-					var sb strings.Builder
-					sb.WriteString("print(")
-					for i, arg := range callExpr.Arguments {
-						if i > 0 {
-							sb.WriteString(", ")
-						}
-						sb.WriteString(pg.GeneratePythonExpression(arg))
-					}
-					sb.WriteString(")\n")
-					return sb.String()
-				}
-			}
-		}
-	}
+// GenerateNamespace génère un namespace comme module Python, construit par une
+// fonction à la manière de l'IIFE de tsc : son corps y est généré comme un
+// programme, puis ses exports deviennent les attributs d'un types.ModuleType
+func (pg *PythonGenerator) GenerateNamespace(nd *ast.NamespaceDeclaration) string {
+	body := (&PythonGenerator{Decorators: pg.Decorators, namespace: true}).Generate(nd.Body)
 
-	return pg.GeneratePythonExpression(es.Expression) + "\n"
+	var sb strings.Builder
+	sb.WriteString("def _" + nd.Name + "():\n")
+	sb.WriteString(indentLines(strings.TrimRight(body, "\n")+"\n\n", "    "))
+	sb.WriteString("    " + nd.Name + " = types.ModuleType(\"" + nd.Name + "\")\n")
+	for _, spec := range namespaceExports(nd) {
+		sb.WriteString("    " + nd.Name + "." + spec.LocalName() + " = " + spec.Name + "\n")
+	}
+	sb.WriteString("    return " + nd.Name + "\n\n\n")
+	sb.WriteString(nd.Name + " = _" + nd.Name + "()\n\n")
+	return sb.String()
 }
 
-func (pg *PythonGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+func (pg *PythonGenerator) GeneratePythonFunction(fd *ast.FunctionDeclaration) string {
 	var sb strings.Builder
 
-	// Python n'a pas de const, on peut utiliser un commentaire ou une convention
-	if vd.IsConst {
-		sb.WriteString("# Constant\n")
+	// Les fonctions génériques sont annotées pour que leurs TypeVar aient un sens
+	annotate := len(fd.TypeParameters) > 0
+	if annotate {
+		defer declareTypeParameters(pg.typeNames, fd.TypeParameters)()
 	}
 
-	sb.WriteString(vd.Name)
-	sb.WriteString(" = ")
+	pg.depth++
+	defer func() { pg.depth-- }()
 
-	switch val := vd.Value.(type) {
-	case *ast.StringLiteral:
-		sb.WriteString(pg.GenerateStringLiteral(val))
-	case *ast.NumberLiteral:
-		sb.WriteString(pg.GenerateNumberLiteral(val))
-	case *ast.BooleanLiteral:
-		sb.WriteString(pg.GenerateBooleanLiteral(val))
-	case *ast.ArrayLiteral:
-		sb.WriteString(pg.GenerateArrayLiteral(val))
-	case *ast.ObjectLiteral:
-		sb.WriteString(pg.GenerateObjectLiteral(val))
-	case *ast.TemplateLiteral:
-		sb.WriteString(pg.GenerateTemplateLiteral(val))
-	default:
-		sb.WriteString(pg.GeneratePythonExpression(val))
+	for _, signature := range fd.Overloads {
+		sb.WriteString(pg.overloadStub(signature, fd.IsAsync))
 	}
 
-	sb.WriteString("\n")
-	return sb.String()
-}
-
-func (pg *PythonGenerator) GenerateCallExpression(ce *ast.CallExpression) string {
-	var sb strings.Builder
-	sb.WriteString(pg.GeneratePythonExpression(ce.Function))
+	if fd.IsAsync {
+		sb.WriteString("async ")
+	}
+	sb.WriteString("def ")
+	sb.WriteString(fd.Name)
 	sb.WriteString("(")
-	for i, arg := range ce.Arguments {
+
+	// Paramètres ; un rest typé dictionnaire devient **kwargs, sinon *args
+	for i, param := range fd.Parameters {
 		if i > 0 {
 			sb.WriteString(", ")
 		}
-		sb.WriteString(pg.GeneratePythonExpression(arg))
+		if param.IsRest {
+			if isRecordType(elementType(param.Type)) {
+				sb.WriteString("**")
+			} else {
+				sb.WriteString("*")
+			}
+		}
+		sb.WriteString(param.Name)
+		if annotate && param.Type != "" {
+			if param.IsRest {
+				sb.WriteString(": " + pg.mapType(elementType(param.Type)))
+			} else {
+				sb.WriteString(": " + pg.mapType(param.Type))
+			}
+		}
+		if param.CanBeOmitted() {
+			sb.WriteString("=" + pg.pythonDefault(param))
+		}
 	}
-	sb.WriteString(")")
-	return sb.String()
-}
 
-func (pg *PythonGenerator) GenerateIndexExpression(ie *ast.IndexExpression) string {
-	return pg.GeneratePythonExpression(ie.Left) + "[" + pg.GeneratePythonExpression(ie.Index) + "]"
-}
+	sb.WriteString(")")
+	if annotate && fd.ReturnType != "" {
+		sb.WriteString(" -> " + pg.mapType(fd.ReturnType))
+	}
+	sb.WriteString(":\n")
 
-func (pg *PythonGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
-	var sb strings.Builder
-	sb.WriteString("[")
-	for i, element := range al.Elements {
-		if i > 0 {
-			sb.WriteString(", ")
+	// Les valeurs par défaut calculées sont évaluées à chaque appel
+	for _, param := range fd.Parameters {
+		if hasComputedDefault(param) {
+			sb.WriteString("    if " + param.Name + " is None:\n")
+			sb.WriteString("        " + param.Name + " = " + pg.GeneratePythonExpression(param.Default) + "\n")
 		}
-		sb.WriteString(pg.GeneratePythonExpression(element))
 	}
-	sb.WriteString("]")
+
+	// Corps de la fonction
+	sb.WriteString(pg.generateBlock(fd.Body))
+
+	sb.WriteString("\n")
 	return sb.String()
 }
 
-func (pg *PythonGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) string {
+// generateBlock génère un bloc indenté d'un niveau ; un bloc vide devient pass
+func (pg *PythonGenerator) generateBlock(statements []ast.Statement) string {
 	var sb strings.Builder
-	sb.WriteString("{")
-	for i, prop := range ol.Properties {
-		if i > 0 {
-			sb.WriteString(", ")
-		}
-		sb.WriteString("\"")
-		sb.WriteString(prop.Key)
-		sb.WriteString("\": ")
-		sb.WriteString(pg.GeneratePythonExpression(prop.Value))
+	for _, stmt := range statements {
+		sb.WriteString(indentLines(pg.GeneratePythonStatement(stmt), "    "))
+	}
+	if sb.Len() == 0 {
+		return "    pass\n"
 	}
-	sb.WriteString("}")
 	return sb.String()
 }
 
-func (pg *PythonGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
-	return "\"" + sl.Value + "\""
+// blockStatements renvoie les instructions d'un bloc, ou l'instruction seule
+func blockStatements(stmt ast.Statement) []ast.Statement {
+	if block, ok := stmt.(*ast.BlockStatement); ok {
+		return block.Statements
+	}
+	if stmt == nil {
+		return nil
+	}
+	return []ast.Statement{stmt}
 }
 
-func (pg *PythonGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
-	return nl.Value
-}
+func (pg *PythonGenerator) GenerateEnum(ed *ast.EnumDeclaration) string {
+	var sb strings.Builder
+	sb.WriteString("class " + ed.Name + "(Enum):\n")
+	for _, member := range resolveEnumMembers(ed) {
+		sb.WriteString("    " + member.Name + " = " + pg.GeneratePythonExpression(member.Value) + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// mapType convertit un type TypeScript en annotation Python
+func (pg *PythonGenerator) mapType(t string) string {
+	if strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "Array<") {
+		return "list[" + pg.mapType(elementType(t)) + "]"
+	}
+	// async def f() -> T : la coroutine est implicite
+	if inner, ok := unwrapPromise(t); ok {
+		return pg.mapType(inner)
+	}
+	if inner, ok := yieldedType(t); ok {
+		return "Iterator[" + pg.mapType(inner) + "]"
+	}
+	switch t {
+	case "string":
+		return "str"
+	case "number":
+		return "float"
+	case integerType:
+		return "int"
+	case "boolean":
+		return "bool"
+	case "void", "undefined", "null":
+		return "None"
+	}
+	if name, args := splitTypeArguments(t); name == "Map" && len(args) == 2 && !pg.typeNames[name] {
+		return "dict[" + pg.mapType(args[0]) + ", " + pg.mapType(args[1]) + "]"
+	}
+	if name, args := splitTypeArguments(t); pg.typeNames[name] {
+		if len(args) == 0 {
+			return name
+		}
+		var mapped []string
+		for _, arg := range args {
+			mapped = append(mapped, pg.mapType(arg))
+		}
+		return name + "[" + strings.Join(mapped, ", ") + "]"
+	}
+	return "object"
+}
+
+// overloadStub génère une signature de surcharge en stub @overload, annoté
+// puisque seuls ses types la distinguent des autres
+func (pg *PythonGenerator) overloadStub(signature *ast.FunctionDeclaration, isAsync bool) string {
+	defer declareTypeParameters(pg.typeNames, signature.TypeParameters)()
+
+	var params []string
+	for _, param := range signature.Parameters {
+		part := param.Name
+		if param.IsRest {
+			part = "*" + part
+			if isRecordType(elementType(param.Type)) {
+				part = "*" + part
+			}
+		}
+		if param.Type != "" {
+			if param.IsRest {
+				part += ": " + pg.mapType(elementType(param.Type))
+			} else {
+				part += ": " + pg.mapType(param.Type)
+			}
+		}
+		if param.CanBeOmitted() {
+			part += " = ..."
+		}
+		params = append(params, part)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("@overload\n")
+	if isAsync {
+		sb.WriteString("async ")
+	}
+	sb.WriteString("def " + signature.Name + "(" + strings.Join(params, ", ") + ")")
+	if signature.ReturnType != "" {
+		sb.WriteString(" -> " + pg.mapType(signature.ReturnType))
+	}
+	sb.WriteString(": ...\n\n")
+	return sb.String()
+}
+
+// generateTypeVars déclare un TypeVar par paramètre de type du programme ;
+// la contrainte extends devient bound. Les TypeVar étant globaux, un nom
+// réutilisé par plusieurs déclarations n'est déclaré qu'une fois.
+func (pg *PythonGenerator) generateTypeVars(statements []ast.Statement) string {
+	var params []ast.TypeParameter
+	collect := func(typeParams []ast.TypeParameter) {
+		params = append(params, typeParams...)
+	}
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *ast.FunctionDeclaration:
+			collect(s.TypeParameters)
+		case *ast.ClassDeclaration:
+			collect(s.TypeParameters)
+			for _, method := range s.Methods {
+				collect(method.TypeParameters)
+			}
+		case *ast.Interface:
+			collect(s.TypeParameters)
+			for _, field := range s.Fields {
+				collect(field.TypeParameters)
+			}
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	declared := map[string]bool{}
+	for _, param := range params {
+		if declared[param.Name] {
+			continue
+		}
+		declared[param.Name] = true
+		sb.WriteString(param.Name + " = TypeVar(\"" + param.Name + "\"")
+		if bound := pg.mapType(param.Constraint); param.Constraint != "" && bound != "object" {
+			sb.WriteString(", bound=\"" + bound + "\"")
+		}
+		sb.WriteString(")\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// typingImports renvoie les noms à importer du module typing
+func (pg *PythonGenerator) typingImports(literalAliases []*ast.TypeAlias, types, functions []ast.Statement, hasTypeVars bool) []string {
+	var usesGeneric, usesProtocol, usesIterator bool
+	for _, stmt := range types {
+		switch s := stmt.(type) {
+		case *ast.ClassDeclaration:
+			usesGeneric = usesGeneric || len(s.TypeParameters) > 0
+			// Seules les méthodes des classes génériques sont annotées
+			for _, method := range s.Methods {
+				_, isIterator := yieldedType(method.ReturnType)
+				usesIterator = usesIterator || (isIterator && len(s.TypeParameters)+len(method.TypeParameters) > 0)
+			}
+		case *ast.Interface:
+			usesProtocol = true
+		}
+	}
+	var usesOverload bool
+	for _, stmt := range functions {
+		if fd, ok := stmt.(*ast.FunctionDeclaration); ok && len(fd.TypeParameters) > 0 {
+			_, isIterator := yieldedType(fd.ReturnType)
+			usesIterator = usesIterator || isIterator
+		}
+		if fd, ok := stmt.(*ast.FunctionDeclaration); ok && len(fd.Overloads) > 0 {
+			usesOverload = true
+		}
+	}
+
+	var imports []string
+	if usesGeneric {
+		imports = append(imports, "Generic")
+	}
+	if usesIterator {
+		imports = append(imports, "Iterator")
+	}
+	if len(literalAliases) > 0 {
+		imports = append(imports, "Literal")
+	}
+	if usesProtocol {
+		imports = append(imports, "Protocol")
+	}
+	if hasTypeVars {
+		imports = append(imports, "TypeVar")
+	}
+	if usesOverload {
+		imports = append(imports, "overload")
+	}
+	return imports
+}
+
+// genericBase renvoie la base Generic[T, U] d'une déclaration générique
+func (pg *PythonGenerator) genericBase(base string, params []ast.TypeParameter) string {
+	var names []string
+	for _, param := range params {
+		names = append(names, param.Name)
+	}
+	return base + "[" + strings.Join(names, ", ") + "]"
+}
+
+// GenerateClass génère une classe ; les champs d'instance initialisés le sont
+// dans __init__, les champs statiques deviennent des attributs de classe
+func (pg *PythonGenerator) GenerateClass(cd *ast.ClassDeclaration) string {
+	cd = withParameterProperties(cd)
+
+	var sb strings.Builder
+	sb.WriteString(pg.decorators(cd.Decorators))
+	sb.WriteString("class " + cd.Name)
+	var bases []string
+	if cd.SuperClass != "" {
+		bases = append(bases, pg.mapType(cd.SuperClass))
+	}
+	if cd.IsAbstract {
+		bases = append(bases, "ABC")
+	}
+	if len(cd.TypeParameters) > 0 {
+		bases = append(bases, pg.genericBase("Generic", cd.TypeParameters))
+	}
+	if len(bases) > 0 {
+		sb.WriteString("(" + strings.Join(bases, ", ") + ")")
+	}
+	sb.WriteString(":\n")
+
+	var members []string
+	var statics strings.Builder
+	for _, field := range cd.Fields {
+		// Python n'a pas de décorateur de champ
+		for _, d := range field.Decorators {
+			statics.WriteString("    # " + decoratorSource(d) + " : champ " + field.Name + "\n")
+		}
+		if field.IsStatic && field.HasDefault {
+			statics.WriteString("    " + pg.memberName(field.Name, field.IsPrivateName) + " = " + pg.GeneratePythonExpression(field.Default) + "\n")
+		}
+	}
+	if statics.Len() > 0 {
+		members = append(members, statics.String()+"\n")
+	}
+
+	if needsConstructor(cd) {
+		init := ast.ClassMethod{
+			Name:       "__init__",
+			Parameters: constructorParameters(cd),
+			Body:       constructorBody(cd),
+		}
+		if constructor := findConstructor(cd); constructor != nil {
+			init.Decorators = constructor.Decorators
+		}
+		members = append(members, pg.generateMethod(cd, init))
+	}
+	properties := map[string]classProperty{}
+	for _, property := range classProperties(cd) {
+		properties[property.Name] = property
+	}
+	for _, method := range cd.Methods {
+		switch {
+		case method.Kind == ast.RegularMethod && method.IsAbstract:
+			members = append(members, pg.generateDecoratedMethod(cd, method, "@abstractmethod\n"))
+		case method.Kind == ast.RegularMethod:
+			members = append(members, pg.generateMethod(cd, method))
+		case method.Kind == ast.Getter || method.Kind == ast.Setter:
+			// Le get et le set d'une propriété sont générés ensemble, à la
+			// place du premier déclaré
+			if property, ok := properties[method.Name]; ok {
+				members = append(members, pg.generateProperty(cd, property))
+				delete(properties, method.Name)
+			}
+		}
+	}
+
+	if len(members) == 0 {
+		sb.WriteString("    pass\n\n")
+	} else {
+		sb.WriteString(strings.Join(members, ""))
+	}
+
+	// Les blocs static { ... } s'exécutent une fois la classe définie
+	if statics := staticBlockStatements(cd); len(statics) > 0 {
+		for _, stmt := range statics {
+			sb.WriteString(pg.GeneratePythonStatement(stmt))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// memberName renvoie le nom Python d'un membre : un nom privé #secret devient
+// __secret, que Python rend propre à la classe
+func (pg *PythonGenerator) memberName(name string, isPrivateName bool) string {
+	if isPrivateName {
+		return mangledName(name)
+	}
+	return name
+}
+
+// mangledName préfixe un nom de __, ce qui le soumet à la substitution de noms
+// privés de Python
+func mangledName(name string) string { return "__" + name }
+
+// generateProperty génère une propriété : le get décoré par @property, le set
+// par @name.setter. Sans get, le set est une méthode passée à property(fset=...)
+func (pg *PythonGenerator) generateProperty(cd *ast.ClassDeclaration, property classProperty) string {
+	if property.Getter == nil {
+		setter := *property.Setter
+		setter.Name = "_set_" + property.Name
+		return pg.generateMethod(cd, setter) + "    " + property.Name + " = property(fset=" + setter.Name + ")\n\n"
+	}
+	code := pg.generateDecoratedMethod(cd, *property.Getter, "@property\n")
+	if property.Setter != nil {
+		code += pg.generateDecoratedMethod(cd, *property.Setter, "@"+property.Name+".setter\n")
+	}
+	return code
+}
+
+// generateMethod génère une méthode comme une fonction à laquelle on ajoute
+// self (sauf @staticmethod), puis l'indente dans le corps de la classe
+func (pg *PythonGenerator) generateMethod(cd *ast.ClassDeclaration, method ast.ClassMethod) string {
+	return pg.generateDecoratedMethod(cd, method, "")
+}
+
+func (pg *PythonGenerator) generateDecoratedMethod(cd *ast.ClassDeclaration, method ast.ClassMethod, decorator string) string {
+	fd := &ast.FunctionDeclaration{
+		Name: pg.memberName(method.Name, method.IsPrivateName),
+		// Les TypeVar de la classe sont globaux : ils ne servent qu'à activer les annotations
+		TypeParameters: append(append([]ast.TypeParameter{}, cd.TypeParameters...), method.TypeParameters...),
+		Parameters:     method.Parameters,
+		ReturnType:     method.ReturnType,
+		IsAsync:        method.IsAsync,
+		IsGenerator:    method.IsGenerator,
+		Body:           method.Body,
+	}
+	if method.IsStatic {
+		decorator += "@staticmethod\n"
+	} else {
+		fd.Parameters = append([]ast.Parameter{{Name: "self"}}, method.Parameters...)
+	}
+	// Python n'a pas de décorateur de paramètre
+	for _, param := range method.Parameters {
+		for _, d := range param.Decorators {
+			decorator = "# " + decoratorSource(d) + " : paramètre " + param.Name + "\n" + decorator
+		}
+	}
+	return indentLines(pg.decorators(method.Decorators)+decorator+pg.GeneratePythonFunction(fd), "    ")
+}
+
+// decorators traduit des décorateurs TypeScript en décorateurs Python ; ceux
+// sans traduction restent en commentaire
+func (pg *PythonGenerator) decorators(decorators []ast.Decorator) string {
+	return decoratorLines(decorators, pg.Decorators, pg.GeneratePythonExpression, "#", "")
+}
+
+// GenerateInterface génère un Protocol : les propriétés sont annotées, les
+// méthodes n'ont qu'une signature
+func (pg *PythonGenerator) GenerateInterface(i *ast.Interface) string {
+	defer declareTypeParameters(pg.typeNames, i.TypeParameters)()
+
+	var sb strings.Builder
+	// Un Protocol qui en étend d'autres doit encore nommer Protocol parmi ses bases
+	interfaces, rejected := classBases(i.Extends, pg.classes)
+	for _, class := range rejected {
+		sb.WriteString("# " + i.Name + " étend la classe " + class + " : " + interfaceExtendsClass[Python] + "\n")
+	}
+	var bases []string
+	for _, base := range interfaces {
+		bases = append(bases, pg.mapType(base))
+	}
+	if len(i.TypeParameters) > 0 {
+		bases = append(bases, pg.genericBase("Protocol", i.TypeParameters))
+	} else {
+		bases = append(bases, "Protocol")
+	}
+	sb.WriteString("class " + i.Name + "(" + strings.Join(bases, ", ") + "):\n")
+	if len(i.Fields) == 0 {
+		sb.WriteString("    pass\n")
+	}
+	for _, field := range i.Fields {
+		if !field.IsMethod {
+			fieldType := pg.mapType(field.Type)
+			if field.Optional {
+				fieldType += " | None"
+			}
+			sb.WriteString("    " + field.Name + ": " + fieldType + "\n")
+			continue
+		}
+		restore := declareTypeParameters(pg.typeNames, field.TypeParameters)
+		params := []string{"self"}
+		for _, param := range field.Parameters {
+			params = append(params, param.Name+": "+pg.mapType(param.Type))
+		}
+		sb.WriteString("    def " + field.Name + "(" + strings.Join(params, ", ") + ") -> " + pg.mapType(field.ReturnType) + ": ...\n")
+		restore()
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// GenerateTypeAlias génère un alias typing.Literal pour une union de littéraux
+func (pg *PythonGenerator) GenerateTypeAlias(ta *ast.TypeAlias) string {
+	var values []string
+	for _, value := range ta.Literals {
+		values = append(values, pg.GenerateStringLiteral(&ast.StringLiteral{Value: value}))
+	}
+	return ta.Name + " = Literal[" + strings.Join(values, ", ") + "]\n"
+}
+
+// pythonDefault renvoie la valeur par défaut Python d'un paramètre omissible
+func (pg *PythonGenerator) pythonDefault(param ast.Parameter) string {
+	if param.Default == nil || hasComputedDefault(param) {
+		return "None"
+	}
+	return pg.GeneratePythonExpression(param.Default)
+}
+
+func (pg *PythonGenerator) GeneratePythonStatement(stmt ast.Statement) string {
+	switch s := stmt.(type) {
+	case *ast.ReturnStatement:
+		if s.Value != nil {
+			return "return " + pg.GeneratePythonExpression(s.Value) + "\n"
+		}
+		return "return\n"
+	case *ast.IfStatement:
+		return pg.GeneratePythonIfStatement(s)
+	case *ast.ForStatement:
+		return pg.GeneratePythonForStatement(s)
+	case *ast.WhileStatement:
+		return "while " + pg.GeneratePythonExpression(s.Condition) + ":\n" + pg.generateBlock(blockStatements(s.Body))
+	case *ast.VariableDeclaration:
+		return pg.GenerateVariableDeclaration(s)
+	case *ast.ExpressionStatement:
+		return pg.GeneratePythonExpressionStatement(s)
+	}
+	return ""
+}
+
+func (pg *PythonGenerator) GeneratePythonIfStatement(is *ast.IfStatement) string {
+	var sb strings.Builder
+	sb.WriteString("if ")
+	sb.WriteString(pg.GeneratePythonExpression(is.Condition))
+	sb.WriteString(":\n")
+	sb.WriteString(pg.generateBlock(blockStatements(is.ThenBranch)))
+
+	if is.ElseBranch != nil {
+		sb.WriteString("else:\n")
+		sb.WriteString(pg.generateBlock(blockStatements(is.ElseBranch)))
+	}
+
+	return sb.String()
+}
+
+// GeneratePythonForStatement génère une boucle for C en while : Python ne
+// connaît que for ... in. for (let i = 0; i < n; i++) devient
+// i = 0 / while i < n: ... / i += 1
+func (pg *PythonGenerator) GeneratePythonForStatement(fs *ast.ForStatement) string {
+	var sb strings.Builder
+	if fs.Init != nil {
+		sb.WriteString(pg.GeneratePythonStatement(fs.Init))
+	}
+	condition := "True"
+	if fs.Condition != nil {
+		condition = pg.GeneratePythonExpression(fs.Condition)
+	}
+	sb.WriteString("while " + condition + ":\n")
+	body := blockStatements(fs.Body)
+	if fs.Update != nil {
+		body = append(append([]ast.Statement{}, body...), fs.Update)
+	}
+	sb.WriteString(pg.generateBlock(body))
+	return sb.String()
+}
+
+func (pg *PythonGenerator) GeneratePythonExpression(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return pg.GenerateStringLiteral(e)
+	case *ast.NumberLiteral:
+		return pg.GenerateNumberLiteral(e)
+	case *ast.BooleanLiteral:
+		if e.Value {
+			return "True"
+		}
+		return "False"
+	case *ast.TemplateLiteral:
+		return pg.GenerateTemplateLiteral(e)
+	case *ast.ArrayLiteral:
+		return pg.GenerateArrayLiteral(e)
+	case *ast.ObjectLiteral:
+		return pg.GenerateObjectLiteral(e)
+	case *ast.CallExpression:
+		return pg.GenerateCallExpression(e)
+	case *ast.IndexExpression:
+		return pg.GenerateIndexExpression(e)
+	case *ast.Identifier:
+		switch e.Value {
+		case "this":
+			return "self"
+		case "super":
+			// super.method() -> super().method()
+			return "super()"
+		}
+		return e.Value
+	case *ast.DotExpression:
+		// self.#count -> self.__count
+		return pg.GeneratePythonExpression(e.Object) + "." + privateAccess(e, mangledName).Property
+	case *ast.ParenthesizedExpression:
+		return "(" + pg.GeneratePythonExpression(e.Expression) + ")"
+	case *ast.InfixExpression:
+		return pg.GeneratePythonExpression(e.Left) + " " + e.Operator + " " + pg.GeneratePythonExpression(e.Right)
+	case *ast.SpreadElement:
+		return "*" + pg.GeneratePythonExpression(e.Argument)
+	case *ast.AssignmentExpression:
+		// Python n'a pas de ++ / --
+		switch {
+		case e.Operator == "++":
+			return pg.GeneratePythonExpression(e.Left) + " += 1"
+		case e.Operator == "--":
+			return pg.GeneratePythonExpression(e.Left) + " -= 1"
+		}
+		return pg.GeneratePythonExpression(e.Left) + " " + e.Operator + " " + pg.GeneratePythonExpression(e.Right)
+	case *ast.NewExpression:
+		var args []string
+		for _, arg := range e.Arguments {
+			args = append(args, pg.GeneratePythonExpression(arg))
+		}
+		class := pg.GeneratePythonExpression(e.Class)
+		if class == "Map" && !pg.typeNames[class] && len(args) == 0 {
+			// new Map<K, V>() -> {}
+			return "{}"
+		}
+		return class + "(" + strings.Join(args, ", ") + ")"
+	case *ast.AwaitExpression:
+		if pg.depth == 0 {
+			return "asyncio.run(" + pg.GeneratePythonExpression(e.Argument) + ")"
+		}
+		return "await " + pg.GeneratePythonExpression(e.Argument)
+	case *ast.YieldExpression:
+		yield := "yield"
+		if e.Delegate {
+			yield += " from"
+		}
+		if e.Argument != nil {
+			yield += " " + pg.GeneratePythonExpression(e.Argument)
+		}
+		return yield
+	case *ast.AsExpression:
+		return assertedOperand(e.Expression, pg.GeneratePythonExpression)
+	case *ast.SatisfiesExpression:
+		return assertedOperand(e.Expression, pg.GeneratePythonExpression)
+	case *ast.NonNullExpression:
+		return assertedOperand(e.Expression, pg.GeneratePythonExpression)
+	case *ast.ArrowFunction:
+		return pg.GenerateLambda(e)
+	}
+	return ""
+}
+
+// GenerateLambda génère une lambda Python, limitée à une expression : un
+// corps réduit à un return en est une, et une fonction async dont le corps
+// attend une coroutine renvoie directement cette coroutine. Les autres
+// fonctions fléchées doivent être nommées (voir GenerateVariableDeclaration).
+func (pg *PythonGenerator) GenerateLambda(af *ast.ArrowFunction) string {
+	var names []string
+	for _, param := range af.Parameters {
+		names = append(names, param.Name)
+	}
+	prefix := "lambda"
+	if len(names) > 0 {
+		prefix += " " + strings.Join(names, ", ")
+	}
+
+	body := af.ExpressionBody
+	if body == nil && len(af.Body) == 1 {
+		if rs, ok := af.Body[0].(*ast.ReturnStatement); ok {
+			body = rs.Value
+		}
+	}
+	if body == nil {
+		return prefix + ": None"
+	}
+	if ae, ok := body.(*ast.AwaitExpression); ok && af.IsAsync {
+		body = ae.Argument
+	}
+	pg.depth++
+	defer func() { pg.depth-- }()
+	return prefix + ": " + pg.GeneratePythonExpression(body)
+}
+
+func (pg *PythonGenerator) GeneratePythonExpressionStatement(es *ast.ExpressionStatement) string {
+	// Convertir console.log en print
+	if callExpr, ok := es.Expression.(*ast.CallExpression); ok {
+		if dotExpr, ok := callExpr.Function.(*ast.DotExpression); ok {
+			if ident, ok := dotExpr.Object.(*ast.Identifier); ok {
+				if ident.Value == "console" && dotExpr.Property == "log" {
+					var sb strings.Builder
+					sb.WriteString("print(")
+					for i, arg := range callExpr.Arguments {
+						if i > 0 {
+							sb.WriteString(", ")
+						}
+						sb.WriteString(pg.GeneratePythonExpression(arg))
+					}
+					sb.WriteString(")\n")
+					return sb.String()
+				}
+			}
+		}
+	}
+
+	return pg.GeneratePythonExpression(es.Expression) + "\n"
+}
+
+func (pg *PythonGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+	var sb strings.Builder
+
+	// Une fonction fléchée à corps de bloc, ou async, devient une fonction nommée
+	if af, ok := vd.Value.(*ast.ArrowFunction); ok && (af.ExpressionBody == nil || af.IsAsync) {
+		return pg.GeneratePythonFunction(&ast.FunctionDeclaration{
+			Name:       vd.Name,
+			Parameters: af.Parameters,
+			ReturnType: af.ReturnType,
+			IsAsync:    af.IsAsync,
+			Body:       arrowBody(af),
+		})
+	}
+
+	// Python n'a pas de const, on peut utiliser un commentaire ou une convention
+	// (au niveau du module seulement, le commentaire casserait l'indentation)
+	if vd.IsConst && pg.depth == 0 {
+		sb.WriteString("# Constant\n")
+	}
+
+	sb.WriteString(vd.Name)
+	sb.WriteString(" = ")
+
+	switch val := vd.Value.(type) {
+	case *ast.StringLiteral:
+		sb.WriteString(pg.GenerateStringLiteral(val))
+	case *ast.NumberLiteral:
+		sb.WriteString(pg.GenerateNumberLiteral(val))
+	case *ast.BooleanLiteral:
+		sb.WriteString(pg.GenerateBooleanLiteral(val))
+	case *ast.ArrayLiteral:
+		sb.WriteString(pg.GenerateArrayLiteral(val))
+	case *ast.ObjectLiteral:
+		sb.WriteString(pg.GenerateObjectLiteral(val))
+	case *ast.TemplateLiteral:
+		sb.WriteString(pg.GenerateTemplateLiteral(val))
+	default:
+		sb.WriteString(pg.GeneratePythonExpression(val))
+	}
+
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func (pg *PythonGenerator) GenerateCallExpression(ce *ast.CallExpression) string {
+	var sb strings.Builder
+	if ident, ok := ce.Function.(*ast.Identifier); ok && ident.Value == "super" {
+		// super(args) -> super().__init__(args)
+		sb.WriteString("super().__init__")
+	} else {
+		sb.WriteString(pg.GeneratePythonExpression(ce.Function))
+	}
+	sb.WriteString("(")
+	for i, arg := range ce.Arguments {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(pg.GeneratePythonExpression(arg))
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func (pg *PythonGenerator) GenerateIndexExpression(ie *ast.IndexExpression) string {
+	return pg.GeneratePythonExpression(ie.Left) + "[" + pg.GeneratePythonExpression(ie.Index) + "]"
+}
+
+func (pg *PythonGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	for i, element := range al.Elements {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(pg.GeneratePythonExpression(element))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+func (pg *PythonGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) string {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i, prop := range ol.Properties {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if spread, ok := prop.Value.(*ast.SpreadElement); ok {
+			sb.WriteString("**")
+			sb.WriteString(pg.GeneratePythonExpression(spread.Argument))
+			continue
+		}
+		sb.WriteString("\"")
+		sb.WriteString(prop.Key)
+		sb.WriteString("\": ")
+		sb.WriteString(pg.GeneratePythonExpression(prop.Value))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func (pg *PythonGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
+	return "\"" + sl.Value + "\""
+}
+
+func (pg *PythonGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
+	return nl.Value
+}
+
+func (pg *PythonGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
+	if bl.Value {
+		return "True"
+	}
+	return "False"
+}
+
+func (pg *PythonGenerator) GenerateTemplateLiteral(tl *ast.TemplateLiteral) string {
+	// En Python, convertir les template literals en f-strings
+	if len(tl.Parts) > 0 {
+		content := tl.Parts[0].TokenLiteral()
+		// Pour l'instant, retourner comme string simple (plus tard on peut parser ${} pour interpolation)
+		return "\"" + content + "\""
+	}
+	return "\"\""
+}
+
+// CSharpGenerator génère du code C#
+type CSharpGenerator struct {
+	Decorators map[string]string // décorateur -> attribut : Serializable -> [Serializable]
+
+	usesLinq         bool // Concat/ToArray pour les spreads de tableaux
+	usesCollections  bool // Dictionary pour les objets littéraux
+	needsMergeHelper bool // un spread d'objet nécessite le helper Merge
+	usesTasks        bool // Task pour les fonctions async
+
+	typeNames map[string]bool               // classes, interfaces et paramètres de type connus
+	imported  map[string]bool               // types importés d'autres modules
+	types     *semantic.Inference           // types déduits des déclarations sans annotation
+	literals  map[*ast.StringLiteral]string // chaînes écrites comme membres d'enum : 'done' -> TaskStatus.Done
+	arrays    map[*ast.ArrayLiteral]string  // type des éléments attendu : this.items = [] dans un number[]
+	rests     map[string]restParameter      // paramètres params : f(1, ...xs) regroupe ses arguments
+	iterator  bool                          // corps d'un générateur : return devient yield break
+
+	classes    map[string]*ast.ClassDeclaration      // hiérarchie : virtual, override
+	interfaces map[string]*ast.Interface             // propriétés implémentées par des auto-propriétés
+	namespaces map[string]map[string]namespaceMember // Geometry.area -> Geometry.Program.area
+	namespace  map[string]namespaceMember            // membres du namespace dont une classe est générée
+}
+
+func (csg *CSharpGenerator) Generate(statements []ast.Statement) string {
+	var body strings.Builder
+
+	csg.literals = enumLiterals(csg.types, statements)
+	csg.arrays = arrayElements(csg.types, statements)
+
+	// Les déclarations sont déjà publiques au sein du namespace : seuls les
+	// imports sont traduits
+	statements, imports, _ := splitModuleStatements(statements)
+	csg.typeNames = collectTypeNames(statements)
+	csg.imported = importedTypes(imports)
+	for name := range csg.imported {
+		csg.typeNames[name] = true
+	}
+	csg.rests = collectRestParameters(statements)
+	csg.classes = collectClasses(statements)
+	csg.interfaces = collectInterfaces(statements)
+	csg.namespaces = collectNamespaces(statements)
+
+	functions, others := splitStatements(statements)
+
+	// Les enums (et unions de littéraux), interfaces et classes sont déclarés
+	// au niveau du namespace
+	var declarations strings.Builder
+	for _, stmt := range others {
+		switch s := stmt.(type) {
+		case *ast.EnumDeclaration:
+			declarations.WriteString(csg.GenerateEnum(s))
+		case *ast.TypeAlias:
+			if ed := literalUnionEnum(s); ed != nil {
+				declarations.WriteString(csg.GenerateEnum(ed))
+			}
+		case *ast.Interface:
+			declarations.WriteString(csg.GenerateInterface(s))
+		case *ast.ClassDeclaration:
+			declarations.WriteString(csg.GenerateClass(s))
+		case *ast.NamespaceDeclaration:
+			declarations.WriteString(csg.GenerateNamespace(s))
+		}
+	}
+
+	// Fonctions statiques de la classe Program
+	for _, fd := range functions {
+		body.WriteString(csg.GenerateFunction(fd))
+		for _, signature := range overloadSignatures(fd, csg.parameterType) {
+			body.WriteString(csg.generateSignature(signature, fd))
+		}
+	}
+
+	if containsAwait(others) {
+		csg.usesTasks = true
+		body.WriteString("        static async Task Main(string[] args)\n        {\n")
+	} else {
+		body.WriteString("        static void Main(string[] args)\n        {\n")
+	}
+
+	for _, stmt := range others {
+		body.WriteString(csg.GenerateStatement(stmt, "            "))
+	}
+
+	body.WriteString("        }\n")
+
+	if csg.needsMergeHelper {
+		body.WriteString("\n")
+		body.WriteString("        static Dictionary<string, object> Merge(params Dictionary<string, object>[] maps)\n        {\n")
+		body.WriteString("            var result = new Dictionary<string, object>();\n")
+		body.WriteString("            foreach (var map in maps)\n            {\n")
+		body.WriteString("                foreach (var entry in map)\n                {\n")
+		body.WriteString("                    result[entry.Key] = entry.Value;\n")
+		body.WriteString("                }\n            }\n")
+		body.WriteString("            return result;\n")
+		body.WriteString("        }\n")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("using System;\n")
+	if csg.usesCollections || csg.needsMergeHelper {
+		sb.WriteString("using System.Collections.Generic;\n")
+	}
+	if csg.usesLinq {
+		sb.WriteString("using System.Linq;\n")
+	}
+	if csg.usesTasks {
+		sb.WriteString("using System.Threading.Tasks;\n")
+	}
+	sb.WriteString(csg.generateUsings(imports))
+	sb.WriteString("\n")
+	sb.WriteString("namespace GeneratedCode\n{\n")
+	sb.WriteString(declarations.String())
+	sb.WriteString("    class Program\n    {\n")
+	sb.WriteString(body.String())
+	sb.WriteString("    }\n}\n")
+	return sb.String()
+}
+
+// GenerateNamespace génère un namespace C# imbriqué dans GeneratedCode. Comme
+// pour un module importé, ses fonctions et variables sont les membres
+// statiques de sa classe Program : Geometry.area(1) -> Geometry.Program.area(1).
+// Ses instructions vont dans le constructeur statique, exécuté au premier
+// accès à la classe.
+func (csg *CSharpGenerator) GenerateNamespace(nd *ast.NamespaceDeclaration) string {
+	var declarations, members, initializer strings.Builder
+	for _, stmt := range namespaceBody(nd) {
+		switch s := stmt.(type) {
+		case *ast.EnumDeclaration:
+			declarations.WriteString(csg.GenerateEnum(s))
+		case *ast.TypeAlias:
+			if ed := literalUnionEnum(s); ed != nil {
+				declarations.WriteString(csg.GenerateEnum(ed))
+			}
+		case *ast.Interface:
+			declarations.WriteString(csg.GenerateInterface(s))
+		case *ast.ClassDeclaration:
+			// Les fonctions du namespace ne sont pas visibles depuis ses classes
+			// sans passer par Program : area(r) -> Program.area(r)
+			outer := csg.namespace
+			csg.namespace = csg.namespaces[nd.Name]
+			declarations.WriteString(csg.GenerateClass(s))
+			csg.namespace = outer
+		case *ast.NamespaceDeclaration:
+			declarations.WriteString(csg.GenerateNamespace(s))
+		case *ast.FunctionDeclaration:
+			members.WriteString("        public " + strings.TrimPrefix(csg.GenerateFunction(s), "        "))
+			for _, signature := range overloadSignatures(s, csg.parameterType) {
+				members.WriteString("        public " + strings.TrimPrefix(csg.generateSignature(signature, s), "        "))
+			}
+		case *ast.VariableDeclaration:
+			members.WriteString("        " + csg.staticField(s) + "\n")
+		case *ast.AmbientDeclaration:
+		default:
+			initializer.WriteString(csg.GenerateStatement(stmt, "            "))
+		}
+	}
+	if initializer.Len() > 0 {
+		members.WriteString("        static Program()\n        {\n" + initializer.String() + "        }\n")
+	}
+	if members.Len() > 0 {
+		declarations.WriteString("    public static class Program\n    {\n")
+		declarations.WriteString(strings.TrimRight(members.String(), "\n") + "\n")
+		declarations.WriteString("    }\n")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("    namespace " + nd.Name + "\n    {\n")
+	if declarations.Len() > 0 {
+		sb.WriteString(indentLines(strings.TrimRight(declarations.String(), "\n")+"\n", "    "))
+	}
+	sb.WriteString("    }\n\n")
+	return sb.String()
+}
+
+// staticField génère une variable de namespace comme champ statique public ;
+// un champ ne peut pas être déclaré var
+func (csg *CSharpGenerator) staticField(vd *ast.VariableDeclaration) string {
+	code := csg.GenerateVariableDeclaration(vd)
+	if strings.HasPrefix(code, "var ") {
+		fieldType := "object"
+		switch value := withoutErasedAssertion(vd).Value.(type) {
+		case *ast.NewExpression:
+			fieldType = csg.GenerateExpression(value.Class) + csg.typeArguments(value.TypeArguments)
+		case *ast.AsExpression:
+			fieldType = csg.mapType(value.Type)
+		}
+		if vd.Type != "" {
+			fieldType = csg.mapType(vd.Type)
+		}
+		code = fieldType + strings.TrimPrefix(code, "var")
+	}
+	return "public static " + code
+}
+
+// generateUsings génère les directives using : un module devient un namespace
+// ("./models/user" -> Models.User). Les types y sont importés par using ou
+// par alias, les fonctions par using static de sa classe Program.
+func (csg *CSharpGenerator) generateUsings(imports []*ast.ImportDeclaration) string {
+	var sb strings.Builder
+	seen := map[string]bool{}
+	add := func(line string) {
+		if !seen[line] {
+			seen[line] = true
+			sb.WriteString(line + "\n")
+		}
+	}
+
+	for _, id := range imports {
+		_, segments := modulePath(id.Source)
+		var parts []string
+		for _, segment := range segments {
+			parts = append(parts, capitalize(segment))
+		}
+		namespace := strings.Join(parts, ".")
+
+		if id.Namespace != "" {
+			add("using " + id.Namespace + " = " + namespace + ";")
+		}
+		specs := id.Specifiers
+		if id.Default != "" {
+			specs = append([]ast.ImportSpecifier{{Name: id.Default}}, specs...)
+		}
+		for _, spec := range specs {
+			switch {
+			case !isTypeName(spec.Name):
+				add("using static " + namespace + ".Program;")
+			case spec.Alias != "":
+				add("using " + spec.Alias + " = " + namespace + "." + spec.Name + ";")
+			default:
+				add("using " + namespace + ";")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// GenerateEnum génère un enum C#. Les enums C# n'acceptent que des valeurs
+// entières : pour un enum chaîne, une méthode d'extension ToValue() fournit
+// la correspondance vers la valeur TypeScript.
+func (csg *CSharpGenerator) GenerateEnum(ed *ast.EnumDeclaration) string {
+	members := resolveEnumMembers(ed)
+	stringEnum := isStringEnum(members)
+
+	var sb strings.Builder
+	var names []string
+	for _, member := range members {
+		if stringEnum {
+			names = append(names, "        "+member.Name)
+		} else {
+			names = append(names, "        "+member.Name+" = "+csg.GenerateExpression(member.Value))
+		}
+	}
+	sb.WriteString("    enum " + ed.Name + "\n    {\n")
+	sb.WriteString(strings.Join(names, ",\n") + "\n")
+	sb.WriteString("    }\n\n")
+
+	if stringEnum {
+		sb.WriteString("    static class " + ed.Name + "Extensions\n    {\n")
+		sb.WriteString("        public static string ToValue(this " + ed.Name + " value)\n        {\n")
+		sb.WriteString("            return value switch\n            {\n")
+		for _, member := range members {
+			sb.WriteString("                " + ed.Name + "." + member.Name + " => \"" + enumValueString(member.Value) + "\",\n")
+		}
+		sb.WriteString("                _ => throw new ArgumentOutOfRangeException(nameof(value)),\n")
+		sb.WriteString("            };\n")
+		sb.WriteString("        }\n")
+		sb.WriteString("    }\n\n")
+	}
+
+	return sb.String()
+}
+
+// mapType convertit un type TypeScript en type C#
+func (csg *CSharpGenerator) mapType(t string) string {
+	if strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "Array<") {
+		return csg.mapType(elementType(t)) + "[]"
+	}
+	if inner, ok := unwrapPromise(t); ok {
+		csg.usesTasks = true
+		if inner == "void" {
+			return "Task"
+		}
+		return "Task<" + csg.mapType(inner) + ">"
+	}
+	if inner, ok := yieldedType(t); ok {
+		csg.usesCollections = true
+		return "IEnumerable<" + csg.mapType(inner) + ">"
+	}
+	switch t {
+	case "string":
+		return "string"
+	case "number":
+		return "double"
+	case integerType:
+		return "int"
+	case "boolean":
+		return "bool"
+	case "void", "":
+		return "void"
+	}
+	if name, args := splitTypeArguments(t); name == "Map" && len(args) == 2 && !csg.typeNames[name] {
+		csg.usesCollections = true
+		return "Dictionary" + csg.typeArguments(args)
+	}
+
+	// Types déclarés et paramètres de type, avec leurs arguments : Box<T>
+	if name, args := splitTypeArguments(t); csg.typeNames[name] {
+		return name + csg.typeArguments(args)
+	}
+	return "object"
+}
+
+// typeArguments génère <int, string> pour un type, un appel ou un new générique
+func (csg *CSharpGenerator) typeArguments(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	var mapped []string
+	for _, arg := range args {
+		mapped = append(mapped, csg.mapType(arg))
+	}
+	return "<" + strings.Join(mapped, ", ") + ">"
+}
+
+// typeParameters génère <T, U> ; C# n'a pas de type par défaut
+func (csg *CSharpGenerator) typeParameters(params []ast.TypeParameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var names []string
+	for _, param := range params {
+		names = append(names, param.Name)
+	}
+	return "<" + strings.Join(names, ", ") + ">"
+}
+
+// constraints génère les clauses where T : Base des paramètres contraints.
+// Seules une classe ou une interface servent de contrainte : string et les
+// enums sont scellés, where K : string n'est pas admis.
+func (csg *CSharpGenerator) constraints(params []ast.TypeParameter) string {
+	var clauses string
+	for _, param := range params {
+		if name, _ := splitTypeArguments(param.Constraint); csg.classes[name] != nil || csg.interfaces[name] != nil || csg.imported[name] {
+			clauses += " where " + param.Name + " : " + csg.mapType(param.Constraint)
+		}
+	}
+	return clauses
+}
+
+// GenerateClass génère une classe au niveau du namespace
+func (csg *CSharpGenerator) GenerateClass(cd *ast.ClassDeclaration) string {
+	defer declareTypeParameters(csg.typeNames, cd.TypeParameters)()
+	cd = withParameterProperties(withImplicitConstructor(cd, csg.classes))
+
+	// Classe parente puis interfaces : class Rect : Shape, IComparable
+	var bases []string
+	if cd.SuperClass != "" {
+		bases = append(bases, csg.mapType(cd.SuperClass))
+	}
+	implemented, rejected := classBases(cd.Implements, csg.classes)
+	for _, i := range implemented {
+		bases = append(bases, csg.mapType(i))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(implementedClassComments(cd.Name, rejected, CSharp, "    "))
+	sb.WriteString(csg.attributes(cd.Decorators, "    "))
+	sb.WriteString("    ")
+	if cd.IsAbstract {
+		sb.WriteString("abstract ")
+	}
+	sb.WriteString("class " + cd.Name + csg.typeParameters(cd.TypeParameters))
+	if len(bases) > 0 {
+		sb.WriteString(" : " + strings.Join(bases, ", "))
+	}
+	sb.WriteString(csg.constraints(cd.TypeParameters) + "\n    {\n")
+
+	// Un champ qui implémente une propriété d'interface devient une auto-propriété
+	properties := map[string]bool{}
+	for _, property := range interfaceProperties(implementedInterfaces(cd, csg.classes), csg.interfaces) {
+		properties[property.Name] = true
+	}
+	for _, field := range cd.Fields {
+		sb.WriteString(csg.attributes(field.Decorators, "        "))
+		sb.WriteString("        " + csg.memberModifiers(memberAccess(field.IsPrivate, field.IsProtected), field.IsStatic) + csg.fieldType(field) + " " + field.Name)
+		if properties[field.Name] && !field.IsStatic {
+			sb.WriteString(" { get; set; }")
+			if field.HasDefault {
+				sb.WriteString(" = " + csg.GenerateExpression(field.Default) + ";")
+			}
+			sb.WriteString("\n")
+			continue
+		}
+		if field.HasDefault {
+			sb.WriteString(" = " + csg.GenerateExpression(field.Default))
+		}
+		sb.WriteString(";\n")
+	}
+	if len(cd.Fields) > 0 && (len(cd.Methods) > 0 || len(cd.StaticBlocks) > 0) {
+		sb.WriteString("\n")
+	}
+
+	var members []string
+	if statics := staticBlockStatements(cd); len(statics) > 0 {
+		// Les blocs static { ... } forment le constructeur statique
+		members = append(members, csg.generateMethod("static "+cd.Name, nil, statics, ""))
+	}
+	if constructor := findConstructor(cd); constructor != nil {
+		// super(args) devient l'initialiseur : base(args)
+		before, call, after := splitSuperCall(constructor.Body)
+		initializer := ""
+		if call != nil {
+			initializer = " : base(" + csg.generateElements(call.Arguments) + ")"
+		}
+		members = append(members, csg.attributes(constructor.Decorators, "        ")+csg.generateMethod("public "+cd.Name, constructor.Parameters, append(append([]ast.Statement{}, before...), after...), initializer))
+	}
+	for _, property := range classProperties(cd) {
+		var decorators []ast.Decorator
+		for _, accessor := range []*ast.ClassMethod{property.Getter, property.Setter} {
+			if accessor != nil {
+				decorators = append(decorators, accessor.Decorators...)
+			}
+		}
+		members = append(members, csg.attributes(decorators, "        ")+csg.generateProperty(property))
+	}
+	for _, method := range regularMethods(cd) {
+		restore := declareTypeParameters(csg.typeNames, method.TypeParameters)
+		signature := csg.memberModifiers(memberAccess(method.IsPrivate, method.IsProtected), method.IsStatic) + csg.inheritanceModifier(cd, method) + csg.returnType(typedReturn(csg.types, &method, method.ReturnType, method.IsGenerator), method.IsAsync, method.IsGenerator) + " " +
+			method.Name + csg.typeParameters(method.TypeParameters)
+		if method.IsAbstract {
+			members = append(members, csg.attributes(method.Decorators, "        ")+"        "+signature+"("+csg.generateParameters(method.Parameters)+")"+csg.constraints(method.TypeParameters)+";\n")
+			restore()
+			continue
+		}
+		csg.iterator = method.IsGenerator
+		members = append(members, csg.attributes(method.Decorators, "        ")+csg.generateMethod(signature, method.Parameters, method.Body, csg.constraints(method.TypeParameters)))
+		csg.iterator = false
+		restore()
+	}
+	sb.WriteString(strings.Join(members, "\n"))
+
+	sb.WriteString("    }\n\n")
+	return sb.String()
+}
+
+// inheritanceModifier renvoie abstract, override ou virtual : en C#, une
+// méthode n'est redéfinissable que si elle est déclarée virtuelle
+func (csg *CSharpGenerator) inheritanceModifier(cd *ast.ClassDeclaration, method ast.ClassMethod) string {
+	switch {
+	case method.IsStatic:
+		return ""
+	case method.IsAbstract:
+		return "abstract "
+	case overridesMethod(cd, method.Name, csg.classes):
+		return "override "
+	case isOverridden(cd, method.Name, csg.classes):
+		return "virtual "
+	}
+	return ""
+}
+
+// generateProperty génère une propriété C# à partir des accesseurs get/set ;
+// le paramètre du set devient le value implicite
+func (csg *CSharpGenerator) generateProperty(property classProperty) string {
+	propertyType := "object"
+	if t := property.Type(); t != "" {
+		propertyType = csg.mapType(t)
+	}
+	var sb strings.Builder
+	sb.WriteString("        " + csg.memberModifiers(property.Access(), property.IsStatic()) + propertyType + " " + property.Name + "\n        {\n")
+	if property.Getter != nil {
+		sb.WriteString("            get\n            {\n")
+		for _, stmt := range property.Getter.Body {
+			sb.WriteString(csg.GenerateStatement(stmt, "                "))
+		}
+		sb.WriteString("            }\n")
+	}
+	if property.Setter != nil {
+		sb.WriteString("            set\n            {\n")
+		if len(property.Setter.Parameters) > 0 && property.Setter.Parameters[0].Name != "value" {
+			sb.WriteString("                var " + property.Setter.Parameters[0].Name + " = value;\n")
+		}
+		for _, stmt := range property.Setter.Body {
+			sb.WriteString(csg.GenerateStatement(stmt, "                "))
+		}
+		sb.WriteString("            }\n")
+	}
+	sb.WriteString("        }\n")
+	return sb.String()
+}
+
+func (csg *CSharpGenerator) generateMethod(signature string, params []ast.Parameter, body []ast.Statement, constraints string) string {
+	var sb strings.Builder
+	sb.WriteString("        " + signature + "(" + csg.generateParameters(params) + ")" + constraints + "\n        {\n")
+	sb.WriteString(csg.generateDefaults(params, "            "))
+	for _, stmt := range body {
+		sb.WriteString(csg.GenerateStatement(stmt, "            "))
+	}
+	sb.WriteString("        }\n")
+	return sb.String()
+}
+
+// GenerateInterface génère une interface dont les propriétés sont en lecture seule
+func (csg *CSharpGenerator) GenerateInterface(i *ast.Interface) string {
+	defer declareTypeParameters(csg.typeNames, i.TypeParameters)()
+
+	var sb strings.Builder
+	bases, rejected := classBases(i.Extends, csg.classes)
+	for _, class := range rejected {
+		sb.WriteString("    // " + i.Name + " étend la classe " + class + " : " + interfaceExtendsClass[CSharp] + "\n")
+	}
+	sb.WriteString("    interface " + i.Name + csg.typeParameters(i.TypeParameters))
+	if len(bases) > 0 {
+		var extends []string
+		for _, base := range bases {
+			extends = append(extends, csg.mapType(base))
+		}
+		sb.WriteString(" : " + strings.Join(extends, ", "))
+	}
+	sb.WriteString(csg.constraints(i.TypeParameters) + "\n    {\n")
+	for _, field := range i.Fields {
+		if field.IsMethod {
+			restore := declareTypeParameters(csg.typeNames, field.TypeParameters)
+			sb.WriteString("        " + csg.mapType(field.ReturnType) + " " + field.Name + csg.typeParameters(field.TypeParameters) +
+				"(" + csg.generateParameters(field.Parameters) + ")" + csg.constraints(field.TypeParameters) + ";\n")
+			restore()
+		} else if field.Optional {
+			sb.WriteString("        " + csg.nullableType(field.Type) + " " + field.Name + " { get; }\n")
+		} else {
+			sb.WriteString("        " + csg.mapType(field.Type) + " " + field.Name + " { get; }\n")
+		}
+	}
+	sb.WriteString("    }\n\n")
+	return sb.String()
+}
+
+func (csg *CSharpGenerator) memberModifiers(access string, isStatic bool) string {
+	modifiers := access + " "
+	if isStatic {
+		modifiers += "static "
+	}
+	return modifiers
+}
+
+// fieldType renvoie le type C# d'un champ, déduit de sa valeur s'il n'est pas annoté
+func (csg *CSharpGenerator) fieldType(field ast.ClassField) string {
+	if t := typed(csg.types, &field, field.Type); t != "" {
+		return csg.mapType(t)
+	}
+	return "object"
+}
+
+// nullableType renvoie le type C# pouvant valoir null
+func (csg *CSharpGenerator) nullableType(t string) string {
+	switch csType := csg.mapType(t); csType {
+	case "int", "double", "bool":
+		return csType + "?"
+	default:
+		return csType
+	}
+}
+
+func (csg *CSharpGenerator) GenerateFunction(fd *ast.FunctionDeclaration) string {
+	defer declareTypeParameters(csg.typeNames, fd.TypeParameters)()
+
+	var sb strings.Builder
+
+	csg.iterator = fd.IsGenerator
+	defer func() { csg.iterator = false }()
+
+	sb.WriteString("        static ")
+	sb.WriteString(csg.returnType(typedReturn(csg.types, fd, fd.ReturnType, fd.IsGenerator), fd.IsAsync, fd.IsGenerator))
+	sb.WriteString(" ")
+	sb.WriteString(fd.Name)
+	sb.WriteString(csg.typeParameters(fd.TypeParameters))
+	sb.WriteString("(")
+	sb.WriteString(csg.generateParameters(fd.Parameters))
+	sb.WriteString(")")
+	sb.WriteString(csg.constraints(fd.TypeParameters))
+	sb.WriteString("\n        {\n")
+
+	sb.WriteString(csg.generateDefaults(fd.Parameters, "            "))
+
+	for _, stmt := range fd.Body {
+		sb.WriteString(csg.GenerateStatement(stmt, "            "))
+	}
+
+	sb.WriteString("        }\n\n")
+	return sb.String()
+}
+
+// returnType génère le type de retour ; une fonction async renvoie une Task,
+// un générateur un IEnumerable
+func (csg *CSharpGenerator) returnType(t string, isAsync, isGenerator bool) string {
+	if _, ok := yieldedType(t); isGenerator && !ok {
+		csg.usesCollections = true
+		return "IEnumerable<object>"
+	}
+	if !isAsync {
+		return csg.mapType(t)
+	}
+	if _, ok := unwrapPromise(t); !ok {
+		t = "Promise<void>"
+	}
+	return asyncPrefix(isAsync) + csg.mapType(t)
+}
+
+// generateParameters génère la liste des paramètres ; un paramètre rest
+// devient params T[]
+func (csg *CSharpGenerator) generateParameters(params []ast.Parameter) string {
+	var parts []string
+	for _, param := range typedParameters(csg.types, params) {
+		part := inlineDecorators(param.Decorators, csg.Decorators, csg.GenerateExpression)
+		part += csg.parameterType(param) + " " + csg.parameterName(param)
+		if param.CanBeOmitted() {
+			if isLiteralExpression(param.Default) {
+				part += " = " + csg.GenerateExpression(param.Default)
+			} else {
+				part += " = null"
+			}
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parameterName renvoie le nom C# d'un paramètre : celui dont le défaut n'est
+// pas constant reçoit le suffixe Opt, generateDefaults déclarant sous son nom
+// une locale non nullable
+func (csg *CSharpGenerator) parameterName(param ast.Parameter) string {
+	if hasComputedDefault(param) {
+		return param.Name + "Opt"
+	}
+	return param.Name
+}
+
+// parameterType renvoie le type C# d'un paramètre de fonction
+func (csg *CSharpGenerator) parameterType(param ast.Parameter) string {
+	if param.IsRest {
+		return "params " + csg.mapType(elementType(param.Type)) + "[]"
+	}
+	if param.CanBeOmitted() && !isLiteralExpression(param.Default) {
+		// Optionnel ou défaut non constant : paramètre nullable = null
+		return csg.nullableType(param.Type)
+	}
+	return csg.mapType(param.Type)
+}
+
+// generateSignature génère une signature de surcharge : une méthode typée qui
+// convertit ses arguments pour appeler l'implémentation, puis son résultat
+func (csg *CSharpGenerator) generateSignature(signature, fd *ast.FunctionDeclaration) string {
+	defer declareTypeParameters(csg.typeNames, signature.TypeParameters)()
+
+	returnType := csg.returnType(signature.ReturnType, fd.IsAsync, fd.IsGenerator)
+	implementationType := csg.returnType(typedReturn(csg.types, fd, fd.ReturnType, fd.IsGenerator), fd.IsAsync, fd.IsGenerator)
+	cast := func(value, from, to string) string {
+		// Un tableau de valeurs n'est pas un object[] : ses éléments sont convertis un à un
+		if element := strings.TrimSuffix(to, "[]"); element != to && strings.HasSuffix(from, "[]") {
+			return "Array.ConvertAll(" + value + ", e => (" + element + ")e)"
+		}
+		return "(" + to + ")" + value
+	}
+
+	var sb strings.Builder
+	sb.WriteString("        static " + returnType + " " + fd.Name + csg.typeParameters(signature.TypeParameters))
+	sb.WriteString("(" + csg.generateParameters(signature.Parameters) + ")" + csg.constraints(signature.TypeParameters))
+	sb.WriteString("\n        {\n")
+	call := fd.Name + "(" + strings.Join(overloadArguments(signature, fd, csg.parameterType, cast), ", ") + ")"
+	switch {
+	case returnType == "void":
+		sb.WriteString("            " + call + ";\n")
+	case returnType != implementationType:
+		sb.WriteString("            return " + cast(call, implementationType, returnType) + ";\n")
+	default:
+		sb.WriteString("            return " + call + ";\n")
+	}
+	sb.WriteString("        }\n\n")
+	return sb.String()
+}
+
+// attributes traduit les décorateurs d'une classe ou d'un membre en attributs ;
+// ceux sans traduction restent en commentaire
+func (csg *CSharpGenerator) attributes(decorators []ast.Decorator, indent string) string {
+	return decoratorLines(decorators, csg.Decorators, csg.GenerateExpression, "//", indent)
+}
+
+// generateDefaults applique en tête de corps les valeurs par défaut non
+// constantes, que C# n'accepte pas dans la signature
+func (csg *CSharpGenerator) generateDefaults(params []ast.Parameter, indent string) string {
+	var sb strings.Builder
+	for _, param := range typedParameters(csg.types, params) {
+		if !hasComputedDefault(param) {
+			continue
+		}
+		defaultValue := csg.GenerateExpression(param.Default)
+		if al, ok := param.Default.(*ast.ArrayLiteral); ok && !hasSpread(al.Elements) && param.Type != "" {
+			defaultValue = "new " + csg.mapType(param.Type) + " { }"
+			if len(al.Elements) > 0 {
+				defaultValue = "new " + csg.mapType(param.Type) + " { " + csg.generateElements(al.Elements) + " }"
+			}
+		}
+		sb.WriteString(indent + "var " + param.Name + " = " + csg.parameterName(param) + " ?? " + defaultValue + ";\n")
+	}
+	return sb.String()
+}
+
+func (csg *CSharpGenerator) GenerateStatement(stmt ast.Statement, indent string) string {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		return indentLines(csg.GenerateVariableDeclaration(s), indent)
+	case *ast.ReturnStatement:
+		if csg.iterator {
+			// La valeur de retour d'un générateur n'est pas produite
+			return indent + "yield break;\n"
+		}
+		if s.Value != nil {
+			return indentLines("return "+csg.GenerateExpression(s.Value)+";\n", indent)
+		}
+		return indent + "return;\n"
+	case *ast.ExpressionStatement:
+		return indentLines(csg.GenerateExpressionStatement(s), indent)
+	case *ast.ForStatement:
+		var header []string
+		if s.Init != nil {
+			header = append(header, strings.TrimSpace(strings.TrimSuffix(csg.GenerateStatement(s.Init, ""), ";\n")))
+		} else {
+			header = append(header, "")
+		}
+		if s.Condition != nil {
+			header = append(header, csg.GenerateExpression(s.Condition))
+		} else {
+			header = append(header, "")
+		}
+		if es, ok := s.Update.(*ast.ExpressionStatement); ok {
+			header = append(header, csg.GenerateExpression(es.Expression))
+		} else {
+			header = append(header, "")
+		}
+		return indent + "for (" + strings.Join(header, "; ") + ")\n" + csg.GenerateBlock(s.Body, indent)
+	case *ast.WhileStatement:
+		return indent + "while (" + csg.GenerateExpression(s.Condition) + ")\n" + csg.GenerateBlock(s.Body, indent)
+	case *ast.IfStatement:
+		var sb strings.Builder
+		sb.WriteString(indent + "if (" + csg.GenerateExpression(s.Condition) + ")\n")
+		sb.WriteString(csg.GenerateBlock(s.ThenBranch, indent))
+		if s.ElseBranch != nil {
+			sb.WriteString(indent + "else\n")
+			sb.WriteString(csg.GenerateBlock(s.ElseBranch, indent))
+		}
+		return sb.String()
+	}
+	return ""
+}
+
+func (csg *CSharpGenerator) GenerateBlock(stmt ast.Statement, indent string) string {
+	var sb strings.Builder
+	sb.WriteString(indent + "{\n")
+	if blockStmt, ok := stmt.(*ast.BlockStatement); ok {
+		for _, inner := range blockStmt.Statements {
+			sb.WriteString(csg.GenerateStatement(inner, indent+"    "))
+		}
+	}
+	sb.WriteString(indent + "}\n")
+	return sb.String()
+}
+
+func (csg *CSharpGenerator) GenerateExpressionStatement(es *ast.ExpressionStatement) string {
+	// yield x -> yield return x ; yield* xs relaie chaque valeur de xs
+	if ye, ok := es.Expression.(*ast.YieldExpression); ok {
+		value := "default"
+		if ye.Argument != nil {
+			value = csg.GenerateExpression(ye.Argument)
+		}
+		if ye.Delegate {
+			return "foreach (var item in " + value + ")\n{\n    yield return item;\n}\n"
+		}
+		return "yield return " + value + ";\n"
+	}
+	// Convertir console.log en Console.WriteLine
+	if callExpr, ok := es.Expression.(*ast.CallExpression); ok && isConsoleLog(callExpr) {
+		var args []string
+		for _, arg := range callExpr.Arguments {
+			args = append(args, csg.GenerateExpression(arg))
+		}
+		return "Console.WriteLine(" + strings.Join(args, " + \" \" + ") + ");\n"
+	}
+	return csg.GenerateExpression(es.Expression) + ";\n"
+}
+
+func (csg *CSharpGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+	declared := variableType(csg.types, vd)
+	vd = withoutErasedAssertion(vd)
+	var sb strings.Builder
+
+	// Sans valeur, la variable a besoin d'un type explicite
+	if vd.Value == nil {
+		csType := "object"
+		if declared != "" {
+			csType = csg.mapType(declared)
+		}
+		return csType + " " + vd.Name + ";\n"
+	}
+
+	// Déterminer le type C# : les types simples sont écrits, les autres
+	// laissés à var, que la valeur générée (Dictionary, lambda) précise
+	switch csType := csg.mapType(declared); strings.TrimSuffix(csType, "[]") {
+	case "string", "int", "double", "bool":
+		sb.WriteString(csType + " ")
+	default:
+		if alias := variableAlias(csg.types, vd); alias != "" && csType == alias {
+			// Alias d'union de littéraux, généré comme un enum
+			sb.WriteString(csType + " ")
+		} else {
+			sb.WriteString("var ")
+		}
+	}
+
+	sb.WriteString(vd.Name)
+	sb.WriteString(" = ")
+
+	switch val := vd.Value.(type) {
+	case *ast.StringLiteral:
+		sb.WriteString(csg.GenerateStringLiteral(val))
+	case *ast.NumberLiteral:
+		sb.WriteString(csg.GenerateNumberLiteral(val))
+	case *ast.BooleanLiteral:
+		sb.WriteString(csg.GenerateBooleanLiteral(val))
+	default:
+		sb.WriteString(csg.GenerateExpression(val))
+	}
+
+	sb.WriteString(";\n")
+	return sb.String()
+}
+
+func (csg *CSharpGenerator) GenerateExpression(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return csg.GenerateStringLiteral(e)
+	case *ast.NumberLiteral:
+		return csg.GenerateNumberLiteral(e)
+	case *ast.BooleanLiteral:
+		return csg.GenerateBooleanLiteral(e)
+	case *ast.Identifier:
+		if e.Value == "super" {
+			return "base"
+		}
+		if member, ok := csg.namespace[e.Value]; ok && member.Value {
+			return "Program." + e.Value
+		}
+		return e.Value
+	case *ast.ParenthesizedExpression:
+		return "(" + csg.GenerateExpression(e.Expression) + ")"
+	case *ast.InfixExpression:
+		left := csg.GenerateExpression(e.Left)
+		if e.Operator == "/" && isInteger(csg.types, e.Left) && isInteger(csg.types, e.Right) {
+			// La division de deux entiers donne un nombre à virgule en TypeScript
+			left = "(double) " + castOperand(e.Left, left)
+		}
+		return left + " " + e.Operator + " " + csg.GenerateExpression(e.Right)
+	case *ast.ArrayLiteral:
+		return csg.GenerateArrayLiteral(e)
+	case *ast.ObjectLiteral:
+		return csg.GenerateObjectLiteral(e)
+	case *ast.CallExpression:
+		return csg.GenerateCallExpression(e)
+	case *ast.IndexExpression:
+		index := csg.GenerateExpression(e.Index)
+		if isFloat(csg.types, e.Index) {
+			// Un indice de tableau est un int
+			index = "(int) " + castOperand(e.Index, index)
+		}
+		return csg.GenerateExpression(e.Left) + "[" + index + "]"
+	case *ast.DotExpression:
+		// this.#count : le champ est private
+		e = privateAccess(e, bareName)
+		if member, ok := namespaceAccess(e, csg.namespaces); ok && member.Value {
+			return csg.GenerateExpression(e.Object) + ".Program." + e.Property
+		}
+		return csg.GenerateExpression(e.Object) + "." + e.Property
+	case *ast.SpreadElement:
+		// Un tableau passé à un paramètre params est déjà « étalé »
+		return csg.GenerateExpression(e.Argument)
+	case *ast.AssignmentExpression:
+		if e.Right == nil {
+			return csg.GenerateExpression(e.Left) + e.Operator
+		}
+		return csg.GenerateExpression(e.Left) + " " + e.Operator + " " + csg.GenerateExpression(e.Right)
+	case *ast.NewExpression:
+		class := csg.GenerateExpression(e.Class)
+		if class == "Map" && !csg.typeNames[class] {
+			// new Map<K, V>() -> new Dictionary<K, V>()
+			csg.usesCollections = true
+			class = "Dictionary"
+		}
+		return "new " + class + csg.typeArguments(e.TypeArguments) + "(" + csg.generateElements(e.Arguments) + ")"
+	case *ast.AwaitExpression:
+		return "await " + csg.GenerateExpression(e.Argument)
+	case *ast.AsExpression:
+		if !castsTo(e.Type) {
+			return assertedOperand(e.Expression, csg.GenerateExpression)
+		}
+		// value as Shape -> ((Shape)value)
+		return "((" + csg.mapType(e.Type) + ")" + assertedOperand(e.Expression, csg.GenerateExpression) + ")"
+	case *ast.SatisfiesExpression:
+		return assertedOperand(e.Expression, csg.GenerateExpression)
+	case *ast.NonNullExpression:
+		// Opérateur de tolérance null : user!.name
+		return assertedOperand(e.Expression, csg.GenerateExpression) + "!"
+	case *ast.ArrowFunction:
+		return csg.GenerateLambda(e)
+	}
+	return ""
+}
+
+// GenerateLambda génère une lambda ; ses paramètres sont typés quand TypeScript
+// les annote, ce qui lui donne un type naturel (var f = (int x) => x * 2)
+func (csg *CSharpGenerator) GenerateLambda(af *ast.ArrowFunction) string {
+	var params []string
+	for _, param := range af.Parameters {
+		if param.Type != "" {
+			params = append(params, csg.mapType(param.Type)+" "+param.Name)
+		} else {
+			params = append(params, param.Name)
+		}
+	}
+	prefix := "(" + strings.Join(params, ", ") + ") => "
+	if af.IsAsync {
+		csg.usesTasks = true
+		prefix = "async " + prefix
+	}
+	if af.ExpressionBody != nil {
+		return prefix + csg.GenerateExpression(af.ExpressionBody)
+	}
+	var body strings.Builder
+	for _, stmt := range af.Body {
+		body.WriteString(csg.GenerateStatement(stmt, "    "))
+	}
+	return prefix + "{\n" + body.String() + "}"
+}
+
+func (csg *CSharpGenerator) GenerateCallExpression(ce *ast.CallExpression) string {
+	return csg.GenerateExpression(ce.Function) + csg.typeArguments(ce.TypeArguments) + "(" + csg.callArguments(ce) + ")"
+}
+
+// callArguments génère les arguments d'un appel. Un paramètre params reçoit
+// soit des éléments, soit un tableau : f(1, ...xs) lui passe la concaténation.
+func (csg *CSharpGenerator) callArguments(ce *ast.CallExpression) string {
+	rest, restArgs, ok := restCall(csg.rests, ce)
+	if !ok || !hasSpread(restArgs) {
+		return csg.generateElements(flattenSpreads(ce.Arguments))
+	}
+	args := csg.generateElements(ce.Arguments[:rest.index])
+	if args != "" {
+		args += ", "
+	}
+	if array, ok := soleSpread(restArgs); ok {
+		return args + csg.GenerateExpression(array)
+	}
+	return args + csg.GenerateArrayLiteral(&ast.ArrayLiteral{Elements: restArgs})
+}
+
+func (csg *CSharpGenerator) generateElements(elements []ast.Expression) string {
+	var parts []string
+	for _, element := range elements {
+		parts = append(parts, csg.GenerateExpression(element))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// objectElement génère un élément d'un object[] : un nombre TypeScript y est
+// un double, 1 s'écrit 1.0
+func (csg *CSharpGenerator) objectElement(element ast.Expression) string {
+	if nl, ok := element.(*ast.NumberLiteral); ok && decimalInteger(nl.Value) {
+		return csg.GenerateNumberLiteral(nl) + ".0"
+	}
+	return csg.GenerateExpression(element)
+}
+
+func (csg *CSharpGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
+	if len(al.Elements) == 0 {
+		// Le type d'un tableau vide ne peut pas être inféré : celui qu'attend
+		// son contexte, object[] à défaut
+		if elem, ok := csg.arrays[al]; ok {
+			return "new " + csg.mapType(elem) + "[] { }"
+		}
+		return "new object[] { }"
+	}
+	elements := flattenSpreads(al.Elements)
+	if !hasSpread(elements) {
+		if elem, ok := csg.arrays[al]; ok {
+			// Le type attendu l'emporte sur celui des éléments : des entiers
+			// dans un double[]
+			return "new " + csg.mapType(elem) + "[] { " + csg.generateElements(elements) + " }"
+		}
+		if mixedArray(csg.types, al) {
+			// new[] { 1, "a" } n'a pas de type d'éléments commun
+			var parts []string
+			for _, element := range elements {
+				parts = append(parts, csg.objectElement(element))
+			}
+			return "new object[] { " + strings.Join(parts, ", ") + " }"
+		}
+		return "new[] { " + csg.generateElements(elements) + " }"
+	}
+
+	// [...a, 1, ...b] -> a.Concat(new[] { 1 }).Concat(b).ToArray() ; les
+	// segments littéraux prennent le type des éléments du tableau
+	csg.usesLinq = true
+	elem, typed := csg.arrays[al]
+	var segments []string
+	var pending []ast.Expression
+	flush := func() {
+		if len(pending) > 0 {
+			literal := &ast.ArrayLiteral{Elements: pending}
+			if typed {
+				csg.arrays[literal] = elem
+			}
+			segments = append(segments, csg.GenerateArrayLiteral(literal))
+			pending = nil
+		}
+	}
+	for _, element := range elements {
+		if spread, ok := element.(*ast.SpreadElement); ok {
+			flush()
+			segments = append(segments, csg.GenerateExpression(spread.Argument))
+		} else {
+			pending = append(pending, element)
+		}
+	}
+	flush()
+
+	result := segments[0]
+	for _, segment := range segments[1:] {
+		result += ".Concat(" + segment + ")"
+	}
+	return result + ".ToArray()"
+}
+
+func (csg *CSharpGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) string {
+	if hasSpreadProperty(ol.Properties) {
+		// C# n'a pas de spread d'objet : fusion explicite via Merge
+		csg.needsMergeHelper = true
+		var parts []string
+		for _, group := range splitSpreadProperties(ol.Properties) {
+			if spread, ok := group[0].Value.(*ast.SpreadElement); ok {
+				parts = append(parts, csg.GenerateExpression(spread.Argument))
+			} else {
+				parts = append(parts, csg.GenerateObjectLiteral(&ast.ObjectLiteral{Properties: group}))
+			}
+		}
+		return "Merge(" + strings.Join(parts, ", ") + ")"
+	}
+
+	csg.usesCollections = true
+	var entries []string
+	for _, prop := range ol.Properties {
+		entries = append(entries, "[\""+prop.Key+"\"] = "+csg.GenerateExpression(prop.Value))
+	}
+	return "new Dictionary<string, object> { " + strings.Join(entries, ", ") + " }"
+}
+
+func (csg *CSharpGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
+	if alias, ok := csg.literals[sl]; ok {
+		member := enumMemberName(sl.Value)
+		return alias + "." + member
+	}
+	return "\"" + sl.Value + "\""
+}
+
+func (csg *CSharpGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
+	return nl.Value
+}
+
+func (csg *CSharpGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
+	if bl.Value {
+		return "true"
+	}
+	return "false"
+}
+
+// GoGenerator génère du code Go
+type GoGenerator struct {
+	usesFmt          bool // console.log -> fmt.Println
+	usesIter         bool // générateurs -> iter.Seq
+	usesMath         bool // reste d'une division à virgule -> math.Mod
+	needsMergeHelper bool // un spread d'objet nécessite le helper mergeMaps
+	needsPtrHelper   bool // un argument optionnel nécessite le helper ptr
+
+	functions map[string]*ast.FunctionDeclaration // signatures pour adapter les appels
+	rests     map[string]restParameter            // fonctions variadiques : f(1, ...xs) regroupe ses arguments
+	enums     map[string]*ast.EnumDeclaration     // Status.Pending -> StatusPending
+	classes   map[string]*ast.ClassDeclaration    // manipulées par pointeur, Box.of -> BoxOf
+	typeNames map[string]bool                     // classes, interfaces et paramètres de type connus
+	contracts map[string]*ast.Interface           // interfaces implémentées par une classe : interfaces Go
+	types     *semantic.Inference                 // types déduits des déclarations sans annotation
+	literals  map[*ast.StringLiteral]string       // chaînes écrites comme membres d'enum : 'done' -> TaskStatusDone
+	arrays    map[*ast.ArrayLiteral]string        // type des éléments attendu : this.items = [] dans un number[]
+	widened   map[ast.Expression]bool             // entiers attendus en float64 : float64(i)
+	receiver  string                              // nom du receveur qui remplace this
+	super     string                              // classe parente embarquée : super.m() -> r.Shape.m()
+	dispatch  *ast.ClassDeclaration               // classe à sous-classes : this.area() -> s.self.area()
+	iterator  bool                                // corps d'un générateur : yield(x) au lieu de yield x
+	accessors map[string]bool                     // propriétés get/set : x.total -> x.total(), x.setTotal(v)
+
+	qualified    map[string]string                     // format -> logger.Format, add exporté -> Add
+	packages     map[string]bool                       // imports de namespace : path.join -> path.Join
+	usedPackages map[string]bool                       // packages référencés : Go refuse un import inutilisé
+	namespaces   map[string]map[string]namespaceMember // Geometry.area -> area
+}
+
+// goImport est un import Go et le nom de package qui le rend nécessaire
+type goImport struct {
+	pkg  string // vide pour un import à conserver (effet de bord)
+	path string
+}
+
+func (gg *GoGenerator) Generate(statements []ast.Statement) string {
+	var body strings.Builder
+
+	gg.literals = enumLiterals(gg.types, statements)
+	gg.arrays = arrayElements(gg.types, statements)
+	gg.widened = widenedIntegers(gg.types, statements)
+
+	statements, imports, exports := splitModuleStatements(statements)
+
+	// Pas de namespace en Go : leurs déclarations sont générées au niveau
+	// du programme et Geometry.area devient area
+	gg.namespaces = collectNamespaces(statements)
+	statements = flattenNamespaces(statements)
+
+	functions, others := splitStatements(statements)
+	gg.functions = map[string]*ast.FunctionDeclaration{}
+	for _, fd := range functions {
+		gg.functions[fd.Name] = fd
+	}
+	gg.rests = collectRestParameters(statements)
+	moduleImports := gg.resolveImports(imports, exports)
+	gg.enums = collectEnums(statements)
+	gg.typeNames = collectTypeNames(statements)
+	gg.accessors = collectAccessors(statements)
+	gg.classes = map[string]*ast.ClassDeclaration{}
+	for _, stmt := range others {
+		if cd, ok := stmt.(*ast.ClassDeclaration); ok {
+			gg.classes[cd.Name] = cd
+		}
+	}
+	gg.contracts = map[string]*ast.Interface{}
+	interfaces := collectInterfaces(statements)
+	for name := range implementedByClasses(gg.classes, interfaces) {
+		gg.contracts[name] = interfaces[name]
+	}
+
+	// Types et constantes au niveau du package
+	var locals []ast.Statement
+	for _, stmt := range others {
+		switch s := stmt.(type) {
+		case *ast.EnumDeclaration:
+			body.WriteString(gg.GenerateEnum(s))
+		case *ast.TypeAlias:
+			// Une union de littéraux devient un type chaîne et ses constantes
+			if ed := literalUnionEnum(s); ed != nil {
+				body.WriteString(gg.GenerateEnum(ed))
+			}
+		case *ast.Interface:
+			body.WriteString(gg.GenerateInterface(s))
+		case *ast.ClassDeclaration:
+			body.WriteString(gg.GenerateClass(s))
+		default:
+			locals = append(locals, stmt)
+		}
+	}
+
+	for _, fd := range functions {
+		// Go n'a pas de surcharge : l'implémentation reste la seule fonction
+		body.WriteString(overloadComments(fd, "//") + gg.GenerateFunction(fd))
+	}
+
+	// Export par défaut : Go n'en a pas, il devient la variable Default
+	for _, ed := range exports {
+		switch {
+		case isDefaultExpression(ed):
+			body.WriteString("var Default = " + gg.GenerateExpression(ed.Declaration.(*ast.ExpressionStatement).Expression) + "\n\n")
+		case ed.IsDefault:
+			body.WriteString("var Default = " + gg.GenerateExpression(&ast.Identifier{Value: declarationName(ed.Declaration)}) + "\n\n")
+		case ed.Source != "":
+			body.WriteString("// export depuis \"" + ed.Source + "\" : Go n'a pas de ré-export\n\n")
+		default:
+			// export { helper as double } -> var Double = Helper
+			for _, spec := range ed.Specifiers {
+				if spec.Alias != "" && !spec.IsType {
+					body.WriteString("var " + capitalize(spec.Alias) + " = " + gg.GenerateExpression(&ast.Identifier{Value: spec.Name}) + "\n\n")
+				}
+			}
+		}
+	}
+
+	body.WriteString("func main() {\n")
+
+	for _, stmt := range locals {
+		body.WriteString(gg.GenerateStatement(stmt, "    "))
+	}
+
+	body.WriteString("}\n")
+
+	if gg.needsMergeHelper {
+		body.WriteString("\n")
+		body.WriteString("func mergeMaps(maps ...map[string]interface{}) map[string]interface{} {\n")
+		body.WriteString("    result := map[string]interface{}{}\n")
+		body.WriteString("    for _, m := range maps {\n")
+		body.WriteString("        for k, v := range m {\n")
+		body.WriteString("            result[k] = v\n")
+		body.WriteString("        }\n")
+		body.WriteString("    }\n")
+		body.WriteString("    return result\n")
+		body.WriteString("}\n")
+	}
+
+	if gg.needsPtrHelper {
+		body.WriteString("\n")
+		body.WriteString("func ptr[T any](v T) *T {\n")
+		body.WriteString("    return &v\n")
+		body.WriteString("}\n")
+	}
+
+	var importPaths []string
+	if gg.usesFmt {
+		importPaths = append(importPaths, "\"fmt\"")
+	}
+	if gg.usesIter {
+		importPaths = append(importPaths, "\"iter\"")
+	}
+	if gg.usesMath {
+		importPaths = append(importPaths, "\"math\"")
+	}
+	for _, imp := range moduleImports {
+		if imp.pkg == "" || gg.usedPackages[imp.pkg] {
+			importPaths = append(importPaths, imp.path)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("package main\n\n")
+	switch len(importPaths) {
+	case 0:
+	case 1:
+		sb.WriteString("import " + importPaths[0] + "\n\n")
+	default:
+		sb.WriteString("import (\n")
+		for _, path := range importPaths {
+			sb.WriteString("    " + path + "\n")
+		}
+		sb.WriteString(")\n\n")
+	}
+	sb.WriteString(body.String())
+	return sb.String()
+}
+
+// resolveImports prépare les imports Go et les renvoie. Un module
+// devient un package dont le nom est le dernier segment ("./utils/logger" ->
+// package logger) ; les noms importés sont qualifiés par ce package et mis en
+// majuscule pour être exportés. Les fonctions exportées du module prennent
+// elles aussi une majuscule.
+func (gg *GoGenerator) resolveImports(imports []*ast.ImportDeclaration, exports []*ast.ExportDeclaration) []goImport {
+	gg.qualified = map[string]string{}
+	gg.packages = map[string]bool{}
+	gg.usedPackages = map[string]bool{}
+
+	var result []goImport
+	for _, id := range imports {
+		_, segments := modulePath(id.Source)
+		if len(segments) == 0 {
+			continue
+		}
+		path := "\"" + strings.Join(segments, "/") + "\""
+		pkg := segments[len(segments)-1]
+
+		specs := valueSpecifiers(id)
+		switch {
+		case id.Namespace != "":
+			gg.packages[id.Namespace] = true
+			if id.Namespace != pkg {
+				path = id.Namespace + " " + path
+			}
+			pkg = id.Namespace
+		case id.Default == "" && len(specs) == 0:
+			if len(id.Specifiers) > 0 || id.IsTypeOnly {
+				// Import de types seuls : les types inconnus deviennent
+				// interface{}, le package serait inutilisé
+				continue
+			}
+			result = append(result, goImport{path: "_ " + path}) // import pour effet de bord
+			continue
+		}
+		if id.Default != "" {
+			gg.qualified[id.Default] = pkg + ".Default"
+		}
+		for _, spec := range specs {
+			gg.qualified[spec.LocalName()] = pkg + "." + capitalize(spec.Name)
+		}
+		result = append(result, goImport{pkg: pkg, path: path})
+	}
+
+	// Les variables restent locales à main : seules les fonctions exportées
+	// sont renommées
+	for name := range exportedNames(exports) {
+		if gg.functions[name] != nil {
+			gg.qualified[name] = capitalize(name)
+		}
+	}
+	// main est le point d'entrée qu'écrit le générateur : la fonction main
+	// du programme devient Main
+	if gg.functions["main"] != nil {
+		gg.qualified["main"] = "Main"
+	}
+	return result
+}
+
+// GenerateEnum génère un type nommé et ses constantes préfixées par le nom de
+// l'enum ; un enum purement auto-incrémenté utilise iota
+func (gg *GoGenerator) GenerateEnum(ed *ast.EnumDeclaration) string {
+	members := resolveEnumMembers(ed)
+	stringEnum := isStringEnum(members)
+
+	var sb strings.Builder
+	if stringEnum {
+		sb.WriteString("type " + ed.Name + " string\n\n")
+	} else {
+		sb.WriteString("type " + ed.Name + " int\n\n")
+	}
+
+	sb.WriteString("const (\n")
+	for i, member := range members {
+		sb.WriteString("    " + ed.Name + member.Name)
+		switch {
+		case stringEnum:
+			sb.WriteString(" " + ed.Name + " = \"" + enumValueString(member.Value) + "\"")
+		case isAutoIncrementedEnum(ed):
+			if i == 0 {
+				sb.WriteString(" " + ed.Name + " = iota")
+			}
+		default:
+			sb.WriteString(" " + ed.Name + " = " + gg.GenerateExpression(member.Value))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(")\n\n")
+	return sb.String()
+}
+
+// mapType convertit un type TypeScript en type Go
+func (gg *GoGenerator) mapType(t string) string {
+	if strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "Array<") {
+		return "[]" + gg.mapType(elementType(t))
+	}
+	if inner, ok := unwrapPromise(t); ok {
+		// Les fonctions async sont exécutées de façon synchrone : Promise<T> -> T
+		return gg.mapType(inner)
+	}
+	if inner, ok := yieldedType(t); ok {
+		gg.usesIter = true
+		return "iter.Seq[" + gg.iteratorElement(inner) + "]"
+	}
+	switch t {
+	case "string":
+		return "string"
+	case "number":
+		return "float64"
+	case integerType:
+		return "int"
+	case "boolean":
+		return "bool"
+	case "void", "":
+		return ""
+	}
+
+	if name, args := splitTypeArguments(t); name == "Map" && len(args) == 2 && !gg.typeNames[name] {
+		return "map[" + gg.mapType(args[0]) + "]" + gg.mapType(args[1])
+	}
+
+	// Types déclarés et paramètres de type : Box[T] ; une classe est
+	// manipulée par pointeur, comme le renvoie son constructeur, sauf si elle
+	// a des sous-classes : son type est alors l'interface de ses méthodes
+	if name, args := splitTypeArguments(t); gg.typeNames[name] {
+		goType := name + gg.typeArguments(args)
+		if cd := gg.classes[name]; cd != nil {
+			if polymorphicClass(cd, gg.classes) {
+				return methodsInterface(name)
+			}
+			return "*" + goType
+		}
+		return goType
+	}
+	return "interface{}"
+}
+
+// typeArguments génère [int, string] pour un type ou un appel générique
+func (gg *GoGenerator) typeArguments(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	var mapped []string
+	for _, arg := range args {
+		mapped = append(mapped, strings.TrimPrefix(gg.mapType(arg), "*"))
+	}
+	return "[" + strings.Join(mapped, ", ") + "]"
+}
+
+// typeParameters génère [T any, U Base] ; Go n'a pas de type par défaut
+func (gg *GoGenerator) typeParameters(params []ast.TypeParameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, param := range params {
+		constraint := "any"
+		if bound := gg.mapType(param.Constraint); param.Constraint != "" && bound != "interface{}" {
+			constraint = strings.TrimPrefix(bound, "*")
+		}
+		parts = append(parts, param.Name+" "+constraint)
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+// typeParameterNames génère [T, U] pour instancier un type générique
+func (gg *GoGenerator) typeParameterNames(params []ast.TypeParameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var names []string
+	for _, param := range params {
+		names = append(names, param.Name)
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// GenerateInterface génère une struct : une interface TypeScript décrit la
+// forme d'un objet, les méthodes deviennent des champs de type func. Une
+// interface implémentée par une classe devient une interface Go, que la classe
+// satisfait : ses propriétés y sont des accesseurs getName().
+func (gg *GoGenerator) GenerateInterface(i *ast.Interface) string {
+	defer declareTypeParameters(gg.typeNames, i.TypeParameters)()
+
+	var sb strings.Builder
+	if gg.contracts[i.Name] != nil {
+		bases, rejected := classBases(i.Extends, gg.classes)
+		for _, class := range rejected {
+			sb.WriteString("// " + i.Name + " étend la classe " + class + " : " + interfaceExtendsClass[Go] + "\n")
+		}
+		sb.WriteString("type " + i.Name + gg.typeParameters(i.TypeParameters) + " interface {\n")
+		for _, base := range bases {
+			sb.WriteString("    " + gg.embeddedType(base) + "\n")
+		}
+		for _, field := range i.Fields {
+			if field.IsMethod {
+				sb.WriteString("    " + gg.methodSignature(field.Name, field.Parameters, field.ReturnType) + "\n")
+			} else {
+				sb.WriteString("    " + getterName(field.Name) + "() " + gg.propertyType(field) + "\n")
+			}
+		}
+		sb.WriteString("}\n\n")
+		return sb.String()
+	}
+
+	sb.WriteString("type " + i.Name + gg.typeParameters(i.TypeParameters) + " struct {\n")
+	for _, base := range i.Extends {
+		sb.WriteString("    " + gg.embeddedType(base) + "\n")
+	}
+	for _, field := range i.Fields {
+		switch {
+		case field.IsMethod:
+			// Les paramètres de type propres à la méthode ne sont pas exprimables : interface{}
+			sb.WriteString("    " + field.Name + " func(" + gg.generateParameters(field.Parameters) + ")")
+			if returnType := gg.mapType(field.ReturnType); returnType != "" {
+				sb.WriteString(" " + returnType)
+			}
+			sb.WriteString("\n")
+		default:
+			sb.WriteString("    " + field.Name + " " + gg.propertyType(field) + "\n")
+		}
+	}
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// propertyType renvoie le type Go d'une propriété d'interface ; une propriété
+// optionnelle est un pointeur
+func (gg *GoGenerator) propertyType(field ast.InterfaceField) string {
+	if field.Optional {
+		return "*" + strings.TrimPrefix(gg.mapType(field.Type), "*")
+	}
+	return gg.mapType(field.Type)
+}
+
+// methodSignature génère la signature d'une méthode dans une interface Go :
+// area() int
+func (gg *GoGenerator) methodSignature(name string, params []ast.Parameter, returnType string) string {
+	signature := name + "(" + gg.generateParameters(params) + ")"
+	if goType := gg.mapType(returnType); goType != "" {
+		signature += " " + goType
+	}
+	return signature
+}
+
+// GenerateClass génère une struct, un constructeur NewX renvoyant un pointeur
+// et des méthodes à receveur pointeur. Les membres statiques deviennent des
+// variables et fonctions du package préfixées par le nom de la classe.
+//
+// Go n'a pas d'héritage : la classe parente est embarquée et ses champs et
+// méthodes sont promus. La liaison dynamique passe par une interface : une
+// classe à sous-classes déclare l'interface ShapeMethods de ses méthodes, la
+// racine de la hiérarchie garde dans son champ self l'objet réel que chaque
+// constructeur y range, et this.area() devient s.self.area().
+func (gg *GoGenerator) GenerateClass(cd *ast.ClassDeclaration) string {
+	defer declareTypeParameters(gg.typeNames, cd.TypeParameters)()
+	cd = withParameterProperties(withImplicitConstructor(cd, gg.classes))
+	gg.receiver = receiverName(cd)
+	gg.super = cd.SuperClass
+	dispatched := dispatchedClass(cd, gg.classes)
+	polymorphic := polymorphicClass(cd, gg.classes)
+	if polymorphic {
+		gg.dispatch = cd
+	}
+	defer func() { gg.receiver, gg.super, gg.dispatch = "", "", nil }()
+
+	instance := cd.Name + gg.typeParameterNames(cd.TypeParameters)
+
+	var sb strings.Builder
+	_, rejected := classBases(cd.Implements, gg.classes)
+	sb.WriteString(implementedClassComments(cd.Name, rejected, Go, ""))
+	if polymorphic {
+		sb.WriteString(gg.generateMethodsInterface(cd))
+	}
+	sb.WriteString("type " + cd.Name + gg.typeParameters(cd.TypeParameters) + " struct {\n")
+	if cd.SuperClass != "" {
+		// Go n'a pas d'héritage : la classe parente est embarquée et ses
+		// champs et méthodes sont promus
+		sb.WriteString("    " + gg.embeddedType(cd.SuperClass) + "\n")
+	}
+	if dispatched && rootClass(cd, gg.classes) == cd {
+		sb.WriteString("    self " + methodsInterface(cd.Name) + " // objet réel, éventuellement d'une sous-classe\n")
+	}
+	for _, field := range cd.Fields {
+		if !field.IsStatic {
+			sb.WriteString("    " + gg.memberName(field.Name, field.IsPrivateName) + " " + gg.fieldType(field) + "\n")
+		}
+	}
+	sb.WriteString("}\n\n")
+
+	for _, field := range cd.Fields {
+		if field.IsStatic {
+			sb.WriteString("var " + cd.Name + capitalize(field.Name) + " " + gg.fieldType(field))
+			if field.HasDefault {
+				sb.WriteString(" = " + gg.GenerateExpression(field.Default))
+			}
+			sb.WriteString("\n\n")
+		}
+	}
+
+	// Constructeur ; self désigne l'objet construit dès que la parente l'est
+	params := constructorParameters(cd)
+	sb.WriteString("func New" + cd.Name + gg.typeParameters(cd.TypeParameters) + "(" + gg.generateParameters(params) + ") *" + instance + " {\n")
+	sb.WriteString(gg.generateDefaults(params))
+	sb.WriteString("    " + gg.receiver + " := &" + instance + "{}\n")
+	before, call, after := splitSuperCall(constructorBody(cd))
+	for _, stmt := range before {
+		sb.WriteString(gg.GenerateStatement(stmt, "    "))
+	}
+	if call != nil {
+		sb.WriteString(gg.GenerateStatement(&ast.ExpressionStatement{Expression: call}, "    "))
+	}
+	if dispatched {
+		sb.WriteString("    " + gg.receiver + ".self = " + gg.receiver + "\n")
+	}
+	for _, stmt := range after {
+		sb.WriteString(gg.GenerateStatement(stmt, "    "))
+	}
+	sb.WriteString("    return " + gg.receiver + "\n")
+	sb.WriteString("}\n\n")
+
+	// Accesseurs : get total() -> total(), set total(v) -> setTotal(v)
+	getter := func(name string) string { return name }
+	methods := append(accessorMethods(cd, getter, setterName), regularMethods(cd)...)
+	inherited := withInheritedMethods(cd, methods, gg.classes)
+	if dispatched {
+		// Les méthodes héritées sont promues et appellent celles de l'objet
+		// réel : il n'y a rien à reprendre
+		inherited = inherited[:len(methods)]
+	}
+	for _, inherited := range inherited {
+		method := inherited.Method
+		restore := declareTypeParameters(gg.typeNames, method.TypeParameters)
+		gg.super = cd.SuperClass
+		for _, parent := range ancestors(cd, gg.classes)[:inherited.Depth] {
+			gg.super = parent.SuperClass
+		}
+		switch {
+		case method.IsStatic:
+			// Un membre statique ne voit pas les paramètres de type de la classe
+			sb.WriteString("func " + cd.Name + capitalize(method.Name) + gg.typeParameters(method.TypeParameters))
+			sb.WriteString("(" + gg.generateParameters(method.Parameters) + ")")
+		case len(method.TypeParameters) > 0:
+			// Go n'autorise pas de paramètres de type sur une méthode : fonction prenant le receveur
+			sb.WriteString("func " + cd.Name + capitalize(method.Name) + gg.typeParameters(append(append([]ast.TypeParameter{}, cd.TypeParameters...), method.TypeParameters...)))
+			receiverParam := []ast.Parameter{{Name: gg.receiver, Type: cd.Name + typeParameterList(cd.TypeParameters)}}
+			sb.WriteString("(" + gg.generateParameters(append(receiverParam, method.Parameters...)) + ")")
+		default:
+			if inherited.Owner != cd {
+				// Go n'a pas de liaison dynamique : la méthode héritée est reprise
+				// pour que ses appels sur le receveur visent les méthodes de la sous-classe
+				sb.WriteString("// Reprise de " + inherited.Owner.Name + "." + method.Name + "\n")
+			}
+			sb.WriteString("func (" + gg.receiver + " *" + instance + ") " + gg.memberName(method.Name, method.IsPrivateName))
+			sb.WriteString("(" + gg.generateParameters(method.Parameters) + ")")
+		}
+		if returnType := gg.returnType(typedReturn(gg.types, &method, method.ReturnType, method.IsGenerator), method.IsGenerator); returnType != "" {
+			sb.WriteString(" " + returnType)
+		}
+		sb.WriteString(" {\n")
+		if method.IsAbstract {
+			sb.WriteString("    panic(\"" + cd.Name + "." + method.Name + " est abstraite\")\n")
+		} else {
+			sb.WriteString(gg.generateDefaults(method.Parameters))
+			sb.WriteString(gg.generateBody(method.Body, method.ReturnType, method.IsGenerator))
+		}
+		sb.WriteString("}\n\n")
+		restore()
+	}
+
+	// Accesseurs des champs lus à travers une interface : celle de la classe,
+	// ou une interface TypeScript qu'elle implémente
+	for _, field := range gg.fieldGetters(cd) {
+		sb.WriteString("func (" + gg.receiver + " *" + instance + ") " + getterName(field.Name) + "() " + gg.fieldType(field) + " {\n")
+		sb.WriteString("    return " + gg.receiver + "." + field.Name + "\n")
+		sb.WriteString("}\n\n")
+	}
+
+	// La conformité aux interfaces implémentées est vérifiée à la compilation
+	for _, name := range cd.Implements {
+		if base, _ := splitTypeArguments(name); gg.contracts[base] != nil {
+			sb.WriteString("var _ " + gg.mapType(name) + " = (*" + instance + ")(nil)\n\n")
+		}
+	}
+
+	// Les blocs static { ... } s'exécutent à l'initialisation du package
+	if statics := staticBlockStatements(cd); len(statics) > 0 {
+		sb.WriteString("func init() {\n")
+		for _, stmt := range statics {
+			sb.WriteString(gg.GenerateStatement(stmt, "    "))
+		}
+		sb.WriteString("}\n\n")
+	}
+
+	return sb.String()
+}
+
+// generateMethodsInterface génère l'interface des méthodes d'une classe à
+// sous-classes : celle de la parente, les méthodes que la classe introduit et
+// les accesseurs de ses champs, qu'une variable de ce type ne lit pas
+// directement
+func (gg *GoGenerator) generateMethodsInterface(cd *ast.ClassDeclaration) string {
+	var sb strings.Builder
+	sb.WriteString("// " + methodsInterface(cd.Name) + " : méthodes de " + cd.Name + " et de ses sous-classes\n")
+	sb.WriteString("type " + methodsInterface(cd.Name) + " interface {\n")
+	if parent := gg.classes[superClassName(cd)]; parent != nil {
+		sb.WriteString("    " + methodsInterface(parent.Name) + "\n")
+	}
+	for _, method := range introducedMethods(cd, gg.classes) {
+		returnType := typedReturn(gg.types, &method, method.ReturnType, false)
+		sb.WriteString("    " + gg.methodSignature(method.Name, method.Parameters, returnType) + "\n")
+	}
+	for _, field := range gg.fieldGetters(cd) {
+		sb.WriteString("    " + getterName(field.Name) + "() " + gg.fieldType(field) + "\n")
+	}
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// fieldGetters renvoie les champs d'une classe (paramètres de constructeur
+// compris) qui ont besoin d'un accesseur getName() : tous ceux d'une classe à
+// sous-classes, dont une variable est une interface, et ceux qui portent une
+// propriété d'une interface implémentée. Une méthode du même nom en tient lieu.
+func (gg *GoGenerator) fieldGetters(cd *ast.ClassDeclaration) []ast.ClassField {
+	cd = withParameterProperties(cd)
+	var fields []ast.ClassField
+	seen := map[string]bool{}
+	add := func(field ast.ClassField) {
+		if seen[field.Name] || methodIntroducer(cd, getterName(field.Name), gg.classes) != nil {
+			return
+		}
+		seen[field.Name] = true
+		fields = append(fields, field)
+	}
+	if polymorphicClass(cd, gg.classes) {
+		for _, field := range cd.Fields {
+			if !field.IsStatic && !field.IsPrivateName {
+				add(field)
+			}
+		}
+	}
+	for _, property := range backedInterfaceProperties(cd, gg.classes, gg.contracts) {
+		owner := fieldOwner(cd, property.Name, gg.classes)
+		if owner != cd && polymorphicClass(owner, gg.classes) {
+			// L'accesseur de la parente est promu
+			continue
+		}
+		for _, field := range withParameterProperties(owner).Fields {
+			if !field.IsStatic && field.Name == property.Name {
+				add(field)
+			}
+		}
+	}
+	return fields
+}
+
+// memberName renvoie le nom Go d'un membre : un nom privé #Secret commence
+// par une minuscule pour ne pas être exporté
+func (gg *GoGenerator) memberName(name string, isPrivateName bool) string {
+	if isPrivateName {
+		return unexported(name)
+	}
+	return name
+}
+
+// embeddedType renvoie le type embarqué pour une classe parente ou une
+// interface étendue : *Shape, Box[T], Named
+func (gg *GoGenerator) embeddedType(t string) string {
+	name, args := splitTypeArguments(t)
+	if gg.classes[name] != nil {
+		return "*" + name + gg.typeArguments(args)
+	}
+	return name + gg.typeArguments(args)
+}
+
+// fieldType renvoie le type Go d'un champ, déduit de sa valeur s'il n'est pas annoté
+func (gg *GoGenerator) fieldType(field ast.ClassField) string {
+	if goType := gg.mapType(typed(gg.types, &field, field.Type)); goType != "" {
+		return goType
+	}
+	return "interface{}"
+}
+
+func (gg *GoGenerator) GenerateFunction(fd *ast.FunctionDeclaration) string {
+	defer declareTypeParameters(gg.typeNames, fd.TypeParameters)()
+
+	var sb strings.Builder
+
+	name := fd.Name
+	if exported, ok := gg.qualified[name]; ok {
+		name = exported
+	}
+
+	sb.WriteString("func ")
+	sb.WriteString(name)
+	sb.WriteString(gg.typeParameters(fd.TypeParameters))
+	sb.WriteString("(")
+	sb.WriteString(gg.generateParameters(fd.Parameters))
+	sb.WriteString(")")
+	if returnType := gg.returnType(typedReturn(gg.types, fd, fd.ReturnType, fd.IsGenerator), fd.IsGenerator); returnType != "" {
+		sb.WriteString(" " + returnType)
+	}
+	sb.WriteString(" {\n")
+
+	sb.WriteString(gg.generateDefaults(fd.Parameters))
+
+	sb.WriteString(gg.generateBody(fd.Body, fd.ReturnType, fd.IsGenerator))
+
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// returnType génère le type de retour ; un générateur renvoie un iter.Seq
+func (gg *GoGenerator) returnType(t string, isGenerator bool) string {
+	if _, ok := yieldedType(t); isGenerator && !ok {
+		gg.usesIter = true
+		return "iter.Seq[interface{}]"
+	}
+	return gg.mapType(t)
+}
+
+// iteratorElement renvoie le type Go des valeurs produites par un générateur
+func (gg *GoGenerator) iteratorElement(t string) string {
+	if element := gg.mapType(t); element != "" {
+		return element
+	}
+	return "interface{}"
+}
+
+// generateBody génère le corps d'une fonction. Celui d'un générateur devient
+// la fonction push d'un iter.Seq : yield x appelle yield(x) et s'arrête dès
+// que le consommateur interrompt son range.
+func (gg *GoGenerator) generateBody(body []ast.Statement, returnType string, isGenerator bool) string {
+	if !isGenerator {
+		var sb strings.Builder
+		for _, stmt := range body {
+			sb.WriteString(gg.GenerateStatement(stmt, "    "))
+		}
+		return sb.String()
+	}
+
+	element := "interface{}"
+	if inner, ok := yieldedType(returnType); ok {
+		element = gg.iteratorElement(inner)
+	}
+
+	gg.iterator = true
+	defer func() { gg.iterator = false }()
+
+	var sb strings.Builder
+	sb.WriteString("    return func(yield func(" + element + ") bool) {\n")
+	for _, stmt := range body {
+		sb.WriteString(gg.GenerateStatement(stmt, "        "))
+	}
+	sb.WriteString("    }\n")
+	return sb.String()
+}
+
+// generateParameters génère la liste des paramètres ; un paramètre rest
+// devient variadique
+func (gg *GoGenerator) generateParameters(params []ast.Parameter) string {
+	var parts []string
+	for _, param := range typedParameters(gg.types, params) {
+		switch {
+		case param.IsRest:
+			parts = append(parts, param.Name+" ..."+gg.mapType(elementType(param.Type)))
+		case param.Default != nil:
+			// Go n'a pas de valeurs par défaut : pointeur nil = argument omis
+			parts = append(parts, param.Name+"Opt *"+gg.mapType(param.Type))
+		case param.Optional:
+			parts = append(parts, param.Name+" *"+gg.mapType(param.Type))
+		default:
+			parts = append(parts, param.Name+" "+gg.mapType(param.Type))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// generateDefaults applique les valeurs par défaut en tête de corps
+func (gg *GoGenerator) generateDefaults(params []ast.Parameter) string {
+	var sb strings.Builder
+	for _, param := range typedParameters(gg.types, params) {
+		if param.Default != nil && !param.IsRest {
+			defaultValue := gg.GenerateExpression(param.Default)
+			if al, ok := param.Default.(*ast.ArrayLiteral); ok && !hasSpread(al.Elements) && param.Type != "" {
+				var elements []string
+				for _, element := range al.Elements {
+					elements = append(elements, gg.GenerateExpression(element))
+				}
+				defaultValue = gg.mapType(param.Type) + "{" + strings.Join(elements, ", ") + "}"
+			}
+			sb.WriteString("    " + param.Name + " := " + defaultValue + "\n")
+			sb.WriteString("    if " + param.Name + "Opt != nil {\n")
+			sb.WriteString("        " + param.Name + " = *" + param.Name + "Opt\n")
+			sb.WriteString("    }\n")
+		}
+	}
+	return sb.String()
+}
+
+func (gg *GoGenerator) GenerateStatement(stmt ast.Statement, indent string) string {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		return indentLines(gg.GenerateVariableDeclaration(s), indent)
+	case *ast.ReturnStatement:
+		if s.Value != nil && !gg.iterator {
+			return indentLines("return "+gg.GenerateExpression(s.Value)+"\n", indent)
+		}
+		return indent + "return\n"
+	case *ast.ExpressionStatement:
+		return indentLines(gg.GenerateExpressionStatement(s), indent)
+	case *ast.ForStatement:
+		var header []string
+		if vd, ok := s.Init.(*ast.VariableDeclaration); ok {
+			header = append(header, vd.Name+" := "+gg.GenerateExpression(vd.Value))
+		} else if es, ok := s.Init.(*ast.ExpressionStatement); ok {
+			header = append(header, gg.GenerateExpression(es.Expression))
+		} else {
+			header = append(header, "")
+		}
+		if s.Condition != nil {
+			header = append(header, gg.GenerateExpression(s.Condition))
+		} else {
+			header = append(header, "")
+		}
+		if es, ok := s.Update.(*ast.ExpressionStatement); ok {
+			header = append(header, gg.GenerateExpression(es.Expression))
+		} else {
+			header = append(header, "")
+		}
+		return indent + "for " + strings.Join(header, "; ") + " " + gg.GenerateBlock(s.Body, indent) + "\n"
+	case *ast.WhileStatement:
+		return indent + "for " + gg.GenerateExpression(s.Condition) + " " + gg.GenerateBlock(s.Body, indent) + "\n"
+	case *ast.IfStatement:
+		var sb strings.Builder
+		sb.WriteString(indent + "if " + gg.GenerateExpression(s.Condition) + " ")
+		sb.WriteString(gg.GenerateBlock(s.ThenBranch, indent))
+		if s.ElseBranch != nil {
+			sb.WriteString(" else ")
+			sb.WriteString(gg.GenerateBlock(s.ElseBranch, indent))
+		}
+		sb.WriteString("\n")
+		return sb.String()
+	}
+	return ""
+}
+
+func (gg *GoGenerator) GenerateBlock(stmt ast.Statement, indent string) string {
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	if blockStmt, ok := stmt.(*ast.BlockStatement); ok {
+		for _, inner := range blockStmt.Statements {
+			sb.WriteString(gg.GenerateStatement(inner, indent+"    "))
+		}
+	}
+	sb.WriteString(indent + "}")
+	return sb.String()
+}
+
+func (gg *GoGenerator) GenerateExpressionStatement(es *ast.ExpressionStatement) string {
+	// yield x -> yield(x), en s'arrêtant si le consommateur a quitté son range ;
+	// yield* xs relaie chaque valeur de xs
+	if ye, ok := es.Expression.(*ast.YieldExpression); ok {
+		value := "nil"
+		if ye.Argument != nil {
+			value = gg.GenerateExpression(ye.Argument)
+		}
+		if ye.Delegate {
+			return "for v := range " + value + " {\n    if !yield(v) {\n        return\n    }\n}\n"
+		}
+		return "if !yield(" + value + ") {\n    return\n}\n"
+	}
+	// Convertir console.log en fmt.Println
+	if callExpr, ok := es.Expression.(*ast.CallExpression); ok && isConsoleLog(callExpr) {
+		gg.usesFmt = true
+		var args []string
+		for _, arg := range callExpr.Arguments {
+			args = append(args, gg.GenerateExpression(arg))
+		}
+		return "fmt.Println(" + strings.Join(args, ", ") + ")\n"
+	}
+	return gg.GenerateExpression(es.Expression) + "\n"
+}
+
+func (gg *GoGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+	declared := gg.mapType(variableType(gg.types, vd))
+	vd = withoutErasedAssertion(vd)
+	var sb strings.Builder
+
+	if vd.Value == nil {
+		if declared == "" {
+			declared = "interface{}"
+		}
+		return "var " + vd.Name + " " + declared + "\n"
+	}
+
+	// Seuls les littéraux de base peuvent être des constantes Go
+	isBasicLiteral := false
+	switch vd.Value.(type) {
+	case *ast.StringLiteral, *ast.NumberLiteral, *ast.BooleanLiteral:
+		isBasicLiteral = true
+	}
+
+	if vd.IsConst && isBasicLiteral {
+		sb.WriteString("const ")
+	} else {
+		sb.WriteString("var ")
+	}
+
+	sb.WriteString(vd.Name)
+
+	// Déterminer le type Go ; celui d'une fonction est déduit du littéral
+	if _, ok := vd.Value.(*ast.ArrowFunction); !ok {
+		sb.WriteString(" ")
+	}
+	switch value := vd.Value.(type) {
+	case *ast.ArrowFunction:
+	case *ast.ObjectLiteral:
+		sb.WriteString("map[string]interface{}")
+	case *ast.AsExpression:
+		// const shape = value as Shape -> var shape Shape = value.(Shape)
+		sb.WriteString(gg.mapType(value.Type))
+	case *ast.ArrayLiteral:
+		if !strings.HasPrefix(declared, "[]") {
+			declared = gg.sliceType(value)
+		}
+		sb.WriteString(declared)
+	default:
+		if declared == "" {
+			declared = "interface{}"
+		}
+		sb.WriteString(declared)
+	}
+
+	sb.WriteString(" = ")
+
+	switch val := vd.Value.(type) {
+	case *ast.ArrayLiteral:
+		// Tableau typé : []float64{0.5, 1}
+		sb.WriteString(gg.sliceLiteral(val.Elements, declared))
+	case *ast.StringLiteral:
+		sb.WriteString(gg.GenerateStringLiteral(val))
+	case *ast.NumberLiteral:
+		// Dans un interface{}, 5 serait un int que value.(float64) refuse
+		sb.WriteString(gg.GenerateExpression(val))
+	case *ast.BooleanLiteral:
+		sb.WriteString(gg.GenerateBooleanLiteral(val))
+	default:
+		sb.WriteString(gg.GenerateExpression(val))
+	}
+
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func (gg *GoGenerator) GenerateExpression(expr ast.Expression) string {
+	if gg.widened[expr] {
+		// Go ne convertit pas un entier en float64
+		if nl, ok := expr.(*ast.NumberLiteral); ok && decimalInteger(nl.Value) {
+			return gg.GenerateNumberLiteral(nl) + ".0"
+		}
+		return "float64(" + gg.generateExpression(expr) + ")"
+	}
+	return gg.generateExpression(expr)
+}
+
+func (gg *GoGenerator) generateExpression(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return gg.GenerateStringLiteral(e)
+	case *ast.NumberLiteral:
+		return gg.GenerateNumberLiteral(e)
+	case *ast.BooleanLiteral:
+		return gg.GenerateBooleanLiteral(e)
+	case *ast.Identifier:
+		if e.Value == "this" && gg.receiver != "" {
+			return gg.receiver
+		}
+		if qualified, ok := gg.qualified[e.Value]; ok {
+			if dot := strings.Index(qualified, "."); dot >= 0 {
+				gg.usedPackages[qualified[:dot]] = true
+			}
+			return qualified
+		}
+		return e.Value
+	case *ast.ParenthesizedExpression:
+		return "(" + gg.GenerateExpression(e.Expression) + ")"
+	case *ast.InfixExpression:
+		if e.Operator == "%" && isFloat(gg.types, e) {
+			// % n'accepte que des entiers en Go
+			gg.usesMath = true
+			return "math.Mod(" + gg.GenerateExpression(e.Left) + ", " + gg.GenerateExpression(e.Right) + ")"
+		}
+		return gg.GenerateExpression(e.Left) + " " + e.Operator + " " + gg.GenerateExpression(e.Right)
+	case *ast.ArrayLiteral:
+		return gg.GenerateArrayLiteral(e)
+	case *ast.ObjectLiteral:
+		return gg.GenerateObjectLiteral(e)
+	case *ast.CallExpression:
+		return gg.GenerateCallExpression(e)
+	case *ast.IndexExpression:
+		if isFloat(gg.types, e.Index) {
+			// Un indice de slice est un int
+			return gg.GenerateExpression(e.Left) + "[int(" + gg.GenerateExpression(e.Index) + ")]"
+		}
+		return gg.GenerateExpression(e.Left) + "[" + gg.GenerateExpression(e.Index) + "]"
+	case *ast.DotExpression:
+		// this.#count -> c.count : un nom en minuscule est privé au paquet
+		e = privateAccess(e, unexported)
+		if _, ok := namespaceAccess(e, gg.namespaces); ok {
+			// Geometry.area -> area : le namespace a été aplati
+			return gg.GenerateExpression(&ast.Identifier{Value: e.Property})
+		}
+		if ed, member := enumMemberAccess(gg.enums, e); member != nil {
+			return ed.Name + member.Name
+		}
+		if isSuperMember(e) && gg.super != "" {
+			// super.describe() -> r.Shape.describe()
+			name, _ := splitTypeArguments(gg.super)
+			return gg.receiver + "." + name + "." + e.Property
+		}
+		if ident, ok := e.Object.(*ast.Identifier); ok && gg.classes[ident.Value] != nil {
+			// Membre statique : Box.count -> BoxCount
+			return ident.Value + capitalize(e.Property)
+		}
+		if method := gg.dispatchedCall(e); method != "" {
+			return method
+		}
+		if getter := gg.interfaceGetter(e); getter != "" {
+			return getter
+		}
+		if ident, ok := e.Object.(*ast.Identifier); ok && gg.packages[ident.Value] {
+			// Membre d'un package importé : path.join -> path.Join
+			gg.usedPackages[ident.Value] = true
+			return ident.Value + "." + capitalize(e.Property)
+		}
+		if gg.accessors[e.Property] {
+			return gg.GenerateExpression(e.Object) + "." + e.Property + "()"
+		}
+		return gg.GenerateExpression(e.Object) + "." + e.Property
+	case *ast.SpreadElement:
+		// f(...xs) -> f(xs...)
+		return gg.GenerateExpression(e.Argument) + "..."
+	case *ast.AssignmentExpression:
+		if property, value := accessorAssignment(e, gg.accessors); property != nil {
+			return gg.GenerateExpression(property.Object) + "." + setterName(property.Property) + "(" + gg.GenerateExpression(value) + ")"
+		}
+		if e.Right == nil {
+			return gg.GenerateExpression(e.Left) + e.Operator
+		}
+		return gg.GenerateExpression(e.Left) + " " + e.Operator + " " + gg.GenerateExpression(e.Right)
+	case *ast.NewExpression:
+		// new Box<string>(v) -> NewBox[string](v)
+		var args []string
+		for _, arg := range e.Arguments {
+			args = append(args, gg.GenerateExpression(arg))
+		}
+		class := gg.GenerateExpression(e.Class)
+		if class == "Map" && !gg.typeNames[class] && len(args) == 0 {
+			// new Map<K, V>() -> map[K]V{}
+			return gg.mapType(newMapType(e.TypeArguments)) + "{}"
+		}
+		if dot := strings.LastIndex(class, "."); dot >= 0 {
+			// Classe importée : new User() -> user.NewUser()
+			class = class[:dot+1] + "New" + class[dot+1:]
+		} else {
+			class = "New" + class
+		}
+		return class + gg.typeArguments(e.TypeArguments) + "(" + strings.Join(args, ", ") + ")"
+	case *ast.AwaitExpression:
+		// Exécution synchrone : await fetchUser(1) -> fetchUser(1)
+		return gg.GenerateExpression(e.Argument)
+	case *ast.AsExpression:
+		if t := gg.mapType(e.Type); castsTo(e.Type) && t != "interface{}" && gg.assertable(e.Expression) {
+			// Assertion de type sur une interface : value as Shape -> value.(Shape)
+			return gg.GenerateExpression(e.Expression) + ".(" + t + ")"
+		}
+		return assertedOperand(e.Expression, gg.GenerateExpression)
+	case *ast.SatisfiesExpression:
+		return assertedOperand(e.Expression, gg.GenerateExpression)
+	case *ast.NonNullExpression:
+		// Un pointeur nil se déréférence sans vérification préalable
+		return assertedOperand(e.Expression, gg.GenerateExpression)
+	case *ast.ArrowFunction:
+		return gg.GenerateFuncLiteral(e)
+	}
+	return ""
+}
+
+// dispatchedCall génère une méthode de this appelée à travers l'objet réel,
+// dans une classe à sous-classes : this.area -> s.self.area, ou
+// s.self.(RectMethods).diag pour une méthode qu'une sous-classe de la racine
+// introduit ; "" hors de ce cas
+func (gg *GoGenerator) dispatchedCall(de *ast.DotExpression) string {
+	if ident, ok := de.Object.(*ast.Identifier); !ok || ident.Value != "this" || gg.dispatch == nil {
+		return ""
+	}
+	introducer := methodIntroducer(gg.dispatch, de.Property, gg.classes)
+	switch introducer {
+	case nil:
+		return ""
+	case rootClass(gg.dispatch, gg.classes):
+		return gg.receiver + ".self." + de.Property
+	}
+	return gg.receiver + ".self.(" + methodsInterface(introducer.Name) + ")." + de.Property
+}
+
+// assertable indique si une valeur a un type interface en Go, seul admis par
+// une assertion value.(T) : any, unknown, ou une interface de méthodes. Sur
+// un type concret, total(1, 2) as number, l'assertion est effacée.
+func (gg *GoGenerator) assertable(expr ast.Expression) bool {
+	if hasConcreteType(expr) {
+		return false
+	}
+	switch source := gg.types.Expression(expr).(type) {
+	case *semantic.Object:
+		cd := gg.classes[source.Name]
+		return gg.contracts[source.Name] != nil || cd != nil && polymorphicClass(cd, gg.classes)
+	case *semantic.Literal:
+		return false
+	case semantic.Type:
+		return gg.mapType(source.String()) == "interface{}"
+	}
+	return true
+}
+
+// interfaceGetter génère la lecture d'un champ sur une valeur dont le type Go
+// est une interface, qui n'expose que des méthodes : shape.name ->
+// shape.getName() ; "" hors de ce cas
+func (gg *GoGenerator) interfaceGetter(de *ast.DotExpression) string {
+	if ident, ok := de.Object.(*ast.Identifier); ok && ident.Value == "this" {
+		return ""
+	}
+	cd := gg.classes[objectTypeName(gg.types, de.Object)]
+	if cd != nil && polymorphicClass(cd, gg.classes) && fieldOwner(cd, de.Property, gg.classes) != nil || interfaceProperty(gg.types, de, gg.contracts) {
+		return gg.GenerateExpression(de.Object) + "." + getterName(de.Property) + "()"
+	}
+	return ""
+}
+
+// GenerateFuncLiteral génère un littéral de fonction ; le type de retour d'une
+// fonction qui renvoie une valeur de type inconnu est interface{}
+func (gg *GoGenerator) GenerateFuncLiteral(af *ast.ArrowFunction) string {
+	var sb strings.Builder
+	sb.WriteString("func(" + gg.generateParameters(af.Parameters) + ")")
+
+	body := arrowBody(af)
+	returnType := gg.mapType(typedReturn(gg.types, af, af.ReturnType, false))
+	if returnType == "" && returnsValue(body) {
+		returnType = "interface{}"
+	}
+	if returnType != "" {
+		sb.WriteString(" " + returnType)
+	}
+
+	sb.WriteString(" {\n")
+	sb.WriteString(gg.generateDefaults(af.Parameters))
+	for _, stmt := range body {
+		sb.WriteString(gg.GenerateStatement(stmt, "    "))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+func (gg *GoGenerator) GenerateCallExpression(ce *ast.CallExpression) string {
+	var fd *ast.FunctionDeclaration
+	if ident, ok := ce.Function.(*ast.Identifier); ok {
+		fd = gg.functions[ident.Value]
+		if ident.Value == "super" && gg.super != "" {
+			// super(args) -> r.Shape = NewShape(args)
+			name, args := splitTypeArguments(gg.super)
+			call := &ast.NewExpression{Class: &ast.Identifier{Value: name}, TypeArguments: args, Arguments: ce.Arguments}
+			return gg.receiver + "." + name + " = " + gg.GenerateExpression(call)
+		}
+	}
+
+	arguments := ce.Arguments
+	rest, restArgs, hasRest := restCall(gg.rests, ce)
+	if hasRest {
+		arguments = arguments[:rest.index]
+	}
+
+	var args []string
+	for i, arg := range arguments {
+		// Les arguments optionnels sont passés par pointeur
+		if fd != nil && i < len(fd.Parameters) && fd.Parameters[i].CanBeOmitted() && !fd.Parameters[i].IsRest {
+			gg.needsPtrHelper = true
+			args = append(args, "ptr("+gg.GenerateExpression(arg)+")")
+		} else {
+			args = append(args, gg.GenerateExpression(arg))
+		}
+	}
+	if hasRest {
+		args = append(args, gg.restArguments(restArgs, "[]"+gg.mapType(elementType(rest.param.Type)))...)
+	}
+
+	// Compléter les arguments omis par nil
+	if fd != nil {
+		for i := len(ce.Arguments); i < len(fd.Parameters); i++ {
+			if fd.Parameters[i].CanBeOmitted() && !fd.Parameters[i].IsRest {
+				args = append(args, "nil")
+			}
+		}
+	}
+
+	return gg.GenerateExpression(ce.Function) + gg.typeArguments(ce.TypeArguments) + "(" + strings.Join(args, ", ") + ")"
+}
+
+// restArguments génère les arguments reçus par un paramètre variadique : un
+// seul ...xs devient xs..., un mélange d'éléments et de spreads est rassemblé
+// dans une slice étalée
+func (gg *GoGenerator) restArguments(args []ast.Expression, sliceType string) []string {
+	if slice, ok := soleSpread(args); ok {
+		return []string{gg.GenerateExpression(slice) + "..."}
+	}
+	if hasSpread(args) {
+		return []string{gg.sliceLiteral(args, sliceType) + "..."}
+	}
+	var result []string
+	for _, arg := range args {
+		result = append(result, gg.GenerateExpression(arg))
+	}
+	return result
+}
+
+func (gg *GoGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
+	return gg.sliceLiteral(al.Elements, gg.sliceType(al))
+}
+
+// sliceType renvoie le type Go d'un tableau littéral : celui qu'attend son
+// contexte, sinon celui de ses éléments, []interface{} s'ils sont inconnus ou
+// de types mêlés
+func (gg *GoGenerator) sliceType(al *ast.ArrayLiteral) string {
+	elem, ok := gg.arrays[al]
+	if !ok {
+		elem = elementText(gg.types, al)
+	}
+	if elem != "" {
+		if mapped := gg.mapType(elem); mapped != "" {
+			return "[]" + mapped
+		}
+	}
+	return "[]interface{}"
+}
+
+// sliceLiteral génère une slice du type donné ; les spreads sont ajoutés par
+// append : [...a, 1] -> append(append([]int{}, a...), 1)
+func (gg *GoGenerator) sliceLiteral(elements []ast.Expression, sliceType string) string {
+	elements = flattenSpreads(elements)
+	if !hasSpread(elements) {
+		var generated []string
+		for _, element := range elements {
+			generated = append(generated, gg.GenerateExpression(element))
+		}
+		return sliceType + "{" + strings.Join(generated, ", ") + "}"
+	}
+
+	result := sliceType + "{}"
+	for _, element := range elements {
+		result = "append(" + result + ", " + gg.GenerateExpression(element) + ")"
+	}
+	return result
+}
+
+func (gg *GoGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) string {
+	if hasSpreadProperty(ol.Properties) {
+		// Go n'a pas de spread d'objet : fusion explicite via mergeMaps
+		gg.needsMergeHelper = true
+		var parts []string
+		for _, group := range splitSpreadProperties(ol.Properties) {
+			if spread, ok := group[0].Value.(*ast.SpreadElement); ok {
+				parts = append(parts, gg.GenerateExpression(spread.Argument))
+			} else {
+				parts = append(parts, gg.GenerateObjectLiteral(&ast.ObjectLiteral{Properties: group}))
+			}
+		}
+		return "mergeMaps(" + strings.Join(parts, ", ") + ")"
+	}
+
+	var entries []string
+	for _, prop := range ol.Properties {
+		entries = append(entries, "\""+prop.Key+"\": "+gg.GenerateExpression(prop.Value))
+	}
+	return "map[string]interface{}{" + strings.Join(entries, ", ") + "}"
+}
+
+func (gg *GoGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
+	if alias, ok := gg.literals[sl]; ok {
+		member := enumMemberName(sl.Value)
+		return alias + member
+	}
+	return "\"" + sl.Value + "\""
+}
+
+func (gg *GoGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
+	return nl.Value
+}
+
+func (gg *GoGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
+	if bl.Value {
+		return "true"
+	}
+	return "false"
+}
+
+// RustGenerator génère du code Rust
+type RustGenerator struct {
+	usesHashMap      bool // objets littéraux -> HashMap
+	needsMergeHelper bool // un spread d'objet nécessite le helper merge_maps
+
+	functions  map[string]*ast.FunctionDeclaration // signatures pour adapter les appels
+	enums      map[string]*ast.EnumDeclaration     // Status.Pending -> Status::Pending
+	classes    map[string]*ast.ClassDeclaration    // Box.of -> Box::of
+	traits     map[string]bool                     // interfaces générées comme traits
+	bounds     map[string]bool                     // types qui contraignent un paramètre de type : T: Base
+	interfaces map[string]*ast.Interface           // traits implémentés par les classes
+	superDepth int                                 // profondeur de la classe qui déclare la méthode reprise : super -> self.base.base
+	superOwner *ast.ClassDeclaration               // classe du corps repris dans un trait : super.describe() -> self.shape_describe()
+	rests      map[string]restParameter            // f(1, 2) -> f(&[1, 2]) pour un paramètre rest
+	typeNames  map[string]bool                     // classes, interfaces et paramètres de type connus
+	imported   map[string]bool                     // types importés d'autres modules, bornes possibles
+	types      *semantic.Inference                 // types déduits des déclarations sans annotation
+	literals   map[*ast.StringLiteral]string       // chaînes écrites comme membres d'enum : 'done' -> TaskStatus::Done
+	arrays     map[*ast.ArrayLiteral]string        // type des éléments attendu : [new Rect()] dans un Shape[]
+	widened    map[ast.Expression]bool             // entiers attendus en f64 : i as f64
+	selfName   string                              // this -> self, ou this dans un constructeur
+	exported   map[string]bool                     // déclarations exportées, rendues pub
+
+	superMethods   []inheritedMethod                     // méthodes à reprendre pour les appels super.m() de la classe
+	superNames     map[string]bool                       // noms des méthodes reprises déjà demandées
+	iteratorFields map[string]bool                       // paramètres et locales d'un générateur, champs de son itérateur
+	accessors      map[string]bool                       // propriétés get/set : x.total -> x.total(), x.set_total(v)
+	namespaces     map[string]map[string]namespaceMember // Geometry.area -> area
+	renamed        map[string]string                     // fonctions dont le nom est pris par le code généré : main -> Main
+}
+
+func (rg *RustGenerator) Generate(statements []ast.Statement) string {
+	var body strings.Builder
+
+	rg.literals = enumLiterals(rg.types, statements)
+	rg.arrays = arrayElements(rg.types, statements)
+	rg.widened = widenedIntegers(rg.types, statements)
+
+	statements, imports, exports := splitModuleStatements(statements)
+
+	// Pas de namespace en Rust : leurs déclarations sont générées au niveau
+	// du programme et Geometry.area devient area
+	rg.namespaces = collectNamespaces(statements)
+	statements = flattenNamespaces(statements)
+	rg.exported = exportedNames(exports)
+
+	functions, others := splitStatements(statements)
+	rg.functions = map[string]*ast.FunctionDeclaration{}
+	for _, fd := range functions {
+		rg.functions[fd.Name] = fd
+	}
+	// main est le point d'entrée qu'écrit le générateur : la fonction main
+	// du programme devient Main, comme en Go
+	rg.renamed = map[string]string{}
+	if rg.functions["main"] != nil {
+		rg.renamed["main"] = "Main"
+	}
+	rg.rests = collectRestParameters(statements)
+	rg.enums = collectEnums(statements)
+	rg.typeNames = collectTypeNames(statements)
+	rg.imported = importedTypes(imports)
+	for name := range rg.imported {
+		rg.typeNames[name] = true
+	}
+	rg.accessors = collectAccessors(statements)
+	rg.interfaces = collectInterfaces(statements)
+	rg.bounds = boundTypes(statements)
+	rg.classes = map[string]*ast.ClassDeclaration{}
+	rg.traits = map[string]bool{}
+	for _, stmt := range others {
+		switch s := stmt.(type) {
+		case *ast.ClassDeclaration:
+			rg.classes[s.Name] = s
+		case *ast.Interface:
+			rg.traits[s.Name] = true
+		}
+	}
+
+	// Les enums (et unions de littéraux) sont déclarés au niveau du module
+	var locals []ast.Statement
+	for _, stmt := range others {
+		switch s := stmt.(type) {
+		case *ast.EnumDeclaration:
+			body.WriteString(rg.GenerateEnum(s))
+		case *ast.TypeAlias:
+			if ed := literalUnionEnum(s); ed != nil {
+				body.WriteString(rg.GenerateEnum(ed))
+			}
+		case *ast.Interface:
+			body.WriteString(rg.GenerateInterface(s))
+		case *ast.ClassDeclaration:
+			body.WriteString(rg.GenerateClass(s))
+		default:
+			locals = append(locals, stmt)
+		}
+	}
+
+	for _, fd := range functions {
+		// Rust n'a pas de surcharge : l'implémentation reste la seule fonction
+		body.WriteString(overloadComments(fd, "//") + rg.GenerateFunction(fd))
+	}
+
+	if containsAwait(locals) {
+		// Un await au niveau du programme nécessite un runtime asynchrone
+		body.WriteString("#[tokio::main]\nasync fn main() {\n")
+	} else {
+		body.WriteString("fn main() {\n")
+	}
+
+	for _, stmt := range locals {
+		body.WriteString(rg.GenerateStatement(stmt, "    "))
+	}
+
+	body.WriteString("}\n")
+
+	if rg.needsMergeHelper {
+		body.WriteString("\n")
+		body.WriteString("fn merge_maps<'a>(maps: &[HashMap<&'a str, String>]) -> HashMap<&'a str, String> {\n")
+		body.WriteString("    let mut result = HashMap::new();\n")
+		body.WriteString("    for m in maps {\n")
+		body.WriteString("        result.extend(m.clone());\n")
+		body.WriteString("    }\n")
+		body.WriteString("    result\n")
+		body.WriteString("}\n")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(rg.generateModules(imports, exports))
+	if rg.usesHashMap || rg.needsMergeHelper {
+		sb.WriteString("use std::collections::HashMap;\n\n")
+	}
+	sb.WriteString(body.String())
+	return sb.String()
+}
+
+// generateModules génère les déclarations mod et use : un fichier du projet
+// est un module de la crate ("./utils/logger" -> mod utils; use
+// crate::utils::logger), un ré-export devient pub use
+func (rg *RustGenerator) generateModules(imports []*ast.ImportDeclaration, exports []*ast.ExportDeclaration) string {
+	var mods, uses []string
+	seen := map[string]bool{}
+	declare := func(source string) string {
+		parents, segments := modulePath(source)
+		if !isRelativeModule(source) {
+			return strings.Join(segments, "::")
+		}
+		if parents == 0 && len(segments) > 0 && !seen[segments[0]] {
+			seen[segments[0]] = true
+			mods = append(mods, "mod "+segments[0]+";")
+		}
+		prefix := "crate"
+		if parents > 0 {
+			prefix = strings.TrimSuffix(strings.Repeat("super::", parents), "::")
+		}
+		return strings.Join(append([]string{prefix}, segments...), "::")
+	}
+	use := func(visibility, path string, names []string) {
+		switch len(names) {
+		case 0:
+		case 1:
+			uses = append(uses, visibility+"use "+path+"::"+names[0]+";")
+		default:
+			uses = append(uses, visibility+"use "+path+"::{"+strings.Join(names, ", ")+"};")
+		}
+	}
+	useModule := func(visibility, path, alias string) {
+		if strings.HasSuffix(path, "::"+alias) || path == alias {
+			uses = append(uses, visibility+"use "+path+";")
+		} else {
+			uses = append(uses, visibility+"use "+path+" as "+alias+";")
+		}
+	}
+
+	for _, id := range imports {
+		path := declare(id.Source)
+		var names []string
+		if id.Default != "" {
+			names = append(names, "default as "+id.Default)
+		}
+		for _, spec := range id.Specifiers {
+			names = append(names, rustUseName(spec))
+		}
+		use("", path, names)
+		if id.Namespace != "" {
+			useModule("", path, id.Namespace)
+		}
+	}
+
+	for _, ed := range exports {
+		switch {
+		case ed.Source != "" && ed.IsWildcard && ed.Namespace != "":
+			useModule("pub ", declare(ed.Source), ed.Namespace)
+		case ed.Source != "" && ed.IsWildcard:
+			uses = append(uses, "pub use "+declare(ed.Source)+"::*;")
+		case ed.Source != "":
+			var names []string
+			for _, spec := range ed.Specifiers {
+				names = append(names, rustUseName(spec))
+			}
+			use("pub ", declare(ed.Source), names)
+		case ed.IsDefault:
+			if name := defaultExportName(ed); name != "" {
+				uses = append(uses, "pub use self::"+rg.functionName(name)+" as default;")
+			}
+		default:
+			// export { helper as double } : le nom d'origine est déjà pub
+			for _, spec := range ed.Specifiers {
+				if spec.Alias != "" {
+					uses = append(uses, "pub use self::"+spec.Name+" as "+spec.Alias+";")
+				}
+			}
+		}
+	}
+
+	var sb strings.Builder
+	if len(mods) > 0 {
+		sb.WriteString(strings.Join(mods, "\n") + "\n\n")
+	}
+	if len(uses) > 0 {
+		sb.WriteString(strings.Join(uses, "\n") + "\n\n")
+	}
+	return sb.String()
+}
+
+func rustUseName(spec ast.ImportSpecifier) string {
+	if spec.Alias != "" {
+		return spec.Name + " as " + spec.Alias
+	}
+	return spec.Name
+}
+
+// functionName renvoie le nom Rust d'une fonction du programme
+func (rg *RustGenerator) functionName(name string) string {
+	if renamed, ok := rg.renamed[name]; ok {
+		return renamed
+	}
+	return name
+}
+
+// visibility renvoie "pub " pour une déclaration exportée du module
+func (rg *RustGenerator) visibility(name string) string {
+	if rg.exported[name] {
+		return "pub "
+	}
+	return ""
+}
+
+// GenerateEnum génère un enum Rust. Les discriminants Rust sont entiers : pour
+// un enum chaîne, la méthode as_str() fournit la valeur TypeScript.
+func (rg *RustGenerator) GenerateEnum(ed *ast.EnumDeclaration) string {
+	members := resolveEnumMembers(ed)
+	stringEnum := isStringEnum(members)
+
+	var sb strings.Builder
+	sb.WriteString("#[derive(Debug, Clone, Copy, PartialEq, Eq)]\n")
+	sb.WriteString(rg.visibility(ed.Name) + "enum " + ed.Name + " {\n")
+	for _, member := range members {
+		if stringEnum {
+			sb.WriteString("    " + member.Name + ",\n")
+		} else {
+			sb.WriteString("    " + member.Name + " = " + rg.GenerateExpression(member.Value) + ",\n")
+		}
+	}
+	sb.WriteString("}\n\n")
+
+	if stringEnum {
+		sb.WriteString("impl " + ed.Name + " {\n")
+		sb.WriteString("    fn as_str(&self) -> &'static str {\n")
+		sb.WriteString("        match self {\n")
+		for _, member := range members {
+			sb.WriteString("            " + ed.Name + "::" + member.Name + " => \"" + enumValueString(member.Value) + "\",\n")
+		}
+		sb.WriteString("        }\n")
+		sb.WriteString("    }\n")
+		sb.WriteString("}\n\n")
+	}
+
+	return sb.String()
+}
+
+// mapType convertit un type TypeScript en type Rust
+func (rg *RustGenerator) mapType(t string) string {
+	if strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "Array<") {
+		return "Vec<" + rg.mapType(elementType(t)) + ">"
+	}
+	if inner, ok := unwrapPromise(t); ok {
+		// Une async fn renvoie directement T : Promise<T> -> T
+		return rg.mapType(inner)
+	}
+	if inner, ok := yieldedType(t); ok {
+		return "Box<dyn Iterator<Item = " + rg.iteratorElement(inner) + ">>"
+	}
+	switch t {
+	case "string":
+		return "String"
+	case "number":
+		return "f64"
+	case integerType:
+		return "i32"
+	case "boolean":
+		return "bool"
+	case "void", "":
+		return ""
+	}
+	if name, args := splitTypeArguments(t); name == "Map" && len(args) == 2 && !rg.typeNames[name] {
+		rg.usesHashMap = true
+		return "HashMap" + rg.typeArguments(args)
+	}
+
+	// Types déclarés et paramètres de type : Stack<T> ; une interface est un
+	// trait, manipulé comme objet trait
+	if name, args := splitTypeArguments(t); rg.typeNames[name] {
+		if rg.traits[name] {
+			return "Box<dyn " + name + rg.typeArguments(args) + ">"
+		}
+		if cd := rg.classes[name]; cd != nil && polymorphicClass(cd, rg.classes) {
+			// Une classe à sous-classes est manipulée par le trait de ses méthodes
+			return "Box<dyn " + methodsInterface(name) + ">"
+		}
+		return name + rg.typeArguments(args)
+	}
+	return "Box<dyn std::any::Any>"
+}
+
+// structType renvoie la struct d'une classe, même si son type est un trait :
+// le champ base d'une sous-classe contient la partie Shape, pas un objet trait
+func (rg *RustGenerator) structType(t string) string {
+	name, args := splitTypeArguments(t)
+	return name + rg.typeArguments(args)
+}
+
+// typeArguments génère <i32, String> pour un type générique instancié
+func (rg *RustGenerator) typeArguments(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	var mapped []string
+	for _, arg := range args {
+		mapped = append(mapped, rg.mapType(arg))
+	}
+	return "<" + strings.Join(mapped, ", ") + ">"
+}
+
+// typeParameters génère <T: Base, U = String>. Seule une interface, générée
+// comme trait, ou un type importé peut servir de borne ; les types par défaut
+// ne sont admis que sur les types (withDefaults), pas sur les fonctions.
+func (rg *RustGenerator) typeParameters(params []ast.TypeParameter, withDefaults bool) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, param := range params {
+		part := param.Name
+		if name, args := splitTypeArguments(param.Constraint); rg.traits[name] || rg.imported[name] {
+			part += ": " + name + rg.typeArguments(args)
+		}
+		if withDefaults && param.Default != "" {
+			part += " = " + rg.mapType(param.Default)
+		}
+		parts = append(parts, part)
+	}
+	return "<" + strings.Join(parts, ", ") + ">"
+}
+
+// GenerateInterface génère un trait ; les propriétés deviennent des accesseurs
+func (rg *RustGenerator) GenerateInterface(i *ast.Interface) string {
+	defer declareTypeParameters(rg.typeNames, i.TypeParameters)()
+
+	var supertraits []string
+	for _, base := range i.Extends {
+		if name, _ := splitTypeArguments(base); rg.traits[name] {
+			supertraits = append(supertraits, rg.traitName(base))
+		}
+	}
+
+	var sb strings.Builder
+	_, rejected := classBases(i.Extends, rg.classes)
+	for _, class := range rejected {
+		sb.WriteString("// " + i.Name + " étend la classe " + class + " : " + interfaceExtendsClass[Rust] + "\n")
+	}
+	sb.WriteString(rg.visibility(i.Name) + "trait " + i.Name + rg.typeParameters(i.TypeParameters, true))
+	if len(supertraits) > 0 {
+		sb.WriteString(": " + strings.Join(supertraits, " + "))
+	}
+	sb.WriteString(" {\n")
+	for _, field := range i.Fields {
+		if !field.IsMethod {
+			returnType := rg.mapType(field.Type)
+			if field.Optional {
+				returnType = "Option<" + returnType + ">"
+			}
+			sb.WriteString("    fn " + field.Name + "(&self) -> " + returnType + ";\n")
+			continue
+		}
+		restore := declareTypeParameters(rg.typeNames, field.TypeParameters)
+		params := "&self"
+		if len(field.Parameters) > 0 {
+			params += ", " + rg.generateParameters(field.Parameters)
+		}
+		sb.WriteString("    fn " + field.Name + rg.typeParameters(field.TypeParameters, false) + "(" + params + ")")
+		if returnType := rg.mapType(field.ReturnType); returnType != "" {
+			sb.WriteString(" -> " + returnType)
+		}
+		sb.WriteString(";\n")
+		restore()
+	}
+	sb.WriteString("}\n\n")
+	sb.WriteString(rg.generateBoxedTrait(i))
+	return sb.String()
+}
+
+// generateBoxedTrait implémente le trait d'une interface qui sert de borne
+// pour Box<dyn Trait>, en déléguant chaque méthode à l'objet boxé :
+// identity::<Box<dyn Base>>(x) remplit alors T: Base. Un trait générique,
+// à supertraits ou à méthodes génériques n'est pas concerné.
+func (rg *RustGenerator) generateBoxedTrait(i *ast.Interface) string {
+	if !rg.bounds[i.Name] || len(i.TypeParameters) > 0 || len(i.Extends) > 0 {
+		return ""
+	}
+	var methods []string
+	for _, field := range i.Fields {
+		if !field.IsMethod {
+			returnType := rg.mapType(field.Type)
+			if field.Optional {
+				returnType = "Option<" + returnType + ">"
+			}
+			methods = append(methods, "    fn "+field.Name+"(&self) -> "+returnType+" {\n        (**self)."+field.Name+"()\n    }\n")
+			continue
+		}
+		if len(field.TypeParameters) > 0 {
+			return ""
+		}
+		params := "&self"
+		var names []string
+		if len(field.Parameters) > 0 {
+			params += ", " + rg.generateParameters(field.Parameters)
+		}
+		for _, param := range field.Parameters {
+			names = append(names, param.Name)
+		}
+		method := "    fn " + field.Name + "(" + params + ")"
+		if returnType := rg.mapType(field.ReturnType); returnType != "" {
+			method += " -> " + returnType
+		}
+		method += " {\n        (**self)." + field.Name + "(" + strings.Join(names, ", ") + ")\n    }\n"
+		methods = append(methods, method)
+	}
+	return "impl " + i.Name + " for Box<dyn " + i.Name + "> {\n" + strings.Join(methods, "\n") + "}\n\n"
+}
+
+// GenerateClass génère une struct et son bloc impl. Le constructeur devient
+// new() : les affectations this.x = ... en tête de corps initialisent la
+// struct, les champs restants prennent leur valeur par défaut. Les champs
+// statiques deviennent des constantes associées.
+//
+// Rust n'a pas d'héritage : la classe parente est un champ base, et la
+// liaison dynamique passe par des traits. Une classe à sous-classes déclare le
+// trait ShapeMethods des méthodes qu'elle introduit ; chaque classe concrète
+// de la hiérarchie l'implémente avec le corps de la méthode la plus proche,
+// repris pour que self y désigne l'objet réel.
+func (rg *RustGenerator) GenerateClass(cd *ast.ClassDeclaration) string {
+	defer declareTypeParameters(rg.typeNames, cd.TypeParameters)()
+	cd = withParameterProperties(withImplicitConstructor(cd, rg.classes))
+	dispatched := dispatchedClass(cd, rg.classes)
+
+	instance := cd.Name + rg.typeParameterNames(cd.TypeParameters)
+
+	var sb strings.Builder
+	_, rejected := classBases(cd.Implements, rg.classes)
+	sb.WriteString(implementedClassComments(cd.Name, rejected, Rust, ""))
+	sb.WriteString(rg.visibility(cd.Name) + "struct " + cd.Name + rg.typeParameters(cd.TypeParameters, true) + " {\n")
+	if cd.SuperClass != "" {
+		// Rust n'a pas d'héritage : la classe parente est un champ base
+		sb.WriteString("    base: " + rg.structType(cd.SuperClass) + ",\n")
+	}
+	for _, field := range cd.Fields {
+		if !field.IsStatic {
+			sb.WriteString("    " + field.Name + ": " + rg.fieldType(field) + ",\n")
+		}
+	}
+	sb.WriteString("}\n\n")
+
+	// Les traits de la hiérarchie sont générés d'abord : leurs corps
+	// demandent les méthodes reprises pour super.m()
+	rg.superMethods, rg.superNames = nil, map[string]bool{}
+	var traits strings.Builder
+	if polymorphicClass(cd, rg.classes) {
+		traits.WriteString(rg.generateMethodsTrait(cd))
+	}
+	if dispatched && !cd.IsAbstract {
+		traits.WriteString(rg.generateMethodsImpls(cd))
+	}
+
+	sb.WriteString("impl" + rg.typeParameters(cd.TypeParameters, false) + " " + instance + " {\n")
+
+	var members []string
+	var constants strings.Builder
+	for _, field := range cd.Fields {
+		if field.IsStatic && field.HasDefault {
+			constants.WriteString("    const " + strings.ToUpper(field.Name) + ": " + rg.fieldType(field) + " = " + rg.GenerateExpression(field.Default) + ";\n")
+		}
+	}
+	if constants.Len() > 0 {
+		members = append(members, constants.String())
+	}
+
+	members = append(members, rg.generateConstructor(cd))
+
+	// Accesseurs : get total() -> total(&self), set total(v) -> set_total(&mut self, v)
+	getter := func(name string) string { return name }
+	setter := func(name string) string { return "set_" + name }
+
+	accessors := accessorMethods(cd, getter, setter)
+	methods := append(append([]ast.ClassMethod{}, accessors...), regularMethods(cd)...)
+	inherited := withInheritedMethods(cd, methods, rg.classes)
+	if dispatched {
+		// Les méthodes liées dynamiquement sont dans les traits de la
+		// hiérarchie ; les accesseurs, absents des traits, restent ici
+		inherited = nil
+		for _, method := range accessors {
+			inherited = append(inherited, inheritedMethod{Owner: cd, Method: method})
+		}
+		for _, method := range regularMethods(cd) {
+			if !dispatchedMethod(method) {
+				inherited = append(inherited, inheritedMethod{Owner: cd, Method: method})
+			}
+		}
+	}
+
+	var iterators strings.Builder
+	for _, inherited := range inherited {
+		method := inherited.Method
+		restore := declareTypeParameters(rg.typeNames, method.TypeParameters)
+		rg.selfName = "self"
+		rg.superDepth = inherited.Depth
+
+		if method.IsGenerator {
+			// Le générateur renvoie une struct itérateur qui emprunte l'objet
+			declaration, init := rg.generateIterator(iteratorName(cd.Name, method.Name), method.Parameters, method.Body, yieldedReturn(rg.types, &method, method.ReturnType), cd, !method.IsStatic)
+			iterators.WriteString(declaration)
+			var mb strings.Builder
+			var params []string
+			if !method.IsStatic {
+				params = append(params, "&self")
+			}
+			if len(method.Parameters) > 0 {
+				params = append(params, rg.generateParameters(method.Parameters))
+			}
+			iteratorType := iteratorName(cd.Name, method.Name)
+			if !method.IsStatic {
+				iteratorType += "<'_" + strings.TrimPrefix(rg.typeParameterNames(cd.TypeParameters), "<")
+				if !strings.HasSuffix(iteratorType, ">") {
+					iteratorType += ">"
+				}
+			} else {
+				iteratorType += rg.typeParameterNames(cd.TypeParameters)
+			}
+			mb.WriteString("    fn " + method.Name + "(" + strings.Join(params, ", ") + ") -> " + iteratorType + " {\n")
+			mb.WriteString(rg.generateDefaults(method.Parameters, "        "))
+			mb.WriteString("        " + init + "\n")
+			mb.WriteString("    }\n")
+			members = append(members, mb.String())
+			rg.selfName = ""
+			restore()
+			continue
+		}
+
+		receiver := ""
+		if !method.IsStatic {
+			receiver = selfReceiver(mutatesThis(method.Body))
+		}
+		var mb strings.Builder
+		if inherited.Owner != cd {
+			// Rust n'a pas de liaison dynamique : la méthode héritée est reprise
+			// pour que ses appels sur self visent les méthodes de la sous-classe
+			mb.WriteString("    // Reprise de " + inherited.Owner.Name + "." + method.Name + "\n")
+		}
+		mb.WriteString(rg.generateMethod(cd.Name, method, method.Name, receiver))
+		members = append(members, mb.String())
+
+		rg.selfName = ""
+		restore()
+	}
+	rg.superDepth = 0
+	members = append(members, rg.generateSuperMethods()...)
+
+	sb.WriteString(strings.Join(members, "\n"))
+	sb.WriteString("}\n\n")
+	sb.WriteString(rg.generateDeref(cd))
+	sb.WriteString(traits.String())
+	sb.WriteString(rg.generateTraitImpls(cd))
+	sb.WriteString(iterators.String())
+
+	// Rust n'exécute pas de code à la définition d'un type, et ses constantes
+	// associées ne se modifient pas : les blocs static restent en commentaire
+	if statics := staticBlockStatements(cd); len(statics) > 0 {
+		sb.WriteString("// static { ... } de " + cd.Name + " : pas d'initialisation de type en Rust\n")
+		for _, stmt := range statics {
+			sb.WriteString(indentLines(rg.GenerateStatement(stmt, ""), "// "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// mutableBinding indique si une variable est déclarée let mut : elle est
+// réaffectée, un de ses champs l'est (t.v = 2, ou t.set_v(2.0) pour un
+// accesseur), ou l'un de ses appels de méthode emprunte &mut self
+func (rg *RustGenerator) mutableBinding(vd *ast.VariableDeclaration) bool {
+	if rg.types == nil {
+		return false
+	}
+	if rg.types.Assigned(vd) {
+		return true
+	}
+	for _, use := range rg.types.Receivers(vd) {
+		call, ok := use.(*ast.CallExpression)
+		if !ok {
+			return true
+		}
+		callee := call.Function.(*ast.DotExpression)
+		if method, ok := rg.types.Member(callee).(*ast.ClassMethod); ok {
+			if rg.mutatingMethod(method) {
+				return true
+			}
+			continue
+		}
+		if rustMutatingMethods[callee.Property] {
+			return true
+		}
+	}
+	return false
+}
+
+// rustMutatingMethods sont les méthodes des tableaux qui modifient un Vec :
+// xs.push(x) demande un let mut
+var rustMutatingMethods = map[string]bool{"push": true, "pop": true, "sort": true, "reverse": true, "fill": true, "splice": true}
+
+// mutatingMethod indique si une méthode de classe prend &mut self : elle
+// modifie this, ou l'une des redéfinitions de sa hiérarchie le fait
+func (rg *RustGenerator) mutatingMethod(method *ast.ClassMethod) bool {
+	if method.Kind == ast.Setter || mutatesThis(method.Body) {
+		return true
+	}
+	for _, cd := range rg.classes {
+		if findMethod(cd, method.Name) != method || !dispatchedClass(cd, rg.classes) {
+			continue
+		}
+		if introducer := methodIntroducer(cd, method.Name, rg.classes); introducer != nil {
+			return rg.dispatchedReceiver(introducer, method.Name) == selfReceiver(true)
+		}
+	}
+	return false
+}
+
+// selfReceiver renvoie le receveur d'une méthode d'instance : &mut self si
+// elle modifie un champ de this
+func selfReceiver(mutates bool) string {
+	if mutates {
+		return "&mut self"
+	}
+	return "&self"
+}
+
+// generateMethod génère une méthode d'un bloc impl sous le nom name ; une
+// méthode abstraite, qu'aucune classe n'implémente, échoue à l'appel
+func (rg *RustGenerator) generateMethod(owner string, method ast.ClassMethod, name, receiver string) string {
+	var params []string
+	if receiver != "" {
+		params = append(params, receiver)
+	}
+	if len(method.Parameters) > 0 {
+		params = append(params, rg.generateParameters(method.Parameters))
+	}
+
+	var mb strings.Builder
+	mb.WriteString("    " + asyncPrefix(method.IsAsync) + "fn " + name + rg.typeParameters(method.TypeParameters, false) + "(" + strings.Join(params, ", ") + ")")
+	if returnType := rg.mapType(typedReturn(rg.types, &method, method.ReturnType, false)); returnType != "" {
+		mb.WriteString(" -> " + returnType)
+	}
+	mb.WriteString(" {\n")
+	if method.IsAbstract {
+		mb.WriteString("        unimplemented!(\"" + owner + "." + method.Name + " est abstraite\")\n")
+	} else {
+		mb.WriteString(rg.generateDefaults(method.Parameters, "        "))
+		for _, stmt := range method.Body {
+			mb.WriteString(rg.GenerateStatement(stmt, "        "))
+		}
+	}
+	mb.WriteString("    }\n")
+	return mb.String()
+}
+
+// classAccessor nomme la méthode d'un trait de hiérarchie qui renvoie la
+// partie Shape d'un objet : as_shape
+func classAccessor(class string) string {
+	return "as_" + strings.ToLower(class)
+}
+
+// dispatchedReceiver renvoie le receveur d'une méthode liée dynamiquement :
+// &mut self si l'une de ses implémentations dans la hiérarchie modifie this
+func (rg *RustGenerator) dispatchedReceiver(introducer *ast.ClassDeclaration, name string) string {
+	for _, cd := range rg.classes {
+		if method := findMethod(cd, name); method != nil && methodIntroducer(cd, name, rg.classes) == introducer && mutatesThis(method.Body) {
+			return selfReceiver(true)
+		}
+	}
+	return selfReceiver(false)
+}
+
+// generateMethodsTrait génère le trait des méthodes qu'introduit une classe à
+// sous-classes, qui étend celui de sa parente. Son accesseur as_shape donne
+// la partie Shape de l'objet, et un objet trait se déréférence vers elle :
+// shape.name se lit sur un Box<dyn ShapeMethods>.
+func (rg *RustGenerator) generateMethodsTrait(cd *ast.ClassDeclaration) string {
+	trait := methodsInterface(cd.Name)
+
+	var sb strings.Builder
+	sb.WriteString("// " + trait + " : méthodes de " + cd.Name + " et de ses sous-classes\n")
+	sb.WriteString(rg.visibility(cd.Name) + "trait " + trait)
+	if parent := rg.classes[superClassName(cd)]; parent != nil {
+		sb.WriteString(": " + methodsInterface(parent.Name))
+	}
+	sb.WriteString(" {\n")
+	sb.WriteString("    fn " + classAccessor(cd.Name) + "(&self) -> &" + cd.Name + ";\n")
+	for _, method := range introducedMethods(cd, rg.classes) {
+		params := []string{rg.dispatchedReceiver(cd, method.Name)}
+		if len(method.Parameters) > 0 {
+			params = append(params, rg.generateParameters(method.Parameters))
+		}
+		sb.WriteString("    " + asyncPrefix(method.IsAsync) + "fn " + method.Name + "(" + strings.Join(params, ", ") + ")")
+		if returnType := rg.mapType(typedReturn(rg.types, &method, method.ReturnType, false)); returnType != "" {
+			sb.WriteString(" -> " + returnType)
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("impl std::ops::Deref for dyn " + trait + " {\n")
+	sb.WriteString("    type Target = " + cd.Name + ";\n\n")
+	sb.WriteString("    fn deref(&self) -> &" + cd.Name + " {\n")
+	sb.WriteString("        self." + classAccessor(cd.Name) + "()\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// generateMethodsImpls implémente pour une classe concrète les traits de sa
+// hiérarchie, de la racine à elle-même. Chaque méthode reprend le corps de la
+// plus proche classe qui l'implémente : self y est l'objet réel, et
+// self.area() y appelle l'area() de la sous-classe.
+func (rg *RustGenerator) generateMethodsImpls(cd *ast.ClassDeclaration) string {
+	var chain []*ast.ClassDeclaration
+	for _, class := range append([]*ast.ClassDeclaration{cd}, ancestors(cd, rg.classes)...) {
+		if polymorphicClass(class, rg.classes) {
+			chain = append([]*ast.ClassDeclaration{class}, chain...)
+		}
+	}
+
+	var sb strings.Builder
+	for _, class := range chain {
+		members := []string{"    fn " + classAccessor(class.Name) + "(&self) -> &" + class.Name + " {\n        self\n    }\n"}
+		for _, introduced := range introducedMethods(class, rg.classes) {
+			owner, method := methodImplementation(cd, introduced.Name, rg.classes)
+			if owner == nil {
+				owner, method = class, &introduced
+			}
+			var mb strings.Builder
+			if owner != cd {
+				mb.WriteString("    // Reprise de " + owner.Name + "." + method.Name + "\n")
+			}
+			rg.selfName, rg.superOwner = "self", owner
+			mb.WriteString(rg.generateMethod(owner.Name, *method, method.Name, rg.dispatchedReceiver(class, method.Name)))
+			rg.selfName, rg.superOwner = "", nil
+			members = append(members, mb.String())
+		}
+		sb.WriteString("impl " + methodsInterface(class.Name) + " for " + cd.Name + " {\n")
+		sb.WriteString(strings.Join(members, "\n"))
+		sb.WriteString("}\n\n")
+	}
+	return sb.String()
+}
+
+// superMethod renvoie le nom de la méthode reprise qu'appelle super.name()
+// dans un corps de la classe rg.superOwner : le corps de la parente y est
+// repris pour l'objet réel, sous le nom shape_describe ; "" si la parente
+// n'implémente pas la méthode
+func (rg *RustGenerator) superMethod(name string) string {
+	parent := rg.classes[superClassName(rg.superOwner)]
+	if parent == nil {
+		return ""
+	}
+	owner, method := methodImplementation(parent, name, rg.classes)
+	if owner == nil || !dispatchedMethod(*method) {
+		return ""
+	}
+	reprise := superMethodName(owner, name)
+	if !rg.superNames[reprise] {
+		rg.superNames[reprise] = true
+		rg.superMethods = append(rg.superMethods, inheritedMethod{Owner: owner, Method: *method})
+	}
+	return reprise
+}
+
+// superMethodName nomme la reprise d'une méthode de la classe owner : shape_describe
+func superMethodName(owner *ast.ClassDeclaration, name string) string {
+	return strings.ToLower(owner.Name) + "_" + name
+}
+
+// generateSuperMethods génère les méthodes reprises demandées par les appels
+// super.m(), et celles que leurs propres corps demandent
+func (rg *RustGenerator) generateSuperMethods() []string {
+	var members []string
+	for len(rg.superMethods) > 0 {
+		reprise := rg.superMethods[0]
+		rg.superMethods = rg.superMethods[1:]
+		method := reprise.Method
+		rg.selfName, rg.superOwner = "self", reprise.Owner
+		members = append(members, "    // super."+method.Name+"() : reprise de "+reprise.Owner.Name+"."+method.Name+"\n"+
+			rg.generateMethod(reprise.Owner.Name, method, superMethodName(reprise.Owner, method.Name), selfReceiver(mutatesThis(method.Body))))
+		rg.selfName, rg.superOwner = "", nil
+	}
+	return members
+}
+
+// generateDeref rend les champs et méthodes de la classe parente accessibles
+// depuis la sous-classe : self.name et rect.describe() passent par self.base
+func (rg *RustGenerator) generateDeref(cd *ast.ClassDeclaration) string {
+	if cd.SuperClass == "" {
+		return ""
+	}
+	generics := rg.typeParameters(cd.TypeParameters, false)
+	instance := cd.Name + rg.typeParameterNames(cd.TypeParameters)
+	base := rg.structType(cd.SuperClass)
+
+	var sb strings.Builder
+	sb.WriteString("impl" + generics + " std::ops::Deref for " + instance + " {\n")
+	sb.WriteString("    type Target = " + base + ";\n\n")
+	sb.WriteString("    fn deref(&self) -> &" + base + " {\n")
+	sb.WriteString("        &self.base\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("impl" + generics + " std::ops::DerefMut for " + instance + " {\n")
+	sb.WriteString("    fn deref_mut(&mut self) -> &mut " + base + " {\n")
+	sb.WriteString("        &mut self.base\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// generateTraitImpls implémente les traits des interfaces de la classe, et
+// ceux qu'ils étendent : les méthodes délèguent aux méthodes de la classe, les
+// propriétés renvoient une copie du champ. Dans une hiérarchie liée
+// dynamiquement, les méthodes n'existent que dans les traits des classes
+// concrètes : chacune implémente aussi les interfaces de ses parentes.
+func (rg *RustGenerator) generateTraitImpls(cd *ast.ClassDeclaration) string {
+	dispatched := dispatchedClass(cd, rg.classes)
+	implemented := cd.Implements
+	if dispatched {
+		if cd.IsAbstract {
+			return ""
+		}
+		implemented = implementedInterfaces(cd, rg.classes)
+	}
+
+	var sb strings.Builder
+	seen := map[string]bool{}
+	var implement func(types []string)
+	implement = func(types []string) {
+		for _, t := range types {
+			name, _ := splitTypeArguments(t)
+			i := rg.interfaces[name]
+			if i == nil || seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			sb.WriteString("impl" + rg.typeParameters(cd.TypeParameters, false) + " " + rg.traitName(t) + " for " + cd.Name + rg.typeParameterNames(cd.TypeParameters) + " {\n")
+			var members []string
+			for _, field := range i.Fields {
+				if !field.IsMethod {
+					members = append(members, "    fn "+field.Name+"(&self) -> "+rg.mapType(field.Type)+" {\n        self."+field.Name+".clone()\n    }\n")
+					continue
+				}
+				params := "&self"
+				var args []string
+				for _, param := range field.Parameters {
+					args = append(args, param.Name)
+				}
+				if len(field.Parameters) > 0 {
+					params += ", " + rg.generateParameters(field.Parameters)
+				}
+				var mb strings.Builder
+				mb.WriteString("    fn " + field.Name + "(" + params + ")")
+				if returnType := rg.mapType(field.ReturnType); returnType != "" {
+					mb.WriteString(" -> " + returnType)
+				}
+				if introducer := methodIntroducer(cd, field.Name, rg.classes); dispatched && introducer != nil {
+					// Deux traits nomment la méthode : l'appel désigne celui de la hiérarchie
+					mb.WriteString(" {\n        " + methodsInterface(introducer.Name) + "::" + field.Name + "(" + strings.Join(append([]string{"self"}, args...), ", ") + ")\n    }\n")
+				} else {
+					// Une méthode propre a priorité sur celle du trait
+					mb.WriteString(" {\n        self." + field.Name + "(" + strings.Join(args, ", ") + ")\n    }\n")
+				}
+				members = append(members, mb.String())
+			}
+			sb.WriteString(strings.Join(members, "\n"))
+			sb.WriteString("}\n\n")
+			implement(i.Extends)
+		}
+	}
+	implement(implemented)
+	return sb.String()
+}
+
+// traitName nomme un trait avec ses arguments de type : Repository<User>
+func (rg *RustGenerator) traitName(t string) string {
+	name, args := splitTypeArguments(t)
+	return name + rg.typeArguments(args)
+}
+
+func (rg *RustGenerator) generateConstructor(cd *ast.ClassDeclaration) string {
+	rg.selfName = "this"
+	defer func() { rg.selfName = "" }()
+
+	before, call, body := splitSuperCall(constructorBody(cd))
+	params := constructorParameters(cd)
+
+	// Valeurs initiales : affectations de tête, puis défaut du type
+	initial := map[string]ast.Expression{}
+	for len(body) > 0 {
+		name, value := thisFieldAssignment(body[0])
+		if name == "" || !hasField(cd, name) {
+			// Un champ hérité s'affecte une fois la struct construite
+			break
+		}
+		initial[name] = value
+		body = body[1:]
+	}
+
+	var fields []string
+	if call != nil {
+		// super(args) initialise le champ base : base: Shape::new(args)
+		name, args := splitTypeArguments(cd.SuperClass)
+		fields = append(fields, "base: "+rg.GenerateExpression(&ast.NewExpression{Class: &ast.Identifier{Value: name}, TypeArguments: args, Arguments: call.Arguments}))
+	} else if cd.SuperClass != "" {
+		fields = append(fields, "base: Default::default()")
+	}
+	for _, field := range cd.Fields {
+		if field.IsStatic {
+			continue
+		}
+		if value, ok := initial[field.Name]; ok {
+			fields = append(fields, field.Name+": "+rg.fieldValue(field, value))
+		} else {
+			fields = append(fields, field.Name+": Default::default()")
+		}
+	}
+	init := "Self { " + strings.Join(fields, ", ") + " }"
+	if len(fields) == 0 {
+		init = "Self {}"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("    fn new(" + rg.generateParameters(params) + ") -> Self {\n")
+	sb.WriteString(rg.generateDefaults(params, "        "))
+	for _, stmt := range before {
+		sb.WriteString(rg.GenerateStatement(stmt, "        "))
+	}
+	if len(body) == 0 {
+		sb.WriteString("        " + init + "\n")
+	} else {
+		sb.WriteString("        let mut this = " + init + ";\n")
+		for _, stmt := range body {
+			sb.WriteString(rg.GenerateStatement(stmt, "        "))
+		}
+		sb.WriteString("        this\n")
+	}
+	sb.WriteString("    }\n")
+	return sb.String()
+}
+
+// fieldValue génère la valeur affectée à un champ : une chaîne littérale est
+// convertie quand le champ est un String
+func (rg *RustGenerator) fieldValue(field ast.ClassField, value ast.Expression) string {
+	if sl, ok := value.(*ast.StringLiteral); ok && rg.literals[sl] == "" && rg.fieldType(field) == "String" {
+		return rg.GenerateExpression(value) + ".to_string()"
+	}
+	return rg.GenerateExpression(value)
+}
+
+// assignedField renvoie la déclaration du champ de classe désigné par une
+// cible d'affectation obj.name, ou nil
+func (rg *RustGenerator) assignedField(target ast.Expression) *ast.ClassField {
+	de, ok := target.(*ast.DotExpression)
+	if !ok || rg.types == nil {
+		return nil
+	}
+	object, ok := rg.types.Expression(de.Object).(*semantic.Object)
+	if !ok || rg.classes[object.Name] == nil {
+		return nil
+	}
+	owner := fieldOwner(rg.classes[object.Name], de.Property, rg.classes)
+	if owner == nil {
+		return nil
+	}
+	for _, field := range withParameterProperties(owner).Fields {
+		if !field.IsStatic && field.Name == de.Property {
+			return &field
+		}
+	}
+	return nil
+}
+
+// typeParameterNames génère <T, U> pour nommer le type dans son bloc impl
+func (rg *RustGenerator) typeParameterNames(params []ast.TypeParameter) string {
+	return typeParameterList(params)
+}
+
+// fieldType renvoie le type Rust d'un champ, déduit de sa valeur s'il n'est pas annoté
+func (rg *RustGenerator) fieldType(field ast.ClassField) string {
+	if _, ok := field.Default.(*ast.StringLiteral); ok && field.Type == "" {
+		return "&'static str"
+	}
+	if rustType := rg.mapType(typed(rg.types, &field, field.Type)); rustType != "" {
+		return rustType
+	}
+	return "Box<dyn std::any::Any>"
+}
+
+func (rg *RustGenerator) GenerateFunction(fd *ast.FunctionDeclaration) string {
+	defer declareTypeParameters(rg.typeNames, fd.TypeParameters)()
+
+	var sb strings.Builder
+
+	returnType := rg.mapType(typedReturn(rg.types, fd, fd.ReturnType, fd.IsGenerator))
+	var init string
+	if fd.IsGenerator {
+		// Le générateur renvoie sa struct itérateur
+		var declaration string
+		yielded := yieldedReturn(rg.types, fd, fd.ReturnType)
+		declaration, init = rg.generateIterator(iteratorName("", fd.Name), fd.Parameters, fd.Body, yielded, nil, false)
+		sb.WriteString(declaration)
+		returnType = "impl Iterator<Item = " + rg.iteratorItem(yielded) + ">"
+	}
+
+	sb.WriteString(rg.visibility(fd.Name) + asyncPrefix(fd.IsAsync) + "fn ")
+	sb.WriteString(rg.functionName(fd.Name))
+	sb.WriteString(rg.typeParameters(fd.TypeParameters, false))
+	sb.WriteString("(")
+	sb.WriteString(rg.generateParameters(fd.Parameters))
+	sb.WriteString(")")
+	if returnType != "" {
+		sb.WriteString(" -> " + returnType)
+	}
+	sb.WriteString(" {\n")
+
+	sb.WriteString(rg.generateDefaults(fd.Parameters, "    "))
+
+	if fd.IsGenerator {
+		sb.WriteString("    " + init + "\n")
+	}
+	for _, stmt := range fd.Body {
+		if fd.IsGenerator {
+			break
+		}
+		sb.WriteString(rg.GenerateStatement(stmt, "    "))
+	}
+
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// iteratorElement renvoie le type Rust des valeurs produites par un générateur
+func (rg *RustGenerator) iteratorElement(t string) string {
+	if element := rg.mapType(t); element != "" {
+		return element
+	}
+	return "()"
+}
+
+// iteratorItem renvoie le type Item de l'itérateur d'un générateur
+func (rg *RustGenerator) iteratorItem(returnType string) string {
+	if inner, ok := yieldedType(returnType); ok {
+		return rg.iteratorElement(inner)
+	}
+	return "Box<dyn std::any::Any>"
+}
+
+// generateIterator génère la struct itérateur d'un générateur et son impl
+// Iterator, et renvoie aussi l'expression qui la construit. Rust n'a pas de
+// yield stable : le corps découpé en états (voir lowerGenerator) devient la
+// méthode next, les paramètres et variables locales des champs. L'itérateur
+// d'une méthode emprunte l'objet dans le champ this.
+func (rg *RustGenerator) generateIterator(name string, params []ast.Parameter, body []ast.Statement, returnType string, owner *ast.ClassDeclaration, borrowsOwner bool) (string, string) {
+	m := lowerGenerator(body)
+	item := rg.iteratorItem(returnType)
+
+	rg.iteratorFields = map[string]bool{}
+	previousSelf := rg.selfName
+	defer func() {
+		rg.iteratorFields = nil
+		rg.selfName = previousSelf
+	}()
+
+	// Paramètres de type de l'itérateur : durée de l'emprunt, puis ceux de la classe
+	var generics []string
+	if borrowsOwner {
+		generics = append(generics, "'a")
+	}
+	if owner != nil {
+		for _, param := range owner.TypeParameters {
+			generics = append(generics, param.Name)
+		}
+	}
+	typeParams := ""
+	if len(generics) > 0 {
+		typeParams = "<" + strings.Join(generics, ", ") + ">"
+	}
+
+	var fields, inits []string
+	fields = append(fields, "state: i32")
+	inits = append(inits, "state: 0")
+	if borrowsOwner {
+		fields = append(fields, "this: &'a "+owner.Name+rg.typeParameterNames(owner.TypeParameters))
+		inits = append(inits, "this: self")
+		rg.selfName = "self.this"
+	}
+	for _, param := range params {
+		rg.iteratorFields[param.Name] = true
+		fieldType := rg.mapType(param.Type)
+		if param.IsRest {
+			fieldType = "Vec<" + rg.mapType(elementType(param.Type)) + ">"
+			inits = append(inits, param.Name+": "+param.Name+".to_vec()")
+		} else {
+			inits = append(inits, param.Name)
+		}
+		fields = append(fields, param.Name+": "+fieldType)
+	}
+	for _, local := range m.Locals {
+		if rg.iteratorFields[local.Name] {
+			continue
+		}
+		rg.iteratorFields[local.Name] = true
+		fields = append(fields, local.Name+": "+rg.localType(local, params))
+		inits = append(inits, local.Name+": Default::default()")
+	}
+	for i := 0; i < m.Delegates; i++ {
+		delegate := "delegate" + strconv.Itoa(i)
+		fields = append(fields, delegate+": Option<Box<dyn Iterator<Item = "+item+">"+rg.delegateBound(borrowsOwner)+">>")
+		inits = append(inits, delegate+": None")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("struct " + name + typeParams + " {\n")
+	for _, field := range fields {
+		sb.WriteString("    " + field + ",\n")
+	}
+	sb.WriteString("}\n\n")
+
+	sb.WriteString("impl" + typeParams + " Iterator for " + name + typeParams + " {\n")
+	sb.WriteString("    type Item = " + item + ";\n\n")
+	sb.WriteString("    fn next(&mut self) -> Option<" + item + "> {\n")
+	sb.WriteString("        loop {\n")
+	sb.WriteString("            match self.state {\n")
+	reachable := m.reachable()
+	for i, state := range m.States {
+		if !reachable[i] {
+			continue
+		}
+		var code strings.Builder
+		for _, stmt := range state.Statements {
+			code.WriteString(rg.GenerateStatement(stmt, ""))
+		}
+		code.WriteString(rg.generateExit(state.Exit, item))
+		sb.WriteString("                " + strconv.Itoa(i) + " => {\n")
+		sb.WriteString(indentLines(code.String(), "                    "))
+		sb.WriteString("                }\n")
+	}
+	sb.WriteString("                _ => return None,\n")
+	sb.WriteString("            }\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("}\n\n")
+
+	return sb.String(), name + " { " + strings.Join(inits, ", ") + " }"
+}
+
+// delegateBound borne la durée de vie d'un itérateur délégué à celle de l'emprunt
+func (rg *RustGenerator) delegateBound(borrowsOwner bool) string {
+	if borrowsOwner {
+		return " + 'a"
+	}
+	return ""
+}
+
+// localType renvoie le type du champ qui porte une variable locale d'un
+// générateur ; une variable initialisée par un paramètre en prend le type
+func (rg *RustGenerator) localType(vd *ast.VariableDeclaration, params []ast.Parameter) string {
+	if vd.Type != "" {
+		return rg.mapType(vd.Type)
+	}
+	switch value := vd.Value.(type) {
+	case *ast.Identifier:
+		for _, param := range params {
+			if param.Name == value.Value && param.Type != "" {
+				return rg.mapType(param.Type)
+			}
+		}
+	case *ast.StringLiteral:
+		return "&'static str"
+	case *ast.NumberLiteral:
+		return rg.mapType(variableType(rg.types, vd))
+	case *ast.BooleanLiteral:
+		return "bool"
+	}
+	return "i32"
+}
+
+// generateExit génère la sortie d'un état de la machine d'un générateur ; une
+// chaîne littérale produite devient un String si l'Item en est un
+func (rg *RustGenerator) generateExit(exit generatorExit, item string) string {
+	target := strconv.Itoa(exit.Target)
+	delegate := "self.delegate" + strconv.Itoa(exit.Delegate)
+	switch exit.Kind {
+	case exitJump:
+		return "self.state = " + target + ";\n"
+	case exitBranch:
+		return "self.state = if " + rg.GenerateExpression(exit.Condition) + " { " + target + " } else { " + strconv.Itoa(exit.Else) + " };\n"
+	case exitYield:
+		value := "()"
+		if exit.Value != nil {
+			value = rg.GenerateExpression(exit.Value)
+		}
+		if sl, ok := exit.Value.(*ast.StringLiteral); ok && rg.literals[sl] == "" && item == "String" {
+			value += ".to_string()"
+		}
+		return "self.state = " + target + ";\nreturn Some(" + value + ");\n"
+	case exitDelegate:
+		return delegate + " = Some(Box::new(" + rg.GenerateExpression(exit.Value) + "));\nself.state = " + target + ";\n"
+	case exitDrain:
+		return "if let Some(value) = " + delegate + ".as_mut().and_then(|it| it.next()) {\n    return Some(value);\n}\nself.state = " + target + ";\n"
+	}
+	return "self.state = -1;\nreturn None;\n"
+}
+
+// generateParameters génère la liste des paramètres ; Rust n'a pas de
+// variadique, un paramètre rest devient une slice
+func (rg *RustGenerator) generateParameters(params []ast.Parameter) string {
+	var parts []string
+	for _, param := range typedParameters(rg.types, params) {
+		switch {
+		case param.IsRest:
+			parts = append(parts, param.Name+": &["+rg.mapType(elementType(param.Type))+"]")
+		case param.CanBeOmitted():
+			// Rust n'a pas de valeurs par défaut : Option<T>
+			parts = append(parts, param.Name+": Option<"+rg.mapType(param.Type)+">")
+		default:
+			parts = append(parts, param.Name+": "+rg.mapType(param.Type))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// generateDefaults applique les valeurs par défaut en tête de corps
+func (rg *RustGenerator) generateDefaults(params []ast.Parameter, indent string) string {
+	var sb strings.Builder
+	for _, param := range params {
+		if param.Default == nil || param.IsRest {
+			continue
+		}
+		if isLiteralExpression(param.Default) {
+			sb.WriteString(indent + "let " + param.Name + " = " + param.Name + ".unwrap_or(" + rg.GenerateExpression(param.Default) + ");\n")
+		} else {
+			sb.WriteString(indent + "let " + param.Name + " = " + param.Name + ".unwrap_or_else(|| " + rg.GenerateExpression(param.Default) + ");\n")
+		}
+	}
+	return sb.String()
+}
+
+func (rg *RustGenerator) GenerateStatement(stmt ast.Statement, indent string) string {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		return indentLines(rg.GenerateVariableDeclaration(s), indent)
+	case *ast.ReturnStatement:
+		if s.Value != nil {
+			return indentLines("return "+rg.GenerateExpression(s.Value)+";\n", indent)
+		}
+		return indent + "return;\n"
+	case *ast.ExpressionStatement:
+		return indentLines(rg.GenerateExpressionStatement(s), indent)
+	case *ast.WhileStatement:
+		return indent + "while " + rg.GenerateExpression(s.Condition) + " " + rg.GenerateBlock(s.Body, indent) + "\n"
+	case *ast.ForStatement:
+		// Rust n'a pas de for C : initialisation, puis while avec la mise à jour en fin de corps
+		var sb strings.Builder
+		if vd, ok := s.Init.(*ast.VariableDeclaration); ok {
+			sb.WriteString(indent + "let mut " + vd.Name + " = " + rg.GenerateExpression(vd.Value) + ";\n")
+		} else if s.Init != nil {
+			sb.WriteString(rg.GenerateStatement(s.Init, indent))
+		}
+		condition := "true"
+		if s.Condition != nil {
+			condition = rg.GenerateExpression(s.Condition)
+		}
+		body := blockStatements(s.Body)
+		if s.Update != nil {
+			body = append(append([]ast.Statement{}, body...), s.Update)
+		}
+		sb.WriteString(indent + "while " + condition + " " + rg.GenerateBlock(&ast.BlockStatement{Statements: body}, indent) + "\n")
+		return sb.String()
+	case *ast.IfStatement:
+		var sb strings.Builder
+		sb.WriteString(indent + "if " + rg.GenerateExpression(s.Condition) + " ")
+		sb.WriteString(rg.GenerateBlock(s.ThenBranch, indent))
+		if s.ElseBranch != nil {
+			sb.WriteString(" else ")
+			sb.WriteString(rg.GenerateBlock(s.ElseBranch, indent))
+		}
+		sb.WriteString("\n")
+		return sb.String()
+	}
+	return ""
+}
+
+func (rg *RustGenerator) GenerateBlock(stmt ast.Statement, indent string) string {
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	if blockStmt, ok := stmt.(*ast.BlockStatement); ok {
+		for _, inner := range blockStmt.Statements {
+			sb.WriteString(rg.GenerateStatement(inner, indent+"    "))
+		}
+	}
+	sb.WriteString(indent + "}")
+	return sb.String()
+}
+
+func (rg *RustGenerator) GenerateExpressionStatement(es *ast.ExpressionStatement) string {
+	// Convertir console.log en println!
+	if callExpr, ok := es.Expression.(*ast.CallExpression); ok && isConsoleLog(callExpr) {
+		var placeholders, args []string
+		for _, arg := range callExpr.Arguments {
+			placeholders = append(placeholders, "{:?}")
+			args = append(args, rg.GenerateExpression(arg))
+		}
+		if len(args) == 0 {
+			return "println!();\n"
+		}
+		return "println!(\"" + strings.Join(placeholders, " ") + "\", " + strings.Join(args, ", ") + ");\n"
+	}
+	return rg.GenerateExpression(es.Expression) + ";\n"
+}
+
+func (rg *RustGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+	declared := variableType(rg.types, vd)
+	vd = withoutErasedAssertion(vd)
+	var sb strings.Builder
+
+	// Sans valeur, la variable est initialisée plus loin
+	if vd.Value == nil {
+		if rustType := rg.mapType(declared); rustType != "" {
+			return "let " + vd.Name + ": " + rustType + ";\n"
+		}
+		return "let " + vd.Name + ";\n"
+	}
+
+	// Une closure a un type anonyme, déduit par le compilateur
+	if af, ok := vd.Value.(*ast.ArrowFunction); ok {
+		return "let " + vd.Name + " = " + rg.GenerateClosure(af) + ";\n"
+	}
+
+	// Une valeur any est une Box<dyn Any>, que value as T déballe
+	boxed := isAnyType(declared)
+
+	// Une const Rust doit être évaluable à la compilation ; une variable
+	// modifiée est mut
+	switch {
+	case vd.IsConst && isLiteralExpression(vd.Value) && !boxed:
+		sb.WriteString("const ")
+	case rg.mutableBinding(vd):
+		sb.WriteString("let mut ")
+	default:
+		sb.WriteString("let ")
+	}
+
+	sb.WriteString(vd.Name)
+	if boxed {
+		sb.WriteString(": Box<dyn std::any::Any> = " + rg.boxedAny(vd.Value) + ";\n")
+		return sb.String()
+	}
+
+	// Les nombres, booléens et chaînes littérales ont leur type écrit ; les
+	// autres types sont laissés à l'inférence de Rust
+	rustType := rg.mapType(declared)
+	if sl, ok := vd.Value.(*ast.StringLiteral); ok && rg.literals[sl] == "" {
+		rustType = "&str"
+	}
+	switch rustType {
+	case "&str", "i32", "f64", "bool":
+		sb.WriteString(": " + rustType)
+	default:
+		if alias := variableAlias(rg.types, vd); alias != "" && rustType == alias {
+			// Alias d'union de littéraux, généré comme un enum
+			sb.WriteString(": " + rustType)
+		}
+	}
+
+	sb.WriteString(" = ")
+
+	switch val := vd.Value.(type) {
+	case *ast.StringLiteral:
+		sb.WriteString(rg.GenerateStringLiteral(val))
+	case *ast.BooleanLiteral:
+		sb.WriteString(rg.GenerateBooleanLiteral(val))
+	default:
+		sb.WriteString(rg.GenerateExpression(val))
+	}
+
+	sb.WriteString(";\n")
+	return sb.String()
+}
+
+func (rg *RustGenerator) GenerateExpression(expr ast.Expression) string {
+	if rg.widened[expr] {
+		// Rust ne convertit pas un entier en f64 : 0 s'écrit 0.0
+		if nl, ok := expr.(*ast.NumberLiteral); ok && decimalInteger(nl.Value) {
+			return rg.GenerateNumberLiteral(nl) + ".0"
+		}
+		return "(" + rg.generateExpression(expr) + " as f64)"
+	}
+	return rg.generateExpression(expr)
+}
+
+func (rg *RustGenerator) generateExpression(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return rg.GenerateStringLiteral(e)
+	case *ast.NumberLiteral:
+		return rg.GenerateNumberLiteral(e)
+	case *ast.BooleanLiteral:
+		return rg.GenerateBooleanLiteral(e)
+	case *ast.Identifier:
+		if e.Value == "this" && rg.selfName != "" {
+			return rg.selfName
+		}
+		if rg.iteratorFields[e.Value] {
+			return "self." + e.Value
+		}
+		return rg.functionName(e.Value)
+	case *ast.ParenthesizedExpression:
+		return "(" + rg.GenerateExpression(e.Expression) + ")"
+	case *ast.InfixExpression:
+		return rg.GenerateExpression(e.Left) + " " + e.Operator + " " + rg.GenerateExpression(e.Right)
+	case *ast.ArrayLiteral:
+		return rg.GenerateArrayLiteral(e)
+	case *ast.ObjectLiteral:
+		return rg.GenerateObjectLiteral(e)
+	case *ast.CallExpression:
+		return rg.GenerateCallExpression(e)
+	case *ast.IndexExpression:
+		index := rg.GenerateExpression(e.Index)
+		if _, literal := e.Index.(*ast.NumberLiteral); !literal && isNumber(rg.types.Expression(e.Index)) {
+			// Un indice de Vec est un usize
+			index = castOperand(e.Index, index) + " as usize"
+		}
+		return rg.GenerateExpression(e.Left) + "[" + index + "]"
+	case *ast.DotExpression:
+		// self.#count -> self.count : un champ sans pub est privé au module
+		e = privateAccess(e, bareName)
+		if _, ok := namespaceAccess(e, rg.namespaces); ok {
+			// Geometry.area -> area : le namespace a été aplati
+			return rg.GenerateExpression(&ast.Identifier{Value: e.Property})
+		}
+		if ed, member := enumMemberAccess(rg.enums, e); member != nil {
+			return ed.Name + "::" + member.Name
+		}
+		if isSuperMember(e) && rg.superOwner != nil {
+			if reprise := rg.superMethod(e.Property); reprise != "" {
+				return rg.selfName + "." + reprise
+			}
+		}
+		if isSuperMember(e) {
+			// super.describe() -> self.base.describe()
+			return rg.selfName + strings.Repeat(".base", rg.superDepth+1) + "." + e.Property
+		}
+		if ident, ok := e.Object.(*ast.Identifier); ok && rg.classes[ident.Value] != nil {
+			return ident.Value + "::" + rg.staticMemberName(rg.classes[ident.Value], e.Property)
+		}
+		if rg.accessors[e.Property] || interfaceProperty(rg.types, e, rg.interfaces) {
+			// Propriété d'une interface, lue par l'accesseur de son trait
+			return rg.GenerateExpression(e.Object) + "." + e.Property + "()"
+		}
+		return rg.GenerateExpression(e.Object) + "." + e.Property
+	case *ast.SpreadElement:
+		// f(...xs) -> f(&xs) : le paramètre rest est une slice
+		return "&" + rg.GenerateExpression(e.Argument)
+	case *ast.AssignmentExpression:
+		if property, value := accessorAssignment(e, rg.accessors); property != nil {
+			return rg.GenerateExpression(property.Object) + ".set_" + property.Property + "(" + rg.GenerateExpression(value) + ")"
+		}
+		// Rust n'a pas de ++ / -- ; un f64 s'incrémente de 1.0
+		one := "1"
+		if isFloat(rg.types, e.Left) {
+			one = "1.0"
+		}
+		switch e.Operator {
+		case "++":
+			return rg.GenerateExpression(e.Left) + " += " + one
+		case "--":
+			return rg.GenerateExpression(e.Left) + " -= " + one
+		}
+		if field := rg.assignedField(e.Left); field != nil && e.Operator == "=" {
+			return rg.GenerateExpression(e.Left) + " = " + rg.fieldValue(*field, e.Right)
+		}
+		return rg.GenerateExpression(e.Left) + " " + e.Operator + " " + rg.GenerateExpression(e.Right)
+	case *ast.NewExpression:
+		// new Stack<number>() -> Stack::<i32>::new()
+		var params []ast.Parameter
+		if ident, ok := e.Class.(*ast.Identifier); ok && rg.classes[ident.Value] != nil {
+			if ctor := findConstructor(rg.classes[ident.Value]); ctor != nil {
+				params = ctor.Parameters
+			}
+		}
+		args := rg.arguments(e.Arguments, params)
+		class := rg.GenerateExpression(e.Class)
+		if class == "Map" && !rg.typeNames[class] {
+			// new Map<K, V>() -> HashMap::<K, V>::new()
+			rg.usesHashMap = true
+			class = "HashMap"
+		}
+		if len(e.TypeArguments) > 0 {
+			class += "::" + rg.typeArguments(e.TypeArguments)
+		}
+		return class + "::new(" + strings.Join(args, ", ") + ")"
+	case *ast.AwaitExpression:
+		return rg.GenerateExpression(e.Argument) + ".await"
+	case *ast.AsExpression:
+		operand := assertedOperand(e.Expression, rg.GenerateExpression)
+		if !castsTo(e.Type) {
+			return operand
+		}
+		t := rg.mapType(e.Type)
+		if source := rg.types.Expression(e.Expression); source == semantic.Any || source == semantic.Unknown {
+			return rg.downcast(operand, e.Type, t)
+		}
+		if isRustPrimitive(t) {
+			// Seules les conversions entre types primitifs s'écrivent avec as
+			return "(" + operand + " as " + t + ")"
+		}
+		return operand
+	case *ast.SatisfiesExpression:
+		return assertedOperand(e.Expression, rg.GenerateExpression)
+	case *ast.NonNullExpression:
+		// Les valeurs pouvant manquer sont des Option<T> : map.get(k)! -> map.get(k).unwrap()
+		if rg.types != nil && !rg.types.Nullable(e.Expression) {
+			return rg.GenerateExpression(e.Expression)
+		}
+		return assertedOperand(e.Expression, rg.GenerateExpression) + ".unwrap()"
+	case *ast.ArrowFunction:
+		return rg.GenerateClosure(e)
+	}
+	return ""
+}
+
+// boxedAny génère une valeur rangée dans un any : Box::new(5.0), une chaîne
+// littérale devenue String ; une valeur déjà any est déjà une Box
+func (rg *RustGenerator) boxedAny(value ast.Expression) string {
+	if rg.types != nil && isAnyType(rg.types.Expression(value).String()) {
+		return rg.GenerateExpression(value)
+	}
+	if _, ok := value.(*ast.StringLiteral); ok {
+		return "Box::new(" + rg.GenerateExpression(value) + ".to_string())"
+	}
+	return "Box::new(" + rg.GenerateExpression(value) + ")"
+}
+
+// downcast génère l'assertion d'une valeur any ou unknown, une Box<dyn Any> :
+// v as string -> v.downcast_ref::<String>().unwrap().clone(). La valeur est
+// copiée, sauf une instance de classe, qui n'est pas clonable et reste
+// empruntée. L'assertion panique si la valeur n'a pas le type annoncé.
+func (rg *RustGenerator) downcast(operand, tsType, rustType string) string {
+	name, _ := splitTypeArguments(tsType)
+	if object := strings.TrimPrefix(rustType, "Box<"); object != rustType && strings.HasPrefix(object, "dyn ") {
+		if object == "dyn std::any::Any>" {
+			return operand
+		}
+		return rg.traitDowncast(operand, name, "&"+strings.TrimSuffix(object, ">"))
+	}
+	ref := operand + ".downcast_ref::<" + rustType + ">().unwrap()"
+	if isRustPrimitive(rustType) {
+		return "*" + ref
+	}
+	if rg.classes[name] != nil {
+		return ref
+	}
+	return ref + ".clone()"
+}
+
+// traitDowncast génère l'assertion d'une valeur any vers une interface ou une
+// classe à sous-classes, un objet trait : Any ne connaît que des types
+// concrets, chaque classe dont les instances ont le type est essayée. Le
+// résultat est emprunté : value as Shape -> { let any = &*value;
+// any.downcast_ref::<Circle>().map(|v| v as &dyn Shape).unwrap() }
+func (rg *RustGenerator) traitDowncast(operand, name, trait string) string {
+	var attempts []string
+	for _, cd := range concreteClasses(name, rg.classes, rg.interfaces) {
+		attempts = append(attempts, "any.downcast_ref::<"+cd.Name+">().map(|v| v as "+trait+")")
+	}
+	if len(attempts) == 0 {
+		// Aucune classe n'a ce type : l'assertion échoue toujours
+		return "unimplemented!(\"" + name + " n'a pas de classe concrète\")"
+	}
+	chain := attempts[0]
+	for _, attempt := range attempts[1:] {
+		chain += ".or_else(|| " + attempt + ")"
+	}
+	return "{ let any = &*" + operand + "; " + chain + ".unwrap() }"
+}
+
+// GenerateClosure génère une closure : |x: i32| x * 2 ; une fonction async
+// renvoie un bloc async move
+func (rg *RustGenerator) GenerateClosure(af *ast.ArrowFunction) string {
+	var params []string
+	for _, param := range af.Parameters {
+		if param.Type != "" {
+			params = append(params, param.Name+": "+rg.mapType(param.Type))
+		} else {
+			params = append(params, param.Name)
+		}
+	}
+	prefix := "|" + strings.Join(params, ", ") + "| "
+	if af.ExpressionBody != nil && !af.IsAsync {
+		return prefix + rg.GenerateExpression(af.ExpressionBody)
+	}
+	if af.IsAsync {
+		prefix += "async move "
+	}
+	var body strings.Builder
+	for _, stmt := range arrowBody(af) {
+		body.WriteString(rg.GenerateStatement(stmt, "    "))
+	}
+	return prefix + "{\n" + body.String() + "}"
+}
+
+// staticMemberName renvoie le nom Rust d'un membre statique : les champs sont
+// des constantes associées en majuscules, les méthodes gardent leur nom
+func (rg *RustGenerator) staticMemberName(cd *ast.ClassDeclaration, property string) string {
+	for _, field := range cd.Fields {
+		if field.IsStatic && field.Name == property {
+			return strings.ToUpper(property)
+		}
+	}
+	return property
+}
+
+func (rg *RustGenerator) GenerateCallExpression(ce *ast.CallExpression) string {
+	params := rg.calleeParameters(ce)
+	var args []string
+	if rest, restArgs, ok := restCall(rg.rests, ce); ok {
+		args = append(rg.arguments(ce.Arguments[:rest.index], params[:rest.index]), rg.restArguments(restArgs, elementType(rest.param.Type)))
+	} else {
+		args = rg.arguments(ce.Arguments, params)
+	}
+
+	callee := rg.GenerateExpression(ce.Function)
+	if len(ce.TypeArguments) > 0 {
+		callee += "::" + rg.typeArguments(ce.TypeArguments)
+	}
+	return callee + "(" + strings.Join(args, ", ") + ")"
+}
+
+// arguments adapte les arguments d'un appel aux paramètres déclarés : un
+// paramètre optionnel reçoit Some(...), ou None quand l'argument est omis
+func (rg *RustGenerator) arguments(arguments []ast.Expression, params []ast.Parameter) []string {
+	var args []string
+	for i, arg := range arguments {
+		switch {
+		case i >= len(params):
+			args = append(args, rg.GenerateExpression(arg))
+		case params[i].CanBeOmitted() && !params[i].IsRest:
+			args = append(args, "Some("+rg.argument(arg, params[i].Type)+")")
+		default:
+			args = append(args, rg.argument(arg, params[i].Type))
+		}
+	}
+
+	// Compléter les arguments omis par None
+	for i := len(arguments); i < len(params); i++ {
+		if params[i].CanBeOmitted() && !params[i].IsRest {
+			args = append(args, "None")
+		}
+	}
+	return args
+}
+
+// calleeParameters renvoie les paramètres de la fonction ou de la méthode
+// appelée, quand elle est déclarée dans le programme
+func (rg *RustGenerator) calleeParameters(ce *ast.CallExpression) []ast.Parameter {
+	switch fn := ce.Function.(type) {
+	case *ast.Identifier:
+		if fd := rg.functions[fn.Value]; fd != nil {
+			return fd.Parameters
+		}
+	case *ast.DotExpression:
+		for _, cd := range rg.classes {
+			for _, method := range regularMethods(cd) {
+				if method.Name == fn.Property {
+					return method.Parameters
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// restArguments regroupe les arguments d'un paramètre rest en une slice :
+// f(1, 2) -> f(&[1, 2]), f(...xs) -> f(&xs), f(1, ...xs) -> f(&[&[1][..], &xs[..]].concat())
+func (rg *RustGenerator) restArguments(args []ast.Expression, elem string) string {
+	if array, ok := soleSpread(args); ok {
+		return "&" + rg.GenerateExpression(array)
+	}
+	if !hasSpread(args) {
+		var parts []string
+		for _, arg := range args {
+			parts = append(parts, rg.argument(arg, elem))
+		}
+		return "&[" + strings.Join(parts, ", ") + "]"
+	}
+	return "&" + rg.spreadArray(args, elem)
+}
+
+// argument génère un argument passé à un paramètre de type t : une chaîne
+// littérale est un &str, convertie quand le paramètre attend un String, et un
+// objet construit pour un trait est mis dans une Box
+func (rg *RustGenerator) argument(arg ast.Expression, t string) string {
+	switch arg.(type) {
+	case *ast.StringLiteral:
+		if rg.mapType(t) == "String" {
+			return rg.GenerateExpression(arg) + ".to_string()"
+		}
+	case *ast.NewExpression:
+		if strings.HasPrefix(rg.mapType(t), "Box<dyn ") {
+			// Un objet passé pour un trait : Box::new(Rect::new(2, 3))
+			return "Box::new(" + rg.GenerateExpression(arg) + ")"
+		}
+	}
+	return rg.GenerateExpression(arg)
+}
+
+// anyElement génère un élément d'un Vec<Box<dyn Any>> : un nombre TypeScript
+// y est un f64, 1 s'écrit 1.0
+func (rg *RustGenerator) anyElement(element ast.Expression) string {
+	if nl, ok := element.(*ast.NumberLiteral); ok && decimalInteger(nl.Value) {
+		return "Box::new(" + rg.GenerateNumberLiteral(nl) + ".0)"
+	}
+	return rg.boxedAny(element)
+}
+
+func (rg *RustGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
+	elements := flattenSpreads(al.Elements)
+	elem := rg.arrays[al]
+	if elem == "" {
+		elem = elementText(rg.types, al)
+	}
+	if !hasSpread(elements) {
+		var parts []string
+		for _, element := range elements {
+			parts = append(parts, rg.argument(element, elem))
+		}
+		if elem == "" && mixedArray(rg.types, al) {
+			// vec![1, "a"] n'a pas de type d'éléments commun : chacun est
+			// boxé, et le premier fixe le type Box<dyn Any> du Vec
+			parts = nil
+			for _, element := range elements {
+				parts = append(parts, rg.anyElement(element))
+			}
+			parts[0] += " as Box<dyn std::any::Any>"
+		}
+		return "vec![" + strings.Join(parts, ", ") + "]"
+	}
+	return rg.spreadArray(elements, elem)
+}
+
+// spreadArray concatène les éléments et les spreads d'un tableau :
+// [...a, 1, ...b] -> [&a[..], &[1][..], &b[..]].concat()
+func (rg *RustGenerator) spreadArray(elements []ast.Expression, elem string) string {
+	var segments []string
+	for _, element := range elements {
+		if spread, ok := element.(*ast.SpreadElement); ok {
+			segments = append(segments, "&"+rg.GenerateExpression(spread.Argument)+"[..]")
+		} else {
+			segments = append(segments, "&["+rg.argument(element, elem)+"][..]")
+		}
+	}
+	return "[" + strings.Join(segments, ", ") + "].concat()"
+}
+
+func (rg *RustGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) string {
+	if hasSpreadProperty(ol.Properties) {
+		// Rust n'a pas de spread d'objet : fusion explicite via merge_maps
+		rg.needsMergeHelper = true
+		var parts []string
+		for _, group := range splitSpreadProperties(ol.Properties) {
+			if spread, ok := group[0].Value.(*ast.SpreadElement); ok {
+				parts = append(parts, rg.GenerateExpression(spread.Argument)+".clone()")
+			} else {
+				parts = append(parts, rg.GenerateObjectLiteral(&ast.ObjectLiteral{Properties: group}))
+			}
+		}
+		return "merge_maps(&[" + strings.Join(parts, ", ") + "])"
+	}
+
+	rg.usesHashMap = true
+	var entries []string
+	for _, prop := range ol.Properties {
+		entries = append(entries, "(\""+prop.Key+"\", "+rg.GenerateExpression(prop.Value)+".to_string())")
+	}
+	return "HashMap::from([" + strings.Join(entries, ", ") + "])"
+}
+
+func (rg *RustGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
+	if alias, ok := rg.literals[sl]; ok {
+		member := enumMemberName(sl.Value)
+		return alias + "::" + member
+	}
+	return "\"" + sl.Value + "\""
+}
+
+func (rg *RustGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
+	return nl.Value
+}
+
+func (rg *RustGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
+	if bl.Value {
+		return "true"
+	}
+	return "false"
+}
+
+// SwiftGenerator génère du code Swift
+type SwiftGenerator struct {
+	typeNames  map[string]bool                       // classes, protocoles et paramètres de type connus
+	types      *semantic.Inference                   // types déduits des déclarations sans annotation
+	literals   map[*ast.StringLiteral]string         // chaînes écrites comme membres d'enum : 'done' -> TaskStatus.Done
+	widened    map[ast.Expression]bool               // entiers attendus en Double : Double(i)
+	classes    map[string]*ast.ClassDeclaration      // classes parentes : override func, override init
+	rests      map[string]restParameter              // paramètres rest reçus en tableau : f(1, [2, 3])
+	namespaces map[string]map[string]namespaceMember // Geometry.area -> area
+}
+
+func (sg *SwiftGenerator) Generate(statements []ast.Statement) string {
+	var sb strings.Builder
+
+	sg.literals = enumLiterals(sg.types, statements)
+	sg.widened = widenedIntegers(sg.types, statements)
+
+	// Les fichiers d'un même module Swift se voient sans import ni export :
+	// seuls les packages externes sont importés
+	statements, imports, _ := splitModuleStatements(statements)
+	// Pas de namespace en Swift : leurs déclarations sont générées au niveau
+	// du programme et Geometry.area devient area
+	sg.namespaces = collectNamespaces(statements)
+	statements = flattenNamespaces(statements)
+	sg.typeNames = collectTypeNames(statements)
+	sg.classes = collectClasses(statements)
+	sg.rests = collectRestParameters(statements)
+
+	seen := map[string]bool{}
+	for _, id := range imports {
+		if _, segments := modulePath(id.Source); !isRelativeModule(id.Source) && len(segments) > 0 {
+			module := capitalize(segments[len(segments)-1])
+			if !seen[module] {
+				seen[module] = true
+				sb.WriteString("import " + module + "\n")
+			}
+		}
+	}
+	if len(seen) > 0 {
+		sb.WriteString("\n")
+	}
+
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *ast.FunctionDeclaration:
+			sb.WriteString(sg.GenerateFunction(s))
+			for _, signature := range overloadSignatures(s, sg.parameterType) {
+				sb.WriteString(sg.generateSignature(signature, s))
+			}
+		case *ast.EnumDeclaration:
+			sb.WriteString(sg.GenerateEnum(s))
+		case *ast.TypeAlias:
+			// Une union de littéraux devient un enum: String
+			if ed := literalUnionEnum(s); ed != nil {
+				sb.WriteString(sg.GenerateEnum(ed))
+			}
+		case *ast.Interface:
+			sb.WriteString(sg.GenerateInterface(s))
+		case *ast.ClassDeclaration:
+			sb.WriteString(sg.GenerateClass(s))
+		default:
+			sb.WriteString(sg.GenerateStatement(stmt, ""))
+		}
+	}
+
+	return sb.String()
+}
+
+// GenerateEnum génère un enum Swift avec raw values Int ou String
+func (sg *SwiftGenerator) GenerateEnum(ed *ast.EnumDeclaration) string {
+	members := resolveEnumMembers(ed)
+	stringEnum := isStringEnum(members)
+
+	var sb strings.Builder
+	if stringEnum {
+		sb.WriteString("enum " + ed.Name + ": String {\n")
+	} else {
+		sb.WriteString("enum " + ed.Name + ": Int {\n")
+	}
+	for _, member := range members {
+		value := sg.GenerateExpression(member.Value)
+		if stringEnum {
+			value = "\"" + enumValueString(member.Value) + "\""
+		}
+		sb.WriteString("    case " + member.Name + " = " + value + "\n")
+	}
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// mapType convertit un type TypeScript en type Swift
+func (sg *SwiftGenerator) mapType(t string) string {
+	if strings.HasSuffix(t, "[]") || strings.HasPrefix(t, "Array<") {
+		return "[" + sg.mapType(elementType(t)) + "]"
+	}
+	if inner, ok := unwrapPromise(t); ok {
+		// Une fonction async renvoie directement T : Promise<T> -> T
+		return sg.mapType(inner)
+	}
+	if inner, ok := yieldedType(t); ok {
+		return "AnySequence<" + sg.iteratorElement(inner) + ">"
+	}
+	switch t {
+	case "string":
+		return "String"
+	case "number":
+		return "Double"
+	case integerType:
+		return "Int"
+	case "boolean":
+		return "Bool"
+	case "void", "":
+		return ""
+	}
+
+	if name, args := splitTypeArguments(t); name == "Map" && len(args) == 2 && !sg.typeNames[name] {
+		// Une clé de dictionnaire doit être Hashable
+		key := sg.mapType(args[0])
+		if key == "Any" {
+			key = "AnyHashable"
+		}
+		return "[" + key + ": " + sg.mapType(args[1]) + "]"
+	}
+
+	// Types déclarés et paramètres de type : Stack<T>
+	if name, args := splitTypeArguments(t); sg.typeNames[name] {
+		return name + sg.typeArguments(args)
+	}
+	return "Any"
+}
+
+// typeArguments génère <Int, String> pour un type générique instancié
+func (sg *SwiftGenerator) typeArguments(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	var mapped []string
+	for _, arg := range args {
+		mapped = append(mapped, sg.mapType(arg))
+	}
+	return "<" + strings.Join(mapped, ", ") + ">"
+}
+
+// typeParameters génère <T: Base, U> ; Swift n'a pas de type par défaut
+func (sg *SwiftGenerator) typeParameters(params []ast.TypeParameter) string {
+	if len(params) == 0 {
+		return ""
+	}
+	var parts []string
+	for _, param := range params {
+		part := param.Name
+		if bound := sg.mapType(param.Constraint); bound != "" && bound != "Any" {
+			part += ": " + bound
+		}
+		parts = append(parts, part)
+	}
+	return "<" + strings.Join(parts, ", ") + ">"
+}
+
+// GenerateInterface génère un protocole ; les paramètres de type deviennent
+// des types associés
+func (sg *SwiftGenerator) GenerateInterface(i *ast.Interface) string {
+	defer declareTypeParameters(sg.typeNames, i.TypeParameters)()
+
+	var sb strings.Builder
+	sb.WriteString("protocol " + i.Name + sg.inheritanceClause(i.Extends) + " {\n")
+	for _, param := range i.TypeParameters {
+		sb.WriteString("    associatedtype " + param.Name + "\n")
+	}
+	for _, field := range i.Fields {
+		if !field.IsMethod {
+			fieldType := sg.mapType(field.Type)
+			if field.Optional {
+				fieldType += "?"
+			}
+			sb.WriteString("    var " + field.Name + ": " + fieldType + " { get }\n")
+			continue
+		}
+		restore := declareTypeParameters(sg.typeNames, field.TypeParameters)
+		sb.WriteString("    func " + field.Name + sg.typeParameters(field.TypeParameters) + "(" + sg.generateParameters(field.Parameters) + ")")
+		if returnType := sg.mapType(field.ReturnType); returnType != "" {
+			sb.WriteString(" -> " + returnType)
+		}
+		sb.WriteString("\n")
+		restore()
+	}
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
 
-func (pg *PythonGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
-	if bl.Value {
-		return "True"
+// GenerateClass génère une classe Swift ; le constructeur devient init
+func (sg *SwiftGenerator) GenerateClass(cd *ast.ClassDeclaration) string {
+	defer declareTypeParameters(sg.typeNames, cd.TypeParameters)()
+	cd = withParameterProperties(withImplicitConstructor(cd, sg.classes))
+
+	var bases []string
+	if cd.SuperClass != "" {
+		bases = append(bases, cd.SuperClass)
 	}
-	return "False"
+	implemented, rejected := classBases(cd.Implements, sg.classes)
+	bases = append(bases, implemented...)
+
+	var sb strings.Builder
+	sb.WriteString(implementedClassComments(cd.Name, rejected, Swift, ""))
+	sb.WriteString("class " + cd.Name + sg.typeParameters(cd.TypeParameters) + sg.inheritanceClause(bases) + " {\n")
+
+	var members []string
+	var fields strings.Builder
+	for _, field := range cd.Fields {
+		fields.WriteString("    " + swiftAccess(field.IsPrivate, field.IsProtected))
+		if field.IsStatic {
+			fields.WriteString("static ")
+		}
+		fields.WriteString("var " + field.Name + ": " + sg.fieldType(field))
+		if field.IsStatic && field.HasDefault {
+			fields.WriteString(" = " + sg.GenerateExpression(field.Default))
+		}
+		fields.WriteString("\n")
+	}
+	if fields.Len() > 0 {
+		members = append(members, fields.String())
+	}
+
+	if needsConstructor(cd) {
+		var mb strings.Builder
+		mb.WriteString("    ")
+		if sg.overridesInit(cd) {
+			mb.WriteString("override ")
+		}
+		mb.WriteString("init(" + sg.generateParameters(constructorParameters(cd)) + ") {\n")
+		mb.WriteString(sg.generateDefaults(constructorParameters(cd), "        "))
+		for _, stmt := range superCallAfterFields(constructorBody(cd)) {
+			mb.WriteString(sg.GenerateStatement(stmt, "        "))
+		}
+		mb.WriteString("    }\n")
+		members = append(members, mb.String())
+	}
+
+	for _, property := range classProperties(cd) {
+		members = append(members, sg.generateProperty(property))
+	}
+
+	for _, method := range regularMethods(cd) {
+		restore := declareTypeParameters(sg.typeNames, method.TypeParameters)
+
+		var mb strings.Builder
+		mb.WriteString("    " + swiftAccess(method.IsPrivate, method.IsProtected))
+		if method.IsStatic {
+			mb.WriteString("static ")
+		}
+		if !method.IsStatic && overridesMethod(cd, method.Name, sg.classes) {
+			mb.WriteString("override ")
+		}
+		mb.WriteString("func " + method.Name + sg.typeParameters(method.TypeParameters) + "(" + sg.generateParameters(method.Parameters) + ")")
+		if method.IsAbstract {
+			// Swift n'a pas de méthode abstraite : une sous-classe doit la redéfinir
+			mb.WriteString(sg.generateEffects(method.ReturnType, method.IsAsync))
+			mb.WriteString(" {\n")
+			mb.WriteString("        fatalError(\"" + cd.Name + "." + method.Name + " est abstraite\")\n")
+		} else if method.IsGenerator {
+			mb.WriteString(" -> " + sg.sequenceType(method.ReturnType) + " {\n")
+			mb.WriteString(sg.generateDefaults(method.Parameters, "        "))
+			mb.WriteString(indentLines(sg.generateSequence(method.Body, method.Parameters, method.ReturnType), "        "))
+		} else {
+			mb.WriteString(sg.generateEffects(typedReturn(sg.types, &method, method.ReturnType, false), method.IsAsync))
+			mb.WriteString(" {\n")
+			mb.WriteString(sg.generateDefaults(method.Parameters, "        "))
+			for _, stmt := range method.Body {
+				mb.WriteString(sg.GenerateStatement(stmt, "        "))
+			}
+		}
+		mb.WriteString("    }\n")
+		members = append(members, mb.String())
+
+		restore()
+	}
+
+	sb.WriteString(strings.Join(members, "\n"))
+	sb.WriteString("}\n\n")
+
+	// Les blocs static { ... } s'exécutent une fois la classe définie
+	if statics := staticBlockStatements(cd); len(statics) > 0 {
+		for _, stmt := range statics {
+			sb.WriteString(sg.GenerateStatement(stmt, ""))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
 }
 
-func (pg *PythonGenerator) GenerateTemplateLiteral(tl *ast.TemplateLiteral) string {
-	// En Python, convertir les template literals en f-strings
-	if len(tl.Parts) > 0 {
-		content := tl.Parts[0].TokenLiteral()
-		// Pour l'instant, retourner comme string simple (plus tard on peut parser ${} pour interpolation)
-		return "\"" + content + "\""
+// inheritanceClause génère la liste des types hérités : Rect: Shape, Named
+func (sg *SwiftGenerator) inheritanceClause(bases []string) string {
+	if len(bases) == 0 {
+		return ""
 	}
-	return "\"\""
+	var names []string
+	for _, base := range bases {
+		names = append(names, sg.mapType(base))
+	}
+	return ": " + strings.Join(names, ", ")
 }
 
-// CSharpGenerator génère du code C#
-type CSharpGenerator struct{}
+// overridesInit indique si le init d'une classe a la même signature que celui
+// de sa classe parente, qu'il doit alors déclarer override
+func (sg *SwiftGenerator) overridesInit(cd *ast.ClassDeclaration) bool {
+	parent := sg.classes[superClassName(cd)]
+	if parent == nil {
+		return false
+	}
+	own, inherited := constructorParameters(cd), constructorParameters(parent)
+	if len(own) != len(inherited) {
+		return false
+	}
+	for i := range own {
+		if sg.mapType(own[i].Type) != sg.mapType(inherited[i].Type) {
+			return false
+		}
+	}
+	return true
+}
 
-func (csg *CSharpGenerator) Generate(statements []ast.Statement) string {
+// generateProperty génère une propriété calculée à partir des accesseurs get/set
+func (sg *SwiftGenerator) generateProperty(property classProperty) string {
+	propertyType := "Any"
+	if t := sg.mapType(property.Type()); t != "" {
+		propertyType = t
+	}
 	var sb strings.Builder
+	sb.WriteString("    " + swiftAccess(property.accessor().IsPrivate, property.accessor().IsProtected))
+	if property.IsStatic() {
+		sb.WriteString("static ")
+	}
+	sb.WriteString("var " + property.Name + ": " + propertyType + " {\n")
+	if property.Getter != nil {
+		sb.WriteString("        get {\n")
+		for _, stmt := range property.Getter.Body {
+			sb.WriteString(sg.GenerateStatement(stmt, "            "))
+		}
+		sb.WriteString("        }\n")
+	}
+	if property.Setter != nil {
+		sb.WriteString("        set")
+		if len(property.Setter.Parameters) > 0 {
+			sb.WriteString("(" + property.Setter.Parameters[0].Name + ")")
+		}
+		sb.WriteString(" {\n")
+		for _, stmt := range property.Setter.Body {
+			sb.WriteString(sg.GenerateStatement(stmt, "            "))
+		}
+		sb.WriteString("        }\n")
+	}
+	sb.WriteString("    }\n")
+	return sb.String()
+}
 
-	sb.WriteString("using System;\n\n")
-	sb.WriteString("namespace GeneratedCode\n{\n")
-	sb.WriteString("    class Program\n    {\n")
-	sb.WriteString("        static void Main(string[] args)\n        {\n")
+// fieldType renvoie le type Swift d'un champ, déduit de sa valeur s'il n'est pas annoté
+func (sg *SwiftGenerator) fieldType(field ast.ClassField) string {
+	if swiftType := sg.mapType(typed(sg.types, &field, field.Type)); swiftType != "" {
+		return swiftType
+	}
+	return "Any"
+}
 
-	for _, stmt := range statements {
-		switch s := stmt.(type) {
-		case *ast.VariableDeclaration:
-			sb.WriteString("            ")
-			sb.WriteString(csg.GenerateVariableDeclaration(s))
-		}
+func (sg *SwiftGenerator) GenerateFunction(fd *ast.FunctionDeclaration) string {
+	defer declareTypeParameters(sg.typeNames, fd.TypeParameters)()
+
+	var sb strings.Builder
+
+	sb.WriteString("func ")
+	sb.WriteString(fd.Name)
+	sb.WriteString(sg.typeParameters(fd.TypeParameters))
+	sb.WriteString("(")
+	sb.WriteString(sg.generateParameters(fd.Parameters))
+	sb.WriteString(")")
+	if fd.IsGenerator {
+		sb.WriteString(" -> " + sg.sequenceType(fd.ReturnType) + " {\n")
+		sb.WriteString(sg.generateDefaults(fd.Parameters, "    "))
+		sb.WriteString(indentLines(sg.generateSequence(fd.Body, fd.Parameters, fd.ReturnType), "    "))
+		sb.WriteString("}\n\n")
+		return sb.String()
+	}
+	sb.WriteString(sg.generateEffects(typedReturn(sg.types, fd, fd.ReturnType, false), fd.IsAsync))
+	sb.WriteString(" {\n")
+	sb.WriteString(sg.generateDefaults(fd.Parameters, "    "))
+
+	for _, stmt := range fd.Body {
+		sb.WriteString(sg.GenerateStatement(stmt, "    "))
 	}
 
-	sb.WriteString("        }\n    }\n}\n")
+	sb.WriteString("}\n\n")
 	return sb.String()
 }
 
-func (csg *CSharpGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
-	var sb strings.Builder
+// iteratorElement renvoie le type Swift des valeurs produites par un générateur
+func (sg *SwiftGenerator) iteratorElement(t string) string {
+	if element := sg.mapType(t); element != "" {
+		return element
+	}
+	return "Void"
+}
 
-	// Déterminer le type C#
-	switch vd.Value.(type) {
-	case *ast.StringLiteral:
-		sb.WriteString("string ")
-	case *ast.NumberLiteral:
-		sb.WriteString("int ") // Simplifié
-	case *ast.BooleanLiteral:
-		sb.WriteString("bool ")
-	default:
-		sb.WriteString("var ")
+// sequenceType renvoie le type de retour d'un générateur : AnySequence<Int>
+func (sg *SwiftGenerator) sequenceType(returnType string) string {
+	if _, ok := yieldedType(returnType); ok {
+		return sg.mapType(returnType)
 	}
+	return "AnySequence<Any>"
+}
 
-	sb.WriteString(vd.Name)
-	sb.WriteString(" = ")
+// generateSequence génère le corps d'un générateur. Swift n'a pas de yield :
+// le corps découpé en états (voir lowerGenerator) devient la closure d'un
+// AnyIterator, et les variables locales, capturées par cette closure, vivent
+// d'un appel de next() à l'autre.
+func (sg *SwiftGenerator) generateSequence(body []ast.Statement, params []ast.Parameter, returnType string) string {
+	m := lowerGenerator(body)
+	element := "Any"
+	if inner, ok := yieldedType(returnType); ok {
+		element = sg.iteratorElement(inner)
+	}
 
-	switch val := vd.Value.(type) {
+	var sb strings.Builder
+	sb.WriteString("return AnySequence { () -> AnyIterator<" + element + "> in\n")
+	sb.WriteString("    var state = 0\n")
+	for _, local := range m.Locals {
+		localType, zero := sg.localType(local, params)
+		sb.WriteString("    var " + local.Name + ": " + localType + " = " + zero + "\n")
+	}
+	for i := 0; i < m.Delegates; i++ {
+		sb.WriteString("    var delegate" + strconv.Itoa(i) + ": AnyIterator<" + element + ">? = nil\n")
+	}
+	sb.WriteString("    return AnyIterator {\n")
+	sb.WriteString("        while true {\n")
+	sb.WriteString("            switch state {\n")
+	reachable := m.reachable()
+	for i, state := range m.States {
+		if !reachable[i] {
+			continue
+		}
+		var code strings.Builder
+		for _, stmt := range state.Statements {
+			code.WriteString(sg.GenerateStatement(stmt, ""))
+		}
+		code.WriteString(sg.generateExit(state.Exit))
+		sb.WriteString("            case " + strconv.Itoa(i) + ":\n")
+		sb.WriteString(indentLines(code.String(), "                "))
+	}
+	sb.WriteString("            default:\n")
+	sb.WriteString("                return nil\n")
+	sb.WriteString("            }\n")
+	sb.WriteString("        }\n")
+	sb.WriteString("    }\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// localType renvoie le type d'une variable locale d'un générateur et sa valeur
+// initiale ; une variable initialisée par un paramètre en prend le type
+func (sg *SwiftGenerator) localType(vd *ast.VariableDeclaration, params []ast.Parameter) (string, string) {
+	t := vd.Type
+	switch value := vd.Value.(type) {
+	case *ast.Identifier:
+		for _, param := range params {
+			if t == "" && param.Name == value.Value {
+				t = param.Type
+			}
+		}
 	case *ast.StringLiteral:
-		sb.WriteString(csg.GenerateStringLiteral(val))
+		t = "string"
 	case *ast.NumberLiteral:
-		sb.WriteString(csg.GenerateNumberLiteral(val))
+		t = variableType(sg.types, vd)
 	case *ast.BooleanLiteral:
-		sb.WriteString(csg.GenerateBooleanLiteral(val))
+		t = "boolean"
 	}
-
-	sb.WriteString(";\n")
-	return sb.String()
+	switch t {
+	case "string":
+		return "String", `""`
+	case "number":
+		return "Double", "0"
+	case integerType:
+		return "Int", "0"
+	case "boolean":
+		return "Bool", "false"
+	}
+	return "Any?", "nil"
 }
 
-func (csg *CSharpGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
-	return "\"" + sl.Value + "\""
+// generateExit génère la sortie d'un état de la machine d'un générateur
+func (sg *SwiftGenerator) generateExit(exit generatorExit) string {
+	target := strconv.Itoa(exit.Target)
+	delegate := "delegate" + strconv.Itoa(exit.Delegate)
+	switch exit.Kind {
+	case exitJump:
+		return "state = " + target + "\n"
+	case exitBranch:
+		return "state = " + sg.GenerateExpression(exit.Condition) + " ? " + target + " : " + strconv.Itoa(exit.Else) + "\n"
+	case exitYield:
+		value := "()"
+		if exit.Value != nil {
+			value = sg.GenerateExpression(exit.Value)
+		}
+		return "state = " + target + "\nreturn " + value + "\n"
+	case exitDelegate:
+		return delegate + " = AnyIterator(" + sg.GenerateExpression(exit.Value) + ".makeIterator())\nstate = " + target + "\n"
+	case exitDrain:
+		return "if let value = " + delegate + "?.next() {\n    return value\n}\nstate = " + target + "\n"
+	}
+	return "state = -1\nreturn nil\n"
 }
 
-func (csg *CSharpGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
-	return nl.Value
+// generateEffects génère ce qui suit les paramètres d'une fonction : les
+// mots-clés async throws, une promesse pouvant être rejetée, puis le type de
+// retour (func f() async throws -> String)
+func (sg *SwiftGenerator) generateEffects(returnType string, isAsync bool) string {
+	var effects string
+	if isAsync {
+		effects = " async throws"
+	}
+	if t := sg.mapType(returnType); t != "" {
+		effects += " -> " + t
+	}
+	return effects
 }
 
-func (csg *CSharpGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
-	if bl.Value {
-		return "true"
+// generateParameters génère des paramètres sans label d'argument ; un
+// paramètre rest devient variadique
+func (sg *SwiftGenerator) generateParameters(params []ast.Parameter) string {
+	var parts []string
+	for _, param := range typedParameters(sg.types, params) {
+		part := "_ " + param.Name + ": " + sg.parameterType(param)
+		switch {
+		case param.IsRest:
+		case hasComputedDefault(param), param.Optional && param.Default == nil:
+			part += " = nil"
+		case param.Default != nil:
+			part += " = " + sg.GenerateExpression(param.Default)
+		}
+		parts = append(parts, part)
 	}
-	return "false"
+	return strings.Join(parts, ", ")
 }
 
-// GoGenerator génère du code Go
-type GoGenerator struct{}
+// parameterType renvoie le type Swift d'un paramètre de fonction. Un
+// paramètre rest est un tableau plutôt qu'un variadique : Swift ne sait pas
+// étaler un tableau dans un variadique, f(...xs) s'écrit alors f(xs).
+func (sg *SwiftGenerator) parameterType(param ast.Parameter) string {
+	switch {
+	case param.IsRest:
+		return "[" + sg.mapType(elementType(param.Type)) + "]"
+	case param.Optional && param.Default == nil, hasComputedDefault(param):
+		// Optionnel ou défaut non constant : paramètre nullable = nil
+		return sg.mapType(param.Type) + "?"
+	}
+	return sg.mapType(param.Type)
+}
 
-func (gg *GoGenerator) Generate(statements []ast.Statement) string {
+// generateDefaults applique en tête de corps les valeurs par défaut non
+// constantes : une valeur par défaut Swift ne peut pas lire un autre paramètre
+func (sg *SwiftGenerator) generateDefaults(params []ast.Parameter, indent string) string {
 	var sb strings.Builder
+	for _, param := range params {
+		if hasComputedDefault(param) {
+			sb.WriteString(indent + "let " + param.Name + " = " + param.Name + " ?? " + sg.GenerateExpression(param.Default) + "\n")
+		}
+	}
+	return sb.String()
+}
 
-	sb.WriteString("package main\n\n")
-	sb.WriteString("func main() {\n")
+// generateSignature génère une signature de surcharge : une fonction typée
+// qui convertit ses arguments pour appeler l'implémentation, puis son résultat
+func (sg *SwiftGenerator) generateSignature(signature, fd *ast.FunctionDeclaration) string {
+	defer declareTypeParameters(sg.typeNames, signature.TypeParameters)()
 
-	for _, stmt := range statements {
-		switch s := stmt.(type) {
-		case *ast.VariableDeclaration:
-			sb.WriteString("    ")
-			sb.WriteString(gg.GenerateVariableDeclaration(s))
+	var sb strings.Builder
+	sb.WriteString("func " + fd.Name + sg.typeParameters(signature.TypeParameters))
+	sb.WriteString("(" + sg.generateParameters(signature.Parameters) + ")" + sg.generateEffects(signature.ReturnType, fd.IsAsync) + " {\n")
+	cast := func(value, from, to string) string {
+		return value + " as " + to
+	}
+	call := fd.Name + "(" + strings.Join(overloadArguments(signature, fd, sg.parameterType, cast), ", ") + ")"
+	if fd.IsAsync {
+		call = "try await " + call
+	}
+	returnType := sg.mapType(signature.ReturnType)
+	switch {
+	case returnType == "":
+		sb.WriteString("    " + call + "\n")
+	case returnType != sg.mapType(fd.ReturnType):
+		sb.WriteString("    return " + call + " as! " + returnType + "\n")
+	default:
+		sb.WriteString("    return " + call + "\n")
+	}
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+func (sg *SwiftGenerator) GenerateStatement(stmt ast.Statement, indent string) string {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		return indentLines(sg.GenerateVariableDeclaration(s), indent)
+	case *ast.ReturnStatement:
+		if s.Value != nil {
+			return indentLines("return "+sg.GenerateExpression(s.Value)+"\n", indent)
+		}
+		return indent + "return\n"
+	case *ast.ExpressionStatement:
+		// Convertir console.log en print
+		if callExpr, ok := s.Expression.(*ast.CallExpression); ok && isConsoleLog(callExpr) {
+			return indentLines("print("+sg.generateArguments(callExpr.Arguments)+")\n", indent)
+		}
+		return indentLines(sg.GenerateExpression(s.Expression)+"\n", indent)
+	case *ast.IfStatement:
+		var sb strings.Builder
+		sb.WriteString(indent + "if " + sg.GenerateExpression(s.Condition) + " ")
+		sb.WriteString(sg.GenerateBlock(s.ThenBranch, indent))
+		if s.ElseBranch != nil {
+			sb.WriteString(" else ")
+			sb.WriteString(sg.GenerateBlock(s.ElseBranch, indent))
 		}
+		sb.WriteString("\n")
+		return sb.String()
+	case *ast.WhileStatement:
+		return indent + "while " + sg.GenerateExpression(s.Condition) + " " + sg.GenerateBlock(s.Body, indent) + "\n"
+	case *ast.ForStatement:
+		// Swift n'a pas de for C : initialisation, puis while avec la mise à jour en fin de corps
+		var sb strings.Builder
+		if vd, ok := s.Init.(*ast.VariableDeclaration); ok {
+			sb.WriteString(indent + "var " + vd.Name + " = " + sg.GenerateExpression(vd.Value) + "\n")
+		} else if s.Init != nil {
+			sb.WriteString(sg.GenerateStatement(s.Init, indent))
+		}
+		condition := "true"
+		if s.Condition != nil {
+			condition = sg.GenerateExpression(s.Condition)
+		}
+		body := blockStatements(s.Body)
+		if s.Update != nil {
+			body = append(append([]ast.Statement{}, body...), s.Update)
+		}
+		sb.WriteString(indent + "while " + condition + " " + sg.GenerateBlock(&ast.BlockStatement{Statements: body}, indent) + "\n")
+		return sb.String()
 	}
+	return ""
+}
 
-	sb.WriteString("}\n")
+func (sg *SwiftGenerator) GenerateBlock(stmt ast.Statement, indent string) string {
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	if blockStmt, ok := stmt.(*ast.BlockStatement); ok {
+		for _, inner := range blockStmt.Statements {
+			sb.WriteString(sg.GenerateStatement(inner, indent+"    "))
+		}
+	}
+	sb.WriteString(indent + "}")
 	return sb.String()
 }
 
-func (gg *GoGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+func (sg *SwiftGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
+	declared := sg.mapType(variableType(sg.types, vd))
+	vd = withoutErasedAssertion(vd)
 	var sb strings.Builder
 
 	if vd.IsConst {
-		sb.WriteString("const ")
+		sb.WriteString("let ")
 	} else {
 		sb.WriteString("var ")
 	}
 
 	sb.WriteString(vd.Name)
-	sb.WriteString(" ")
 
-	// Déterminer le type Go
-	switch vd.Value.(type) {
-	case *ast.StringLiteral:
-		sb.WriteString("string")
-	case *ast.NumberLiteral:
-		sb.WriteString("int")
-	case *ast.BooleanLiteral:
-		sb.WriteString("bool")
+	// Le type d'une closure est déduit de sa signature
+	if _, ok := vd.Value.(*ast.ArrowFunction); ok {
+		sb.WriteString(" = " + sg.GenerateExpression(vd.Value) + "\n")
+		return sb.String()
+	}
+	sb.WriteString(": ")
+	if declared == "" {
+		declared = "Any"
+	}
+
+	// Sans valeur, la variable est initialisée plus loin
+	if vd.Value == nil {
+		return sb.String() + declared + "\n"
+	}
+
+	// Déterminer le type Swift
+	switch value := vd.Value.(type) {
+	case *ast.ArrayLiteral:
+		if !strings.HasPrefix(declared, "[") {
+			declared = "[Any]"
+		}
+		sb.WriteString(declared)
+	case *ast.ObjectLiteral:
+		sb.WriteString("[String: Any]")
+	case *ast.AsExpression:
+		// let shape: Shape = (value as! Shape)
+		sb.WriteString(sg.mapType(value.Type))
 	default:
-		sb.WriteString("interface{}")
+		sb.WriteString(declared)
 	}
 
 	sb.WriteString(" = ")
 
 	switch val := vd.Value.(type) {
 	case *ast.StringLiteral:
-		sb.WriteString(gg.GenerateStringLiteral(val))
+		sb.WriteString(sg.GenerateStringLiteral(val))
 	case *ast.NumberLiteral:
-		sb.WriteString(gg.GenerateNumberLiteral(val))
+		// Dans un Any, 5 serait un Int que value as! Double refuse
+		sb.WriteString(sg.GenerateExpression(val))
 	case *ast.BooleanLiteral:
-		sb.WriteString(gg.GenerateBooleanLiteral(val))
+		sb.WriteString(sg.GenerateBooleanLiteral(val))
+	default:
+		sb.WriteString(sg.GenerateExpression(val))
 	}
 
 	sb.WriteString("\n")
 	return sb.String()
 }
 
-func (gg *GoGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
+func (sg *SwiftGenerator) GenerateExpression(expr ast.Expression) string {
+	if sg.widened[expr] {
+		// Swift ne convertit pas un entier en Double
+		if nl, ok := expr.(*ast.NumberLiteral); ok && decimalInteger(nl.Value) {
+			return sg.GenerateNumberLiteral(nl) + ".0"
+		}
+		return "Double(" + sg.generateExpression(expr) + ")"
+	}
+	return sg.generateExpression(expr)
+}
+
+func (sg *SwiftGenerator) generateExpression(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return sg.GenerateStringLiteral(e)
+	case *ast.NumberLiteral:
+		return sg.GenerateNumberLiteral(e)
+	case *ast.BooleanLiteral:
+		return sg.GenerateBooleanLiteral(e)
+	case *ast.Identifier:
+		if e.Value == "this" {
+			return "self"
+		}
+		return e.Value
+	case *ast.ParenthesizedExpression:
+		return "(" + sg.GenerateExpression(e.Expression) + ")"
+	case *ast.InfixExpression:
+		if e.Operator == "%" && isFloat(sg.types, e) {
+			// % n'accepte que des entiers en Swift
+			return castOperand(e.Left, sg.GenerateExpression(e.Left)) + ".truncatingRemainder(dividingBy: " + sg.GenerateExpression(e.Right) + ")"
+		}
+		return sg.GenerateExpression(e.Left) + " " + e.Operator + " " + sg.GenerateExpression(e.Right)
+	case *ast.ArrayLiteral:
+		return sg.GenerateArrayLiteral(e)
+	case *ast.ObjectLiteral:
+		return sg.GenerateObjectLiteral(e)
+	case *ast.CallExpression:
+		if ident, ok := e.Function.(*ast.Identifier); ok && ident.Value == "super" {
+			return "super.init(" + sg.generateArguments(e.Arguments) + ")"
+		}
+		return sg.GenerateExpression(e.Function) + "(" + sg.callArguments(e) + ")"
+	case *ast.IndexExpression:
+		if isFloat(sg.types, e.Index) {
+			// Un indice de tableau est un Int
+			return sg.GenerateExpression(e.Left) + "[Int(" + sg.GenerateExpression(e.Index) + ")]"
+		}
+		return sg.GenerateExpression(e.Left) + "[" + sg.GenerateExpression(e.Index) + "]"
+	case *ast.DotExpression:
+		// self.#count -> self.count : le membre est private
+		e = privateAccess(e, bareName)
+		if _, ok := namespaceAccess(e, sg.namespaces); ok {
+			// Geometry.area -> area : le namespace a été aplati
+			return sg.GenerateExpression(&ast.Identifier{Value: e.Property})
+		}
+		return sg.GenerateExpression(e.Object) + "." + e.Property
+	case *ast.SpreadElement:
+		// Swift ne sait pas étaler un tableau dans un variadique : on passe le tableau
+		return sg.GenerateExpression(e.Argument)
+	case *ast.AssignmentExpression:
+		// Swift n'a pas de ++ / --
+		switch e.Operator {
+		case "++":
+			return sg.GenerateExpression(e.Left) + " += 1"
+		case "--":
+			return sg.GenerateExpression(e.Left) + " -= 1"
+		}
+		return sg.GenerateExpression(e.Left) + " " + e.Operator + " " + sg.GenerateExpression(e.Right)
+	case *ast.NewExpression:
+		// Les arguments de type d'un appel générique sont inférés, mais ceux
+		// d'une instanciation doivent être explicites : Box<String>("hi")
+		class := sg.GenerateExpression(e.Class)
+		if class == "Map" && !sg.typeNames[class] && len(e.Arguments) == 0 {
+			// new Map<K, V>() -> [K: V]()
+			return sg.mapType(newMapType(e.TypeArguments)) + "()"
+		}
+		return class + sg.typeArguments(e.TypeArguments) + "(" + sg.generateArguments(e.Arguments) + ")"
+	case *ast.AwaitExpression:
+		// Une fonction async throws s'appelle avec try
+		return "try await " + sg.GenerateExpression(e.Argument)
+	case *ast.AsExpression:
+		if t := sg.mapType(e.Type); castsTo(e.Type) && t != "Any" {
+			// Conversion forcée : value as Shape -> (value as! Shape)
+			return "(" + assertedOperand(e.Expression, sg.GenerateExpression) + " as! " + t + ")"
+		}
+		return assertedOperand(e.Expression, sg.GenerateExpression)
+	case *ast.SatisfiesExpression:
+		return assertedOperand(e.Expression, sg.GenerateExpression)
+	case *ast.NonNullExpression:
+		// Déballage forcé d'un optionnel : user!.name
+		if sg.types != nil && !sg.types.Nullable(e.Expression) {
+			return sg.GenerateExpression(e.Expression)
+		}
+		return assertedOperand(e.Expression, sg.GenerateExpression) + "!"
+	case *ast.ArrowFunction:
+		return sg.GenerateClosure(e)
+	}
+	return ""
+}
+
+// GenerateClosure génère une closure : { (x: Int) -> Int in x * 2 }
+func (sg *SwiftGenerator) GenerateClosure(af *ast.ArrowFunction) string {
+	var params []string
+	for _, param := range af.Parameters {
+		if param.Type != "" {
+			params = append(params, param.Name+": "+sg.mapType(param.Type))
+		} else {
+			params = append(params, param.Name)
+		}
+	}
+	signature := "{ (" + strings.Join(params, ", ") + ")" + sg.generateEffects(af.ReturnType, af.IsAsync) + " in"
+	if af.ExpressionBody != nil {
+		return signature + " " + sg.GenerateExpression(af.ExpressionBody) + " }"
+	}
+	var body strings.Builder
+	for _, stmt := range af.Body {
+		body.WriteString(sg.GenerateStatement(stmt, "    "))
+	}
+	return signature + "\n" + body.String() + "}"
+}
+
+func (sg *SwiftGenerator) generateArguments(arguments []ast.Expression) string {
+	var args []string
+	for _, arg := range arguments {
+		args = append(args, sg.GenerateExpression(arg))
+	}
+	return strings.Join(args, ", ")
+}
+
+// callArguments génère les arguments d'un appel ; ceux que reçoit un
+// paramètre rest sont rassemblés en un tableau : f(1, 2) -> f([1, 2])
+func (sg *SwiftGenerator) callArguments(ce *ast.CallExpression) string {
+	rest, restArgs, ok := restCall(sg.rests, ce)
+	if !ok {
+		return sg.generateArguments(ce.Arguments)
+	}
+	args := sg.generateArguments(ce.Arguments[:rest.index])
+	if args != "" {
+		args += ", "
+	}
+	if array, ok := soleSpread(restArgs); ok {
+		return args + sg.GenerateExpression(array)
+	}
+	return args + sg.GenerateArrayLiteral(&ast.ArrayLiteral{Elements: restArgs})
+}
+
+func (sg *SwiftGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
+	elements := flattenSpreads(al.Elements)
+	if !hasSpread(elements) {
+		return "[" + sg.generateArguments(elements) + "]"
+	}
+
+	// [...a, 1, ...b] -> a + [1] + b
+	var segments []string
+	var pending []ast.Expression
+	flush := func() {
+		if len(pending) > 0 {
+			segments = append(segments, "["+sg.generateArguments(pending)+"]")
+			pending = nil
+		}
+	}
+	for _, element := range elements {
+		if spread, ok := element.(*ast.SpreadElement); ok {
+			flush()
+			segments = append(segments, sg.GenerateExpression(spread.Argument))
+		} else {
+			pending = append(pending, element)
+		}
+	}
+	flush()
+	return strings.Join(segments, " + ")
+}
+
+func (sg *SwiftGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) string {
+	if hasSpreadProperty(ol.Properties) {
+		// { ...a, x: 1 } -> a.merging(["x": 1]) { _, new in new }
+		var result string
+		for i, group := range splitSpreadProperties(ol.Properties) {
+			var part string
+			if spread, ok := group[0].Value.(*ast.SpreadElement); ok {
+				part = sg.GenerateExpression(spread.Argument)
+			} else {
+				part = sg.GenerateObjectLiteral(&ast.ObjectLiteral{Properties: group})
+			}
+			if i == 0 {
+				result = part
+			} else {
+				result += ".merging(" + part + ") { _, new in new }"
+			}
+		}
+		return result
+	}
+
+	if len(ol.Properties) == 0 {
+		return "[:]"
+	}
+	var entries []string
+	for _, prop := range ol.Properties {
+		entries = append(entries, "\""+prop.Key+"\": "+sg.GenerateExpression(prop.Value))
+	}
+	return "[" + strings.Join(entries, ", ") + "]"
+}
+
+func (sg *SwiftGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
+	if alias, ok := sg.literals[sl]; ok {
+		member := enumMemberName(sl.Value)
+		return alias + "." + member
+	}
 	return "\"" + sl.Value + "\""
 }
 
-func (gg *GoGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
+func (sg *SwiftGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
 	return nl.Value
 }
 
-func (gg *GoGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
+func (sg *SwiftGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
 	if bl.Value {
 		return "true"
 	}
 	return "false"
 }
 
-// RustGenerator génère du code Rust
-type RustGenerator struct{}
+// PHPGenerator génère du code PHP
+type PHPGenerator struct {
+	enums   map[string]*ast.EnumDeclaration  // Status.Pending -> Status::Pending
+	classes map[string]*ast.ClassDeclaration // Box.count -> Box::$count
 
-func (rg *RustGenerator) Generate(statements []ast.Statement) string {
+	closures  map[string]bool // variables contenant une closure, appelées $f(x)
+	accessors map[string]bool // propriétés get/set : $x->getTotal(), $x->setTotal($v)
+
+	interfaces map[string]*ast.Interface             // propriétés d'interface, implémentées par des méthodes
+	namespaces map[string]map[string]namespaceMember // Geometry.area -> area
+}
+
+func (pg *PHPGenerator) Generate(statements []ast.Statement) string {
 	var sb strings.Builder
 
-	sb.WriteString("fn main() {\n")
+	sb.WriteString("<?php\n\n")
+
+	// PHP n'a pas d'export : tout ce qui est déclaré est visible une fois le
+	// fichier inclus
+	statements, imports, _ := splitModuleStatements(statements)
+	// Pas de namespace en PHP : leurs déclarations sont générées au niveau
+	// du programme et Geometry.area devient area
+	pg.namespaces = collectNamespaces(statements)
+	statements = flattenNamespaces(statements)
+	sb.WriteString(pg.generateImports(imports))
+
+	pg.enums = collectEnums(statements)
+	pg.closures = map[string]bool{}
+	pg.accessors = collectAccessors(statements)
+	pg.interfaces = collectInterfaces(statements)
+	pg.classes = map[string]*ast.ClassDeclaration{}
+	for _, stmt := range statements {
+		if cd, ok := stmt.(*ast.ClassDeclaration); ok {
+			pg.classes[cd.Name] = cd
+		}
+	}
 
 	for _, stmt := range statements {
 		switch s := stmt.(type) {
-		case *ast.VariableDeclaration:
-			sb.WriteString("    ")
-			sb.WriteString(rg.GenerateVariableDeclaration(s))
+		case *ast.FunctionDeclaration:
+			// PHP n'a pas de surcharge : l'implémentation reste la seule fonction
+			sb.WriteString(overloadComments(s, "//") + pg.GenerateFunction(s))
+		case *ast.EnumDeclaration:
+			sb.WriteString(pg.GenerateEnum(s))
+		case *ast.Interface:
+			sb.WriteString(pg.GenerateInterface(s))
+		case *ast.ClassDeclaration:
+			sb.WriteString(pg.GenerateClass(s))
+		default:
+			sb.WriteString(pg.GenerateStatement(stmt, ""))
 		}
 	}
 
-	sb.WriteString("}\n")
 	return sb.String()
 }
 
-func (rg *RustGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
-	var sb strings.Builder
+// GenerateEnum génère un backed enum PHP 8.1 (int ou string)
+func (pg *PHPGenerator) GenerateEnum(ed *ast.EnumDeclaration) string {
+	members := resolveEnumMembers(ed)
+	stringEnum := isStringEnum(members)
 
-	if vd.IsConst {
-		sb.WriteString("const ")
+	var sb strings.Builder
+	if stringEnum {
+		sb.WriteString("enum " + ed.Name + ": string {\n")
 	} else {
-		sb.WriteString("let ")
+		sb.WriteString("enum " + ed.Name + ": int {\n")
 	}
+	for _, member := range members {
+		value := pg.GenerateExpression(member.Value)
+		if stringEnum {
+			value = "\"" + enumValueString(member.Value) + "\""
+		}
+		sb.WriteString("    case " + member.Name + " = " + value + ";\n")
+	}
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
 
-	sb.WriteString(vd.Name)
-	sb.WriteString(": ")
-
-	// Déterminer le type Rust
-	switch vd.Value.(type) {
-	case *ast.StringLiteral:
-		sb.WriteString("&str")
-	case *ast.NumberLiteral:
-		sb.WriteString("i32")
-	case *ast.BooleanLiteral:
-		sb.WriteString("bool")
-	default:
-		sb.WriteString("_")
+// generateImports génère les inclusions : un fichier du projet est inclus par
+// require_once, un package externe est chargé par l'autoloader Composer et
+// ses noms importés par use (types) ou use function (fonctions)
+func (pg *PHPGenerator) generateImports(imports []*ast.ImportDeclaration) string {
+	var requires, uses []string
+	seen := map[string]bool{}
+	require := func(line string) {
+		if !seen[line] {
+			seen[line] = true
+			requires = append(requires, line)
+		}
 	}
 
-	sb.WriteString(" = ")
+	for _, id := range imports {
+		if isRelativeModule(id.Source) {
+			path := strings.TrimPrefix(id.Source, "./")
+			for _, ext := range []string{".ts", ".tsx", ".js"} {
+				path = strings.TrimSuffix(path, ext)
+			}
+			require("require_once __DIR__ . '/" + path + ".php';")
+			continue
+		}
 
-	switch val := vd.Value.(type) {
-	case *ast.StringLiteral:
-		sb.WriteString(rg.GenerateStringLiteral(val))
-	case *ast.NumberLiteral:
-		sb.WriteString(rg.GenerateNumberLiteral(val))
-	case *ast.BooleanLiteral:
-		sb.WriteString(rg.GenerateBooleanLiteral(val))
+		require("require_once __DIR__ . '/vendor/autoload.php';")
+		_, segments := modulePath(id.Source)
+		if len(segments) == 0 {
+			continue
+		}
+		var parts []string
+		for _, segment := range segments {
+			parts = append(parts, capitalize(segment))
+		}
+		namespace := strings.Join(parts, "\\")
+
+		if alias := capitalize(id.Namespace); alias == parts[len(parts)-1] {
+			uses = append(uses, "use "+namespace+";")
+		} else if id.Namespace != "" {
+			uses = append(uses, "use "+namespace+" as "+alias+";")
+		}
+		specs := id.Specifiers
+		if id.Default != "" {
+			specs = append([]ast.ImportSpecifier{{Name: id.Default}}, specs...)
+		}
+		for _, spec := range specs {
+			line := "use "
+			if !isTypeName(spec.Name) {
+				line += "function "
+			}
+			line += namespace + "\\" + spec.Name
+			if spec.Alias != "" {
+				line += " as " + spec.Alias
+			}
+			uses = append(uses, line+";")
+		}
 	}
 
-	sb.WriteString(";\n")
+	var sb strings.Builder
+	for _, line := range requires {
+		sb.WriteString(line + "\n")
+	}
+	for _, line := range uses {
+		sb.WriteString(line + "\n")
+	}
+	if sb.Len() > 0 {
+		sb.WriteString("\n")
+	}
 	return sb.String()
 }
 
-func (rg *RustGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
-	return "\"" + sl.Value + "\""
-}
-
-func (rg *RustGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
-	return nl.Value
+// GenerateInterface génère une interface PHP ; PHP n'y admet que des
+// méthodes, les propriétés deviennent des accesseurs. Les paramètres de type
+// sont effacés.
+func (pg *PHPGenerator) GenerateInterface(i *ast.Interface) string {
+	var sb strings.Builder
+	bases, rejected := classBases(i.Extends, pg.classes)
+	for _, class := range rejected {
+		sb.WriteString("// " + i.Name + " étend la classe " + class + " : " + interfaceExtendsClass[PHP] + "\n")
+	}
+	sb.WriteString("interface " + i.Name)
+	if len(bases) > 0 {
+		sb.WriteString(" extends " + pg.typeNameList(bases))
+	}
+	sb.WriteString(" {\n")
+	for _, field := range i.Fields {
+		if field.IsMethod {
+			sb.WriteString("    public function " + field.Name + "(" + pg.generateParameters(field.Parameters) + ");\n")
+		} else {
+			sb.WriteString("    public function " + field.Name + "();\n")
+		}
+	}
+	sb.WriteString("}\n\n")
+	return sb.String()
 }
 
-func (rg *RustGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
-	if bl.Value {
-		return "true"
+// typeNameList nomme des types en effaçant leurs arguments : Repo<T>, Named -> Repo, Named
+func (pg *PHPGenerator) typeNameList(types []string) string {
+	var names []string
+	for _, t := range types {
+		name, _ := splitTypeArguments(t)
+		names = append(names, name)
 	}
-	return "false"
+	return strings.Join(names, ", ")
 }
 
-// SwiftGenerator génère du code Swift
-type SwiftGenerator struct{}
+// GenerateClass génère une classe PHP ; le constructeur devient __construct
+// et les paramètres de type sont effacés
+func (pg *PHPGenerator) GenerateClass(cd *ast.ClassDeclaration) string {
+	cd = withParameterProperties(cd)
+	implemented, rejected := classBases(cd.Implements, pg.classes)
 
-func (sg *SwiftGenerator) Generate(statements []ast.Statement) string {
 	var sb strings.Builder
+	sb.WriteString(implementedClassComments(cd.Name, rejected, PHP, ""))
+	if cd.IsAbstract {
+		sb.WriteString("abstract ")
+	}
+	sb.WriteString("class " + cd.Name)
+	if cd.SuperClass != "" {
+		sb.WriteString(" extends " + superClassName(cd))
+	}
+	if len(implemented) > 0 {
+		sb.WriteString(" implements " + pg.typeNameList(implemented))
+	}
+	sb.WriteString(" {\n")
 
-	for _, stmt := range statements {
-		switch s := stmt.(type) {
-		case *ast.VariableDeclaration:
-			sb.WriteString(sg.GenerateVariableDeclaration(s))
+	var members []string
+	var fields strings.Builder
+	for _, field := range cd.Fields {
+		fields.WriteString("    " + pg.memberModifiers(memberAccess(field.IsPrivate, field.IsProtected), field.IsStatic) + "$" + field.Name)
+		if field.HasDefault && (field.IsStatic || pg.isConstantDefault(field.Default)) {
+			fields.WriteString(" = " + pg.GenerateExpression(field.Default))
 		}
+		fields.WriteString(";\n")
 	}
+	if fields.Len() > 0 {
+		members = append(members, fields.String())
+	}
+
+	if needsConstructor(cd) {
+		// Les valeurs constantes sont déjà dans la déclaration des propriétés
+		var body []ast.Statement
+		for _, field := range cd.Fields {
+			if !field.IsStatic && field.HasDefault && !pg.isConstantDefault(field.Default) {
+				body = append(body, fieldInitializer(field))
+			}
+		}
+		var params []ast.Parameter
+		if ctor := findConstructor(cd); ctor != nil {
+			body = append(body, ctor.Body...)
+			params = ctor.Parameters
+		}
+		members = append(members, pg.generateMethod("public ", "__construct", params, body))
+	}
+
+	for _, method := range append(accessorMethods(cd, getterName, setterName), regularMethods(cd)...) {
+		if method.IsAbstract {
+			members = append(members, "    abstract "+pg.memberModifiers(memberAccess(method.IsPrivate, method.IsProtected), method.IsStatic)+"function "+method.Name+"("+pg.generateParameters(method.Parameters)+");\n")
+			continue
+		}
+		members = append(members, pg.generateMethod(pg.memberModifiers(memberAccess(method.IsPrivate, method.IsProtected), method.IsStatic), method.Name, method.Parameters, method.Body))
+	}
+
+	// Les propriétés d'une interface PHP sont des méthodes : name() renvoie le champ
+	for _, property := range backedInterfaceProperties(cd, pg.classes, pg.interfaces) {
+		body := []ast.Statement{&ast.ReturnStatement{Value: &ast.DotExpression{Object: &ast.Identifier{Value: "this"}, Property: property.Name}}}
+		members = append(members, pg.generateMethod("public ", property.Name, nil, body))
+	}
+
+	sb.WriteString(strings.Join(members, "\n"))
+	sb.WriteString("}\n\n")
 
+	// Les blocs static { ... } s'exécutent une fois la classe définie
+	if statics := staticBlockStatements(cd); len(statics) > 0 {
+		for _, stmt := range statics {
+			sb.WriteString(pg.GenerateStatement(stmt, ""))
+		}
+		sb.WriteString("\n")
+	}
 	return sb.String()
 }
 
-func (sg *SwiftGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
-	var sb strings.Builder
+func (pg *PHPGenerator) memberModifiers(access string, isStatic bool) string {
+	modifiers := access + " "
+	if isStatic {
+		modifiers += "static "
+	}
+	return modifiers
+}
 
-	if vd.IsConst {
-		sb.WriteString("let ")
-	} else {
-		sb.WriteString("var ")
+func (pg *PHPGenerator) generateMethod(modifiers, name string, params []ast.Parameter, body []ast.Statement) string {
+	var sb strings.Builder
+	sb.WriteString("    " + modifiers + "function " + name + "(" + pg.generateParameters(params) + ") {\n")
+	sb.WriteString(pg.generateDefaults(params, "        "))
+	for _, stmt := range body {
+		sb.WriteString(pg.GenerateStatement(stmt, "        "))
 	}
+	sb.WriteString("    }\n")
+	return sb.String()
+}
 
-	sb.WriteString(vd.Name)
-	sb.WriteString(": ")
+func (pg *PHPGenerator) GenerateFunction(fd *ast.FunctionDeclaration) string {
+	var sb strings.Builder
 
-	// Déterminer le type Swift
-	switch vd.Value.(type) {
-	case *ast.StringLiteral:
-		sb.WriteString("String")
-	case *ast.NumberLiteral:
-		sb.WriteString("Int")
-	case *ast.BooleanLiteral:
-		sb.WriteString("Bool")
-	default:
-		sb.WriteString("Any")
-	}
+	sb.WriteString("function ")
+	sb.WriteString(fd.Name)
+	sb.WriteString("(")
+	sb.WriteString(pg.generateParameters(fd.Parameters))
+	sb.WriteString(") {\n")
 
-	sb.WriteString(" = ")
+	sb.WriteString(pg.generateDefaults(fd.Parameters, "    "))
 
-	switch val := vd.Value.(type) {
-	case *ast.StringLiteral:
-		sb.WriteString(sg.GenerateStringLiteral(val))
-	case *ast.NumberLiteral:
-		sb.WriteString(sg.GenerateNumberLiteral(val))
-	case *ast.BooleanLiteral:
-		sb.WriteString(sg.GenerateBooleanLiteral(val))
+	for _, stmt := range fd.Body {
+		sb.WriteString(pg.GenerateStatement(stmt, "    "))
 	}
 
-	sb.WriteString("\n")
+	sb.WriteString("}\n\n")
 	return sb.String()
 }
 
-func (sg *SwiftGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
-	return "\"" + sl.Value + "\""
+// generateParameters génère la liste des paramètres ; un paramètre rest
+// devient ...$args
+func (pg *PHPGenerator) generateParameters(params []ast.Parameter) string {
+	var parts []string
+	for _, param := range params {
+		part := "$" + param.Name
+		if param.IsRest {
+			part = "..." + part
+		}
+		if param.CanBeOmitted() {
+			if pg.isConstantDefault(param.Default) {
+				part += " = " + pg.GenerateExpression(param.Default)
+			} else {
+				part += " = null"
+			}
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
 }
 
-func (sg *SwiftGenerator) GenerateNumberLiteral(nl *ast.NumberLiteral) string {
-	return nl.Value
+// generateDefaults applique en tête de corps les valeurs par défaut : PHP
+// n'accepte que des expressions constantes dans la signature
+func (pg *PHPGenerator) generateDefaults(params []ast.Parameter, indent string) string {
+	var sb strings.Builder
+	for _, param := range params {
+		if param.Default != nil && !pg.isConstantDefault(param.Default) {
+			sb.WriteString(indent + "$" + param.Name + " ??= " + pg.GenerateExpression(param.Default) + ";\n")
+		}
+	}
+	return sb.String()
 }
 
-func (sg *SwiftGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
-	if bl.Value {
-		return "true"
+// isConstantDefault indique si une valeur par défaut est une expression
+// constante PHP (littéral ou tableau de littéraux)
+func (pg *PHPGenerator) isConstantDefault(expr ast.Expression) bool {
+	if expr == nil {
+		return false
 	}
-	return "false"
+	switch e := expr.(type) {
+	case *ast.ArrayLiteral:
+		for _, element := range e.Elements {
+			if !pg.isConstantDefault(element) {
+				return false
+			}
+		}
+		return true
+	case *ast.ObjectLiteral:
+		for _, prop := range e.Properties {
+			if !pg.isConstantDefault(prop.Value) {
+				return false
+			}
+		}
+		return true
+	}
+	return isLiteralExpression(expr)
 }
 
-// PHPGenerator génère du code PHP
-type PHPGenerator struct{}
+func (pg *PHPGenerator) GenerateStatement(stmt ast.Statement, indent string) string {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		return indentLines(pg.GenerateVariableDeclaration(s), indent)
+	case *ast.ReturnStatement:
+		if s.Value != nil {
+			return indentLines("return "+pg.GenerateExpression(s.Value)+";\n", indent)
+		}
+		return indent + "return;\n"
+	case *ast.ExpressionStatement:
+		// Convertir console.log en echo
+		if callExpr, ok := s.Expression.(*ast.CallExpression); ok && isConsoleLog(callExpr) {
+			var args []string
+			for _, arg := range callExpr.Arguments {
+				args = append(args, pg.GenerateExpression(arg))
+			}
+			return indentLines("echo "+strings.Join(args, " . \" \" . ")+" . PHP_EOL;\n", indent)
+		}
+		return indentLines(pg.GenerateExpression(s.Expression)+";\n", indent)
+	case *ast.IfStatement:
+		var sb strings.Builder
+		sb.WriteString(indent + "if (" + pg.GenerateExpression(s.Condition) + ") ")
+		sb.WriteString(pg.GenerateBlock(s.ThenBranch, indent))
+		if s.ElseBranch != nil {
+			sb.WriteString(" else ")
+			sb.WriteString(pg.GenerateBlock(s.ElseBranch, indent))
+		}
+		sb.WriteString("\n")
+		return sb.String()
+	case *ast.WhileStatement:
+		return indent + "while (" + pg.GenerateExpression(s.Condition) + ") " + pg.GenerateBlock(s.Body, indent) + "\n"
+	case *ast.ForStatement:
+		var init, update string
+		if s.Init != nil {
+			init = strings.TrimSuffix(pg.GenerateStatement(s.Init, ""), ";\n")
+		}
+		if s.Update != nil {
+			update = strings.TrimSuffix(pg.GenerateStatement(s.Update, ""), ";\n")
+		}
+		var condition string
+		if s.Condition != nil {
+			condition = pg.GenerateExpression(s.Condition)
+		}
+		return indent + "for (" + init + "; " + condition + "; " + update + ") " + pg.GenerateBlock(s.Body, indent) + "\n"
+	}
+	return ""
+}
 
-func (pg *PHPGenerator) Generate(statements []ast.Statement) string {
+func (pg *PHPGenerator) GenerateBlock(stmt ast.Statement, indent string) string {
 	var sb strings.Builder
-
-	sb.WriteString("<?php\n\n")
-
-	for _, stmt := range statements {
-		switch s := stmt.(type) {
-		case *ast.VariableDeclaration:
-			sb.WriteString(pg.GenerateVariableDeclaration(s))
+	sb.WriteString("{\n")
+	if blockStmt, ok := stmt.(*ast.BlockStatement); ok {
+		for _, inner := range blockStmt.Statements {
+			sb.WriteString(pg.GenerateStatement(inner, indent+"    "))
 		}
 	}
-
+	sb.WriteString(indent + "}")
 	return sb.String()
 }
 
 func (pg *PHPGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration) string {
 	var sb strings.Builder
 
+	if _, ok := vd.Value.(*ast.ArrowFunction); ok {
+		pg.closures[vd.Name] = true
+	}
+
 	sb.WriteString("$")
 	sb.WriteString(vd.Name)
 	sb.WriteString(" = ")
@@ -1281,12 +9090,177 @@ func (pg *PHPGenerator) GenerateVariableDeclaration(vd *ast.VariableDeclaration)
 		sb.WriteString(pg.GenerateNumberLiteral(val))
 	case *ast.BooleanLiteral:
 		sb.WriteString(pg.GenerateBooleanLiteral(val))
+	default:
+		sb.WriteString(pg.GenerateExpression(val))
 	}
 
 	sb.WriteString(";\n")
 	return sb.String()
 }
 
+func (pg *PHPGenerator) GenerateExpression(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.StringLiteral:
+		return pg.GenerateStringLiteral(e)
+	case *ast.NumberLiteral:
+		return pg.GenerateNumberLiteral(e)
+	case *ast.BooleanLiteral:
+		return pg.GenerateBooleanLiteral(e)
+	case *ast.Identifier:
+		return "$" + e.Value
+	case *ast.ParenthesizedExpression:
+		return "(" + pg.GenerateExpression(e.Expression) + ")"
+	case *ast.InfixExpression:
+		operator := e.Operator
+		// La concaténation de chaînes s'écrit avec '.' en PHP
+		if operator == "+" && (isStringExpression(e.Left) || isStringExpression(e.Right)) {
+			operator = "."
+		}
+		return pg.GenerateExpression(e.Left) + " " + operator + " " + pg.GenerateExpression(e.Right)
+	case *ast.ArrayLiteral:
+		return pg.GenerateArrayLiteral(e)
+	case *ast.ObjectLiteral:
+		return pg.GenerateObjectLiteral(e)
+	case *ast.CallExpression:
+		return pg.GenerateCallExpression(e)
+	case *ast.IndexExpression:
+		return pg.GenerateExpression(e.Left) + "[" + pg.GenerateExpression(e.Index) + "]"
+	case *ast.DotExpression:
+		// $this->#count -> $this->count : la propriété est private
+		e = privateAccess(e, bareName)
+		if _, ok := namespaceAccess(e, pg.namespaces); ok {
+			// Geometry.area -> area : le namespace a été aplati
+			return pg.GenerateExpression(&ast.Identifier{Value: e.Property})
+		}
+		if ed, member := enumMemberAccess(pg.enums, e); member != nil {
+			return ed.Name + "::" + member.Name
+		}
+		if isSuperMember(e) {
+			return "parent::" + e.Property
+		}
+		if ident, ok := e.Object.(*ast.Identifier); ok && pg.classes[ident.Value] != nil {
+			// Membre statique : Box::$count pour un champ, Box::of pour une méthode
+			for _, field := range pg.classes[ident.Value].Fields {
+				if field.IsStatic && field.Name == e.Property {
+					return ident.Value + "::$" + e.Property
+				}
+			}
+			return ident.Value + "::" + e.Property
+		}
+		if pg.accessors[e.Property] {
+			return pg.GenerateExpression(e.Object) + "->" + getterName(e.Property) + "()"
+		}
+		return pg.GenerateExpression(e.Object) + "->" + e.Property
+	case *ast.SpreadElement:
+		return "..." + pg.GenerateExpression(e.Argument)
+	case *ast.AssignmentExpression:
+		if property, value := accessorAssignment(e, pg.accessors); property != nil {
+			return pg.GenerateExpression(property.Object) + "->" + setterName(property.Property) + "(" + pg.GenerateExpression(value) + ")"
+		}
+		if e.Operator == "++" || e.Operator == "--" {
+			return pg.GenerateExpression(e.Left) + e.Operator
+		}
+		return pg.GenerateExpression(e.Left) + " " + e.Operator + " " + pg.GenerateExpression(e.Right)
+	case *ast.NewExpression:
+		// Les arguments de type sont effacés ; le nom de classe ne prend pas de '$'
+		var args []string
+		for _, arg := range e.Arguments {
+			args = append(args, pg.GenerateExpression(arg))
+		}
+		class := pg.GenerateExpression(e.Class)
+		if ident, ok := flattenedAccess(e.Class, pg.namespaces).(*ast.Identifier); ok {
+			class = ident.Value
+		}
+		if class == "Map" && pg.classes[class] == nil && len(args) == 0 {
+			// new Map<K, V>() -> [] : un tableau PHP est associatif
+			return "[]"
+		}
+		return "new " + class + "(" + strings.Join(args, ", ") + ")"
+	case *ast.AwaitExpression:
+		// PHP n'a pas d'async : les fonctions sont exécutées de façon synchrone
+		return pg.GenerateExpression(e.Argument)
+	case *ast.YieldExpression:
+		// Une fonction qui contient yield est un générateur PHP
+		if e.Delegate {
+			return "yield from " + pg.GenerateExpression(e.Argument)
+		}
+		if e.Argument == nil {
+			return "yield"
+		}
+		return "yield " + pg.GenerateExpression(e.Argument)
+	case *ast.AsExpression:
+		return assertedOperand(e.Expression, pg.GenerateExpression)
+	case *ast.SatisfiesExpression:
+		return assertedOperand(e.Expression, pg.GenerateExpression)
+	case *ast.NonNullExpression:
+		return assertedOperand(e.Expression, pg.GenerateExpression)
+	case *ast.ArrowFunction:
+		return pg.GenerateClosure(e)
+	}
+	return ""
+}
+
+// GenerateClosure génère une arrow function fn($x) => $x * 2, ou une fonction
+// anonyme pour un corps en bloc
+func (pg *PHPGenerator) GenerateClosure(af *ast.ArrowFunction) string {
+	params := pg.generateParameters(af.Parameters)
+	if af.ExpressionBody != nil {
+		return "fn(" + params + ") => " + pg.GenerateExpression(af.ExpressionBody)
+	}
+	var body strings.Builder
+	body.WriteString(pg.generateDefaults(af.Parameters, "    "))
+	for _, stmt := range af.Body {
+		body.WriteString(pg.GenerateStatement(stmt, "    "))
+	}
+	return "function (" + params + ") {\n" + body.String() + "}"
+}
+
+func (pg *PHPGenerator) GenerateCallExpression(ce *ast.CallExpression) string {
+	var args []string
+	for _, arg := range ce.Arguments {
+		args = append(args, pg.GenerateExpression(arg))
+	}
+
+	// Les noms de fonctions ne prennent pas de '$', contrairement aux closures
+	callee := pg.GenerateExpression(ce.Function)
+	if ident, ok := flattenedAccess(ce.Function, pg.namespaces).(*ast.Identifier); ok && !pg.closures[ident.Value] {
+		callee = ident.Value
+		if ident.Value == "super" {
+			callee = "parent::__construct"
+		}
+	}
+	return callee + "(" + strings.Join(args, ", ") + ")"
+}
+
+func (pg *PHPGenerator) GenerateArrayLiteral(al *ast.ArrayLiteral) string {
+	var elements []string
+	for _, element := range al.Elements {
+		elements = append(elements, pg.GenerateExpression(element))
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+func (pg *PHPGenerator) GenerateObjectLiteral(ol *ast.ObjectLiteral) string {
+	if hasSpreadProperty(ol.Properties) {
+		// { ...a, x: 1 } -> array_merge($a, ['x' => 1])
+		var parts []string
+		for _, group := range splitSpreadProperties(ol.Properties) {
+			if spread, ok := group[0].Value.(*ast.SpreadElement); ok {
+				parts = append(parts, pg.GenerateExpression(spread.Argument))
+			} else {
+				parts = append(parts, pg.GenerateObjectLiteral(&ast.ObjectLiteral{Properties: group}))
+			}
+		}
+		return "array_merge(" + strings.Join(parts, ", ") + ")"
+	}
+
+	var entries []string
+	for _, prop := range ol.Properties {
+		entries = append(entries, "'"+prop.Key+"' => "+pg.GenerateExpression(prop.Value))
+	}
+	return "[" + strings.Join(entries, ", ") + "]"
+}
+
 func (pg *PHPGenerator) GenerateStringLiteral(sl *ast.StringLiteral) string {
 	return "\"" + sl.Value + "\""
 }
@@ -1304,5 +9278,5 @@ func (pg *PHPGenerator) GenerateBooleanLiteral(bl *ast.BooleanLiteral) string {
 
 // Fonction de compatibilité pour l'ancien code
 func GenerateJS(statements []ast.Statement) string {
-	return Generate(statements, JavaScript)
+	return Generate(&ast.Program{Statements: statements}, JavaScript)
 }
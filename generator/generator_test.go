@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"ProjetGo/lexer"
+	"ProjetGo/parser"
+	"strings"
+	"testing"
+)
+
+// outputCase attend, dans le code généré pour chaque langage cible, les
+// extraits want et aucun des extraits absent
+type outputCase struct {
+	name   string
+	source string
+	want   map[TargetLanguage][]string
+	absent map[TargetLanguage][]string
+}
+
+func generate(source string, target TargetLanguage) string {
+	return Generate(parser.New(lexer.New(source)).ParseProgram(), target)
+}
+
+func runOutputCases(t *testing.T, tests []outputCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, target := range []TargetLanguage{JavaScript, Java, Python, CSharp, Go, Rust, Swift, PHP} {
+				want, absent := tt.want[target], tt.absent[target]
+				if len(want) == 0 && len(absent) == 0 {
+					continue
+				}
+				output := generate(tt.source, target)
+				for _, fragment := range want {
+					if !strings.Contains(output, fragment) {
+						t.Errorf("%s : %q absent de\n%s", target, fragment, output)
+					}
+				}
+				for _, fragment := range absent {
+					if strings.Contains(output, fragment) {
+						t.Errorf("%s : %q inattendu dans\n%s", target, fragment, output)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParenthesizedExpressions(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "priorité changée",
+			source: "function f(a: number, b: number) {\n  let r = (a + b) * 2;\n  return r;\n}",
+			want: map[TargetLanguage][]string{
+				JavaScript: {"let r = (a + b) * 2;"},
+				Java:       {"double r = (a + b) * 2;"},
+				Python:     {"r = (a + b) * 2"},
+				CSharp:     {"double r = (a + b) * 2;"},
+				Go:         {"(a + b) * 2"},
+				Rust:       {"(a + b) * 2.0"},
+				Swift:      {"(a + b) * 2.0"},
+				PHP:        {"$r = ($a + $b) * 2;"},
+			},
+		},
+		{
+			name:   "accès sur une expression parenthésée",
+			source: "let s = (1 + 2).toString();",
+			want: map[TargetLanguage][]string{
+				JavaScript: {"let s = (1 + 2).toString();"},
+			},
+		},
+	})
+}
@@ -0,0 +1,238 @@
+package generator
+
+import "ProjetGo/ast"
+
+// yieldedType renvoie T pour les types d'itération TypeScript (Generator<T>,
+// Iterable<T>, IterableIterator<T>, Iterator<T>) : c'est le type des valeurs
+// produites par un générateur
+func yieldedType(t string) (string, bool) {
+	name, args := splitTypeArguments(t)
+	switch name {
+	case "Generator", "Iterable", "IterableIterator", "Iterator":
+		if len(args) > 0 {
+			return args[0], true
+		}
+	}
+	return "", false
+}
+
+// yieldStatement renvoie le yield d'une instruction yield x; ou nil
+func yieldStatement(stmt ast.Statement) *ast.YieldExpression {
+	if es, ok := stmt.(*ast.ExpressionStatement); ok {
+		if ye, ok := es.Expression.(*ast.YieldExpression); ok {
+			return ye
+		}
+	}
+	return nil
+}
+
+// suspends indique si une instruction contient un yield ou un return : elle
+// doit alors être découpée en états dans les langages sans générateurs natifs
+func suspends(stmt ast.Statement) bool {
+	switch s := stmt.(type) {
+	case *ast.ExpressionStatement:
+		return yieldStatement(s) != nil
+	case *ast.ReturnStatement:
+		return true
+	case *ast.BlockStatement:
+		for _, inner := range s.Statements {
+			if suspends(inner) {
+				return true
+			}
+		}
+	case *ast.IfStatement:
+		return suspends(s.ThenBranch) || (s.ElseBranch != nil && suspends(s.ElseBranch))
+	case *ast.ForStatement:
+		return s.Body != nil && suspends(s.Body)
+	case *ast.WhileStatement:
+		return s.Body != nil && suspends(s.Body)
+	}
+	return false
+}
+
+// generatorExitKind décrit la sortie d'un état de la machine d'un générateur
+type generatorExitKind int
+
+const (
+	exitDone     generatorExitKind = iota // fin de l'itération
+	exitJump                              // passe à Target
+	exitBranch                            // Target si Condition, sinon Else
+	exitYield                             // produit Value, reprend à Target
+	exitDelegate                          // ouvre l'itérateur Delegate sur Value, puis Target
+	exitDrain                             // produit les valeurs de Delegate, puis Target
+)
+
+type generatorExit struct {
+	Kind      generatorExitKind
+	Condition ast.Expression
+	Value     ast.Expression
+	Target    int
+	Else      int
+	Delegate  int
+}
+
+// generatorState est un état de la machine : des instructions exécutées en
+// séquence, puis une sortie
+type generatorState struct {
+	Statements []ast.Statement
+	Exit       generatorExit
+}
+
+// generatorMachine est le corps d'un générateur découpé en états, pour les
+// langages qui l'implémentent sous forme d'itérateur (Java, Rust, Swift).
+// Les variables locales vivent d'un état à l'autre : elles sont remontées
+// dans Locals et leurs déclarations deviennent des affectations.
+type generatorMachine struct {
+	States    []*generatorState
+	Locals    []*ast.VariableDeclaration
+	Delegates int // nombre d'itérateurs ouverts par yield*
+}
+
+// lowerGenerator découpe le corps d'un générateur en machine à états ; l'état
+// 0 est l'état initial
+func lowerGenerator(body []ast.Statement) *generatorMachine {
+	m := &generatorMachine{}
+	m.lower(body, m.newState())
+	return m
+}
+
+func (m *generatorMachine) newState() int {
+	m.States = append(m.States, &generatorState{})
+	return len(m.States) - 1
+}
+
+// lower ajoute des instructions à l'état current et renvoie l'état dans lequel
+// l'exécution se poursuit après elles
+func (m *generatorMachine) lower(statements []ast.Statement, current int) int {
+	for _, stmt := range statements {
+		current = m.lowerStatement(stmt, current)
+	}
+	return current
+}
+
+func (m *generatorMachine) lowerStatement(stmt ast.Statement, current int) int {
+	if stmt == nil {
+		return current
+	}
+	if !suspends(stmt) {
+		if hoisted := m.hoist(stmt); hoisted != nil {
+			m.States[current].Statements = append(m.States[current].Statements, hoisted)
+		}
+		return current
+	}
+
+	switch s := stmt.(type) {
+	case *ast.ExpressionStatement:
+		ye := yieldStatement(s)
+		next := m.newState()
+		if !ye.Delegate {
+			m.States[current].Exit = generatorExit{Kind: exitYield, Value: ye.Argument, Target: next}
+			return next
+		}
+		// yield* : ouverture de l'itérateur, puis un état qui le vide
+		delegate := m.Delegates
+		m.Delegates++
+		after := m.newState()
+		m.States[current].Exit = generatorExit{Kind: exitDelegate, Value: ye.Argument, Target: next, Delegate: delegate}
+		m.States[next].Exit = generatorExit{Kind: exitDrain, Target: after, Delegate: delegate}
+		return after
+	case *ast.ReturnStatement:
+		// La valeur de retour d'un générateur n'est pas produite par l'itération
+		m.States[current].Exit = generatorExit{Kind: exitDone}
+		return m.newState()
+	case *ast.BlockStatement:
+		return m.lower(s.Statements, current)
+	case *ast.IfStatement:
+		then, after := m.newState(), m.newState()
+		otherwise := after
+		if s.ElseBranch != nil {
+			otherwise = m.newState()
+		}
+		m.States[current].Exit = generatorExit{Kind: exitBranch, Condition: s.Condition, Target: then, Else: otherwise}
+		m.jump(m.lowerStatement(s.ThenBranch, then), after)
+		if s.ElseBranch != nil {
+			m.jump(m.lowerStatement(s.ElseBranch, otherwise), after)
+		}
+		return after
+	case *ast.WhileStatement:
+		return m.lowerLoop(s.Condition, s.Body, nil, current)
+	case *ast.ForStatement:
+		current = m.lowerStatement(s.Init, current)
+		return m.lowerLoop(s.Condition, s.Body, s.Update, current)
+	}
+	return current
+}
+
+// lowerLoop découpe une boucle : un état teste la condition, le corps (suivi
+// de la mise à jour d'un for) y revient
+func (m *generatorMachine) lowerLoop(condition ast.Expression, body, update ast.Statement, current int) int {
+	test, start, after := m.newState(), m.newState(), m.newState()
+	m.jump(current, test)
+	if condition != nil {
+		m.States[test].Exit = generatorExit{Kind: exitBranch, Condition: condition, Target: start, Else: after}
+	} else {
+		m.jump(test, start)
+	}
+	end := m.lowerStatement(body, start)
+	end = m.lowerStatement(update, end)
+	m.jump(end, test)
+	return after
+}
+
+// reachable indique pour chaque état s'il peut être atteint depuis l'état
+// initial : un return laisse derrière lui des états inutiles
+func (m *generatorMachine) reachable() []bool {
+	seen := make([]bool, len(m.States))
+	pending := []int{0}
+	for len(pending) > 0 {
+		i := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if seen[i] {
+			continue
+		}
+		seen[i] = true
+		switch exit := m.States[i].Exit; exit.Kind {
+		case exitBranch:
+			pending = append(pending, exit.Target, exit.Else)
+		case exitJump, exitYield, exitDelegate, exitDrain:
+			pending = append(pending, exit.Target)
+		}
+	}
+	return seen
+}
+
+func (m *generatorMachine) jump(from, to int) {
+	m.States[from].Exit = generatorExit{Kind: exitJump, Target: to}
+}
+
+// hoist remonte une déclaration de variable dans Locals et la remplace par
+// une affectation ; les autres instructions sont renvoyées telles quelles
+func (m *generatorMachine) hoist(stmt ast.Statement) ast.Statement {
+	vd, ok := stmt.(*ast.VariableDeclaration)
+	if !ok {
+		return stmt
+	}
+	known := false
+	for _, local := range m.Locals {
+		if local.Name == vd.Name {
+			known = true
+		}
+	}
+	if !known {
+		m.Locals = append(m.Locals, vd)
+	}
+	if vd.Value == nil {
+		return nil
+	}
+	return &ast.ExpressionStatement{Expression: &ast.AssignmentExpression{
+		Left:     &ast.Identifier{Value: vd.Name},
+		Operator: "=",
+		Right:    vd.Value,
+	}}
+}
+
+// iteratorName dérive le nom du type itérateur d'un générateur : range ->
+// RangeIterator, Tree.walk -> TreeWalkIterator
+func iteratorName(owner, name string) string {
+	return owner + capitalize(name) + "Iterator"
+}
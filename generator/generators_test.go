@@ -0,0 +1,43 @@
+package generator
+
+import "testing"
+
+func TestGeneratorYields(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "entier produit dans un Generator<number>",
+			source: "function* gen(): Generator<number> {\n  yield 1;\n}",
+			want: map[TargetLanguage][]string{
+				Java: {"private Double current;", "current = 1.0;"},
+				Rust: {"type Item = f64;", "return Some(1.0);"},
+			},
+			absent: map[TargetLanguage][]string{
+				Java: {"current = 1;"},
+			},
+		},
+		{
+			name:   "compteur produit dans un Generator<number>",
+			source: "function* count(n: number): Generator<number> {\n  for (let i = 0; i < n; i++) {\n    yield i;\n  }\n}",
+			want: map[TargetLanguage][]string{
+				Java: {"current = (double) i;"},
+			},
+		},
+		{
+			name:   "type des valeurs déduit des yield",
+			source: "function* gen() {\n  yield 1;\n  yield 2;\n}\nfunction* words() {\n  yield \"a\";\n}",
+			want: map[TargetLanguage][]string{
+				Rust: {"fn gen() -> impl Iterator<Item = f64> {", "return Some(2.0);", "fn words() -> impl Iterator<Item = String> {", "return Some(\"a\".to_string());"},
+			},
+			absent: map[TargetLanguage][]string{
+				Rust: {"Box<dyn std::any::Any>"},
+			},
+		},
+		{
+			name:   "délégation",
+			source: "function* inner() {\n  yield 1;\n}\nfunction* outer() {\n  yield* inner();\n}\nfunction* loose(items: any) {\n  yield* items;\n}",
+			want: map[TargetLanguage][]string{
+				Rust: {"fn outer() -> impl Iterator<Item = f64> {", "fn loose(items: Box<dyn std::any::Any>) -> impl Iterator<Item = Box<dyn std::any::Any>> {"},
+			},
+		},
+	})
+}
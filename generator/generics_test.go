@@ -0,0 +1,71 @@
+package generator
+
+import "testing"
+
+func TestNewMap(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "new Map<string, number>()",
+			source: "const scores = new Map<string, number>();",
+			want: map[TargetLanguage][]string{
+				Java:   {"new java.util.HashMap<String, Double>()"},
+				CSharp: {"new Dictionary<string, double>()"},
+				Go:     {"var scores map[string]float64 = map[string]float64{}"},
+				Rust:   {"HashMap::<String, f64>::new()"},
+				Swift:  {"let scores: [String: Double] = [String: Double]()"},
+				Python: {"scores = {}"},
+				PHP:    {"$scores = [];"},
+			},
+			absent: map[TargetLanguage][]string{
+				Go:     {"NewMap"},
+				Swift:  {"Map<"},
+				Python: {"Map()"},
+				PHP:    {"new Map"},
+			},
+		},
+		{
+			name:   "new Map() sans arguments de type",
+			source: "const cache = new Map();",
+			want: map[TargetLanguage][]string{
+				Go:    {"map[interface{}]interface{}{}"},
+				Swift: {"[AnyHashable: Any]()"},
+			},
+		},
+		{
+			name:   "classe Map du programme",
+			source: "class Map {\n  size = 0;\n}\nconst m = new Map();",
+			want: map[TargetLanguage][]string{
+				Go:     {"NewMap()"},
+				Swift:  {"Map()"},
+				Python: {"m = Map()"},
+				PHP:    {"new Map()"},
+			},
+		},
+	})
+}
+
+func TestGenericArguments(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "entier passé à un paramètre de type",
+			source: "class Box<T> {\n  constructor(public value: T) {}\n}\nconst b = new Box<number>(3);",
+			want: map[TargetLanguage][]string{
+				Java: {"new Box<Double>(3.0)"},
+			},
+		},
+		{
+			name:   "interface en argument de type d'une fonction bornée",
+			source: "interface Base {\n  size(): number;\n}\nclass Impl implements Base {\n  size(): number {\n    return 2;\n  }\n}\nfunction identity<T extends Base>(x: T): T {\n  return x;\n}\nconst i = identity<Base>(new Impl());",
+			want: map[TargetLanguage][]string{
+				Rust: {"impl Base for Box<dyn Base> {\n    fn size(&self) -> f64 {\n        (**self).size()\n    }\n}", "identity::<Box<dyn Base>>(Box::new(Impl::new()))"},
+			},
+		},
+		{
+			name:   "interface sans borne",
+			source: "interface Base {\n  size(): number;\n}",
+			absent: map[TargetLanguage][]string{
+				Rust: {"for Box<dyn Base>"},
+			},
+		},
+	})
+}
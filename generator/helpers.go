@@ -0,0 +1,210 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// hasSpread indique si une liste d'éléments contient un ...spread
+func hasSpread(elements []ast.Expression) bool {
+	for _, element := range elements {
+		if _, ok := element.(*ast.SpreadElement); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSpreadProperty indique si un objet littéral contient un ...spread
+func hasSpreadProperty(properties []ast.ObjectProperty) bool {
+	for _, prop := range properties {
+		if _, ok := prop.Value.(*ast.SpreadElement); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// flattenSpreads remplace le spread d'un tableau littéral par ses éléments :
+// f(1, ...[2, 3]) -> f(1, 2, 3)
+func flattenSpreads(elements []ast.Expression) []ast.Expression {
+	if !hasSpread(elements) {
+		return elements
+	}
+	var result []ast.Expression
+	for _, element := range elements {
+		if spread, ok := element.(*ast.SpreadElement); ok {
+			if array, ok := spread.Argument.(*ast.ArrayLiteral); ok {
+				result = append(result, flattenSpreads(array.Elements)...)
+				continue
+			}
+		}
+		result = append(result, element)
+	}
+	return result
+}
+
+// soleSpread renvoie xs quand la liste se réduit à ...xs
+func soleSpread(elements []ast.Expression) (ast.Expression, bool) {
+	if len(elements) == 1 {
+		if spread, ok := elements[0].(*ast.SpreadElement); ok {
+			return spread.Argument, true
+		}
+	}
+	return nil, false
+}
+
+// restParameter est le paramètre rest d'une fonction ou d'une méthode, avec sa
+// position
+type restParameter struct {
+	index int
+	param ast.Parameter
+}
+
+// collectRestParameters indexe par nom les fonctions et les méthodes qui ont
+// un paramètre rest : leurs appels regroupent les derniers arguments en un
+// tableau dans les langages où f(1, ...xs) ne s'écrit pas tel quel
+func collectRestParameters(statements []ast.Statement) map[string]restParameter {
+	rests := map[string]restParameter{}
+	add := func(name string, params []ast.Parameter) {
+		if n := len(params); n > 0 && params[n-1].IsRest {
+			rests[name] = restParameter{index: n - 1, param: params[n-1]}
+		}
+	}
+	for _, stmt := range declaredStatements(statements) {
+		switch s := stmt.(type) {
+		case *ast.FunctionDeclaration:
+			add(s.Name, s.Parameters)
+		case *ast.ClassDeclaration:
+			for _, method := range s.Methods {
+				add(method.Name, method.Parameters)
+			}
+		}
+	}
+	return rests
+}
+
+// restCall renvoie le paramètre rest de la fonction ou de la méthode appelée,
+// et les arguments qu'il reçoit : ceux qui suivent les paramètres fixes
+func restCall(rests map[string]restParameter, ce *ast.CallExpression) (restParameter, []ast.Expression, bool) {
+	var name string
+	switch fn := ce.Function.(type) {
+	case *ast.Identifier:
+		name = fn.Value
+	case *ast.DotExpression:
+		name = fn.Property
+	}
+	rest, ok := rests[name]
+	if !ok || len(ce.Arguments) < rest.index {
+		return restParameter{}, nil, false
+	}
+	return rest, flattenSpreads(ce.Arguments[rest.index:]), true
+}
+
+// splitSpreadProperties découpe les propriétés d'un objet en groupes à fusionner
+// dans l'ordre : chaque spread forme son propre groupe, les propriétés simples
+// consécutives sont regroupées. { ...a, x: 1, y: 2, ...b } -> [a] [x y] [b]
+func splitSpreadProperties(properties []ast.ObjectProperty) [][]ast.ObjectProperty {
+	var groups [][]ast.ObjectProperty
+	var pending []ast.ObjectProperty
+
+	for _, prop := range properties {
+		if _, ok := prop.Value.(*ast.SpreadElement); ok {
+			if len(pending) > 0 {
+				groups = append(groups, pending)
+				pending = nil
+			}
+			groups = append(groups, []ast.ObjectProperty{prop})
+		} else {
+			pending = append(pending, prop)
+		}
+	}
+	if len(pending) > 0 {
+		groups = append(groups, pending)
+	}
+
+	return groups
+}
+
+// isConsoleLog indique si un appel est console.log(...)
+func isConsoleLog(ce *ast.CallExpression) bool {
+	if dotExpr, ok := ce.Function.(*ast.DotExpression); ok {
+		if ident, ok := dotExpr.Object.(*ast.Identifier); ok {
+			return ident.Value == "console" && dotExpr.Property == "log"
+		}
+	}
+	return false
+}
+
+// splitStatements sépare les déclarations de fonctions du reste du programme,
+// pour les langages où le code de haut niveau vit dans un point d'entrée main
+func splitStatements(statements []ast.Statement) (functions []*ast.FunctionDeclaration, others []ast.Statement) {
+	for _, stmt := range statements {
+		if fd, ok := stmt.(*ast.FunctionDeclaration); ok {
+			functions = append(functions, fd)
+		} else {
+			others = append(others, stmt)
+		}
+	}
+	return functions, others
+}
+
+// isStringExpression indique si une expression produit à coup sûr une chaîne
+// (littéral, template ou concaténation contenant une chaîne)
+func isStringExpression(expr ast.Expression) bool {
+	switch e := expr.(type) {
+	case *ast.StringLiteral, *ast.TemplateLiteral:
+		return true
+	case *ast.InfixExpression:
+		return e.Operator == "+" && (isStringExpression(e.Left) || isStringExpression(e.Right))
+	case *ast.ParenthesizedExpression:
+		return isStringExpression(e.Expression)
+	}
+	return false
+}
+
+// isLiteralExpression indique si une expression est un littéral simple,
+// utilisable comme valeur par défaut constante dans les langages stricts
+func isLiteralExpression(expr ast.Expression) bool {
+	switch expr.(type) {
+	case *ast.StringLiteral, *ast.NumberLiteral, *ast.BooleanLiteral:
+		return true
+	}
+	return false
+}
+
+// hasComputedDefault indique une valeur par défaut qui n'est pas une
+// constante : elle est évaluée à chaque appel et peut lire un paramètre
+// précédent, ce que Python, C# et Swift n'acceptent pas dans la signature
+func hasComputedDefault(param ast.Parameter) bool {
+	return param.Default != nil && !isLiteralExpression(param.Default)
+}
+
+// firstOmittableParameter renvoie l'indice à partir duquel tous les paramètres
+// peuvent être omis (optionnels ou avec valeur par défaut), ou -1 s'il n'y en a
+// pas. Les fonctions avec un paramètre rest ne sont pas concernées.
+func firstOmittableParameter(params []ast.Parameter) int {
+	first := -1
+	for i := len(params) - 1; i >= 0; i-- {
+		if params[i].IsRest {
+			return -1
+		}
+		if !params[i].CanBeOmitted() {
+			break
+		}
+		first = i
+	}
+	return first
+}
+
+// indentLines préfixe chaque ligne non vide d'un bloc de code généré, pour
+// imbriquer une fonction dans une classe
+func indentLines(code, prefix string) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
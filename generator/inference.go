@@ -0,0 +1,550 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/semantic"
+	"strings"
+)
+
+// integerType désigne un nombre entier. TypeScript ne le distingue pas de
+// number ; les générateurs typés traduisent number par un nombre à virgule
+// (double, float64, f64, Double) et integerType par un entier, réservé aux
+// valeurs que l'analyse prouve entières : let i = 0 puis i++.
+const integerType = "integer"
+
+// infer analyse le programme pour les générateurs typés (C#, Go, Rust,
+// Swift), qui écrivent le type de chaque déclaration
+func infer(program *ast.Program) *semantic.Inference {
+	return semantic.Infer(program, semantic.Analyze(program))
+}
+
+// typed renvoie le type TypeScript d'une variable, d'un paramètre ou d'un
+// champ : son annotation, ou sinon le type déduit de sa valeur. "" quand
+// l'analyse n'en sait rien d'utile (any, union, objet littéral) : le
+// générateur garde alors son type par défaut.
+func typed(types *semantic.Inference, node ast.Node, annotation string) string {
+	if types == nil {
+		return annotation
+	}
+	if annotation != "" {
+		return annotation
+	}
+	return typeText(types.Declaration(node), types.Integral(node))
+}
+
+// variableType renvoie le type TypeScript d'une variable. Un littéral simple
+// garde son propre type plutôt que l'annotation : const mode: Mode = 'dark'
+// reste une chaîne, sauf quand Mode est une union de littéraux traduite en
+// enum.
+func variableType(types *semantic.Inference, vd *ast.VariableDeclaration) string {
+	if isAnyType(vd.Type) {
+		// let value: any = "text" garde le type any
+		return vd.Type
+	}
+	switch vd.Value.(type) {
+	case *ast.StringLiteral:
+		if alias := variableAlias(types, vd); alias != "" {
+			return alias
+		}
+		return "string"
+	case *ast.BooleanLiteral:
+		return "boolean"
+	case *ast.NumberLiteral:
+		if vd.Type != "" {
+			return vd.Type
+		}
+		return refineNumber("number", types != nil && types.Integral(vd))
+	}
+	return typed(types, vd, vd.Type)
+}
+
+// typedReturn renvoie le type de retour d'une fonction, d'une méthode ou d'une
+// fonction fléchée : son annotation, ou celui de ses return. Une valeur
+// renvoyée dont le type est inconnu donne any, pas void.
+func typedReturn(types *semantic.Inference, fn ast.Node, annotation string, isGenerator bool) string {
+	if method, ok := fn.(*ast.ClassMethod); types == nil || isGenerator || ok && method.IsAbstract {
+		// Une méthode abstraite n'a pas de return d'où déduire son type
+		return annotation
+	}
+	if annotation != "" {
+		return annotation
+	}
+	if t := typeText(types.Return(fn), types.Integral(fn)); t != "" {
+		return t
+	}
+	return "any"
+}
+
+// yieldedReturn renvoie le type de retour d'un générateur : sans annotation,
+// Generator<T> quand tous ses yield produisent une valeur de type T
+func yieldedReturn(types *semantic.Inference, fn ast.Node, annotation string) string {
+	if types == nil || annotation != "" {
+		return annotation
+	}
+	if generator, ok := types.Return(fn).(*semantic.Reference); ok && generator.Name == "Generator" && len(generator.Args) == 1 {
+		if element := typeText(generator.Args[0], false); element != "" {
+			return "Generator<" + element + ">"
+		}
+	}
+	return annotation
+}
+
+// typedParameters renvoie une copie des paramètres où le type manquant d'un
+// paramètre à valeur par défaut est déduit de cette valeur : step(by = 1)
+// prend un by: number
+func typedParameters(types *semantic.Inference, params []ast.Parameter) []ast.Parameter {
+	if types == nil || len(params) == 0 {
+		return params
+	}
+	result := make([]ast.Parameter, len(params))
+	for i := range params {
+		result[i] = params[i]
+		if params[i].Default != nil && !params[i].IsRest {
+			result[i].Type = typed(types, &params[i], params[i].Type)
+		}
+	}
+	return result
+}
+
+// elementText renvoie le type TypeScript des éléments d'un tableau : string
+// pour [...names, "z"] ; "" s'il n'est pas connu
+func elementText(types *semantic.Inference, expr ast.Expression) string {
+	if types == nil {
+		return ""
+	}
+	if array, ok := types.Expression(expr).(*semantic.Array); ok {
+		return typeText(array.Elem, types.Integral(expr))
+	}
+	return ""
+}
+
+// mixedArray indique un tableau littéral dont les éléments n'ont pas de type
+// commun : [1, "a"] est un (number | string)[]
+func mixedArray(types *semantic.Inference, al *ast.ArrayLiteral) bool {
+	if types == nil {
+		return false
+	}
+	array, ok := types.Expression(al).(*semantic.Array)
+	if !ok {
+		return false
+	}
+	_, union := array.Elem.(*semantic.Union)
+	return union && elementText(types, al) == ""
+}
+
+// refineNumber remplace number par integerType pour une valeur déduite dont
+// l'analyse prouve qu'elle est entière
+func refineNumber(annotation string, integral bool) string {
+	if integral && annotation == "number" {
+		return integerType
+	}
+	return annotation
+}
+
+// typeText écrit un type déduit sous la forme d'une annotation TypeScript,
+// celle que les générateurs savent traduire
+func typeText(t semantic.Type, integral bool) string {
+	switch t := t.(type) {
+	case *semantic.Basic:
+		switch t {
+		case semantic.Number:
+			return refineNumber("number", integral)
+		case semantic.String, semantic.Boolean, semantic.Void:
+			return t.Name
+		}
+	case *semantic.Literal:
+		return typeText(t.Base, integral)
+	case *semantic.Array:
+		if elem := typeText(t.Elem, integral); elem != "" && !strings.Contains(elem, "<") {
+			return elem + "[]"
+		}
+	case *semantic.Union:
+		if alias := literalAlias(t); alias != "" {
+			return alias
+		}
+		// T | undefined se traduit comme T ; true | false est un boolean
+		text := ""
+		for _, member := range t.Types {
+			if member == semantic.Null || member == semantic.Undefined {
+				continue
+			}
+			memberText := typeText(member, integral)
+			if memberText == "" || text != "" && memberText != text {
+				return ""
+			}
+			text = memberText
+		}
+		return text
+	case *semantic.Object:
+		return t.Name
+	case *semantic.EnumType:
+		return t.Name
+	case *semantic.TypeParam:
+		return t.Name
+	case *semantic.Reference:
+		if t.Name == "Promise" && len(t.Args) == 1 {
+			if inner := typeText(t.Args[0], integral); inner != "" {
+				return "Promise<" + inner + ">"
+			}
+			return "Promise<any>"
+		}
+		if t.Name == "Map" && len(t.Args) == 2 {
+			// new Map<string, number>() : Map<string, number>
+			key, value := typeText(t.Args[0], false), typeText(t.Args[1], false)
+			if key != "" && value != "" {
+				return "Map<" + key + ", " + value + ">"
+			}
+		}
+	}
+	return ""
+}
+
+// literalAlias renvoie le nom d'un alias d'union de littéraux chaîne, que les
+// générateurs typés traduisent en enum : TaskStatus pour
+// type TaskStatus = 'pending' | 'done' ; "" pour tout autre type
+func literalAlias(t semantic.Type) string {
+	union, ok := t.(*semantic.Union)
+	if !ok || union.Name == "" {
+		return ""
+	}
+	for _, member := range union.Types {
+		if literal, ok := member.(*semantic.Literal); !ok || literal.Base != semantic.String {
+			return ""
+		}
+	}
+	return union.Name
+}
+
+// variableAlias renvoie l'alias traduit en enum qui type une variable, ou ""
+func variableAlias(types *semantic.Inference, vd *ast.VariableDeclaration) string {
+	if types == nil {
+		return ""
+	}
+	return literalAlias(types.Declaration(vd))
+}
+
+// enumLiterals repère les chaînes littérales écrites là où le type attendu est
+// un alias traduit en enum : let s: TaskStatus = 'pending', setStatus('done'),
+// return 'done', s == 'done'. Elles s'écrivent comme le membre de l'enum ; la
+// table associe chacune au nom de l'alias.
+func enumLiterals(types *semantic.Inference, statements []ast.Statement) map[*ast.StringLiteral]string {
+	literals := map[*ast.StringLiteral]string{}
+	expectedTypes(types, statements, func(t semantic.Type, expr ast.Expression) {
+		if sl, ok := expr.(*ast.StringLiteral); ok {
+			if alias := literalAlias(t); alias != "" {
+				literals[sl] = alias
+			}
+		}
+	})
+	return literals
+}
+
+// arrayElements repère les tableaux littéraux écrits là où un type de tableau
+// est attendu, et donne le type de leurs éléments : number pour
+// this.items = [] quand items est un number[]. Un tableau vide n'a sinon pas
+// de type.
+func arrayElements(types *semantic.Inference, statements []ast.Statement) map[*ast.ArrayLiteral]string {
+	elements := map[*ast.ArrayLiteral]string{}
+	expectedTypes(types, statements, func(t semantic.Type, expr ast.Expression) {
+		if al, ok := expr.(*ast.ArrayLiteral); ok {
+			if array, ok := t.(*semantic.Array); ok {
+				if elem := typeText(array.Elem, false); elem != "" {
+					elements[al] = elem
+				}
+			}
+		}
+	})
+	return elements
+}
+
+// expectedTypes appelle expect sur chaque expression écrite là où le
+// programme attend un type : valeur d'une déclaration, membre droit d'une
+// affectation, opérande d'une comparaison, argument, valeur renvoyée
+func expectedTypes(types *semantic.Inference, statements []ast.Statement, expect func(semantic.Type, ast.Expression)) {
+	if types == nil {
+		return
+	}
+
+	// Pile des nœuds en cours de visite : un return se rapporte à la
+	// fonction la plus proche
+	var stack []ast.Node
+	enclosingFunction := func() ast.Node {
+		for i := len(stack) - 1; i >= 0; i-- {
+			switch stack[i].(type) {
+			case *ast.FunctionDeclaration, *ast.ClassMethod, *ast.ArrowFunction:
+				return stack[i]
+			}
+		}
+		return nil
+	}
+
+	visit := func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		switch n := node.(type) {
+		case *ast.VariableDeclaration:
+			expect(types.Declaration(n), n.Value)
+		case *ast.Parameter:
+			expect(types.Declaration(n), n.Default)
+		case *ast.ClassField:
+			expect(types.Declaration(n), n.Default)
+		case *ast.AssignmentExpression:
+			if n.Operator == "=" {
+				expect(types.Expression(n.Left), n.Right)
+			}
+		case *ast.InfixExpression:
+			switch n.Operator {
+			case "==", "!=", "===", "!==":
+				expect(types.Expression(n.Left), n.Right)
+				expect(types.Expression(n.Right), n.Left)
+			}
+		case *ast.CallExpression:
+			if signature, ok := types.Expression(n.Function).(*semantic.Signature); ok {
+				for i, arg := range n.Arguments {
+					if i < len(signature.Params) && !signature.Params[i].Rest {
+						expect(signature.Params[i].Type, arg)
+					}
+				}
+			}
+		case *ast.ReturnStatement:
+			if fn := enclosingFunction(); fn != nil {
+				returned := types.Return(fn)
+				if promise, ok := returned.(*semantic.Reference); ok && promise.Name == "Promise" && len(promise.Args) == 1 {
+					returned = promise.Args[0]
+				}
+				expect(returned, n.Value)
+			}
+		}
+		stack = append(stack, node)
+		return true
+	}
+	for _, stmt := range statements {
+		ast.Inspect(stmt, visit)
+	}
+}
+
+// widenedIntegers repère les valeurs entières écrites là où un nombre à
+// virgule est attendu : let total: number = count, f(1) pour f(x: number),
+// i * 0.5, a / b. Go, Rust et Swift ne convertissent pas un entier en
+// nombre à virgule ; les générateurs écrivent float64(i), i as f64 ou
+// Double(i), et 1.0 pour un littéral. Dans une opération, seuls les
+// opérandes simples sont convertis : le texte généré garde la précédence
+// du langage cible.
+func widenedIntegers(types *semantic.Inference, statements []ast.Statement) map[ast.Expression]bool {
+	widened := map[ast.Expression]bool{}
+	if types == nil {
+		return widened
+	}
+
+	var widen func(expr ast.Expression)
+	widen = func(expr ast.Expression) {
+		switch e := expr.(type) {
+		case nil:
+		case *ast.InfixExpression:
+			if arithmetic(e.Operator) {
+				widen(e.Left)
+				widen(e.Right)
+				return
+			}
+		case *ast.ParenthesizedExpression:
+			widen(e.Expression)
+			return
+		case *ast.ArrayLiteral:
+			for _, element := range e.Elements {
+				widen(element)
+			}
+			return
+		case *ast.SpreadElement:
+			// [...xs, ...[3, 4]]
+			widen(e.Argument)
+			return
+		}
+		if isInteger(types, expr) {
+			widened[expr] = true
+		}
+	}
+	// expect convertit une valeur écrite là où le type attendu est number,
+	// number[] ou any, et qui n'est pas une déclaration entière : value as
+	// number lit un nombre à virgule dans un any
+	expect := func(expected semantic.Type, integral bool, expr ast.Expression) {
+		if array, ok := expected.(*semantic.Array); ok {
+			expected = array.Elem
+		}
+		if !integral && (isNumber(expected) || expected == semantic.Any || expected == semantic.Unknown) {
+			widen(expr)
+		}
+	}
+
+	var stack []ast.Node
+	enclosingFunction := func() ast.Node {
+		for i := len(stack) - 1; i >= 0; i-- {
+			switch stack[i].(type) {
+			case *ast.FunctionDeclaration, *ast.ClassMethod, *ast.ArrowFunction:
+				return stack[i]
+			}
+		}
+		return nil
+	}
+
+	visit := func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		switch n := node.(type) {
+		case *ast.VariableDeclaration:
+			expect(types.Declaration(n), types.Integral(n), n.Value)
+		case *ast.Parameter:
+			expect(types.Declaration(n), false, n.Default)
+		case *ast.ClassField:
+			expect(types.Declaration(n), types.Integral(n), n.Default)
+		case *ast.AssignmentExpression:
+			if n.Right != nil {
+				expect(types.Expression(n.Left), types.Integral(n.Left), n.Right)
+			}
+		case *ast.InfixExpression:
+			switch {
+			case arithmetic(n.Operator):
+				// i * 0.5, a / b : l'opération se fait en nombres à virgule
+				if isNumber(types.Expression(n)) && !types.Integral(n) {
+					widen(n)
+				}
+			case comparison(n.Operator):
+				// i < limit
+				if isInteger(types, n.Left) != isInteger(types, n.Right) && isNumber(types.Expression(n.Left)) && isNumber(types.Expression(n.Right)) {
+					widen(n.Left)
+					widen(n.Right)
+				}
+			}
+		case *ast.CallExpression:
+			expectArguments(types.Callee(n), n.Arguments, expect)
+		case *ast.NewExpression:
+			expectArguments(types.Callee(n), n.Arguments, expect)
+		case *ast.ReturnStatement:
+			if fn := enclosingFunction(); fn != nil {
+				returned := types.Return(fn)
+				if promise, ok := returned.(*semantic.Reference); ok && promise.Name == "Promise" && len(promise.Args) == 1 {
+					returned = promise.Args[0]
+				}
+				expect(returned, types.Integral(fn), n.Value)
+			}
+		case *ast.YieldExpression:
+			// yield i dans un Generator<number>
+			if fn := enclosingFunction(); fn != nil && !n.Delegate {
+				if yielded, ok := types.Return(fn).(*semantic.Reference); ok && len(yielded.Args) > 0 {
+					expect(yielded.Args[0], false, n.Argument)
+				}
+			}
+		}
+		stack = append(stack, node)
+		return true
+	}
+	for _, stmt := range statements {
+		ast.Inspect(stmt, visit)
+	}
+	return widened
+}
+
+// expectArguments attend de chaque argument le type de son paramètre ; un
+// paramètre n'est jamais entier
+func expectArguments(signature *semantic.Signature, args []ast.Expression, expect func(semantic.Type, bool, ast.Expression)) {
+	if signature == nil {
+		return
+	}
+	for i, arg := range args {
+		switch {
+		case i < len(signature.Params) && !signature.Params[i].Rest:
+			expect(signature.Params[i].Type, false, arg)
+		case len(signature.Params) > 0 && signature.Params[len(signature.Params)-1].Rest:
+			// f(1, 2) ou f(1, ...[2, 3]) pour f(...xs: number[])
+			expect(signature.Params[len(signature.Params)-1].Type, false, arg)
+		}
+	}
+}
+
+// isNumber indique si un type est number ou un littéral numérique
+func isNumber(t semantic.Type) bool {
+	if literal, ok := t.(*semantic.Literal); ok {
+		return literal.Base == semantic.Number
+	}
+	return t == semantic.Number
+}
+
+// isAnyType indique si une annotation est any ou unknown
+func isAnyType(t string) bool {
+	return t == "any" || t == "unknown"
+}
+
+// isInteger indique si une expression est un nombre dont le langage cible a
+// un type entier
+func isInteger(types *semantic.Inference, expr ast.Expression) bool {
+	return types != nil && isNumber(types.Expression(expr)) && types.Integral(expr)
+}
+
+// isFloat indique si une expression est un nombre que le langage cible
+// représente à virgule
+func isFloat(types *semantic.Inference, expr ast.Expression) bool {
+	return types != nil && isNumber(types.Expression(expr)) && !types.Integral(expr)
+}
+
+func arithmetic(operator string) bool {
+	switch operator {
+	case "+", "-", "*", "/", "%":
+		return true
+	}
+	return false
+}
+
+func comparison(operator string) bool {
+	switch operator {
+	case "==", "!=", "===", "!==", "<", ">", "<=", ">=":
+		return true
+	}
+	return false
+}
+
+// decimalInteger indique si un littéral numérique s'écrit comme un entier
+// décimal, auquel .0 suffit pour en faire un nombre à virgule
+func decimalInteger(literal string) bool {
+	return !strings.ContainsAny(literal, ".eExXoObB")
+}
+
+// castOperand met entre parenthèses une expression générée avant de la
+// convertir, sauf si c'est une opérande simple : (a + b) as usize, i as usize
+func castOperand(expr ast.Expression, text string) string {
+	switch expr.(type) {
+	case *ast.Identifier, *ast.NumberLiteral, *ast.DotExpression, *ast.CallExpression, *ast.IndexExpression, *ast.ParenthesizedExpression:
+		return text
+	}
+	return "(" + text + ")"
+}
+
+// objectTypeName renvoie le nom de la classe ou de l'interface dont une
+// expression est une instance, ou ""
+func objectTypeName(types *semantic.Inference, expr ast.Expression) string {
+	if types == nil {
+		return ""
+	}
+	if object, ok := types.Expression(expr).(*semantic.Object); ok {
+		return object.Name
+	}
+	return ""
+}
+
+// interfaceProperty indique si obj.name lit une propriété d'une valeur typée
+// par l'une des interfaces données, que les langages où une interface ne
+// déclare que des méthodes (Go, Rust) lisent par un accesseur
+func interfaceProperty(types *semantic.Inference, de *ast.DotExpression, interfaces map[string]*ast.Interface) bool {
+	name := objectTypeName(types, de.Object)
+	if interfaces[name] == nil {
+		return false
+	}
+	for _, property := range interfaceProperties([]string{name}, interfaces) {
+		if property.Name == de.Property {
+			return true
+		}
+	}
+	return false
+}
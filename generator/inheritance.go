@@ -0,0 +1,483 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"sort"
+)
+
+// superCall renvoie l'appel super(...) d'une instruction, ou nil
+func superCall(stmt ast.Statement) *ast.CallExpression {
+	es, ok := stmt.(*ast.ExpressionStatement)
+	if !ok {
+		return nil
+	}
+	ce, ok := es.Expression.(*ast.CallExpression)
+	if !ok {
+		return nil
+	}
+	if ident, ok := ce.Function.(*ast.Identifier); ok && ident.Value == "super" {
+		return ce
+	}
+	return nil
+}
+
+// splitSuperCall sépare un corps de constructeur autour de son appel
+// super(...) : les instructions qui le précèdent, l'appel (nil s'il n'y en a
+// pas) et celles qui le suivent
+func splitSuperCall(body []ast.Statement) ([]ast.Statement, *ast.CallExpression, []ast.Statement) {
+	for i, stmt := range body {
+		if call := superCall(stmt); call != nil {
+			return body[:i], call, body[i+1:]
+		}
+	}
+	return nil, nil, body
+}
+
+// afterSuperCall insère des instructions juste après l'appel super(...) d'un
+// corps de constructeur, ou en tête s'il n'y en a pas : en TypeScript, this
+// n'existe qu'une fois le constructeur parent exécuté
+func afterSuperCall(body, inserted []ast.Statement) []ast.Statement {
+	if len(inserted) == 0 {
+		return body
+	}
+	before, call, after := splitSuperCall(body)
+	result := append([]ast.Statement{}, before...)
+	if call != nil {
+		result = append(result, &ast.ExpressionStatement{Expression: call})
+	}
+	result = append(result, inserted...)
+	return append(result, after...)
+}
+
+// isSuperMember indique si une expression est un accès super.method
+func isSuperMember(expr ast.Expression) bool {
+	if de, ok := expr.(*ast.DotExpression); ok {
+		if ident, ok := de.Object.(*ast.Identifier); ok && ident.Value == "super" {
+			return true
+		}
+	}
+	return false
+}
+
+// superClassName renvoie le nom de la classe parente, sans ses arguments de type
+func superClassName(cd *ast.ClassDeclaration) string {
+	name, _ := splitTypeArguments(cd.SuperClass)
+	return name
+}
+
+// collectClasses indexe les classes du programme par nom
+func collectClasses(statements []ast.Statement) map[string]*ast.ClassDeclaration {
+	classes := map[string]*ast.ClassDeclaration{}
+	for _, stmt := range declaredStatements(statements) {
+		if cd, ok := stmt.(*ast.ClassDeclaration); ok {
+			classes[cd.Name] = cd
+		}
+	}
+	return classes
+}
+
+// ancestors renvoie les classes parentes connues, de la plus proche à la plus
+// lointaine ; une classe importée ou inconnue arrête la remontée
+func ancestors(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) []*ast.ClassDeclaration {
+	var result []*ast.ClassDeclaration
+	seen := map[string]bool{cd.Name: true}
+	for parent := classes[superClassName(cd)]; parent != nil && !seen[parent.Name]; parent = classes[superClassName(parent)] {
+		seen[parent.Name] = true
+		result = append(result, parent)
+	}
+	return result
+}
+
+// hasField indique si une classe déclare un champ d'instance name
+func hasField(cd *ast.ClassDeclaration, name string) bool {
+	for _, field := range cd.Fields {
+		if !field.IsStatic && field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// findMethod renvoie la méthode d'instance name déclarée par une classe, ou nil
+func findMethod(cd *ast.ClassDeclaration, name string) *ast.ClassMethod {
+	for i := range cd.Methods {
+		if method := &cd.Methods[i]; method.Kind == ast.RegularMethod && !method.IsStatic && method.Name == name {
+			return method
+		}
+	}
+	return nil
+}
+
+// overridesMethod indique si une méthode redéfinit une méthode d'une classe parente
+func overridesMethod(cd *ast.ClassDeclaration, name string, classes map[string]*ast.ClassDeclaration) bool {
+	for _, parent := range ancestors(cd, classes) {
+		if findMethod(parent, name) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isOverridden indique si une méthode est redéfinie par une sous-classe, pour
+// les langages où elle doit alors être déclarée virtuelle (C#)
+func isOverridden(cd *ast.ClassDeclaration, name string, classes map[string]*ast.ClassDeclaration) bool {
+	for _, other := range classes {
+		if other.Name == cd.Name || findMethod(other, name) == nil {
+			continue
+		}
+		for _, parent := range ancestors(other, classes) {
+			if parent.Name == cd.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasSubclasses indique si une classe est étendue par une autre classe du programme
+func hasSubclasses(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) bool {
+	for _, other := range classes {
+		if superClassName(other) == cd.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// dispatchedClass indique si les méthodes d'une classe sont liées
+// dynamiquement dans les langages sans héritage (Go, Rust), par une interface
+// ou un trait : la classe a une parente ou des sous-classes connues, et ni
+// elle ni ses parentes ne sont génériques
+func dispatchedClass(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) bool {
+	chain := append([]*ast.ClassDeclaration{cd}, ancestors(cd, classes)...)
+	if len(chain) == 1 && !hasSubclasses(cd, classes) {
+		return false
+	}
+	for _, class := range chain {
+		if _, args := splitTypeArguments(class.SuperClass); len(class.TypeParameters) > 0 || len(args) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// polymorphicClass indique si une classe liée dynamiquement a des sous-classes
+// qui le sont aussi : son type désigne alors l'interface (Go) ou le trait
+// (Rust) de ses méthodes, que ses sous-classes implémentent
+func polymorphicClass(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) bool {
+	if !dispatchedClass(cd, classes) {
+		return false
+	}
+	for _, other := range classes {
+		if superClassName(other) == cd.Name && dispatchedClass(other, classes) {
+			return true
+		}
+	}
+	return false
+}
+
+// methodsInterface nomme l'interface (Go) ou le trait (Rust) des méthodes
+// d'une classe à sous-classes : Shape -> ShapeMethods
+func methodsInterface(class string) string {
+	return class + "Methods"
+}
+
+// rootClass renvoie la plus lointaine parente connue d'une classe, ou la classe
+// elle-même
+func rootClass(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) *ast.ClassDeclaration {
+	if parents := ancestors(cd, classes); len(parents) > 0 {
+		return parents[len(parents)-1]
+	}
+	return cd
+}
+
+// dispatchedMethod indique si une méthode est liée dynamiquement : une méthode
+// d'instance ordinaire, qui n'est ni un générateur ni générique et dont le nom
+// n'est pas privé (#m ne se redéfinit pas)
+func dispatchedMethod(method ast.ClassMethod) bool {
+	return method.Kind == ast.RegularMethod && !method.IsStatic && !method.IsPrivateName && !method.IsGenerator && len(method.TypeParameters) == 0
+}
+
+// introducedMethods renvoie les méthodes liées dynamiquement qu'une classe
+// déclare sans qu'une de ses parentes les déclare : ce sont celles de son
+// interface ou de son trait, les autres appartiennent à une parente
+func introducedMethods(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) []ast.ClassMethod {
+	var result []ast.ClassMethod
+	for _, method := range regularMethods(cd) {
+		if dispatchedMethod(method) && !overridesMethod(cd, method.Name, classes) {
+			result = append(result, method)
+		}
+	}
+	return result
+}
+
+// methodIntroducer renvoie la classe qui introduit la méthode liée
+// dynamiquement name parmi une classe et ses parentes, ou nil
+func methodIntroducer(cd *ast.ClassDeclaration, name string, classes map[string]*ast.ClassDeclaration) *ast.ClassDeclaration {
+	var introducer *ast.ClassDeclaration
+	for _, class := range append([]*ast.ClassDeclaration{cd}, ancestors(cd, classes)...) {
+		if method := findMethod(class, name); method != nil && dispatchedMethod(*method) {
+			introducer = class
+		}
+	}
+	return introducer
+}
+
+// methodImplementation renvoie la classe qui fournit le corps de la méthode
+// name à une classe : elle-même ou sa plus proche parente qui la déclare sans
+// qu'elle soit abstraite ; nil si aucune ne le fait
+func methodImplementation(cd *ast.ClassDeclaration, name string, classes map[string]*ast.ClassDeclaration) (*ast.ClassDeclaration, *ast.ClassMethod) {
+	for _, class := range append([]*ast.ClassDeclaration{cd}, ancestors(cd, classes)...) {
+		if method := findMethod(class, name); method != nil && !method.IsAbstract {
+			return class, method
+		}
+	}
+	return nil, nil
+}
+
+// fieldOwner renvoie la classe qui déclare le champ d'instance name parmi une
+// classe et ses parentes, paramètres de constructeur compris, ou nil
+func fieldOwner(cd *ast.ClassDeclaration, name string, classes map[string]*ast.ClassDeclaration) *ast.ClassDeclaration {
+	for _, class := range append([]*ast.ClassDeclaration{cd}, ancestors(cd, classes)...) {
+		if hasField(withParameterProperties(class), name) {
+			return class
+		}
+	}
+	return nil
+}
+
+// inheritedMethods renvoie les méthodes d'instance concrètes héritées et non
+// redéfinies, avec la profondeur de la classe qui les déclare (1 pour la
+// parente), pour les sous-classes génériques qui échappent à la liaison
+// dynamique (dispatchedClass) de Go et Rust : elles en reprennent le corps
+// pour que this.area() y appelle leur propre area(). Les méthodes des classes
+// génériques et les générateurs, dont le corps dépend de la classe qui les
+// déclare, ne sont pas repris.
+func inheritedMethods(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) []inheritedMethod {
+	var result []inheritedMethod
+	declared := map[string]bool{}
+	for _, method := range regularMethods(cd) {
+		declared[method.Name] = true
+	}
+	for depth, parent := range ancestors(cd, classes) {
+		for _, method := range regularMethods(parent) {
+			if declared[method.Name] || method.IsStatic {
+				continue
+			}
+			declared[method.Name] = true
+			if !method.IsAbstract && !method.IsGenerator && len(parent.TypeParameters) == 0 {
+				result = append(result, inheritedMethod{Owner: parent, Depth: depth + 1, Method: method})
+			}
+		}
+	}
+	return result
+}
+
+// withInheritedMethods complète les méthodes générées pour une classe par
+// celles qu'elle hérite (inheritedMethods) ; ses propres méthodes ont la
+// profondeur 0
+func withInheritedMethods(cd *ast.ClassDeclaration, methods []ast.ClassMethod, classes map[string]*ast.ClassDeclaration) []inheritedMethod {
+	var result []inheritedMethod
+	for _, method := range methods {
+		result = append(result, inheritedMethod{Owner: cd, Method: method})
+	}
+	return append(result, inheritedMethods(cd, classes)...)
+}
+
+type inheritedMethod struct {
+	Owner  *ast.ClassDeclaration
+	Depth  int // 1 pour une méthode de la classe parente, 2 pour la suivante...
+	Method ast.ClassMethod
+}
+
+// classBases renvoie les interfaces qu'étend une interface, ou qu'implémente
+// une classe, en écartant les classes : TypeScript accepte les deux, ce que
+// les langages cibles n'expriment pas. Les classes écartées sont renvoyées à
+// part pour être signalées dans le code généré.
+func classBases(bases []string, classes map[string]*ast.ClassDeclaration) ([]string, []string) {
+	var interfaces, rejected []string
+	for _, base := range bases {
+		if name, _ := splitTypeArguments(base); classes[name] != nil {
+			rejected = append(rejected, base)
+		} else {
+			interfaces = append(interfaces, base)
+		}
+	}
+	return interfaces, rejected
+}
+
+// collectInterfaces indexe les interfaces du programme par nom
+func collectInterfaces(statements []ast.Statement) map[string]*ast.Interface {
+	interfaces := map[string]*ast.Interface{}
+	for _, stmt := range declaredStatements(statements) {
+		if i, ok := stmt.(*ast.Interface); ok {
+			interfaces[i.Name] = i
+		}
+	}
+	return interfaces
+}
+
+// interfaceProperties renvoie les propriétés (hors méthodes) des interfaces
+// nommées et de celles qu'elles étendent, pour les langages où une classe doit
+// les implémenter par des accesseurs
+func interfaceProperties(names []string, interfaces map[string]*ast.Interface) []ast.InterfaceField {
+	var properties []ast.InterfaceField
+	seen := map[string]bool{}
+	var visit func(names []string)
+	visit = func(names []string) {
+		for _, typeName := range names {
+			name, _ := splitTypeArguments(typeName)
+			i := interfaces[name]
+			if i == nil || seen[name] {
+				continue
+			}
+			seen[name] = true
+			for _, field := range i.Fields {
+				if !field.IsMethod && !seen["."+field.Name] {
+					seen["."+field.Name] = true
+					properties = append(properties, field)
+				}
+			}
+			visit(i.Extends)
+		}
+	}
+	visit(names)
+	return properties
+}
+
+// implementedInterfaces renvoie les interfaces implémentées par une classe et
+// par ses classes parentes
+func implementedInterfaces(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) []string {
+	implemented := append([]string{}, cd.Implements...)
+	for _, parent := range ancestors(cd, classes) {
+		implemented = append(implemented, parent.Implements...)
+	}
+	return implemented
+}
+
+// implementedByClasses renvoie les interfaces qu'implémente une classe du
+// programme, et celles qu'elles étendent : dans les langages où une classe ne
+// satisfait qu'une interface de méthodes (Go), ce sont elles qui en deviennent
+func implementedByClasses(classes map[string]*ast.ClassDeclaration, interfaces map[string]*ast.Interface) map[string]bool {
+	implemented := map[string]bool{}
+	var visit func(names []string)
+	visit = func(names []string) {
+		for _, typeName := range names {
+			name, _ := splitTypeArguments(typeName)
+			if i := interfaces[name]; i != nil && !implemented[name] {
+				implemented[name] = true
+				visit(i.Extends)
+			}
+		}
+	}
+	for _, cd := range classes {
+		visit(cd.Implements)
+	}
+	return implemented
+}
+
+// backedInterfaceProperties renvoie les propriétés des interfaces implémentées
+// par une classe qu'un champ de la classe ou de ses parentes porte sans qu'une
+// méthode du même nom existe : dans les langages où une interface ne déclare
+// que des méthodes, la classe les implémente par un accesseur name()
+func backedInterfaceProperties(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration, interfaces map[string]*ast.Interface) []ast.InterfaceField {
+	var properties []ast.InterfaceField
+	for _, property := range interfaceProperties(cd.Implements, interfaces) {
+		if findMethod(cd, property.Name) != nil {
+			continue
+		}
+		for _, owner := range append([]*ast.ClassDeclaration{cd}, ancestors(cd, classes)...) {
+			if hasField(withParameterProperties(owner), property.Name) {
+				properties = append(properties, property)
+				break
+			}
+		}
+	}
+	return properties
+}
+
+// superCallAfterFields déplace les affectations this.champ = valeur qui suivent
+// l'appel super(...) avant celui-ci, pour les langages où une sous-classe doit
+// initialiser ses propres champs avant d'appeler le constructeur parent (Swift)
+func superCallAfterFields(body []ast.Statement) []ast.Statement {
+	before, call, after := splitSuperCall(body)
+	if call == nil {
+		return body
+	}
+	result := append([]ast.Statement{}, before...)
+	for len(after) > 0 {
+		if field, _ := thisFieldAssignment(after[0]); field == "" {
+			break
+		}
+		result = append(result, after[0])
+		after = after[1:]
+	}
+	result = append(result, &ast.ExpressionStatement{Expression: call})
+	return append(result, after...)
+}
+
+// withImplicitConstructor renvoie la sous-classe dotée du constructeur que
+// TypeScript lui donne implicitement, constructor(...args) { super(...args) },
+// avec les paramètres du constructeur parent, pour les langages où un
+// constructeur ne s'hérite pas ; la classe d'origine n'est pas modifiée
+func withImplicitConstructor(cd *ast.ClassDeclaration, classes map[string]*ast.ClassDeclaration) *ast.ClassDeclaration {
+	if cd.SuperClass == "" || findConstructor(cd) != nil {
+		return cd
+	}
+	var params []ast.Parameter
+	for _, parent := range ancestors(cd, classes) {
+		if findConstructor(parent) != nil {
+			params = constructorParameters(parent)
+			break
+		}
+	}
+	call := &ast.CallExpression{Function: &ast.Identifier{Value: "super"}}
+	var inherited []ast.Parameter
+	for _, param := range params {
+		param.IsProperty, param.IsPrivate, param.IsProtected, param.IsReadonly = false, false, false, false
+		inherited = append(inherited, param)
+		var arg ast.Expression = &ast.Identifier{Value: param.Name}
+		if param.IsRest {
+			arg = &ast.SpreadElement{Argument: arg}
+		}
+		call.Arguments = append(call.Arguments, arg)
+	}
+
+	expanded := *cd
+	expanded.Methods = append([]ast.ClassMethod{{
+		Name:       "constructor",
+		Kind:       ast.Constructor,
+		Parameters: inherited,
+		Body:       []ast.Statement{&ast.ExpressionStatement{Expression: call}},
+	}}, cd.Methods...)
+	return &expanded
+}
+
+// concreteClasses renvoie, triées par nom, les classes non abstraites dont les
+// instances ont le type name : la classe et ses sous-classes, ou celles qui
+// implémentent l'interface, directement, par une interface dérivée ou par une
+// classe parente
+func concreteClasses(name string, classes map[string]*ast.ClassDeclaration, interfaces map[string]*ast.Interface) []*ast.ClassDeclaration {
+	var result []*ast.ClassDeclaration
+	for _, cd := range classes {
+		if !cd.IsAbstract && instanceOf(cd, name, classes, interfaces) {
+			result = append(result, cd)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// instanceOf indique si les instances d'une classe ont le type name
+func instanceOf(cd *ast.ClassDeclaration, name string, classes map[string]*ast.ClassDeclaration, interfaces map[string]*ast.Interface) bool {
+	if cd.Name == name {
+		return true
+	}
+	for _, parent := range ancestors(cd, classes) {
+		if parent.Name == name {
+			return true
+		}
+	}
+	lineage := map[string]*ast.ClassDeclaration{cd.Name: {Implements: implementedInterfaces(cd, classes)}}
+	return implementedByClasses(lineage, interfaces)[name]
+}
@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"fmt"
+	"strings"
+)
+
+// JSXRuntime choisit la forme des éléments JSX générés en JavaScript
+type JSXRuntime string
+
+const (
+	ClassicJSX   JSXRuntime = "classic"   // React.createElement("div", props, ...enfants)
+	AutomaticJSX JSXRuntime = "automatic" // _jsx("div", { ...props, children }) de react/jsx-runtime
+)
+
+// findJSX renvoie le premier élément ou fragment JSX du programme, ou nil :
+// seul JavaScript sait générer du JSX
+func findJSX(statements []ast.Statement) ast.Expression {
+	var found ast.Expression
+	inspectStatements(statements, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.JSXElement:
+			found = n
+		case *ast.JSXFragment:
+			found = n
+		}
+		return found == nil
+	})
+	return found
+}
+
+// jsxDiagnostic remplace la sortie d'un langage qui n'a pas d'équivalent au
+// JSX : mieux vaut une erreur claire qu'une traduction approximative
+func jsxDiagnostic(targetLang TargetLanguage, node ast.Expression) string {
+	comment := "//"
+	if targetLang == Python {
+		comment = "#"
+	}
+	tag := "<>"
+	if element, ok := node.(*ast.JSXElement); ok {
+		tag = "<" + element.Name + ">"
+	}
+	where := ""
+	if pos := ast.Position(node).Pos; pos.IsValid() {
+		where = fmt.Sprintf(" ligne %d, colonne %d", pos.Line, pos.Column)
+	}
+	return comment + " Erreur : JSX non pris en charge pour la cible " + string(targetLang) + " (élément " + tag + where + ").\n" +
+		comment + " Les éléments JSX ne se génèrent qu'en JavaScript : utilisez -target=js.\n"
+}
+
+// isIntrinsicElement indique si une balise désigne un élément HTML (div,
+// my-widget) plutôt qu'un composant (Button, Menu.Item)
+func isIntrinsicElement(name string) bool {
+	return name != "" && name[0] >= 'a' && name[0] <= 'z' && !strings.Contains(name, ".")
+}
+
+// jsxString met un texte ou un nom de balise entre guillemets JavaScript
+func jsxString(value string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(value) + "\""
+}
+
+// jsxPropertyKey renvoie la clé d'objet d'un attribut : aria-label doit être
+// entre guillemets
+func jsxPropertyKey(name string) string {
+	if strings.Contains(name, "-") {
+		return jsxString(name)
+	}
+	return name
+}
+
+// isJSXElement indique si un enfant JSX est lui-même un élément ou un fragment
+func isJSXElement(expr ast.Expression) bool {
+	switch expr.(type) {
+	case *ast.JSXElement, *ast.JSXFragment:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"strings"
+	"unicode"
+)
+
+// splitModuleStatements sépare les imports et exports du reste du programme.
+// La déclaration portée par un export (export function f ...) reste dans le
+// corps, pour que chaque générateur la traite normalement ; seules les
+// expressions d'un export default n'y figurent pas.
+func splitModuleStatements(statements []ast.Statement) ([]ast.Statement, []*ast.ImportDeclaration, []*ast.ExportDeclaration) {
+	var body []ast.Statement
+	var imports []*ast.ImportDeclaration
+	var exports []*ast.ExportDeclaration
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *ast.ImportDeclaration:
+			imports = append(imports, s)
+		case *ast.ExportDeclaration:
+			exports = append(exports, s)
+			if s.Declaration != nil && !isDefaultExpression(s) {
+				body = append(body, s.Declaration)
+			}
+		default:
+			body = append(body, stmt)
+		}
+	}
+	return body, imports, exports
+}
+
+// isDefaultExpression indique si un export est export default <expression>
+func isDefaultExpression(ed *ast.ExportDeclaration) bool {
+	_, ok := ed.Declaration.(*ast.ExpressionStatement)
+	return ed.IsDefault && ok
+}
+
+// defaultExportName renvoie le nom exporté par défaut : celui de la
+// déclaration, ou l'identifiant de export default nom ; "" pour une autre expression
+func defaultExportName(ed *ast.ExportDeclaration) string {
+	if es, ok := ed.Declaration.(*ast.ExpressionStatement); ok {
+		if ident, ok := es.Expression.(*ast.Identifier); ok {
+			return ident.Value
+		}
+		return ""
+	}
+	return declarationName(ed.Declaration)
+}
+
+// declarationName renvoie le nom déclaré par une instruction, ou ""
+func declarationName(stmt ast.Statement) string {
+	switch s := stmt.(type) {
+	case *ast.FunctionDeclaration:
+		return s.Name
+	case *ast.VariableDeclaration:
+		return s.Name
+	case *ast.ClassDeclaration:
+		return s.Name
+	case *ast.Interface:
+		return s.Name
+	case *ast.EnumDeclaration:
+		return s.Name
+	case *ast.TypeAlias:
+		return s.Name
+	case *ast.NamespaceDeclaration:
+		return s.Name
+	}
+	return ""
+}
+
+// isTypeDeclaration indique si une déclaration disparaît à la compilation
+func isTypeDeclaration(stmt ast.Statement) bool {
+	switch s := stmt.(type) {
+	case *ast.Interface, *ast.TypeAlias, *ast.AmbientDeclaration:
+		return true
+	case *ast.NamespaceDeclaration:
+		return !isInstantiated(s)
+	}
+	return false
+}
+
+// exportedNames renvoie les noms locaux exportés par le module, déclarations
+// exportées et export { a as b } sans ré-export
+func exportedNames(exports []*ast.ExportDeclaration) map[string]bool {
+	names := map[string]bool{}
+	for _, ed := range exports {
+		if name := declarationName(ed.Declaration); name != "" {
+			names[name] = true
+		}
+		if ed.Source == "" {
+			for _, spec := range ed.Specifiers {
+				names[spec.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// importedTypes renvoie les noms de type importés d'autres modules, ceux qui
+// commencent par une majuscule : import { Model } from "./model" permet
+// d'écrire T extends Model, que les annotations gardent tel quel
+func importedTypes(imports []*ast.ImportDeclaration) map[string]bool {
+	names := map[string]bool{}
+	add := func(name string) {
+		if name != "" && unicode.IsUpper([]rune(name)[0]) {
+			names[name] = true
+		}
+	}
+	for _, id := range imports {
+		add(id.Default)
+		for _, spec := range id.Specifiers {
+			if spec.Alias != "" {
+				add(spec.Alias)
+			} else {
+				add(spec.Name)
+			}
+		}
+	}
+	return names
+}
+
+// valueSpecifiers renvoie les noms importés qui existent à l'exécution
+func valueSpecifiers(id *ast.ImportDeclaration) []ast.ImportSpecifier {
+	if id.IsTypeOnly {
+		return nil
+	}
+	var specs []ast.ImportSpecifier
+	for _, spec := range id.Specifiers {
+		if !spec.IsType {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+// isRelativeModule indique si un module désigne un fichier du projet
+func isRelativeModule(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
+
+// modulePath découpe un chemin de module : "../lib/utils.ts" donne 1 niveau
+// parent et [lib utils], "@scope/pkg" donne [scope pkg]
+func modulePath(source string) (int, []string) {
+	parents := 0
+	for {
+		if strings.HasPrefix(source, "./") {
+			source = source[2:]
+		} else if strings.HasPrefix(source, "../") {
+			source = source[3:]
+			parents++
+		} else {
+			break
+		}
+	}
+	for _, ext := range []string{".ts", ".tsx", ".js", ".mjs"} {
+		source = strings.TrimSuffix(source, ext)
+	}
+	source = strings.TrimPrefix(source, "@")
+
+	var segments []string
+	for _, segment := range strings.Split(source, "/") {
+		if segment != "" && segment != "index" {
+			segments = append(segments, strings.ReplaceAll(segment, "-", "_"))
+		}
+	}
+	return parents, segments
+}
+
+// isTypeName indique si un nom importé désigne vraisemblablement un type
+// (classe, interface, enum) plutôt qu'une fonction ou une valeur
+func isTypeName(name string) bool {
+	return name != "" && name[0] >= 'A' && name[0] <= 'Z'
+}
@@ -0,0 +1,28 @@
+package generator
+
+import "testing"
+
+func TestMainFunction(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "export default function main",
+			source: "export default function main(): void {\n  console.log(\"hi\");\n}\nmain();",
+			want: map[TargetLanguage][]string{
+				Go:   {"func Main() {", "var Default = Main", "func main() {\n    Main()\n}"},
+				Rust: {"pub use self::Main as default;", "pub fn Main() {", "fn main() {\n    Main();\n}"},
+			},
+			absent: map[TargetLanguage][]string{
+				Go:   {"func main() {\n    fmt"},
+				Rust: {"pub fn main"},
+			},
+		},
+		{
+			name:   "function main",
+			source: "function main(): void {\n  console.log(\"hi\");\n}\nmain();",
+			want: map[TargetLanguage][]string{
+				Go:   {"func Main() {", "Main()"},
+				Rust: {"fn Main() {", "Main();"},
+			},
+		},
+	})
+}
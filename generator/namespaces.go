@@ -0,0 +1,308 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/semantic"
+	"strings"
+)
+
+// declaredStatements renvoie les instructions du programme complétées des
+// déclarations ambiantes (declare class X, declare function f(): T) et des
+// membres des namespaces : elles ne génèrent rien par elles-mêmes dans les
+// langages qui les effacent, mais leurs types restent connus des générateurs
+func declaredStatements(statements []ast.Statement) []ast.Statement {
+	var result []ast.Statement
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *ast.AmbientDeclaration:
+			result = append(result, declaredStatements([]ast.Statement{s.Declaration})...)
+		case *ast.ExportDeclaration:
+			if s.Declaration != nil && !isDefaultExpression(s) {
+				result = append(result, declaredStatements([]ast.Statement{s.Declaration})...)
+			}
+		case *ast.NamespaceDeclaration:
+			result = append(result, stmt)
+			result = append(result, declaredStatements(s.Body)...)
+		default:
+			result = append(result, stmt)
+		}
+	}
+	return result
+}
+
+// isInstantiated indique si un namespace contient du code : comme tsc, on
+// n'émet rien pour un namespace qui ne déclare que des types
+func isInstantiated(nd *ast.NamespaceDeclaration) bool {
+	for _, stmt := range nd.Body {
+		if ed, ok := stmt.(*ast.ExportDeclaration); ok {
+			stmt = ed.Declaration
+		}
+		switch s := stmt.(type) {
+		case nil, *ast.Interface, *ast.TypeAlias, *ast.AmbientDeclaration:
+		case *ast.NamespaceDeclaration:
+			if isInstantiated(s) {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// namespaceExports renvoie les noms exportés par un namespace, déclarations
+// export function f ... et export { a as b }
+func namespaceExports(nd *ast.NamespaceDeclaration) []ast.ImportSpecifier {
+	var exports []ast.ImportSpecifier
+	for _, stmt := range nd.Body {
+		ed, ok := stmt.(*ast.ExportDeclaration)
+		if !ok || ed.IsTypeOnly || isTypeDeclaration(ed.Declaration) {
+			continue
+		}
+		if name := declarationName(ed.Declaration); name != "" {
+			exports = append(exports, ast.ImportSpecifier{Name: name})
+		}
+		for _, spec := range ed.Specifiers {
+			if !spec.IsType {
+				exports = append(exports, spec)
+			}
+		}
+	}
+	return exports
+}
+
+// namespaceBody renvoie les instructions d'un namespace, déclarations
+// exportées comprises, pour les langages où la visibilité ne se traduit pas
+func namespaceBody(nd *ast.NamespaceDeclaration) []ast.Statement {
+	var body []ast.Statement
+	for _, stmt := range nd.Body {
+		if ed, ok := stmt.(*ast.ExportDeclaration); ok {
+			if ed.Declaration == nil || isDefaultExpression(ed) {
+				continue
+			}
+			stmt = ed.Declaration
+		}
+		body = append(body, stmt)
+	}
+	return body
+}
+
+// namespaceMember décrit un membre de namespace ; Value distingue les
+// fonctions et variables des types (classes, enums, namespaces imbriqués)
+type namespaceMember struct {
+	Value bool
+}
+
+// collectNamespaces indexe les membres de chaque namespace par chemin :
+// Geometry, Geometry.Shapes, et aussi Shapes pour les accès relatifs depuis
+// l'intérieur de Geometry
+func collectNamespaces(statements []ast.Statement) map[string]map[string]namespaceMember {
+	namespaces := map[string]map[string]namespaceMember{}
+	var visit func(statements []ast.Statement, prefix string)
+	visit = func(statements []ast.Statement, prefix string) {
+		for _, stmt := range statements {
+			if ed, ok := stmt.(*ast.ExportDeclaration); ok {
+				stmt = ed.Declaration
+			}
+			nd, ok := stmt.(*ast.NamespaceDeclaration)
+			if !ok || nd.IsModule {
+				continue
+			}
+			path := prefix + nd.Name
+			members := map[string]namespaceMember{}
+			for _, member := range nd.Body {
+				if ed, ok := member.(*ast.ExportDeclaration); ok {
+					member = ed.Declaration
+				}
+				switch m := member.(type) {
+				case *ast.FunctionDeclaration:
+					members[m.Name] = namespaceMember{Value: true}
+				case *ast.VariableDeclaration:
+					members[m.Name] = namespaceMember{Value: true}
+				case *ast.ClassDeclaration, *ast.EnumDeclaration, *ast.NamespaceDeclaration:
+					members[declarationName(m)] = namespaceMember{}
+				}
+			}
+			namespaces[path] = members
+			if _, ok := namespaces[nd.Name]; !ok {
+				namespaces[nd.Name] = members
+			}
+			visit(nd.Body, path+".")
+		}
+	}
+	visit(statements, "")
+	return namespaces
+}
+
+// expressionPath renvoie le chemin d'un accès Geometry.Shapes, ou ""
+func expressionPath(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		return e.Value
+	case *ast.DotExpression:
+		if object := expressionPath(e.Object); object != "" {
+			return object + "." + e.Property
+		}
+	}
+	return ""
+}
+
+// namespaceAccess indique si un accès Geometry.area désigne un membre de
+// namespace, et lequel
+func namespaceAccess(de *ast.DotExpression, namespaces map[string]map[string]namespaceMember) (namespaceMember, bool) {
+	members, ok := namespaces[expressionPath(de.Object)]
+	if !ok {
+		return namespaceMember{}, false
+	}
+	member, ok := members[de.Property]
+	return member, ok
+}
+
+// flattenNamespaces remplace chaque namespace par ses déclarations, pour les
+// langages sans équivalent où elles vivent au niveau du programme ; les
+// accès Geometry.area y deviennent area (namespaceAccess). Les déclarations
+// ambiantes, qui n'ont pas d'implémentation, sont retirées.
+func flattenNamespaces(statements []ast.Statement) []ast.Statement {
+	var result []ast.Statement
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *ast.AmbientDeclaration:
+		case *ast.NamespaceDeclaration:
+			result = append(result, flattenNamespaces(namespaceBody(s))...)
+		default:
+			result = append(result, stmt)
+		}
+	}
+	return result
+}
+
+// flattenedAccess remplace un accès Geometry.area par l'identifiant area
+// quand le namespace a été aplati, pour que l'appelant le traite comme un
+// nom déclaré au niveau du programme
+func flattenedAccess(expr ast.Expression, namespaces map[string]map[string]namespaceMember) ast.Expression {
+	if de, ok := expr.(*ast.DotExpression); ok {
+		if _, ok := namespaceAccess(de, namespaces); ok {
+			return &ast.Identifier{Value: de.Property}
+		}
+	}
+	return expr
+}
+
+// flattensNamespaces liste les langages qui aplatissent les namespaces
+var flattensNamespaces = map[TargetLanguage]bool{Go: true, Rust: true, Swift: true, PHP: true}
+
+// namespaceCollision est un membre de namespace dont le nom est aussi déclaré
+// au niveau du programme ou dans un autre namespace : une fois les namespaces
+// aplatis, les deux déclarations seraient en conflit
+type namespaceCollision struct {
+	Path        string // Geometry.Shapes
+	Namespace   *ast.NamespaceDeclaration
+	Declaration ast.Statement
+	Name        string
+}
+
+// namespaceCollisions renvoie, dans l'ordre du source, les membres de
+// namespace en conflit avec une déclaration d'une autre portée. Les
+// déclarations répétées dans une même portée (surcharges, fusion
+// d'interfaces) ne comptent qu'une fois.
+func namespaceCollisions(statements []ast.Statement) []namespaceCollision {
+	scopes := map[string]map[string]bool{}
+	var members []namespaceCollision
+	var visit func(statements []ast.Statement, nd *ast.NamespaceDeclaration, path string)
+	visit = func(statements []ast.Statement, nd *ast.NamespaceDeclaration, path string) {
+		for _, stmt := range statements {
+			if ed, ok := stmt.(*ast.ExportDeclaration); ok {
+				stmt = ed.Declaration
+			}
+			switch s := stmt.(type) {
+			case *ast.AmbientDeclaration:
+				continue
+			case *ast.NamespaceDeclaration:
+				if path != "" {
+					visit(s.Body, s, path+"."+s.Name)
+				} else {
+					visit(s.Body, s, s.Name)
+				}
+				continue
+			}
+			name := declarationName(stmt)
+			if name == "" {
+				continue
+			}
+			if scopes[name] == nil {
+				scopes[name] = map[string]bool{}
+			}
+			scopes[name][path] = true
+			if nd != nil {
+				members = append(members, namespaceCollision{Path: path, Namespace: nd, Declaration: stmt, Name: name})
+			}
+		}
+	}
+	visit(statements, nil, "")
+	var collisions []namespaceCollision
+	for _, member := range members {
+		if len(scopes[member.Name]) > 1 {
+			collisions = append(collisions, member)
+		}
+	}
+	return collisions
+}
+
+// prefixNamespaceCollisions renomme les fonctions et variables de namespace
+// en conflit (namespaceCollisions) avant l'aplatissement : x de A devient
+// A_x, celui de Outer.Inner devient Outer_Inner_x, et les références au sein
+// du namespace comme les accès qualifiés A.x suivent. Les types en conflit
+// sont signalés par Diagnose : leurs références sont des annotations.
+func prefixNamespaceCollisions(program *ast.Program) *ast.Program {
+	info := semantic.Analyze(program)
+	symbols := map[*semantic.Symbol]string{}
+	declarations := map[ast.Span]string{}
+	qualified := map[string]map[string]string{}
+	for _, c := range namespaceCollisions(program.Statements) {
+		switch c.Declaration.(type) {
+		case *ast.FunctionDeclaration, *ast.VariableDeclaration:
+		default:
+			continue
+		}
+		name := strings.ReplaceAll(c.Path, ".", "_") + "_" + c.Name
+		if scope := info.ScopeOf(c.Namespace); scope != nil {
+			if sym := scope.LookupLocal(c.Name); sym != nil {
+				symbols[sym] = name
+			}
+		}
+		declarations[ast.Position(c.Declaration)] = name
+		// Accès par le chemin complet, et relatif depuis le namespace parent
+		for _, path := range []string{c.Path, c.Namespace.Name} {
+			if qualified[path] == nil {
+				qualified[path] = map[string]string{}
+			}
+			if _, ok := qualified[path][c.Name]; !ok {
+				qualified[path][c.Name] = name
+			}
+		}
+	}
+	if len(declarations) == 0 {
+		return program
+	}
+	return ast.Rewrite(program, func(node ast.Node) ast.Node {
+		switch n := node.(type) {
+		case *ast.Identifier:
+			if name, ok := symbols[info.SymbolOf(n)]; ok {
+				return &ast.Identifier{Span: n.Span, Value: name}
+			}
+		case *ast.FunctionDeclaration:
+			if name, ok := declarations[n.Span]; ok {
+				n.Name = name
+			}
+		case *ast.VariableDeclaration:
+			if name, ok := declarations[n.Span]; ok {
+				n.Name = name
+			}
+		case *ast.DotExpression:
+			if name, ok := qualified[expressionPath(n.Object)][n.Property]; ok {
+				n.Property = name
+			}
+		}
+		return node
+	}).(*ast.Program)
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"ProjetGo/lexer"
+	"ProjetGo/parser"
+	"testing"
+)
+
+func TestNamespaceCollisions(t *testing.T) {
+	runOutputCases(t, []outputCase{
+		{
+			name:   "même variable dans deux namespaces",
+			source: "namespace A {\n  export const x = 1;\n  export function f(): number {\n    return x + 1;\n  }\n}\nnamespace B {\n  export const x = 2;\n}\nconst y = A.x + B.x + A.f();",
+			want: map[TargetLanguage][]string{
+				Go:    {"const A_x int = 1", "const B_x int = 2", "return float64(A_x) + 1.0", "A_x + B_x + f()"},
+				Rust:  {"const A_x: i32 = 1;", "const B_x: i32 = 2;", "A_x + B_x + f()"},
+				Swift: {"let A_x: Int = 1", "let B_x: Int = 2", "return Double(A_x) + 1.0"},
+				PHP:   {"$A_x = 1;", "$B_x = 2;", "$y = $A_x + $B_x + f();"},
+			},
+			absent: map[TargetLanguage][]string{
+				Go:    {"const x"},
+				Swift: {"let x"},
+				PHP:   {"$x"},
+			},
+		},
+		{
+			name:   "fonction de namespace imbriqué qui masque une fonction du programme",
+			source: "function area(r: number): number {\n  return r;\n}\nnamespace Outer {\n  export namespace Inner {\n    export function area(r: number): number {\n      return r * r;\n    }\n  }\n  export function twice(r: number): number {\n    return Inner.area(r) * 2;\n  }\n}\nconst a = Outer.Inner.area(2) + area(1) + Outer.twice(3);",
+			want: map[TargetLanguage][]string{
+				Go:    {"func area(r float64) float64 {", "func Outer_Inner_area(r float64) float64 {", "return Outer_Inner_area(r) * 2.0", "Outer_Inner_area(2.0) + area(1.0) + twice(3.0)"},
+				Swift: {"func Outer_Inner_area(_ r: Double) -> Double {", "Outer_Inner_area(2.0) + area(1.0)"},
+			},
+		},
+		{
+			name:   "sans conflit",
+			source: "namespace Geometry {\n  export function area(r: number): number {\n    return r * r;\n  }\n}\nconst a = Geometry.area(2);",
+			want: map[TargetLanguage][]string{
+				Go: {"func area(r float64) float64 {", "area(2.0)"},
+			},
+			absent: map[TargetLanguage][]string{
+				Go: {"Geometry_"},
+			},
+		},
+	})
+}
+
+func TestNamespaceTypeCollisions(t *testing.T) {
+	program := parser.New(lexer.New("namespace A {\n  export class Shape {}\n}\nnamespace B {\n  export class Shape {}\n  export interface Named {}\n  export interface Named {}\n}")).ParseProgram()
+	for _, target := range []TargetLanguage{Go, Rust, Swift, PHP} {
+		diagnostics := Diagnose(program, target)
+		if len(diagnostics) != 2 {
+			t.Fatalf("%s : 2 diagnostics attendus, obtenu %v", target, diagnostics)
+		}
+		if got := diagnostics[0].String(); got != "2:10: ["+string(target)+"] Shape de A est aussi déclaré hors de ce namespace : les namespaces aplatis confondent les deux types" {
+			t.Errorf("%s : %s", target, got)
+		}
+	}
+	if diagnostics := Diagnose(program, Java); len(diagnostics) != 0 {
+		t.Errorf("java : aucun diagnostic attendu, obtenu %v", diagnostics)
+	}
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// overloadSignatures renvoie les signatures de surcharge à générer à côté de
+// l'implémentation. Une signature dont les types de paramètres, une fois
+// traduits, coïncident avec ceux de l'implémentation (arguments omissibles
+// compris) ou d'une signature déjà retenue est omise : l'appel se résout
+// déjà vers cette déclaration.
+func overloadSignatures(fd *ast.FunctionDeclaration, paramType func(ast.Parameter) string) []*ast.FunctionDeclaration {
+	key := func(params []ast.Parameter) string {
+		var types []string
+		for _, param := range params {
+			types = append(types, paramType(param))
+		}
+		return strings.Join(types, ", ")
+	}
+
+	seen := map[string]bool{}
+	for n := len(fd.Parameters); n >= 0; n-- {
+		if n < len(fd.Parameters) && !fd.Parameters[n].CanBeOmitted() && !fd.Parameters[n].IsRest {
+			break
+		}
+		seen[key(fd.Parameters[:n])] = true
+	}
+
+	var signatures []*ast.FunctionDeclaration
+	for _, signature := range fd.Overloads {
+		if k := key(signature.Parameters); !seen[k] {
+			seen[k] = true
+			signatures = append(signatures, signature)
+		}
+	}
+	return signatures
+}
+
+// overloadArguments renvoie les arguments par lesquels une surcharge appelle
+// l'implémentation. Un argument dont le type diffère est converti vers celui
+// de l'implémentation, sans quoi l'appel se résoudrait vers la surcharge
+// elle-même ; les paramètres absents de la signature sont omissibles dans
+// l'implémentation et prennent leur valeur par défaut.
+func overloadArguments(signature, fd *ast.FunctionDeclaration, paramType func(ast.Parameter) string, cast func(value, from, to string) string) []string {
+	var args []string
+	for i, param := range signature.Parameters {
+		if i >= len(fd.Parameters) || param.IsRest || fd.Parameters[i].IsRest {
+			args = append(args, param.Name)
+			continue
+		}
+		if from, to := paramType(param), paramType(fd.Parameters[i]); from != to {
+			args = append(args, cast(param.Name, from, to))
+		} else {
+			args = append(args, param.Name)
+		}
+	}
+	return args
+}
+
+// signatureSource redonne une signature de surcharge telle qu'écrite en
+// TypeScript, pour la garder en commentaire dans les langages sans surcharge
+func signatureSource(fd *ast.FunctionDeclaration) string {
+	var params []string
+	for _, param := range fd.Parameters {
+		part := param.Name
+		if param.IsRest {
+			part = "..." + part
+		}
+		if param.Optional {
+			part += "?"
+		}
+		if param.Type != "" {
+			part += ": " + param.Type
+		}
+		params = append(params, part)
+	}
+	source := fd.Name + "(" + strings.Join(params, ", ") + ")"
+	if fd.ReturnType != "" {
+		source += ": " + fd.ReturnType
+	}
+	return source
+}
+
+// overloadComments liste les signatures de surcharge au-dessus de la fonction
+// unique qui les implémente, dans les langages sans surcharge
+func overloadComments(fd *ast.FunctionDeclaration, comment string) string {
+	var sb strings.Builder
+	for _, signature := range fd.Overloads {
+		sb.WriteString(comment + " Surcharge : " + signatureSource(signature) + "\n")
+	}
+	return sb.String()
+}
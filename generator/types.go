@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// elementType renvoie le type des éléments d'un type tableau TypeScript
+// (number[] -> number, Array<string> -> string). Pour un type non tableau,
+// le type est renvoyé tel quel.
+func elementType(t string) string {
+	if strings.HasSuffix(t, "[]") {
+		return strings.TrimSuffix(t, "[]")
+	}
+	if strings.HasPrefix(t, "Array<") && strings.HasSuffix(t, ">") {
+		return strings.TrimSuffix(strings.TrimPrefix(t, "Array<"), ">")
+	}
+	return t
+}
+
+// isRecordType indique si un type TypeScript décrit un dictionnaire clé/valeur
+// (Record<string, T>, { [key: string]: T }, object)
+func isRecordType(t string) bool {
+	return strings.HasPrefix(t, "Record<") || strings.HasPrefix(t, "{") || t == "object"
+}
+
+// splitTypeArguments sépare un type générique en nom et arguments de premier
+// niveau : Map<string, Array<number>> -> Map [string Array<number>]
+func splitTypeArguments(t string) (string, []string) {
+	open := strings.Index(t, "<")
+	if open < 0 || !strings.HasSuffix(t, ">") {
+		return t, nil
+	}
+
+	var args []string
+	depth, start := 0, open+1
+	inner := t[:len(t)-1]
+	for i := start; i < len(inner); i++ {
+		switch inner[i] {
+		case '<', '(', '{', '[':
+			depth++
+		case '>', ')', '}', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, strings.TrimSpace(inner[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	args = append(args, strings.TrimSpace(inner[start:]))
+	return t[:open], args
+}
+
+// newMapType renvoie le type construit par new Map<K, V>() ; sans arguments
+// de type, clés et valeurs sont any
+func newMapType(args []string) string {
+	if len(args) != 2 {
+		return "Map<any, any>"
+	}
+	return "Map<" + args[0] + ", " + args[1] + ">"
+}
+
+// collectTypeNames indexe les types nommés déclarés dans le programme (classes,
+// interfaces, enums et unions de littéraux, y compris ambiants ou membres d'un
+// namespace), que les générateurs typés reprennent tels quels au lieu de les
+// remplacer par un type universel
+func collectTypeNames(statements []ast.Statement) map[string]bool {
+	names := map[string]bool{}
+	for _, stmt := range declaredStatements(statements) {
+		switch s := stmt.(type) {
+		case *ast.ClassDeclaration:
+			names[s.Name] = true
+		case *ast.Interface:
+			names[s.Name] = true
+		case *ast.EnumDeclaration:
+			names[s.Name] = true
+		case *ast.TypeAlias:
+			if len(s.Literals) > 0 {
+				names[s.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// boundTypes renvoie les noms des types qui contraignent un paramètre de type
+// du programme : Base pour <T extends Base>
+func boundTypes(statements []ast.Statement) map[string]bool {
+	bounds := map[string]bool{}
+	for _, stmt := range statements {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			if tp, ok := node.(*ast.TypeParameter); ok && tp.Constraint != "" {
+				name, _ := splitTypeArguments(tp.Constraint)
+				bounds[name] = true
+			}
+			return true
+		})
+	}
+	return bounds
+}
+
+// isTypeParameter indique si un type annoté est l'un des paramètres de type
+// d'une déclaration générique : value: T dans class Box<T>
+func isTypeParameter(t string, params []ast.TypeParameter) bool {
+	for _, param := range params {
+		if param.Name == t {
+			return true
+		}
+	}
+	return false
+}
+
+// declareTypeParameters ajoute les paramètres de type d'une déclaration
+// générique aux types connus, le temps de la générer : l'appel de la fonction
+// renvoyée restaure l'état précédent
+func declareTypeParameters(typeNames map[string]bool, params []ast.TypeParameter) func() {
+	previous := map[string]bool{}
+	for _, param := range params {
+		previous[param.Name] = typeNames[param.Name]
+		typeNames[param.Name] = true
+	}
+	return func() {
+		for name, known := range previous {
+			if known {
+				typeNames[name] = true
+			} else {
+				delete(typeNames, name)
+			}
+		}
+	}
+}
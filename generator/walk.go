@@ -0,0 +1,30 @@
+package generator
+
+import "ProjetGo/ast"
+
+// inspectStatements applique ast.Inspect à chaque instruction d'un corps ou
+// du programme
+func inspectStatements(statements []ast.Statement, f func(ast.Node) bool) {
+	for _, stmt := range statements {
+		if stmt != nil {
+			ast.Inspect(stmt, f)
+		}
+	}
+}
+
+// isFunctionScope indique si un nœud a son propre corps de fonction : un
+// return ou un await qui s'y trouve ne concerne pas le code englobant
+func isFunctionScope(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.FunctionDeclaration, *ast.ArrowFunction, *ast.ClassDeclaration, *ast.ClassExpression:
+		return true
+	}
+	return false
+}
+
+// bindsThis indique si un nœud introduit son propre this ; les fonctions
+// fléchées gardent celui qui les englobe
+func bindsThis(node ast.Node) bool {
+	_, isArrow := node.(*ast.ArrowFunction)
+	return isFunctionScope(node) && !isArrow
+}
@@ -1,329 +1,483 @@
 package lexer
 
+import "strings"
+
 type TokenType string
 
 type Token struct {
-    Type    TokenType
-    Literal string
-    Line    int
-    Column  int
+	Type      TokenType
+	Literal   string
+	Line      int
+	Column    int
+	Offset    int // position du token dans l'entrée
+	End       int // position qui suit le token
+	EndLine   int
+	EndColumn int
 }
 
 const (
-    ILLEGAL = "ILLEGAL"
-    EOF     = "EOF"
-
-    IDENT     = "IDENT"     // variable, fonction
-    KEYWORD   = "KEYWORD"   // let, const, function, return
-    NUMBER    = "NUMBER"    // 123, 3.14
-    STRING    = "STRING"    // "hello"
-    TEMPLATE  = "TEMPLATE"  // `hello ${name}`
-    COMMENT   = "COMMENT"   // // commentaire
-    OPERATOR  = "OPERATOR"  // =, +, -, *, /
-    COLON     = ":"
-    SEMICOLON = ";"
-    COMMA     = ","
-    LPAREN    = "("
-    RPAREN    = ")"
-    LBRACE    = "{"
-    RBRACE    = "}"
-    LBRACKET  = "["
-    RBRACKET  = "]"
-    DOT       = "."
-    PIPE      = "|"
-    ARROW     = "=>"
-    QUESTION  = "?"
-    EXCLAMATION = "!"
+	ILLEGAL = "ILLEGAL"
+	EOF     = "EOF"
+
+	IDENT       = "IDENT"    // variable, fonction
+	KEYWORD     = "KEYWORD"  // let, const, function, return
+	NUMBER      = "NUMBER"   // 123, 3.14
+	STRING      = "STRING"   // "hello"
+	TEMPLATE    = "TEMPLATE" // `hello ${name}`
+	COMMENT     = "COMMENT"  // // commentaire, /* commentaire */
+	OPERATOR    = "OPERATOR" // =, +, -, *, /
+	COLON       = ":"
+	SEMICOLON   = ";"
+	COMMA       = ","
+	LPAREN      = "("
+	RPAREN      = ")"
+	LBRACE      = "{"
+	RBRACE      = "}"
+	LBRACKET    = "["
+	RBRACKET    = "]"
+	DOT         = "."
+	PIPE        = "|"
+	ARROW       = "=>"
+	QUESTION    = "?"
+	EXCLAMATION = "!"
+	ELLIPSIS    = "..."
+	AT          = "@"        // décorateur : @Injectable()
+	JSX_TEXT    = "JSX_TEXT" // texte entre deux balises JSX
 )
 
 var keywords = map[string]TokenType{
-    "let":       KEYWORD,
-    "const":     KEYWORD,
-    "var":       KEYWORD,
-    "function":  KEYWORD,
-    "return":    KEYWORD,
-    "true":      KEYWORD,
-    "false":     KEYWORD,
-    "type":      KEYWORD,
-    "interface": KEYWORD,
-    "class":     KEYWORD,
-    "async":     KEYWORD,
-    "await":     KEYWORD,
-    "new":       KEYWORD,
-    "this":      KEYWORD,
-    "private":   KEYWORD,
-    "public":    KEYWORD,
-    "static":    KEYWORD,
-    "if":        KEYWORD,
-    "else":      KEYWORD,
-    "for":       KEYWORD,
-    "while":     KEYWORD,
-    "do":        KEYWORD,
-    "break":     KEYWORD,
-    "continue":  KEYWORD,
-    "try":       KEYWORD,
-    "catch":     KEYWORD,
-    "throw":     KEYWORD,
-    "switch":    KEYWORD,
-    "case":      KEYWORD,
-    "default":   KEYWORD,
-    "console":   KEYWORD,
-    "void":      KEYWORD,
+	"let":       KEYWORD,
+	"const":     KEYWORD,
+	"var":       KEYWORD,
+	"function":  KEYWORD,
+	"return":    KEYWORD,
+	"true":      KEYWORD,
+	"false":     KEYWORD,
+	"type":      KEYWORD,
+	"interface": KEYWORD,
+	"class":     KEYWORD,
+	"async":     KEYWORD,
+	"await":     KEYWORD,
+	"yield":     KEYWORD,
+	"new":       KEYWORD,
+	"this":      KEYWORD,
+	"private":   KEYWORD,
+	"public":    KEYWORD,
+	"static":    KEYWORD,
+	"if":        KEYWORD,
+	"else":      KEYWORD,
+	"for":       KEYWORD,
+	"while":     KEYWORD,
+	"do":        KEYWORD,
+	"break":     KEYWORD,
+	"continue":  KEYWORD,
+	"try":       KEYWORD,
+	"catch":     KEYWORD,
+	"throw":     KEYWORD,
+	"switch":    KEYWORD,
+	"case":      KEYWORD,
+	"default":   KEYWORD,
+	"console":   KEYWORD,
+	"void":      KEYWORD,
+	"enum":      KEYWORD,
+	"import":    KEYWORD,
+	"export":    KEYWORD,
 }
 
 type Lexer struct {
-    input        string
-    position     int  // position actuelle
-    readPosition int  // position après lecture
-    ch           byte // caractère courant
-    line         int
-    column       int
+	input        string
+	position     int  // position actuelle
+	readPosition int  // position après lecture
+	ch           byte // caractère courant
+	line         int
+	column       int
+
+	JSX bool // fichier .tsx : <div> ouvre un élément JSX et non une assertion
+
+	comments []Token // commentaires lus, dans l'ordre de l'entrée
 }
 
 func New(input string) *Lexer {
-    l := &Lexer{input: input, line: 1, column: 0}
-    l.readChar()
-    return l
+	l := &Lexer{input: input, line: 1, column: 0}
+	l.readChar()
+	return l
+}
+
+// NewJSX crée un lexer en mode JSX, pour un fichier .tsx
+func NewJSX(input string) *Lexer {
+	l := New(input)
+	l.JSX = true
+	return l
+}
+
+// Seek replace le lexer sur une position de l'entrée : le parser y relit en
+// texte JSX ce qui suit une balise, déjà découpé en tokens ordinaires
+func (l *Lexer) Seek(offset int) {
+	// Un // lu dans du texte JSX n'était pas un commentaire
+	for len(l.comments) > 0 && l.comments[len(l.comments)-1].Offset >= offset {
+		l.comments = l.comments[:len(l.comments)-1]
+	}
+	before := l.input[:offset]
+	l.line = 1 + strings.Count(before, "\n")
+	l.column = offset - (strings.LastIndex(before, "\n") + 1)
+	l.readPosition = offset
+	l.readChar()
+}
+
+// ReadJSXText lit le texte d'un élément JSX jusqu'à la balise ou
+// l'expression suivante : <p>Bonjour {name}</p> -> "Bonjour "
+func (l *Lexer) ReadJSXText() Token {
+	tok := Token{Type: JSX_TEXT, Line: l.line, Column: l.column, Offset: l.position}
+	if l.ch == '\n' {
+		// readChar compte déjà le saut de ligne dans la ligne suivante
+		tok.Line, tok.Column = l.line-1, l.position-strings.LastIndex(l.input[:l.position], "\n")
+	}
+	for l.ch != '<' && l.ch != '{' && l.ch != 0 {
+		l.readChar()
+	}
+	tok.Literal = l.input[tok.Offset:l.position]
+	l.setEnd(&tok)
+	return tok
+}
+
+// setEnd complète un token lu de la position qui le suit, pour l'étendue des
+// nœuds de l'AST
+func (l *Lexer) setEnd(tok *Token) {
+	// En fin d'entrée, la position du lexer dépasse la longueur de l'entrée
+	tok.Offset = min(tok.Offset, len(l.input))
+	tok.End = min(l.position, len(l.input))
+	if tok.Type == COMMENT {
+		// readComment s'arrête sur le saut de ligne, que le lexer passe ensuite
+		tok.End = tok.Offset + len(tok.Literal)
+	}
+	text := l.input[tok.Offset:tok.End]
+	if newline := strings.LastIndex(text, "\n"); newline >= 0 {
+		tok.EndLine = tok.Line + strings.Count(text, "\n")
+		tok.EndColumn = len(text) - newline
+	} else {
+		tok.EndLine = tok.Line
+		tok.EndColumn = tok.Column + len(text)
+	}
 }
 
 func (l *Lexer) readChar() {
-    if l.readPosition >= len(l.input) {
-        l.ch = 0
-    } else {
-        l.ch = l.input[l.readPosition]
-    }
-    if l.ch == '\n' {
-        l.line++
-        l.column = 0
-    } else {
-        l.column++
-    }
-    l.position = l.readPosition
-    l.readPosition++
+	if l.readPosition >= len(l.input) {
+		l.ch = 0
+	} else {
+		l.ch = l.input[l.readPosition]
+	}
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	} else {
+		l.column++
+	}
+	l.position = l.readPosition
+	l.readPosition++
 }
 
 func (l *Lexer) peekChar() byte {
-    if l.readPosition >= len(l.input) {
-        return 0
-    } else {
-        return l.input[l.readPosition]
-    }
+	if l.readPosition >= len(l.input) {
+		return 0
+	} else {
+		return l.input[l.readPosition]
+	}
 }
 
 func (l *Lexer) NextToken() Token {
-    l.skipWhitespace()
-
-    tok := Token{Line: l.line, Column: l.column}
-
-    switch l.ch {
-    case '=':
-        if l.peekChar() == '=' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else if l.peekChar() == '>' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: ARROW, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else {
-            tok = newToken(OPERATOR, "=", l)
-        }
-    case '+':
-        if l.peekChar() == '+' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else {
-            tok = newToken(OPERATOR, "+", l)
-        }
-    case '-':
-        if l.peekChar() == '-' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else {
-            tok = newToken(OPERATOR, "-", l)
-        }
-    case '*':
-        tok = newToken(OPERATOR, "*", l)
-    case '/':
-        if l.peekChar() == '/' {
-            tok.Type = COMMENT
-            tok.Literal = l.readComment()
-        } else {
-            tok = newToken(OPERATOR, "/", l)
-        }
-    case '<':
-        if l.peekChar() == '=' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else {
-            tok = newToken(OPERATOR, "<", l)
-        }
-    case '>':
-        if l.peekChar() == '=' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else {
-            tok = newToken(OPERATOR, ">", l)
-        }
-    case '!':
-        if l.peekChar() == '=' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else {
-            tok = newToken(EXCLAMATION, "!", l)
-        }
-    case '&':
-        if l.peekChar() == '&' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else {
-            tok = newToken(OPERATOR, "&", l)
-        }
-    case '|':
-        if l.peekChar() == '|' {
-            ch := l.ch
-            l.readChar()
-            tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
-        } else {
-            tok = newToken(PIPE, "|", l)
-        }
-    case '?':
-        tok = newToken(QUESTION, "?", l)
-    case '.':
-        tok = newToken(DOT, ".", l)
-    case ';':
-        tok = newToken(SEMICOLON, ";", l)
-    case ':':
-        tok = newToken(COLON, ":", l)
-    case '(':
-        tok = newToken(LPAREN, "(", l)
-    case ')':
-        tok = newToken(RPAREN, ")", l)
-    case '{':
-        tok = newToken(LBRACE, "{", l)
-    case '}':
-        tok = newToken(RBRACE, "}", l)
-    case '[':
-        tok = newToken(LBRACKET, "[", l)
-    case ']':
-        tok = newToken(RBRACKET, "]", l)
-    case ',':
-        tok = newToken(COMMA, ",", l)
-    case '"':
-        tok.Type = STRING
-        tok.Literal = l.readString()
-        return tok
-    case '\'':
-        tok.Type = STRING
-        tok.Literal = l.readSingleQuoteString()
-        return tok
-    case '`':
-        tok.Type = TEMPLATE
-        tok.Literal = l.readTemplateLiteral()
-        return tok
-    case 0:
-        tok.Type = EOF
-        tok.Literal = ""
-    default:
-        if isLetter(l.ch) {
-            ident := l.readIdentifier()
-            tok.Literal = ident
-            if t, ok := keywords[ident]; ok {
-                tok.Type = t
-            } else {
-                tok.Type = IDENT
-            }
-            return tok
-        } else if isDigit(l.ch) {
-            tok.Type = NUMBER
-            tok.Literal = l.readNumber()
-            return tok
-        } else {
-            tok = newToken(ILLEGAL, string(l.ch), l)
-        }
-    }
-
-    l.readChar()
-    return tok
+	l.skipWhitespace()
+
+	// Un opérateur de deux caractères est créé sur son second : la position
+	// du token est celle de son premier caractère
+	offset, line, column := l.position, l.line, l.column
+	tok := l.readToken()
+	tok.Offset, tok.Line, tok.Column = offset, line, column
+	l.setEnd(&tok)
+	if tok.Type == COMMENT {
+		l.comments = append(l.comments, tok)
+	}
+	return tok
+}
+
+// Input renvoie le texte source lu par le lexer
+func (l *Lexer) Input() string {
+	return l.input
+}
+
+// Comments renvoie les commentaires lus jusqu'ici. Une copie du lexer (pour
+// une lecture spéculative) garde sa propre longueur de liste : les
+// commentaires relus après un retour arrière ne sont pas dupliqués.
+func (l *Lexer) Comments() []Token {
+	return l.comments
+}
+
+func (l *Lexer) readToken() Token {
+	tok := Token{Line: l.line, Column: l.column}
+
+	switch l.ch {
+	case '=':
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else if l.peekChar() == '>' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: ARROW, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else {
+			tok = newToken(OPERATOR, "=", l)
+		}
+	case '+':
+		if l.peekChar() == '+' || l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else {
+			tok = newToken(OPERATOR, "+", l)
+		}
+	case '-':
+		if l.peekChar() == '-' || l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else {
+			tok = newToken(OPERATOR, "-", l)
+		}
+	case '*':
+		if l.peekChar() == '=' {
+			l.readChar()
+			tok = newToken(OPERATOR, "*=", l)
+		} else {
+			tok = newToken(OPERATOR, "*", l)
+		}
+	case '/':
+		if l.peekChar() == '/' {
+			tok.Type = COMMENT
+			tok.Literal = l.readComment()
+		} else if l.peekChar() == '*' {
+			tok.Type = COMMENT
+			tok.Literal = l.readBlockComment()
+		} else if l.peekChar() == '=' {
+			l.readChar()
+			tok = newToken(OPERATOR, "/=", l)
+		} else {
+			tok = newToken(OPERATOR, "/", l)
+		}
+	case '%':
+		tok = newToken(OPERATOR, "%", l)
+	case '<':
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else {
+			tok = newToken(OPERATOR, "<", l)
+		}
+	case '>':
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else {
+			tok = newToken(OPERATOR, ">", l)
+		}
+	case '!':
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else {
+			tok = newToken(EXCLAMATION, "!", l)
+		}
+	case '&':
+		if l.peekChar() == '&' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else {
+			tok = newToken(OPERATOR, "&", l)
+		}
+	case '|':
+		if l.peekChar() == '|' {
+			ch := l.ch
+			l.readChar()
+			tok = Token{Type: OPERATOR, Literal: string(ch) + string(l.ch), Line: l.line, Column: l.column}
+		} else {
+			tok = newToken(PIPE, "|", l)
+		}
+	case '?':
+		tok = newToken(QUESTION, "?", l)
+	case '.':
+		if l.peekChar() == '.' && l.readPosition+1 < len(l.input) && l.input[l.readPosition+1] == '.' {
+			l.readChar()
+			l.readChar()
+			tok = newToken(ELLIPSIS, "...", l)
+		} else {
+			tok = newToken(DOT, ".", l)
+		}
+	case ';':
+		tok = newToken(SEMICOLON, ";", l)
+	case ':':
+		tok = newToken(COLON, ":", l)
+	case '(':
+		tok = newToken(LPAREN, "(", l)
+	case ')':
+		tok = newToken(RPAREN, ")", l)
+	case '{':
+		tok = newToken(LBRACE, "{", l)
+	case '}':
+		tok = newToken(RBRACE, "}", l)
+	case '[':
+		tok = newToken(LBRACKET, "[", l)
+	case ']':
+		tok = newToken(RBRACKET, "]", l)
+	case ',':
+		tok = newToken(COMMA, ",", l)
+	case '@':
+		tok = newToken(AT, "@", l)
+	case '#':
+		if !isLetter(l.peekChar()) {
+			tok = newToken(ILLEGAL, "#", l)
+			break
+		}
+		// Nom privé #secret : un identifiant dont le # fait partie du nom
+		l.readChar()
+		tok.Type = IDENT
+		tok.Literal = "#" + l.readIdentifier()
+		return tok
+	case '"':
+		tok.Type = STRING
+		tok.Literal = l.readString()
+		return tok
+	case '\'':
+		tok.Type = STRING
+		tok.Literal = l.readSingleQuoteString()
+		return tok
+	case '`':
+		tok.Type = TEMPLATE
+		tok.Literal = l.readTemplateLiteral()
+		return tok
+	case 0:
+		tok.Type = EOF
+		tok.Literal = ""
+	default:
+		if isLetter(l.ch) {
+			ident := l.readIdentifier()
+			tok.Literal = ident
+			if t, ok := keywords[ident]; ok {
+				tok.Type = t
+			} else {
+				tok.Type = IDENT
+			}
+			return tok
+		} else if isDigit(l.ch) {
+			tok.Type = NUMBER
+			tok.Literal = l.readNumber()
+			return tok
+		} else {
+			tok = newToken(ILLEGAL, string(l.ch), l)
+		}
+	}
+
+	l.readChar()
+	return tok
 }
 
 func newToken(tokenType TokenType, ch string, l *Lexer) Token {
-    return Token{Type: tokenType, Literal: ch, Line: l.line, Column: l.column}
+	return Token{Type: tokenType, Literal: ch, Line: l.line, Column: l.column}
 }
 
 func (l *Lexer) skipWhitespace() {
-    for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
-        l.readChar()
-    }
+	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+		l.readChar()
+	}
 }
 
 func (l *Lexer) readIdentifier() string {
-    start := l.position
-    for isLetter(l.ch) || isDigit(l.ch) {
-        l.readChar()
-    }
-    return l.input[start:l.position]
+	start := l.position
+	for isLetter(l.ch) || isDigit(l.ch) {
+		l.readChar()
+	}
+	return l.input[start:l.position]
 }
 
 func (l *Lexer) readNumber() string {
-    start := l.position
-    for isDigit(l.ch) {
-        l.readChar()
-    }
-    return l.input[start:l.position]
+	start := l.position
+	for isDigit(l.ch) {
+		l.readChar()
+	}
+	// Partie décimale (3.14)
+	if l.ch == '.' && isDigit(l.peekChar()) {
+		l.readChar()
+		for isDigit(l.ch) {
+			l.readChar()
+		}
+	}
+	return l.input[start:l.position]
 }
 
 func (l *Lexer) readString() string {
-    l.readChar() // skip "
-    start := l.position
-    for l.ch != '"' && l.ch != 0 {
-        l.readChar()
-    }
-    str := l.input[start:l.position]
-    l.readChar() // skip closing "
-    return str
+	l.readChar() // skip "
+	start := l.position
+	for l.ch != '"' && l.ch != 0 {
+		l.readChar()
+	}
+	str := l.input[start:l.position]
+	l.readChar() // skip closing "
+	return str
 }
 
 func (l *Lexer) readSingleQuoteString() string {
-    l.readChar() // skip '
-    start := l.position
-    for l.ch != '\'' && l.ch != 0 {
-        l.readChar()
-    }
-    str := l.input[start:l.position]
-    l.readChar() // skip closing '
-    return str
+	l.readChar() // skip '
+	start := l.position
+	for l.ch != '\'' && l.ch != 0 {
+		l.readChar()
+	}
+	str := l.input[start:l.position]
+	l.readChar() // skip closing '
+	return str
 }
 
 func (l *Lexer) readTemplateLiteral() string {
-    l.readChar() // skip `
-    start := l.position
-    for l.ch != '`' && l.ch != 0 {
-        l.readChar()
-    }
-    str := l.input[start:l.position]
-    l.readChar() // skip closing `
-    return str
+	l.readChar() // skip `
+	start := l.position
+	for l.ch != '`' && l.ch != 0 {
+		l.readChar()
+	}
+	str := l.input[start:l.position]
+	l.readChar() // skip closing `
+	return str
 }
 
 func (l *Lexer) readComment() string {
-    start := l.position
-    for l.ch != '\n' && l.ch != 0 {
-        l.readChar()
-    }
-    return l.input[start:l.position]
+	start := l.position
+	for l.ch != '\n' && l.ch != 0 {
+		l.readChar()
+	}
+	return l.input[start:l.position]
+}
+
+// readBlockComment lit un commentaire /* ... */ ou /** ... */, éventuellement
+// sur plusieurs lignes ; le lexer reste sur le / final
+func (l *Lexer) readBlockComment() string {
+	start := l.position
+	l.readChar() // passer '/'
+	l.readChar() // passer '*'
+	for l.ch != 0 && !(l.ch == '*' && l.peekChar() == '/') {
+		l.readChar()
+	}
+	if l.ch == 0 {
+		// Commentaire non fermé : il s'étend jusqu'à la fin de l'entrée
+		return l.input[start:]
+	}
+	l.readChar() // aller sur '/'
+	return l.input[start : l.position+1]
 }
 
 func isLetter(ch byte) bool {
-    return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
 }
 
 func isDigit(ch byte) bool {
-    return '0' <= ch && ch <= '9'
+	return '0' <= ch && ch <= '9'
 }
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"ProjetGo/ast"
 	"ProjetGo/generator"
 	"ProjetGo/lexer"
 	"ProjetGo/parser"
+	"ProjetGo/printer"
+	"ProjetGo/semantic"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -18,7 +23,13 @@ type Config struct {
 	Console bool
 	File    string
 	Target  string
+	Module  string
 	Verbose bool
+	Emit    string // code, ast-json ou ts
+
+	Decorators   string // legacy ou tc39
+	JSX          string // classic ou automatic
+	DecoratorMap string // fichier JSON de correspondances des décorateurs
 }
 
 func parseFlags() *Config {
@@ -29,7 +40,12 @@ func parseFlags() *Config {
 	flag.BoolVar(&config.Console, "console", false, "Run in console mode")
 	flag.StringVar(&config.File, "file", "", "Input file to transpile")
 	flag.StringVar(&config.Target, "target", "all", "Target language (js,java,python,csharp,go,rust,swift,php,all)")
+	flag.StringVar(&config.Module, "module", "esm", "JavaScript module format (esm,commonjs)")
+	flag.StringVar(&config.Decorators, "decorators", "tc39", "JavaScript decorator format (tc39,legacy)")
+	flag.StringVar(&config.JSX, "jsx", "classic", "JavaScript JSX runtime for .tsx files (classic,automatic)")
+	flag.StringVar(&config.DecoratorMap, "decorator-map", "", "JSON file mapping decorators to Java/Python/C# ({\"Injectable\": {\"java\": \"@Service\"}})")
 	flag.BoolVar(&config.Verbose, "verbose", false, "Verbose output")
+	flag.StringVar(&config.Emit, "emit", "code", "Output kind (code,ast-json,ts); ast-json prints the parsed tree of -file (or stdin) as JSON, ts reformats it as TypeScript")
 
 	flag.Parse()
 
@@ -93,12 +109,14 @@ class Calculator {
 
 	start := time.Now()
 
-	l := lexer.New(input)
-	p := parser.New(l)
-	program := p.ParseProgram()
+	program, err := loadProgram(config.File, input)
+	if err != nil {
+		fmt.Printf("❌ Erreur lors de la lecture de l'arbre JSON: %v\n", err)
+		return
+	}
 
 	// Check for parsing errors
-	if len(program) == 0 {
+	if len(program.Statements) == 0 {
 		fmt.Println("❌ Aucun code valide détecté. Vérifiez la syntaxe.")
 		return
 	}
@@ -108,12 +126,45 @@ class Calculator {
 		fmt.Printf("⏱️  Temps de parsing: %v\n\n", elapsed)
 	}
 
+	fileName := config.File
+	if fileName == "" {
+		fileName = "<exemple>"
+	}
+
+	// Comme tsc, les erreurs de type n'empêchent pas la génération
+	if diagnostics := semantic.Diagnose(program); len(diagnostics) > 0 {
+		fmt.Println("⚠️  Diagnostics:")
+		for _, diagnostic := range diagnostics {
+			fmt.Printf("   %s:%s\n", fileName, diagnostic)
+		}
+		fmt.Println()
+	}
+
 	// Generate code for specified targets
 	targets := getTargetLanguages(config.Target)
 
+	options := generator.Options{
+		Modules:    generator.ModuleFormat(config.Module),
+		Decorators: generator.DecoratorFormat(config.Decorators),
+		JSX:        generator.JSXRuntime(config.JSX),
+	}
+	if config.DecoratorMap != "" {
+		content, err := os.ReadFile(config.DecoratorMap)
+		if err == nil {
+			err = json.Unmarshal(content, &options.DecoratorMappings)
+		}
+		if err != nil {
+			fmt.Printf("❌ Erreur lors de la lecture des correspondances de décorateurs: %v\n", err)
+			return
+		}
+	}
 	for _, target := range targets {
 		fmt.Printf("=== %s Output ===\n", getLanguageName(target))
-		fmt.Println(generator.Generate(program, target))
+		// Ce que la cible n'exprime pas est signalé avant le code généré
+		for _, diagnostic := range generator.Diagnose(program, target) {
+			fmt.Printf("⚠️  %s:%s\n", fileName, diagnostic)
+		}
+		fmt.Println(generator.GenerateWithOptions(program, target, options))
 		fmt.Println()
 	}
 
@@ -123,6 +174,55 @@ class Calculator {
 	}
 }
 
+// loadProgram parse le source d'un fichier ; un .json est un arbre déjà
+// parsé, écrit par -emit=ast-json
+func loadProgram(fileName, input string) (*ast.Program, error) {
+	if strings.HasSuffix(fileName, ".json") {
+		return ast.DecodeJSON([]byte(input))
+	}
+
+	// Dans un .tsx, <div> ouvre un élément JSX
+	l := lexer.New(input)
+	if strings.HasSuffix(fileName, ".tsx") {
+		l = lexer.NewJSX(input)
+	}
+	program := parser.New(l).ParseProgram()
+	program.FileName = fileName
+	return program, nil
+}
+
+// runEmit écrit l'arbre du fichier (ou de l'entrée standard) en JSON, ou le
+// réimprime en TypeScript formaté, sans autre sortie : le résultat est
+// destiné à d'autres outils
+func runEmit(config *Config) {
+	var content []byte
+	var err error
+	if config.File != "" {
+		content, err = os.ReadFile(config.File)
+	} else {
+		content, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Erreur lors de la lecture du fichier: %v\n", err)
+		os.Exit(1)
+	}
+
+	program, err := loadProgram(config.File, string(content))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Erreur lors de la lecture de l'arbre JSON: %v\n", err)
+		os.Exit(1)
+	}
+	if config.Emit == "ts" {
+		fmt.Print(printer.Print(program))
+		return
+	}
+	if content, err = ast.EncodeJSON(program); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Erreur lors de l'écriture de l'arbre JSON: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(content))
+}
+
 func getTargetLanguages(target string) []generator.TargetLanguage {
 	switch strings.ToLower(target) {
 	case "js", "javascript":
@@ -181,6 +281,11 @@ func getLanguageName(target generator.TargetLanguage) string {
 func main() {
 	config := parseFlags()
 
+	if config.Emit == "ast-json" || config.Emit == "ts" {
+		runEmit(config)
+		return
+	}
+
 	fmt.Println("🚀 Transpilateur Multi-Langages v2.0")
 	fmt.Println("====================================")
 
@@ -201,6 +306,8 @@ func main() {
 	fmt.Println("   go run . --target=python    # Langage cible spécifique")
 	fmt.Println("   go run . --port=3000        # Port personnalisé")
 	fmt.Println("   go run . --verbose          # Sortie détaillée")
+	fmt.Println("   go run . --emit=ast-json    # Arbre syntaxique en JSON")
+	fmt.Println("   go run . --emit=ts          # Reformater le source TypeScript")
 	fmt.Println()
 
 	StartWebServer()
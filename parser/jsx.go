@@ -0,0 +1,225 @@
+package parser
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/lexer"
+	"strings"
+)
+
+// parseJSX parse un élément ou un fragment JSX utilisé comme expression et
+// passe son '>' final
+func (p *Parser) parseJSX() ast.Expression {
+	element := p.parseJSXElement()
+	if element != nil {
+		p.nextToken() // passer '>'
+	}
+	return element
+}
+
+// parseJSXElement parse <Tag attr="v">enfants</Tag>, <Tag /> ou le fragment
+// <>enfants</>. Il s'arrête sur le '>' final : ce qui le suit se lit comme du
+// texte JSX si l'élément est un enfant, comme des tokens sinon.
+func (p *Parser) parseJSXElement() ast.Expression {
+	start := p.start()
+	p.nextToken() // passer '<'
+
+	if p.isJSXClose() {
+		fragment := &ast.JSXFragment{Children: p.parseJSXChildren()}
+		if !p.parseJSXClosingTag("") {
+			return nil
+		}
+		fragment.Span = p.spanThrough(start)
+		return fragment
+	}
+
+	if p.curToken.Type != lexer.IDENT && p.curToken.Type != lexer.KEYWORD {
+		return nil
+	}
+	element := &ast.JSXElement{Name: p.parseJSXName()}
+
+	for !p.isJSXClose() && p.curToken.Type != lexer.EOF {
+		if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "/" {
+			// <Tag /> : pas d'enfants ni de balise fermante
+			p.nextToken() // passer '/'
+			element.SelfClosing = true
+			element.Span = p.spanThrough(start)
+			return element
+		}
+		attribute, ok := p.parseJSXAttribute()
+		if !ok {
+			return nil
+		}
+		element.Attributes = append(element.Attributes, attribute)
+	}
+
+	element.Children = p.parseJSXChildren()
+	if !p.parseJSXClosingTag(element.Name) {
+		return nil
+	}
+	element.Span = p.spanThrough(start)
+	return element
+}
+
+// parseJSXAttribute parse name="v", name={expr}, name seul ou {...props}
+func (p *Parser) parseJSXAttribute() (attribute ast.JSXAttribute, ok bool) {
+	start := p.start()
+	if p.curToken.Type == lexer.LBRACE && p.peekToken.Type == lexer.ELLIPSIS {
+		p.nextToken() // passer '{'
+		p.nextToken() // passer '...'
+		attribute = ast.JSXAttribute{Value: p.parseExpression(), IsSpread: true}
+		if p.curToken.Type != lexer.RBRACE {
+			return attribute, false
+		}
+		p.nextToken() // passer '}'
+		attribute.Span = p.span(start)
+		return attribute, true
+	}
+
+	if p.curToken.Type != lexer.IDENT && p.curToken.Type != lexer.KEYWORD {
+		return ast.JSXAttribute{}, false
+	}
+	attribute = ast.JSXAttribute{Name: p.parseJSXName()}
+	defer func() { attribute.Span = p.span(start) }()
+	if p.curToken.Type != lexer.OPERATOR || p.curToken.Literal != "=" {
+		return attribute, true
+	}
+	p.nextToken() // passer '='
+
+	valueStart := p.start()
+	switch p.curToken.Type {
+	case lexer.STRING:
+		value := p.curToken.Literal
+		p.nextToken()
+		attribute.Value = &ast.StringLiteral{Span: p.span(valueStart), Value: value}
+	case lexer.LBRACE:
+		p.nextToken() // passer '{'
+		container := &ast.JSXExpressionContainer{Expression: p.parseExpression()}
+		attribute.Value = container
+		if p.curToken.Type != lexer.RBRACE {
+			return attribute, false
+		}
+		p.nextToken() // passer '}'
+		container.Span = p.span(valueStart)
+	case lexer.OPERATOR:
+		if p.curToken.Literal != "<" {
+			return attribute, false
+		}
+		// icon=<Icon /> : un élément peut servir de valeur
+		attribute.Value = p.parseJSX()
+	default:
+		return attribute, false
+	}
+	return attribute, attribute.Value != nil
+}
+
+// parseJSXName lit un nom de balise ou d'attribut, qui peut contenir des
+// tirets (aria-label) ou des points (Menu.Item) collés à ses parties
+func (p *Parser) parseJSXName() string {
+	name := p.curToken.Literal
+	end := p.curToken.Offset + len(p.curToken.Literal)
+	p.nextToken()
+
+	for (p.curToken.Type == lexer.DOT || (p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "-")) &&
+		p.curToken.Offset == end && p.peekToken.Offset == end+1 &&
+		(p.peekToken.Type == lexer.IDENT || p.peekToken.Type == lexer.KEYWORD) {
+		name += p.curToken.Literal + p.peekToken.Literal
+		end = p.peekToken.Offset + len(p.peekToken.Literal)
+		p.nextToken()
+		p.nextToken()
+	}
+	return name
+}
+
+// parseJSXChildren lit les enfants d'un élément, le token courant étant le
+// '>' de la balise ouvrante ; il s'arrête sur le '<' de la balise fermante.
+// Le texte entre balises n'est pas découpé en tokens : le lexer est replacé
+// après chaque '>' ou '}' pour le lire tel quel.
+func (p *Parser) parseJSXChildren() []ast.Expression {
+	var children []ast.Expression
+	for {
+		p.l.Seek(p.curToken.Offset + 1)
+		raw := p.l.ReadJSXText()
+		if text := jsxText(raw.Literal); text != "" {
+			children = append(children, &ast.JSXText{Span: ast.Span{Pos: tokenLocation(raw), End: tokenEnd(raw)}, Value: text})
+		}
+		p.nextToken()
+		p.nextToken()
+
+		switch {
+		case p.curToken.Type == lexer.EOF:
+			return children
+		case p.curToken.Type == lexer.LBRACE:
+			start := p.start()
+			p.nextToken() // passer '{'
+			if p.curToken.Type == lexer.RBRACE {
+				// {} vide : pas d'enfant
+				continue
+			}
+			container := &ast.JSXExpressionContainer{Expression: p.parseSpreadOrExpression()}
+			children = append(children, container)
+			if p.curToken.Type != lexer.RBRACE {
+				return children
+			}
+			container.Span = p.spanThrough(start)
+		case p.peekToken.Type == lexer.OPERATOR && p.peekToken.Literal == "/":
+			return children
+		default:
+			child := p.parseJSXElement()
+			if child == nil {
+				return children
+			}
+			children = append(children, child)
+		}
+	}
+}
+
+// parseJSXClosingTag passe la balise fermante </Tag> ou </> jusqu'à son '>'
+// et indique si elle est bien formée
+func (p *Parser) parseJSXClosingTag(name string) bool {
+	if p.curToken.Type != lexer.OPERATOR || p.curToken.Literal != "<" {
+		return false
+	}
+	p.nextToken() // passer '<'
+	p.nextToken() // passer '/'
+	if name != "" {
+		p.parseJSXName()
+	}
+	return p.isJSXClose()
+}
+
+// isJSXClose indique si le token courant est le '>' qui ferme une balise
+func (p *Parser) isJSXClose() bool {
+	return p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == ">"
+}
+
+// jsxText normalise les blancs d'un texte JSX comme React : les lignes sont
+// débarrassées de leur indentation, les lignes vides disparaissent et les
+// autres sont jointes par une espace
+func jsxText(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	last := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			last = i
+		}
+	}
+
+	var sb strings.Builder
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, "\t", " ")
+		if i > 0 {
+			line = strings.TrimLeft(line, " ")
+		}
+		if i < len(lines)-1 {
+			line = strings.TrimRight(line, " ")
+		}
+		if line == "" {
+			continue
+		}
+		sb.WriteString(line)
+		if i != last {
+			sb.WriteString(" ")
+		}
+	}
+	return sb.String()
+}
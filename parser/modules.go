@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/lexer"
+)
+
+// parseImport lit une déclaration import :
+//
+//	import "./m"
+//	import d, { a as b, type T } from "./m"
+//	import * as ns from "./m"
+//	import type { T } from "./m"
+func (p *Parser) parseImport() ast.Statement {
+	start := p.start()
+	p.nextToken() // passer 'import'
+	decl := &ast.ImportDeclaration{}
+
+	// import "./m" : import pour effet de bord
+	if p.curToken.Type == lexer.STRING {
+		decl.Source = p.curToken.Literal
+		p.nextToken()
+		p.skipSemicolon()
+		decl.Span = p.span(start)
+		return decl
+	}
+
+	// 'type' est un modificateur sauf dans import type from "./m"
+	if p.curToken.Literal == "type" && p.peekToken.Literal != "from" && p.peekToken.Type != lexer.COMMA {
+		decl.IsTypeOnly = true
+		p.nextToken()
+	}
+
+	if p.curToken.Type == lexer.IDENT || p.curToken.Type == lexer.KEYWORD {
+		decl.Default = p.curToken.Literal
+		p.nextToken()
+		if p.curToken.Type == lexer.COMMA {
+			p.nextToken()
+		}
+	}
+
+	switch {
+	case p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "*":
+		decl.Namespace = p.parseNamespaceAlias()
+	case p.curToken.Type == lexer.LBRACE:
+		decl.Specifiers = p.parseModuleSpecifiers()
+	}
+
+	decl.Source = p.parseModuleSource()
+	decl.Span = p.span(start)
+	return decl
+}
+
+// parseExport lit une déclaration export
+func (p *Parser) parseExport() ast.Statement {
+	start := p.start()
+	p.nextToken() // passer 'export'
+	decl := &ast.ExportDeclaration{}
+	defer func() { decl.Span = p.span(start) }()
+
+	switch {
+	case p.curToken.Literal == "default":
+		p.nextToken()
+		decl.IsDefault = true
+		switch p.curToken.Literal {
+		case "function", "async", "class", "abstract", "interface", "enum", "@":
+			decl.Declaration = p.ParseStatement()
+		default:
+			decl.Declaration = p.parseExpressionStatement()
+		}
+		return decl
+
+	case p.curToken.Literal == "type" && p.peekToken.Type == lexer.LBRACE:
+		// export type { T } : 'type' suivi d'un alias serait export type T = ...
+		decl.IsTypeOnly = true
+		p.nextToken()
+		decl.Specifiers = p.parseModuleSpecifiers()
+
+	case p.curToken.Type == lexer.LBRACE:
+		decl.Specifiers = p.parseModuleSpecifiers()
+
+	case p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "*":
+		decl.IsWildcard = true
+		if p.peekToken.Literal == "as" {
+			decl.Namespace = p.parseNamespaceAlias()
+		} else {
+			p.nextToken() // passer '*'
+		}
+
+	default:
+		decl.Declaration = p.ParseStatement()
+		return decl
+	}
+
+	if p.curToken.Literal == "from" {
+		decl.Source = p.parseModuleSource()
+	} else {
+		p.skipSemicolon()
+	}
+	return decl
+}
+
+// parseNamespaceAlias lit * as ns et renvoie ns
+func (p *Parser) parseNamespaceAlias() string {
+	p.nextToken() // passer '*'
+	if p.curToken.Literal != "as" {
+		return ""
+	}
+	p.nextToken() // passer 'as'
+	name := p.curToken.Literal
+	p.nextToken()
+	return name
+}
+
+// parseModuleSpecifiers lit { a, b as c, type T } ; le token courant est '{'
+func (p *Parser) parseModuleSpecifiers() []ast.ImportSpecifier {
+	p.nextToken() // passer '{'
+
+	var specifiers []ast.ImportSpecifier
+	for p.curToken.Type != lexer.RBRACE && p.curToken.Type != lexer.EOF {
+		start := p.start()
+		spec := ast.ImportSpecifier{}
+		// { type T } mais pas { type } ni { type as t }
+		if p.curToken.Literal == "type" && p.peekToken.Type != lexer.COMMA && p.peekToken.Type != lexer.RBRACE && p.peekToken.Literal != "as" {
+			spec.IsType = true
+			p.nextToken()
+		}
+		spec.Name = p.curToken.Literal
+		p.nextToken()
+		if p.curToken.Literal == "as" {
+			p.nextToken()
+			spec.Alias = p.curToken.Literal
+			p.nextToken()
+		}
+		spec.Span = p.span(start)
+		specifiers = append(specifiers, spec)
+
+		if p.curToken.Type == lexer.COMMA {
+			p.nextToken()
+		} else if p.curToken.Type != lexer.RBRACE {
+			break
+		}
+	}
+	if p.curToken.Type == lexer.RBRACE {
+		p.nextToken() // passer '}'
+	}
+	return specifiers
+}
+
+// parseModuleSource lit from "./m" et le ';' final
+func (p *Parser) parseModuleSource() string {
+	if p.curToken.Literal == "from" {
+		p.nextToken()
+	}
+	source := ""
+	if p.curToken.Type == lexer.STRING {
+		source = p.curToken.Literal
+		p.nextToken()
+	}
+	p.skipSemicolon()
+	return source
+}
@@ -1,14 +1,16 @@
 package parser
 
 import (
-	"ProjetGo/lexer"
 	"ProjetGo/ast"
+	"ProjetGo/lexer"
+	"strings"
 )
 
 type Parser struct {
 	l         *lexer.Lexer
 	curToken  lexer.Token
 	peekToken lexer.Token
+	lastEnd   ast.Location // fin du dernier token lu, où se termine le nœud en cours
 }
 
 func New(l *lexer.Lexer) *Parser {
@@ -20,20 +22,95 @@ func New(l *lexer.Lexer) *Parser {
 }
 
 func (p *Parser) nextToken() {
+	if p.curToken.Type != "" && p.curToken.Type != lexer.COMMENT {
+		p.lastEnd = tokenEnd(p.curToken)
+	}
 	p.curToken = p.peekToken
 	p.peekToken = p.l.NextToken()
+	// Les commentaires, gardés par le lexer pour Program.Comments, peuvent
+	// se glisser au milieu d'une expression : f(/* x */ 1)
+	for p.peekToken.Type == lexer.COMMENT {
+		p.peekToken = p.l.NextToken()
+	}
+}
+
+// start renvoie la position du token courant, où commence le nœud lu
+func (p *Parser) start() ast.Location {
+	return tokenLocation(p.curToken)
+}
+
+// span renvoie l'étendue d'un nœud commencé à start et terminé par le
+// dernier token lu
+func (p *Parser) span(start ast.Location) ast.Span {
+	return ast.Span{Pos: start, End: p.lastEnd}
+}
+
+// spanThrough renvoie l'étendue d'un nœud commencé à start et terminé par le
+// token courant, qui n'est pas encore passé
+func (p *Parser) spanThrough(start ast.Location) ast.Span {
+	return ast.Span{Pos: start, End: tokenEnd(p.curToken)}
+}
+
+func tokenLocation(tok lexer.Token) ast.Location {
+	return ast.Location{Offset: tok.Offset, Line: tok.Line, Column: tok.Column}
+}
+
+func tokenEnd(tok lexer.Token) ast.Location {
+	return ast.Location{Offset: tok.End, Line: tok.EndLine, Column: tok.EndColumn}
+}
+
+// parserState est un instantané du parser, pour les lectures spéculatives
+type parserState struct {
+	lexer     lexer.Lexer
+	curToken  lexer.Token
+	peekToken lexer.Token
+	lastEnd   ast.Location
+}
+
+func (p *Parser) saveState() parserState {
+	return parserState{lexer: *p.l, curToken: p.curToken, peekToken: p.peekToken, lastEnd: p.lastEnd}
+}
+
+func (p *Parser) restoreState(state parserState) {
+	*p.l = state.lexer
+	p.curToken = state.curToken
+	p.peekToken = state.peekToken
+	p.lastEnd = state.lastEnd
 }
 func (p *Parser) ParseStatement() ast.Statement {
 	// Ignorer les commentaires
 	for p.curToken.Type == lexer.COMMENT {
 		p.nextToken()
 	}
-	
+	start := p.start()
+
 	switch p.curToken.Literal {
-	case "let", "const", "var":
+	case "const":
+		if p.peekToken.Literal == "enum" {
+			p.nextToken() // passer 'const'
+			enum := p.parseEnum()
+			enum.IsConst = true
+			enum.Pos = start
+			return enum
+		}
+		return p.parseVariableDeclaration()
+	case "let", "var":
 		return p.parseVariableDeclaration()
+	case "enum":
+		return p.parseEnum()
 	case "function":
 		return p.parseFunction()
+	case "async":
+		if p.peekToken.Literal == "function" {
+			p.nextToken() // passer 'async'
+			if fd, ok := p.parseFunction().(*ast.FunctionDeclaration); ok {
+				fd.IsAsync = true
+				fd.Pos = start
+				return fd
+			}
+			return nil
+		}
+		return p.parseExpressionStatement()
 	case "if":
 		return p.parseIfStatement()
 	case "for":
@@ -48,6 +125,32 @@ func (p *Parser) ParseStatement() ast.Statement {
 		return p.parseInterface()
 	case "class":
 		return p.parseClass()
+	case "abstract":
+		if p.peekToken.Literal == "class" {
+			p.nextToken() // passer 'abstract'
+			class := p.parseClass()
+			class.IsAbstract = true
+			class.Pos = start
+			return class
+		}
+		return p.parseExpressionStatement()
+	case "import":
+		return p.parseImport()
+	case "export":
+		return p.parseExport()
+	case "@":
+		return p.parseDecoratedClass()
+	case "namespace", "module":
+		// module reste un identifiant ordinaire : module.exports = ...
+		if p.peekToken.Type == lexer.IDENT || (p.curToken.Literal == "module" && p.peekToken.Type == lexer.STRING) {
+			return p.parseNamespace()
+		}
+		return p.parseExpressionStatement()
+	case "declare":
+		if p.peekToken.Type == lexer.IDENT || p.peekToken.Type == lexer.KEYWORD {
+			return p.parseAmbientDeclaration()
+		}
+		return p.parseExpressionStatement()
 	default:
 		// Essayer de parser comme expression statement
 		return p.parseExpressionStatement()
@@ -55,29 +158,31 @@ func (p *Parser) ParseStatement() ast.Statement {
 }
 
 func (p *Parser) parseIfStatement() ast.Statement {
+	start := p.start()
 	p.nextToken() // passer 'if'
-	
+
 	if p.curToken.Type != lexer.LPAREN {
 		return nil
 	}
 	p.nextToken() // passer '('
-	
+
 	condition := p.parseExpression()
-	
+
 	if p.curToken.Type != lexer.RPAREN {
 		return nil
 	}
 	p.nextToken() // passer ')'
-	
+
 	thenBranch := p.parseBlockStatement()
-	
+
 	var elseBranch ast.Statement
 	if p.curToken.Literal == "else" {
 		p.nextToken()
 		elseBranch = p.parseBlockStatement()
 	}
-	
+
 	return &ast.IfStatement{
+		Span:       p.span(start),
 		Condition:  condition,
 		ThenBranch: thenBranch,
 		ElseBranch: elseBranch,
@@ -85,31 +190,36 @@ func (p *Parser) parseIfStatement() ast.Statement {
 }
 
 func (p *Parser) parseForStatement() ast.Statement {
+	start := p.start()
 	p.nextToken() // passer 'for'
-	
+
 	if p.curToken.Type != lexer.LPAREN {
 		return nil
 	}
 	p.nextToken() // passer '('
-	
+
 	init := p.ParseStatement()
+	if p.curToken.Type == lexer.SEMICOLON {
+		p.nextToken() // init vide ou sans ';' consommé
+	}
 	condition := p.parseExpression()
-	
+
 	if p.curToken.Type != lexer.SEMICOLON {
 		return nil
 	}
 	p.nextToken() // passer ';'
-	
+
 	update := p.ParseStatement()
-	
+
 	if p.curToken.Type != lexer.RPAREN {
 		return nil
 	}
 	p.nextToken() // passer ')'
-	
+
 	body := p.parseBlockStatement()
-	
+
 	return &ast.ForStatement{
+		Span:      p.span(start),
 		Init:      init,
 		Condition: condition,
 		Update:    update,
@@ -118,48 +228,58 @@ func (p *Parser) parseForStatement() ast.Statement {
 }
 
 func (p *Parser) parseWhileStatement() ast.Statement {
+	start := p.start()
 	p.nextToken() // passer 'while'
-	
+
 	if p.curToken.Type != lexer.LPAREN {
 		return nil
 	}
 	p.nextToken() // passer '('
-	
+
 	condition := p.parseExpression()
-	
+
 	if p.curToken.Type != lexer.RPAREN {
 		return nil
 	}
 	p.nextToken() // passer ')'
-	
+
 	body := p.parseBlockStatement()
-	
+
 	return &ast.WhileStatement{
+		Span:      p.span(start),
 		Condition: condition,
 		Body:      body,
 	}
 }
 
 func (p *Parser) parseReturnStatement() ast.Statement {
+	start := p.start()
 	p.nextToken() // passer 'return'
-	
+
 	var value ast.Expression
-	if p.curToken.Type != lexer.SEMICOLON {
+	if p.curToken.Type != lexer.SEMICOLON && p.curToken.Type != lexer.RBRACE {
 		value = p.parseExpression()
 	}
-	
-	return &ast.ReturnStatement{Value: value}
+	p.skipSemicolon()
+
+	return &ast.ReturnStatement{Span: p.span(start), Value: value}
 }
 
 func (p *Parser) parseBlockStatement() ast.Statement {
 	if p.curToken.Type != lexer.LBRACE {
 		return nil
 	}
+	start := p.start()
 	p.nextToken() // passer '{'
-	
+
 	var statements []ast.Statement
-	
+
 	for p.curToken.Type != lexer.RBRACE && p.curToken.Type != lexer.EOF {
+		// Un commentaire avant l'accolade fermante ne doit pas la faire passer
+		if p.curToken.Type == lexer.COMMENT {
+			p.nextToken()
+			continue
+		}
 		stmt := p.ParseStatement()
 		if stmt != nil {
 			statements = append(statements, stmt)
@@ -169,186 +289,465 @@ func (p *Parser) parseBlockStatement() ast.Statement {
 		}
 		// Ne pas faire p.nextToken() ici car ParseStatement() gère déjà l'avancement
 	}
-	
+
 	if p.curToken.Type == lexer.RBRACE {
 		p.nextToken() // passer '}'
 	}
-	
-	return &ast.BlockStatement{Statements: statements}
+
+	return &ast.BlockStatement{Span: p.span(start), Statements: groupOverloads(statements)}
+}
+
+// groupOverloads rattache les signatures de surcharge à l'implémentation qui
+// les suit : function f(x: string): string; function f(x: any) { ... }
+// devient une seule déclaration. Une signature sans implémentation reste seule.
+func groupOverloads(statements []ast.Statement) []ast.Statement {
+	var result, pending []ast.Statement
+	var signatures []*ast.FunctionDeclaration
+	for _, stmt := range statements {
+		fd := overloadedFunction(stmt)
+		if fd != nil && len(signatures) > 0 && signatures[0].Name == fd.Name {
+			if fd.Body == nil {
+				pending = append(pending, stmt)
+				signatures = append(signatures, fd)
+				continue
+			}
+			fd.Overloads = signatures
+			pending, signatures = nil, nil
+		}
+		result = append(result, pending...)
+		pending, signatures = nil, nil
+		if fd != nil && fd.Body == nil {
+			pending = []ast.Statement{stmt}
+			signatures = []*ast.FunctionDeclaration{fd}
+			continue
+		}
+		result = append(result, stmt)
+	}
+	return append(result, pending...)
+}
+
+// overloadedFunction renvoie la fonction déclarée, exportée ou non
+func overloadedFunction(stmt ast.Statement) *ast.FunctionDeclaration {
+	if ed, ok := stmt.(*ast.ExportDeclaration); ok {
+		stmt = ed.Declaration
+	}
+	fd, _ := stmt.(*ast.FunctionDeclaration)
+	return fd
 }
 
 func (p *Parser) parseExpressionStatement() ast.Statement {
+	start := p.start()
 	expr := p.parseExpression()
 	if expr == nil {
 		return nil
 	}
-	return &ast.ExpressionStatement{Expression: expr}
+	p.skipSemicolon()
+	return &ast.ExpressionStatement{Span: p.span(start), Expression: expr}
+}
+
+// skipSemicolon consomme le ';' optionnel qui termine une instruction
+func (p *Parser) skipSemicolon() {
+	if p.curToken.Type == lexer.SEMICOLON {
+		p.nextToken()
+	}
 }
 
 func (p *Parser) parseExpression() ast.Expression {
-	return p.parseInfixExpression()
+	start := p.start()
+	left := p.parseInfixExpression()
+
+	// Assertions de type : valeur as Type, config satisfies Shape
+	for left != nil && p.curToken.Type == lexer.IDENT && (p.curToken.Literal == "as" || p.curToken.Literal == "satisfies") {
+		keyword := p.curToken.Literal
+		p.nextToken() // passer 'as' ou 'satisfies'
+		if keyword == "as" {
+			as := &ast.AsExpression{Expression: left, Type: p.parseType()}
+			as.Span = p.span(start)
+			left = as
+		} else {
+			satisfies := &ast.SatisfiesExpression{Expression: left, Type: p.parseType()}
+			satisfies.Span = p.span(start)
+			left = satisfies
+		}
+	}
+
+	// Affectations : x = 1, this.total += n (associatives à droite)
+	if left != nil && p.curToken.Type == lexer.OPERATOR && isAssignmentOperator(p.curToken.Literal) {
+		operator := p.curToken.Literal
+		p.nextToken() // passer l'opérateur
+		assignment := &ast.AssignmentExpression{Left: left, Operator: operator, Right: p.parseExpression()}
+		assignment.Span = p.span(start)
+		return assignment
+	}
+
+	return left
+}
+
+func isAssignmentOperator(op string) bool {
+	switch op {
+	case "=", "+=", "-=", "*=", "/=":
+		return true
+	}
+	return false
 }
 
 func (p *Parser) parseInfixExpression() ast.Expression {
+	return p.parseBinaryExpression(1)
+}
+
+// parseBinaryExpression parse une suite d'opérations binaires dont les
+// opérateurs ont au moins la priorité minPrecedence : a + b * c donne
+// a + (b * c), et a - b - c donne (a - b) - c
+func (p *Parser) parseBinaryExpression(minPrecedence int) ast.Expression {
+	start := p.start()
 	left := p.parsePrimaryExpression()
-	
-	// Chercher un opérateur dans le token suivant
-	if p.peekToken.Type == lexer.OPERATOR && 
-	   (p.peekToken.Literal == "+" || p.peekToken.Literal == "-" || 
-		p.peekToken.Literal == "*" || p.peekToken.Literal == "/" ||
-		p.peekToken.Literal == "==" || p.peekToken.Literal == "!=" ||
-		p.peekToken.Literal == "<" || p.peekToken.Literal == ">" ||
-		p.peekToken.Literal == "<=" || p.peekToken.Literal == ">=" ||
-		p.peekToken.Literal == "&&" || p.peekToken.Literal == "||") {
-		
-		p.nextToken() // aller sur l'opérateur
+	if left == nil {
+		return nil
+	}
+
+	// Chaque expression laisse le token courant juste après elle :
+	// l'opérateur éventuel est donc le token courant
+	for p.curToken.Type == lexer.OPERATOR {
 		operator := p.curToken.Literal
+		precedence := ast.Precedence(operator)
+		if precedence == 0 || precedence < minPrecedence {
+			break
+		}
 		p.nextToken() // aller sur l'opérande de droite
-		right := p.parseInfixExpression()
-		
-		return &ast.InfixExpression{
+		right := p.parseBinaryExpression(precedence + 1)
+		left = &ast.InfixExpression{
+			Span:     p.span(start),
 			Left:     left,
 			Operator: operator,
 			Right:    right,
 		}
 	}
-	
+
 	return left
 }
 
 func (p *Parser) parsePrimaryExpression() ast.Expression {
+	start := p.start()
 	switch p.curToken.Type {
 	case lexer.IDENT:
-		// Gérer 'console' comme un identifiant spécial
-		if p.curToken.Literal == "console" {
-			return p.parseConsoleCall()
+		if p.peekToken.Type == lexer.ARROW {
+			return p.parseArrowFunction(false)
 		}
 		return p.parseIdentifierOrCall()
+	case lexer.LPAREN:
+		if arrow := p.parseArrowFunction(false); arrow != nil {
+			return arrow
+		}
+		return p.parseParenthesizedExpression()
+	case lexer.OPERATOR:
+		if p.curToken.Literal == "<" && p.l.JSX {
+			// Dans un .tsx, < ouvre un élément JSX : l'assertion <T>x n'y existe pas
+			return p.parseJSX()
+		}
+		if p.curToken.Literal == "<" {
+			return p.parseAngleBracketAssertion()
+		}
 	case lexer.STRING:
-		return &ast.StringLiteral{Value: p.curToken.Literal}
+		lit := &ast.StringLiteral{Value: p.curToken.Literal}
+		p.nextToken()
+		lit.Span = p.span(start)
+		return lit
 	case lexer.TEMPLATE:
 		return p.parseTemplateLiteral()
 	case lexer.NUMBER:
-		return &ast.NumberLiteral{Value: p.curToken.Literal}
+		lit := &ast.NumberLiteral{Value: p.curToken.Literal}
+		p.nextToken()
+		lit.Span = p.span(start)
+		return lit
 	case lexer.LBRACKET:
-		return p.parseArrayLiteral()
+		return p.parsePostfixExpression(p.parseArrayLiteral())
 	case lexer.LBRACE:
 		return p.parseObjectLiteral()
 	case lexer.KEYWORD:
-		if p.curToken.Literal == "true" {
-			return &ast.BooleanLiteral{Value: true}
-		} else if p.curToken.Literal == "false" {
-			return &ast.BooleanLiteral{Value: false}
+		switch p.curToken.Literal {
+		case "true", "false":
+			lit := &ast.BooleanLiteral{Value: p.curToken.Literal == "true"}
+			p.nextToken()
+			lit.Span = p.span(start)
+			return lit
+		case "console", "this":
+			// 'console' et 'this' sont lexés comme mots-clés mais se comportent comme des identifiants
+			return p.parseIdentifierOrCall()
+		case "new":
+			return p.parsePostfixExpression(p.parseNewExpression())
+		case "await":
+			// await lie plus fort que les opérateurs binaires : await a + b
+			p.nextToken() // passer 'await'
+			await := &ast.AwaitExpression{Argument: p.parsePrimaryExpression()}
+			await.Span = p.span(start)
+			return await
+		case "yield":
+			return p.parseYieldExpression()
+		case "class":
+			// const Point = class { ... } : expression de classe, nommée ou non
+			class := &ast.ClassExpression{Class: p.parseClass()}
+			class.Span = class.Class.Span
+			return class
+		case "async":
+			if p.peekToken.Type == lexer.LPAREN || p.peekToken.Type == lexer.IDENT {
+				p.nextToken() // passer 'async'
+				return p.parseArrowFunction(true)
+			}
 		}
 	}
 	return nil
 }
 
+// parseAngleBracketAssertion parse l'ancienne forme d'assertion <Type>valeur
+func (p *Parser) parseAngleBracketAssertion() ast.Expression {
+	start := p.start()
+	p.nextToken() // passer '<'
+	t := p.parseType()
+	if p.curToken.Type != lexer.OPERATOR || p.curToken.Literal != ">" {
+		return nil
+	}
+	p.nextToken() // passer '>'
+
+	operand := p.parsePrimaryExpression()
+	if arrow, ok := operand.(*ast.ArrowFunction); ok {
+		// Fonction fléchée générique <T>(x: T) => x : le paramètre de type est ignoré
+		return arrow
+	}
+	return &ast.AsExpression{Span: p.span(start), Expression: operand, Type: t, AngleBracket: true}
+}
+
+// parseParenthesizedExpression parse une expression entre parenthèses,
+// éventuellement suivie d'un accès : (a + b) * 2, (a + b).toFixed(2). Autour
+// d'une assertion, (value as Shape).area(), ou d'un élément JSX mis entre
+// parenthèses pour s'étendre sur plusieurs lignes, l'AST ne garde pas les
+// parenthèses : les générateurs les remettent au besoin. Sans parenthèse
+// fermante, l'état est restauré et nil est renvoyé.
+func (p *Parser) parseParenthesizedExpression() ast.Expression {
+	state := p.saveState()
+	start := p.start()
+	p.nextToken() // passer '('
+	inner := p.parseExpression()
+	if inner == nil || p.curToken.Type != lexer.RPAREN {
+		p.restoreState(state)
+		return nil
+	}
+	p.nextToken() // passer ')'
+	switch inner.(type) {
+	case *ast.AsExpression, *ast.SatisfiesExpression, *ast.NonNullExpression, *ast.JSXElement, *ast.JSXFragment:
+		return p.parsePostfixExpression(inner)
+	}
+	return p.parsePostfixExpression(&ast.ParenthesizedExpression{Span: p.span(start), Expression: inner})
+}
+
+// parseYieldExpression parse yield, yield valeur et yield* itérable ; au
+// contraire de await, yield porte sur toute l'expression qui suit : yield a + b
+func (p *Parser) parseYieldExpression() ast.Expression {
+	start := p.start()
+	p.nextToken() // passer 'yield'
+
+	yield := &ast.YieldExpression{}
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "*" {
+		yield.Delegate = true
+		p.nextToken() // passer '*'
+	}
+
+	switch p.curToken.Type {
+	case lexer.SEMICOLON, lexer.RPAREN, lexer.RBRACE, lexer.RBRACKET, lexer.COMMA, lexer.EOF:
+	default:
+		yield.Argument = p.parseExpression()
+	}
+	yield.Span = p.span(start)
+	return yield
+}
+
+// parseArrowFunction parse x => expr ou (a: T, b?: U): R => { ... }. Si le
+// token courant ouvre une parenthèse qui n'est pas suivie de '=>', l'état est
+// restauré et nil est renvoyé.
+func (p *Parser) parseArrowFunction(isAsync bool) ast.Expression {
+	state := p.saveState()
+	start := p.start()
+	arrow := &ast.ArrowFunction{IsAsync: isAsync}
+
+	if p.curToken.Type == lexer.IDENT {
+		arrow.Parameters = []ast.Parameter{{Name: p.curToken.Literal}}
+		p.nextToken()
+		arrow.Parameters[0].Span = p.span(start)
+	} else {
+		arrow.Parameters = p.parseParameters()
+		if p.curToken.Type == lexer.COLON {
+			p.nextToken() // passer ':'
+			arrow.ReturnType = p.parseType()
+		}
+	}
+
+	if p.curToken.Type != lexer.ARROW {
+		p.restoreState(state)
+		return nil
+	}
+	p.nextToken() // passer '=>'
+
+	if p.curToken.Type == lexer.LBRACE {
+		if block, ok := p.parseBlockStatement().(*ast.BlockStatement); ok {
+			arrow.Body = block.Statements
+		}
+		if arrow.Body == nil {
+			arrow.Body = []ast.Statement{}
+		}
+	} else {
+		arrow.ExpressionBody = p.parseExpression()
+	}
+	arrow.Span = p.span(start)
+	return arrow
+}
+
 func (p *Parser) parseIdentifierOrCall() ast.Expression {
+	start := p.start()
 	ident := &ast.Identifier{Value: p.curToken.Literal}
-	
-	// Vérifier s'il y a un appel de fonction
-	if p.peekToken.Type == lexer.LPAREN {
-		p.nextToken() // aller à '('
-		return p.parseFunctionCall(ident)
+	p.nextToken()
+	ident.Span = p.span(start)
+	return p.parsePostfixExpression(ident)
+}
+
+// parseNewExpression parse new Name.Qualifie<T>(args)
+func (p *Parser) parseNewExpression() ast.Expression {
+	start := p.start()
+	p.nextToken() // passer 'new'
+
+	ne := &ast.NewExpression{}
+	name := &ast.Identifier{Value: p.curToken.Literal}
+	nameStart := p.start()
+	p.nextToken()
+	name.Span = p.span(nameStart)
+	var class ast.Expression = name
+	for p.curToken.Type == lexer.DOT {
+		class = p.parseDotAccess(class)
+	}
+	ne.Class = class
+
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<" {
+		ne.TypeArguments, _ = p.tryParseTypeArguments()
+	}
+	if p.curToken.Type == lexer.LPAREN {
+		call := p.parseFunctionCall(class).(*ast.CallExpression)
+		ne.Arguments = call.Arguments
 	}
-	
-	// Vérifier l'accès par index [0] ou propriété .prop
-	if p.peekToken.Type == lexer.LBRACKET {
-		p.nextToken() // aller à '['
-		return p.parseIndexAccess(ident)
+	ne.Span = p.span(start)
+
+	return ne
+}
+
+// parsePostfixExpression enchaîne les appels, accès par index [0], accès
+// aux propriétés .prop, les assertions non nulles ! et les ++/-- postfixés
+func (p *Parser) parsePostfixExpression(expr ast.Expression) ast.Expression {
+	start := ast.Position(expr).Pos
+	for {
+		switch {
+		case p.curToken.Type == lexer.LPAREN:
+			expr = p.parseFunctionCall(expr)
+		case p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<":
+			// Appel générique identity<number>(5), sinon comparaison
+			typeArgs, ok := p.tryParseTypeArguments()
+			if !ok {
+				return expr
+			}
+			call := p.parseFunctionCall(expr).(*ast.CallExpression)
+			call.TypeArguments = typeArgs
+			call.Pos = start
+			expr = call
+		case p.curToken.Type == lexer.LBRACKET:
+			expr = p.parseIndexAccess(expr)
+		case p.curToken.Type == lexer.DOT:
+			expr = p.parseDotAccess(expr)
+		case p.curToken.Type == lexer.EXCLAMATION:
+			// Assertion non nulle : map.get(key)!.length
+			p.nextToken()
+			expr = &ast.NonNullExpression{Span: p.span(start), Expression: expr}
+		case p.curToken.Type == lexer.OPERATOR && (p.curToken.Literal == "++" || p.curToken.Literal == "--"):
+			operator := p.curToken.Literal
+			p.nextToken()
+			expr = &ast.AssignmentExpression{Span: p.span(start), Left: expr, Operator: operator}
+		default:
+			return expr
+		}
 	}
-	
-	if p.peekToken.Type == lexer.DOT {
-		p.nextToken() // aller à '.'
-		return p.parseDotAccess(ident)
+}
+
+// parseSpreadOrExpression parse un élément qui peut être précédé de '...'
+func (p *Parser) parseSpreadOrExpression() ast.Expression {
+	if p.curToken.Type == lexer.ELLIPSIS {
+		start := p.start()
+		p.nextToken() // passer '...'
+		spread := &ast.SpreadElement{Argument: p.parseExpression()}
+		spread.Span = p.span(start)
+		return spread
 	}
-	
-	return ident
+	return p.parseExpression()
 }
 
 func (p *Parser) parseFunctionCall(fn ast.Expression) ast.Expression {
+	start := ast.Position(fn).Pos
 	p.nextToken() // passer '('
-	
+
 	var args []ast.Expression
 	for p.curToken.Type != lexer.RPAREN && p.curToken.Type != lexer.EOF {
-		arg := p.parseExpression()
+		arg := p.parseSpreadOrExpression()
 		if arg != nil {
 			args = append(args, arg)
 		}
-		
+
 		if p.curToken.Type == lexer.COMMA {
 			p.nextToken()
 		} else {
 			break
 		}
 	}
-	
+
 	// Passer la parenthèse fermante
 	if p.curToken.Type == lexer.RPAREN {
 		p.nextToken()
 	}
-	
-	return &ast.CallExpression{Function: fn, Arguments: args}
+
+	return &ast.CallExpression{Span: p.span(start), Function: fn, Arguments: args}
 }
 
 func (p *Parser) parseIndexAccess(obj ast.Expression) ast.Expression {
 	p.nextToken() // passer '['
 	index := p.parseExpression()
-	
+
 	if p.curToken.Type == lexer.RBRACKET {
 		p.nextToken() // passer ']'
 	}
-	
-	return &ast.IndexExpression{Left: obj, Index: index}
+
+	return &ast.IndexExpression{Span: p.span(ast.Position(obj).Pos), Left: obj, Index: index}
 }
 
 func (p *Parser) parseDotAccess(obj ast.Expression) ast.Expression {
 	p.nextToken() // passer '.'
 	property := p.curToken.Literal
 	p.nextToken()
-	
-	return &ast.DotExpression{Object: obj, Property: property}
-}
-
-func (p *Parser) parseConsoleCall() ast.Expression {
-	// On est sur 'console'
-	consoleObj := &ast.Identifier{Value: "console"}
-	
-	// Vérifier s'il y a un point
-	if p.peekToken.Type == lexer.DOT {
-		p.nextToken() // aller à '.'
-		p.nextToken() // aller à 'log' (ou autre méthode)
-		
-		method := p.curToken.Literal
-		methodAccess := &ast.DotExpression{Object: consoleObj, Property: method}
-		
-		// Vérifier s'il y a un appel de fonction
-		if p.peekToken.Type == lexer.LPAREN {
-			p.nextToken() // aller à '('
-			return p.parseFunctionCall(methodAccess)
-		}
-		
-		return methodAccess
-	}
-	
-	return consoleObj
+
+	return &ast.DotExpression{Span: p.span(ast.Position(obj).Pos), Object: obj, Property: property}
 }
 
 func (p *Parser) parseTemplateLiteral() ast.Expression {
-	return &ast.TemplateLiteral{Parts: []ast.Expression{&ast.StringLiteral{Value: p.curToken.Literal}}}
+	start := p.start()
+	text := &ast.StringLiteral{Value: p.curToken.Literal}
+	p.nextToken()
+	text.Span = p.span(start)
+	return &ast.TemplateLiteral{Span: text.Span, Parts: []ast.Expression{text}}
 }
 
 func (p *Parser) parseArrayLiteral() ast.Expression {
+	start := p.start()
 	p.nextToken() // passer '['
-	
+
 	var elements []ast.Expression
 	for p.curToken.Type != lexer.RBRACKET && p.curToken.Type != lexer.EOF {
-		element := p.parseExpression()
+		element := p.parseSpreadOrExpression()
 		if element != nil {
 			elements = append(elements, element)
 		}
-		
+
 		if p.curToken.Type == lexer.COMMA {
 			p.nextToken()
 		} else if p.curToken.Type != lexer.RBRACKET {
@@ -356,28 +755,37 @@ func (p *Parser) parseArrayLiteral() ast.Expression {
 			p.nextToken()
 		}
 	}
-	
+
 	if p.curToken.Type == lexer.RBRACKET {
 		p.nextToken() // passer ']'
 	}
-	
-	return &ast.ArrayLiteral{Elements: elements}
+
+	return &ast.ArrayLiteral{Span: p.span(start), Elements: elements}
 }
 
 func (p *Parser) parseObjectLiteral() ast.Expression {
+	start := p.start()
 	p.nextToken() // passer '{'
-	
+
 	var properties []ast.ObjectProperty
 	for p.curToken.Type != lexer.RBRACE && p.curToken.Type != lexer.EOF {
-		if p.curToken.Type == lexer.IDENT {
+		if p.curToken.Type == lexer.ELLIPSIS {
+			// { ...other }
+			spread := p.parseSpreadOrExpression()
+			properties = append(properties, ast.ObjectProperty{Span: ast.Position(spread), Value: spread})
+			if p.curToken.Type == lexer.COMMA {
+				p.nextToken()
+			}
+		} else if p.curToken.Type == lexer.IDENT {
+			propertyStart := p.start()
 			key := p.curToken.Literal
 			p.nextToken() // aller à ':'
-			
+
 			if p.curToken.Type == lexer.COLON {
 				p.nextToken() // passer ':'
 				value := p.parseExpression()
-				properties = append(properties, ast.ObjectProperty{Key: key, Value: value})
-				
+				properties = append(properties, ast.ObjectProperty{Span: p.span(propertyStart), Key: key, Value: value})
+
 				if p.curToken.Type == lexer.COMMA {
 					p.nextToken()
 				}
@@ -386,148 +794,532 @@ func (p *Parser) parseObjectLiteral() ast.Expression {
 			p.nextToken()
 		}
 	}
-	
+
 	if p.curToken.Type == lexer.RBRACE {
 		p.nextToken() // passer '}'
 	}
-	
-	return &ast.ObjectLiteral{Properties: properties}
+
+	return &ast.ObjectLiteral{Span: p.span(start), Properties: properties}
 }
 
 func (p *Parser) skipUnsupportedStatement() {
 	// Ignorer jusqu'au prochain ; ou } ou fin de fichier
-	for p.curToken.Type != lexer.SEMICOLON && 
-		p.curToken.Type != lexer.RBRACE && 
+	for p.curToken.Type != lexer.SEMICOLON &&
+		p.curToken.Type != lexer.RBRACE &&
 		p.curToken.Type != lexer.EOF {
 		p.nextToken()
 	}
 }
 
+// parseNamespace parse namespace Geometry { ... }, module Geometry { ... } et
+// declare module "x" { ... } ; namespace A.B { } devient A { export B { } }
+func (p *Parser) parseNamespace() ast.Statement {
+	start := p.start()
+	p.nextToken() // passer 'namespace', 'module' ou le '.' de A.B
+	ns := &ast.NamespaceDeclaration{Name: p.curToken.Literal, IsModule: p.curToken.Type == lexer.STRING}
+	p.nextToken()
+
+	switch {
+	case p.curToken.Type == lexer.DOT:
+		inner := p.parseNamespace()
+		ns.Body = []ast.Statement{&ast.ExportDeclaration{Span: ast.Position(inner), Declaration: inner}}
+	case p.curToken.Type != lexer.LBRACE:
+		// declare module "x"; : module sans corps, dont tout import est any
+		p.skipSemicolon()
+	default:
+		if block, ok := p.parseBlockStatement().(*ast.BlockStatement); ok {
+			ns.Body = block.Statements
+		}
+		if ns.Body == nil {
+			ns.Body = []ast.Statement{}
+		}
+	}
+	ns.Span = p.span(start)
+	return ns
+}
+
+// parseAmbientDeclaration parse declare const x: T, declare function f(): T,
+// declare class, declare namespace, declare module "x" et declare global
+func (p *Parser) parseAmbientDeclaration() ast.Statement {
+	start := p.start()
+	p.nextToken() // passer 'declare'
+
+	if p.curToken.Literal == "global" && p.peekToken.Type == lexer.LBRACE {
+		// declare global { ... } : le corps complète la portée globale
+		globalStart := p.start()
+		global := &ast.NamespaceDeclaration{Name: "global", Body: []ast.Statement{}}
+		p.nextToken() // aller sur '{'
+		if block, ok := p.parseBlockStatement().(*ast.BlockStatement); ok && block.Statements != nil {
+			global.Body = block.Statements
+		}
+		global.Span = p.span(globalStart)
+		return &ast.AmbientDeclaration{Span: p.span(start), Declaration: global}
+	}
+
+	declaration := p.ParseStatement()
+	if declaration == nil {
+		return nil
+	}
+	return &ast.AmbientDeclaration{Span: p.span(start), Declaration: declaration}
+}
+
 func (p *Parser) parseTypeAlias() ast.Statement {
 	// type TaskStatus = 'pending' | 'in_progress' | 'done';
-	// Pour l'instant, on va juste capturer le nom et ignorer le reste
+	start := p.start()
 	p.nextToken() // passer 'type'
-	name := p.curToken.Literal
-	
-	// Ignorer le reste jusqu'au ;
+	ta := &ast.TypeAlias{Name: p.curToken.Literal}
+	p.nextToken()
+
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<" {
+		ta.TypeParameters = p.parseTypeParameters()
+	}
+
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "=" {
+		p.nextToken() // passer '='
+		// Une union peut commencer par '|' : type T = | 'a' | 'b'
+		if p.curToken.Type == lexer.PIPE {
+			p.nextToken()
+		}
+		ta.Type = p.parseType()
+		ta.Literals = stringLiteralUnion(ta.Type)
+	}
+
+	// Ignorer ce qui n'a pas été compris jusqu'au ;
 	for p.curToken.Type != lexer.SEMICOLON && p.curToken.Type != lexer.EOF {
 		p.nextToken()
 	}
-	
-	return &ast.TypeAlias{Name: name, Type: "string"} // simplifié
+	p.skipSemicolon()
+	ta.Span = p.span(start)
+
+	return ta
+}
+
+func (p *Parser) parseEnum() *ast.EnumDeclaration {
+	// enum Status { Pending, Active = 5, Done = "done" }
+	start := p.start()
+	p.nextToken() // passer 'enum'
+	enum := &ast.EnumDeclaration{Name: p.curToken.Literal}
+	p.nextToken()
+
+	if p.curToken.Type != lexer.LBRACE {
+		enum.Span = p.span(start)
+		return enum
+	}
+	p.nextToken() // passer '{'
+
+	for p.curToken.Type != lexer.RBRACE && p.curToken.Type != lexer.EOF {
+		if p.curToken.Type != lexer.IDENT && p.curToken.Type != lexer.STRING {
+			p.nextToken()
+			continue
+		}
+
+		memberStart := p.start()
+		member := ast.EnumMember{Name: p.curToken.Literal}
+		p.nextToken()
+
+		// Valeur explicite
+		if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "=" {
+			p.nextToken() // passer '='
+			member.Value = p.parseExpression()
+		}
+		member.Span = p.span(memberStart)
+		enum.Members = append(enum.Members, member)
+
+		if p.curToken.Type == lexer.COMMA {
+			p.nextToken()
+		}
+	}
+
+	if p.curToken.Type == lexer.RBRACE {
+		p.nextToken() // passer '}'
+	}
+	enum.Span = p.span(start)
+
+	return enum
 }
 
 func (p *Parser) parseInterface() ast.Statement {
-	// interface Task { ... }
+	// interface Repository<T> { items: T[]; find(id: number): T; }
+	start := p.start()
 	p.nextToken() // passer 'interface'
-	name := p.curToken.Literal
-	
-	// Ignorer le corps de l'interface pour l'instant
+	iface := &ast.Interface{Name: p.curToken.Literal}
+	p.nextToken()
+
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<" {
+		iface.TypeParameters = p.parseTypeParameters()
+	}
+
+	if p.curToken.Literal == "extends" {
+		iface.Extends = p.parseTypeList()
+	}
+	for p.curToken.Type != lexer.LBRACE && p.curToken.Type != lexer.EOF {
+		p.nextToken()
+	}
+	p.nextToken() // passer '{'
+
 	for p.curToken.Type != lexer.RBRACE && p.curToken.Type != lexer.EOF {
+		if p.curToken.Type != lexer.IDENT && p.curToken.Type != lexer.KEYWORD && p.curToken.Type != lexer.STRING {
+			p.nextToken()
+			continue
+		}
+
+		fieldStart := p.start()
+		field := ast.InterfaceField{Name: p.curToken.Literal}
 		p.nextToken()
+		if p.curToken.Type == lexer.QUESTION {
+			field.Optional = true
+			p.nextToken()
+		}
+
+		switch {
+		case p.curToken.Type == lexer.COLON:
+			p.nextToken() // passer ':'
+			field.Type = p.parseType()
+		case p.curToken.Type == lexer.LPAREN || (p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<"):
+			// Signature de méthode : find<K>(id: K): T
+			field.IsMethod = true
+			if p.curToken.Type == lexer.OPERATOR {
+				field.TypeParameters = p.parseTypeParameters()
+			}
+			field.Parameters = p.parseParameters()
+			if p.curToken.Type == lexer.COLON {
+				p.nextToken() // passer ':'
+				field.ReturnType = p.parseType()
+			}
+		}
+		field.Span = p.span(fieldStart)
+		iface.Fields = append(iface.Fields, field)
+
+		if p.curToken.Type == lexer.SEMICOLON || p.curToken.Type == lexer.COMMA {
+			p.nextToken()
+		}
+	}
+
+	if p.curToken.Type == lexer.RBRACE {
+		p.nextToken() // passer '}'
 	}
-	
-	return &ast.Interface{Name: name, Fields: []ast.InterfaceField{}}
+	iface.Span = p.span(start)
+
+	return iface
 }
 
-func (p *Parser) parseClass() ast.Statement {
-	// class TaskManager { ... }
+func (p *Parser) parseClass() *ast.ClassDeclaration {
+	// class Box<T> extends Base<T> implements Printable { private value: T; constructor(value: T) { ... } get(): T { ... } }
+	start := p.start()
 	p.nextToken() // passer 'class'
-	name := p.curToken.Literal
-	
-	// Ignorer le corps de la classe pour l'instant
-	braceCount := 0
-	for {
-		if p.curToken.Type == lexer.LBRACE {
-			braceCount++
-		} else if p.curToken.Type == lexer.RBRACE {
-			braceCount--
-			if braceCount == 0 {
-				break
+	class := &ast.ClassDeclaration{}
+	// Une expression de classe peut être anonyme : class extends Base { ... }
+	if p.curToken.Type == lexer.IDENT && p.curToken.Literal != "extends" && p.curToken.Literal != "implements" {
+		class.Name = p.curToken.Literal
+		p.nextToken()
+	}
+
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<" {
+		class.TypeParameters = p.parseTypeParameters()
+	}
+
+	if p.curToken.Literal == "extends" {
+		p.nextToken() // passer 'extends'
+		class.SuperClass = p.parseType()
+	}
+	if p.curToken.Literal == "implements" {
+		class.Implements = p.parseTypeList()
+	}
+	for p.curToken.Type != lexer.LBRACE && p.curToken.Type != lexer.EOF {
+		p.nextToken()
+	}
+	p.nextToken() // passer '{'
+
+	for p.curToken.Type != lexer.RBRACE && p.curToken.Type != lexer.EOF {
+		p.parseClassMember(class)
+	}
+
+	if p.curToken.Type == lexer.RBRACE {
+		p.nextToken() // passer '}'
+	}
+	class.Span = p.span(start)
+
+	return class
+}
+
+// parseDecoratedClass lit les décorateurs d'une classe, placés avant ou
+// après export : @Injectable() export class X, export @Injectable() class X
+func (p *Parser) parseDecoratedClass() ast.Statement {
+	start := p.start()
+	decorators := p.parseDecorators()
+	stmt := p.ParseStatement()
+	class, ok := stmt.(*ast.ClassDeclaration)
+	if ed, isExport := stmt.(*ast.ExportDeclaration); isExport {
+		class, ok = ed.Declaration.(*ast.ClassDeclaration)
+		// L'étendue de l'export et de la classe commence aux décorateurs
+		ed.Pos = start
+	}
+	if ok {
+		class.Decorators = append(decorators, class.Decorators...)
+		class.Pos = start
+	}
+	return stmt
+}
+
+// parseDecorators lit les décorateurs @log, @core.Component et
+// @Injectable({ ... }) qui précèdent une classe, un membre ou un paramètre
+func (p *Parser) parseDecorators() []ast.Decorator {
+	var decorators []ast.Decorator
+	for p.curToken.Type == lexer.AT {
+		start := p.start()
+		p.nextToken() // passer '@'
+		decorator := ast.Decorator{Name: p.curToken.Literal}
+		p.nextToken()
+		for p.curToken.Type == lexer.DOT {
+			p.nextToken() // passer '.'
+			decorator.Name += "." + p.curToken.Literal
+			p.nextToken()
+		}
+		if p.curToken.Type == lexer.LPAREN {
+			decorator.IsCall = true
+			if call, ok := p.parseFunctionCall(nil).(*ast.CallExpression); ok {
+				decorator.Arguments = call.Arguments
 			}
-		} else if p.curToken.Type == lexer.EOF {
-			break
 		}
+		decorator.Span = p.span(start)
+		decorators = append(decorators, decorator)
+	}
+	return decorators
+}
+
+// parseClassMember parse un champ ou une méthode et l'ajoute à la classe
+func (p *Parser) parseClassMember(class *ast.ClassDeclaration) {
+	start := p.start()
+	decorators := p.parseDecorators()
+	isPrivate, isProtected, isStatic, isAsync, isAbstract, isReadonly := false, false, false, false, false, false
+	kind := ast.RegularMethod
+
+	// Modificateurs, sauf s'ils nomment eux-mêmes le membre : static() {}
+modifiers:
+	for p.peekToken.Type != lexer.LPAREN && p.peekToken.Type != lexer.COLON && p.peekToken.Type != lexer.SEMICOLON {
+		switch p.curToken.Literal {
+		case "private":
+			isPrivate = true
+		case "protected":
+			isProtected = true
+		case "static":
+			isStatic = true
+		case "async":
+			isAsync = true
+		case "abstract":
+			isAbstract = true
+		case "get":
+			kind = ast.Getter
+		case "set":
+			kind = ast.Setter
+		case "readonly":
+			isReadonly = true
+		case "public", "override", "declare":
+		default:
+			break modifiers
+		}
+		p.nextToken()
+	}
+
+	// *entries() : méthode génératrice
+	isGenerator := false
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "*" {
+		isGenerator = true
 		p.nextToken()
 	}
-	
-	return &ast.ClassDeclaration{Name: name, Fields: []ast.ClassField{}, Methods: []ast.ClassMethod{}}
+
+	// static { ... } : bloc d'initialisation de la classe
+	if isStatic && p.curToken.Type == lexer.LBRACE {
+		if block, ok := p.parseBlockStatement().(*ast.BlockStatement); ok {
+			class.StaticBlocks = append(class.StaticBlocks, ast.StaticBlock{Span: p.span(start), Body: block.Statements})
+		}
+		return
+	}
+
+	if p.curToken.Type != lexer.IDENT && p.curToken.Type != lexer.KEYWORD && p.curToken.Type != lexer.STRING {
+		p.nextToken()
+		return
+	}
+	memberName := p.curToken.Literal
+	p.nextToken()
+
+	// #secret : nom privé, réservé à la classe
+	isPrivateName := strings.HasPrefix(memberName, "#")
+	if isPrivateName {
+		memberName = memberName[1:]
+		isPrivate = true
+	}
+
+	if p.curToken.Type == lexer.QUESTION {
+		p.nextToken()
+	}
+
+	// Méthode : name<T>(params): Type { body }
+	if p.curToken.Type == lexer.LPAREN || (p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<") {
+		if memberName == "constructor" {
+			kind = ast.Constructor
+		}
+		method := ast.ClassMethod{Name: memberName, Decorators: decorators, Kind: kind, IsPrivate: isPrivate, IsPrivateName: isPrivateName, IsProtected: isProtected, IsStatic: isStatic, IsAsync: isAsync, IsGenerator: isGenerator, IsAbstract: isAbstract}
+		if p.curToken.Type == lexer.OPERATOR {
+			method.TypeParameters = p.parseTypeParameters()
+		}
+		method.Parameters = p.parseParameters()
+		if p.curToken.Type == lexer.COLON {
+			p.nextToken() // passer ':'
+			method.ReturnType = p.parseType()
+		}
+		if block, ok := p.parseBlockStatement().(*ast.BlockStatement); ok {
+			// Comme pour une fonction, un corps vide reste non nil
+			method.Body = append([]ast.Statement{}, block.Statements...)
+		} else {
+			p.skipSemicolon() // signature sans corps
+		}
+		method.Span = p.span(start)
+		class.Methods = append(class.Methods, method)
+		return
+	}
+
+	// Champ : name: Type = valeur;
+	field := ast.ClassField{Name: memberName, Decorators: decorators, IsPrivate: isPrivate, IsPrivateName: isPrivateName, IsProtected: isProtected, IsStatic: isStatic, IsReadonly: isReadonly}
+	if p.curToken.Type == lexer.COLON {
+		p.nextToken() // passer ':'
+		field.Type = p.parseType()
+	}
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "=" {
+		p.nextToken() // passer '='
+		field.HasDefault = true
+		field.Default = p.parseExpression()
+	}
+	p.skipSemicolon()
+	field.Span = p.span(start)
+	class.Fields = append(class.Fields, field)
 }
 
 func (p *Parser) parseFunction() ast.Statement {
 	// function name(params): returnType { body }
+	start := p.start()
 	p.nextToken() // passer 'function'
-	
+
+	// function* name() : générateur
+	isGenerator := false
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "*" {
+		isGenerator = true
+		p.nextToken()
+	}
+
 	if p.curToken.Type != lexer.IDENT {
 		return nil
 	}
-	
+
 	name := p.curToken.Literal
-	p.nextToken() // aller à '('
-	
+	p.nextToken() // aller à '(' ou '<'
+
+	var typeParams []ast.TypeParameter
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<" {
+		typeParams = p.parseTypeParameters()
+	}
+
 	if p.curToken.Type != lexer.LPAREN {
 		return nil
 	}
-	
+
 	params := p.parseParameters()
-	
+
 	// Type de retour optionnel
 	var returnType string
 	if p.curToken.Type == lexer.COLON {
 		p.nextToken() // passer ':'
-		if p.curToken.Type == lexer.IDENT {
-			returnType = p.curToken.Literal
-			p.nextToken()
-		}
+		returnType = p.parseType()
+	}
+
+	fd := &ast.FunctionDeclaration{
+		Name:           name,
+		TypeParameters: typeParams,
+		Parameters:     params,
+		ReturnType:     returnType,
+		IsGenerator:    isGenerator,
 	}
-	
-	// Corps de la fonction
+
+	// Signature sans corps : declare function f(): T;
 	if p.curToken.Type != lexer.LBRACE {
-		return nil
+		p.skipSemicolon()
+		fd.Span = p.span(start)
+		return fd
 	}
-	
+
 	body := p.parseBlockStatement()
-	
-	var bodyStatements []ast.Statement
+
+	// Un corps vide reste non nil pour le distinguer d'une signature
+	fd.Body = []ast.Statement{}
 	if blockStmt, ok := body.(*ast.BlockStatement); ok {
-		bodyStatements = blockStmt.Statements
-	}
-	
-	return &ast.FunctionDeclaration{
-		Name:       name,
-		Parameters: params,
-		ReturnType: returnType,
-		Body:       bodyStatements,
+		fd.Body = append(fd.Body, blockStmt.Statements...)
 	}
+	fd.Span = p.span(start)
+	return fd
 }
 
 func (p *Parser) parseParameters() []ast.Parameter {
 	var params []ast.Parameter
-	
+
 	p.nextToken() // passer '('
-	
+
 	// Boucle avec sécurité contre les boucles infinies
 	maxIterations := 50 // Sécurité
 	iterations := 0
-	
+
 	for p.curToken.Type != lexer.RPAREN && p.curToken.Type != lexer.EOF && iterations < maxIterations {
 		iterations++
-		
+
+		start := p.start()
+		decorators := p.parseDecorators()
+
+		// Modificateurs d'une propriété de paramètre, sauf s'ils nomment le paramètre
+		isProperty, isPrivate, isProtected, isReadonly := false, false, false, false
+		for p.isParameterModifier() {
+			isProperty = true
+			switch p.curToken.Literal {
+			case "private":
+				isPrivate = true
+			case "protected":
+				isProtected = true
+			case "readonly":
+				isReadonly = true
+			}
+			p.nextToken()
+		}
+
+		isRest := false
+		if p.curToken.Type == lexer.ELLIPSIS {
+			// Paramètre rest : ...args: number[]
+			isRest = true
+			p.nextToken()
+		}
+
 		if p.curToken.Type == lexer.IDENT {
-			param := ast.Parameter{Name: p.curToken.Literal}
+			param := ast.Parameter{Name: p.curToken.Literal, Decorators: decorators, IsRest: isRest, IsProperty: isProperty, IsPrivate: isPrivate, IsProtected: isProtected, IsReadonly: isReadonly}
 			p.nextToken()
-			
+
+			// Paramètre optionnel : x?: T
+			if p.curToken.Type == lexer.QUESTION {
+				param.Optional = true
+				p.nextToken()
+			}
+
 			// Type optionnel
 			if p.curToken.Type == lexer.COLON {
 				p.nextToken() // passer ':'
-				if p.curToken.Type == lexer.IDENT {
-					param.Type = p.curToken.Literal
-					p.nextToken()
-				}
+				param.Type = p.parseType()
+			}
+
+			// Valeur par défaut : pas: number = 1
+			if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "=" {
+				p.nextToken() // passer '='
+				param.Default = p.parseExpression()
 			}
-			
+			param.Span = p.span(start)
+
 			params = append(params, param)
-			
+
 			if p.curToken.Type == lexer.COMMA {
 				p.nextToken() // passer ','
 			} else if p.curToken.Type != lexer.RPAREN {
@@ -539,52 +1331,52 @@ func (p *Parser) parseParameters() []ast.Parameter {
 			p.nextToken()
 		}
 	}
-	
+
 	if p.curToken.Type == lexer.RPAREN {
 		p.nextToken() // passer ')'
 	}
-	
+
 	return params
 }
 
+// isParameterModifier indique si le token courant est un modificateur de
+// propriété de paramètre (private, readonly...) suivi du nom du paramètre
+func (p *Parser) isParameterModifier() bool {
+	switch p.curToken.Literal {
+	case "private", "protected", "public", "readonly":
+		return p.peekToken.Type == lexer.IDENT || p.peekToken.Type == lexer.KEYWORD
+	}
+	return false
+}
+
 func (p *Parser) parseVariableDeclaration() *ast.VariableDeclaration {
+	start := p.start()
 	vd := &ast.VariableDeclaration{}
 	vd.IsConst = p.curToken.Literal == "const"
+	vd.IsVar = p.curToken.Literal == "var"
 
 	p.nextToken() // identifiant
 	vd.Name = p.curToken.Literal
 
 	p.nextToken() // : ou =
 	if p.curToken.Type == lexer.COLON {
-		// Skip le type (simple ou complexe) jusqu'à =
-		for p.curToken.Type != lexer.OPERATOR || p.curToken.Literal != "=" {
-			if p.curToken.Type == lexer.EOF {
-				break
-			}
-			
-			// Capturer les types simples
-			if p.curToken.Type == lexer.IDENT && vd.Type == "" {
-				vd.Type = p.curToken.Literal
-			}
-			
-			// Gérer les types array comme "number[]"
-			if p.curToken.Type == lexer.LBRACKET && p.peekToken.Type == lexer.RBRACKET {
-				vd.Type = vd.Type + "[]"
-				p.nextToken() // skip ']'
-			}
-			
-			p.nextToken()
-		}
+		p.nextToken() // passer ':'
+		vd.Type = p.parseType()
 	}
 
 	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "=" {
 		p.nextToken()
 		vd.Value = p.parseExpression()
 	}
+	p.skipSemicolon()
+	vd.Span = p.span(start)
 
 	return vd
 }
-func (p *Parser) ParseProgram() []ast.Statement {
+
+// ParseProgram lit tout le source et renvoie la racine de l'arbre, avec les
+// commentaires, les directives et la table des lignes du fichier
+func (p *Parser) ParseProgram() *ast.Program {
 	var statements []ast.Statement
 
 	for p.curToken.Type != lexer.EOF {
@@ -593,13 +1385,49 @@ func (p *Parser) ParseProgram() []ast.Statement {
 			p.nextToken()
 			continue
 		}
-		
+
 		stmt := p.ParseStatement()
 		if stmt != nil {
 			statements = append(statements, stmt)
+		} else {
+			// Token non reconnu : avancer pour éviter la boucle infinie
+			p.nextToken()
+		}
+	}
+
+	source := p.l.Input()
+	program := &ast.Program{Source: source, Lines: ast.NewLineTable(source)}
+	program.Pos = ast.Location{Offset: 0, Line: 1, Column: 1}
+	program.End = program.Lines.Location(len(source))
+	program.Directives, statements = splitDirectives(statements)
+	program.Statements = groupOverloads(statements)
+	for _, tok := range p.l.Comments() {
+		program.Comments = append(program.Comments, ast.Comment{Span: ast.Span{Pos: tokenLocation(tok), End: tokenEnd(tok)}, Text: tok.Literal})
+	}
+	for _, stmt := range program.Statements {
+		switch stmt.(type) {
+		case *ast.ImportDeclaration, *ast.ExportDeclaration:
+			program.IsModule = true
 		}
-		p.nextToken()
 	}
+	return program
+}
 
-	return statements
+// splitDirectives sépare le prologue de directives ("use strict";), les
+// chaînes seules en tête du fichier, des instructions qui le suivent
+func splitDirectives(statements []ast.Statement) ([]string, []ast.Statement) {
+	var directives []string
+	for len(statements) > 0 {
+		es, ok := statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			break
+		}
+		directive, ok := es.Expression.(*ast.StringLiteral)
+		if !ok {
+			break
+		}
+		directives = append(directives, directive.Value)
+		statements = statements[1:]
+	}
+	return directives, statements
 }
@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/lexer"
+	"testing"
+)
+
+func parse(source string) *ast.Program {
+	return New(lexer.New(source)).ParseProgram()
+}
+
+// grouping rend explicite le regroupement d'une expression : (l op r) pour
+// une opération binaire, [e] pour une parenthèse du source
+func grouping(expr ast.Expression) string {
+	switch e := expr.(type) {
+	case *ast.InfixExpression:
+		return "(" + grouping(e.Left) + " " + e.Operator + " " + grouping(e.Right) + ")"
+	case *ast.ParenthesizedExpression:
+		return "[" + grouping(e.Expression) + "]"
+	case *ast.Identifier:
+		return e.Value
+	case *ast.NumberLiteral:
+		return e.Value
+	case nil:
+		return "<nil>"
+	}
+	return expr.TokenLiteral()
+}
+
+func TestParseBinaryExpressions(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{source: "a + b * c;", want: "(a + (b * c))"},
+		{source: "a * b + c;", want: "((a * b) + c)"},
+		{source: "a - b - c;", want: "((a - b) - c)"},
+		{source: "a / b % c;", want: "((a / b) % c)"},
+		{source: "a < b + 1 && c;", want: "((a < (b + 1)) && c)"},
+		{source: "a || b && c;", want: "(a || (b && c))"},
+		{source: "(a + b) * 2;", want: "([(a + b)] * 2)"},
+		{source: "a - (b - c);", want: "(a - [(b - c)])"},
+		{source: "((a));", want: "[[a]]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			program := parse(tt.source)
+			if len(program.Statements) != 1 {
+				t.Fatalf("%d instructions, attendu 1", len(program.Statements))
+			}
+			stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+			if !ok {
+				t.Fatalf("%T, attendu *ast.ExpressionStatement", program.Statements[0])
+			}
+			if got := grouping(stmt.Expression); got != tt.want {
+				t.Errorf("%s, attendu %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAccessModifiers(t *testing.T) {
+	program := parse("class A {\n  protected x = 1;\n  private y = 2;\n  protected run() {}\n  constructor(protected a: number, private b: number) {}\n}")
+	class := program.Statements[0].(*ast.ClassDeclaration)
+	if x := class.Fields[0]; !x.IsProtected || x.IsPrivate {
+		t.Errorf("x : protected %v, private %v", x.IsProtected, x.IsPrivate)
+	}
+	if y := class.Fields[1]; y.IsProtected || !y.IsPrivate {
+		t.Errorf("y : protected %v, private %v", y.IsProtected, y.IsPrivate)
+	}
+	if run := class.Methods[0]; !run.IsProtected || run.IsPrivate {
+		t.Errorf("run : protected %v, private %v", run.IsProtected, run.IsPrivate)
+	}
+	params := class.Methods[1].Parameters
+	if a := params[0]; !a.IsProperty || !a.IsProtected || a.IsPrivate {
+		t.Errorf("a : propriété %v, protected %v, private %v", a.IsProperty, a.IsProtected, a.IsPrivate)
+	}
+	if b := params[1]; !b.IsProperty || b.IsProtected || !b.IsPrivate {
+		t.Errorf("b : propriété %v, protected %v, private %v", b.IsProperty, b.IsProtected, b.IsPrivate)
+	}
+}
+
+func TestParseAssertionsDropParentheses(t *testing.T) {
+	// (value as Shape).area() : la parenthèse ne sert qu'à l'assertion
+	program := parse("(value as Shape).area();")
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	call, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("%T, attendu *ast.CallExpression", stmt.Expression)
+	}
+	dot, ok := call.Function.(*ast.DotExpression)
+	if !ok {
+		t.Fatalf("%T, attendu *ast.DotExpression", call.Function)
+	}
+	if _, ok := dot.Object.(*ast.AsExpression); !ok {
+		t.Errorf("objet %T, attendu *ast.AsExpression", dot.Object)
+	}
+}
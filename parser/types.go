@@ -0,0 +1,262 @@
+package parser
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/lexer"
+	"strings"
+)
+
+// parseType lit une annotation de type TypeScript et renvoie sa forme textuelle
+// normalisée : number, string[], Map<string, number>, 'a' | 'b', (x: number) => void...
+// En sortie, le token courant est le premier token après le type.
+func (p *Parser) parseType() string {
+	var sb strings.Builder
+	sb.WriteString(p.parseArrayType())
+
+	// Unions et intersections : A | B, A & B
+	for p.curToken.Type == lexer.PIPE || (p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "&") {
+		sb.WriteString(" " + p.curToken.Literal + " ")
+		p.nextToken()
+		sb.WriteString(p.parseArrayType())
+	}
+
+	return sb.String()
+}
+
+// parseArrayType lit un type primaire suivi d'éventuels suffixes []
+func (p *Parser) parseArrayType() string {
+	t := p.parsePrimaryType()
+	for p.curToken.Type == lexer.LBRACKET && p.peekToken.Type == lexer.RBRACKET {
+		p.nextToken() // passer '['
+		p.nextToken() // passer ']'
+		t += "[]"
+	}
+	return t
+}
+
+func (p *Parser) parsePrimaryType() string {
+	switch p.curToken.Type {
+	case lexer.IDENT, lexer.KEYWORD:
+		name := p.curToken.Literal
+		p.nextToken()
+
+		// Noms qualifiés : React.ReactNode
+		for p.curToken.Type == lexer.DOT {
+			p.nextToken()
+			name += "." + p.curToken.Literal
+			p.nextToken()
+		}
+
+		// Arguments de type : Array<string>, Map<string, number>
+		if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "<" {
+			p.nextToken() // passer '<'
+			var args []string
+			for !p.curTokenIsTypeEnd() {
+				args = append(args, p.parseType())
+				if p.curToken.Type == lexer.COMMA {
+					p.nextToken()
+				} else {
+					break
+				}
+			}
+			if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == ">" {
+				p.nextToken() // passer '>'
+			}
+			name += "<" + strings.Join(args, ", ") + ">"
+		}
+		return name
+
+	case lexer.STRING:
+		// Type littéral : 'pending'
+		lit := "'" + p.curToken.Literal + "'"
+		p.nextToken()
+		return lit
+
+	case lexer.NUMBER:
+		lit := p.curToken.Literal
+		p.nextToken()
+		return lit
+
+	case lexer.LPAREN:
+		return p.parseParenOrFunctionType()
+
+	case lexer.LBRACE:
+		return p.parseObjectType()
+	}
+
+	return ""
+}
+
+// parseTypeList lit la liste de types qui suit extends ou implements :
+// implements Printable, Comparable<T>
+func (p *Parser) parseTypeList() []string {
+	var types []string
+	p.nextToken() // passer 'extends' ou 'implements'
+	for p.curToken.Type == lexer.IDENT || p.curToken.Type == lexer.KEYWORD {
+		types = append(types, p.parseType())
+		if p.curToken.Type != lexer.COMMA {
+			break
+		}
+		p.nextToken() // passer ','
+	}
+	return types
+}
+
+// curTokenIsTypeEnd indique la fin d'une liste d'arguments de type
+func (p *Parser) curTokenIsTypeEnd() bool {
+	return p.curToken.Type == lexer.EOF ||
+		(p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == ">")
+}
+
+// parseParenOrFunctionType gère (A | B) et (x: number, y?: string) => void
+func (p *Parser) parseParenOrFunctionType() string {
+	p.nextToken() // passer '('
+
+	var parts []string
+	for p.curToken.Type != lexer.RPAREN && p.curToken.Type != lexer.EOF {
+		prefix := ""
+		if p.curToken.Type == lexer.ELLIPSIS {
+			prefix = "..."
+			p.nextToken()
+		}
+
+		// Paramètre nommé (x: T, y?: T) ou simple type entre parenthèses
+		if p.curToken.Type == lexer.IDENT &&
+			(p.peekToken.Type == lexer.COLON || p.peekToken.Type == lexer.QUESTION) {
+			name := p.curToken.Literal
+			p.nextToken()
+			if p.curToken.Type == lexer.QUESTION {
+				name += "?"
+				p.nextToken()
+			}
+			p.nextToken() // passer ':'
+			parts = append(parts, prefix+name+": "+p.parseType())
+		} else {
+			parts = append(parts, prefix+p.parseType())
+		}
+
+		if p.curToken.Type == lexer.COMMA {
+			p.nextToken()
+		} else if p.curToken.Type != lexer.RPAREN {
+			break
+		}
+	}
+	if p.curToken.Type == lexer.RPAREN {
+		p.nextToken() // passer ')'
+	}
+
+	if p.curToken.Type == lexer.ARROW {
+		p.nextToken() // passer '=>'
+		return "(" + strings.Join(parts, ", ") + ") => " + p.parseType()
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// parseObjectType gère les types objets littéraux : { id: number; name?: string }
+func (p *Parser) parseObjectType() string {
+	p.nextToken() // passer '{'
+
+	var members []string
+	for p.curToken.Type != lexer.RBRACE && p.curToken.Type != lexer.EOF {
+		if p.curToken.Type != lexer.IDENT && p.curToken.Type != lexer.KEYWORD && p.curToken.Type != lexer.STRING {
+			p.nextToken()
+			continue
+		}
+		name := p.curToken.Literal
+		p.nextToken()
+		if p.curToken.Type == lexer.QUESTION {
+			name += "?"
+			p.nextToken()
+		}
+		if p.curToken.Type == lexer.COLON {
+			p.nextToken() // passer ':'
+			members = append(members, name+": "+p.parseType())
+		}
+		if p.curToken.Type == lexer.SEMICOLON || p.curToken.Type == lexer.COMMA {
+			p.nextToken()
+		}
+	}
+	if p.curToken.Type == lexer.RBRACE {
+		p.nextToken() // passer '}'
+	}
+
+	return "{ " + strings.Join(members, "; ") + " }"
+}
+
+// stringLiteralUnion renvoie les valeurs d'une union de littéraux chaîne
+// ('a' | 'b' -> [a b]), ou nil si le type contient autre chose
+func stringLiteralUnion(t string) []string {
+	var values []string
+	for _, part := range strings.Split(t, " | ") {
+		if len(part) < 2 || !strings.HasPrefix(part, "'") || !strings.HasSuffix(part, "'") {
+			return nil
+		}
+		values = append(values, part[1:len(part)-1])
+	}
+	return values
+}
+
+// parseTypeParameters lit une liste <T, K extends keyof T, V = string>.
+// Le token courant doit être '<' ; en sortie, il est le premier token après '>'.
+func (p *Parser) parseTypeParameters() []ast.TypeParameter {
+	p.nextToken() // passer '<'
+
+	var params []ast.TypeParameter
+	for p.curToken.Type == lexer.IDENT {
+		start := p.start()
+		param := ast.TypeParameter{Name: p.curToken.Literal}
+		p.nextToken()
+
+		if p.curToken.Type == lexer.IDENT && p.curToken.Literal == "extends" {
+			p.nextToken() // passer 'extends'
+			param.Constraint = p.parseType()
+		}
+		if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == "=" {
+			p.nextToken() // passer '='
+			param.Default = p.parseType()
+		}
+		param.Span = p.span(start)
+		params = append(params, param)
+
+		if p.curToken.Type != lexer.COMMA {
+			break
+		}
+		p.nextToken() // passer ','
+	}
+
+	if p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == ">" {
+		p.nextToken() // passer '>'
+	}
+	return params
+}
+
+// tryParseTypeArguments tente de lire les arguments de type d'un appel
+// générique : identity<number>(5). En TypeScript, '<' n'introduit des arguments
+// de type que si la liste se ferme par '>' immédiatement suivi de '(' ; sinon
+// c'est une comparaison (a < b) et l'état du parser est restauré.
+func (p *Parser) tryParseTypeArguments() ([]string, bool) {
+	saved := p.saveState()
+	p.nextToken() // passer '<'
+
+	var args []string
+	for !p.curTokenIsTypeEnd() {
+		arg := p.parseType()
+		if arg == "" {
+			break
+		}
+		args = append(args, arg)
+		if p.curToken.Type != lexer.COMMA {
+			break
+		}
+		p.nextToken()
+	}
+
+	if len(args) > 0 && p.curToken.Type == lexer.OPERATOR && p.curToken.Literal == ">" &&
+		p.peekToken.Type == lexer.LPAREN {
+		p.nextToken() // passer '>'
+		return args, true
+	}
+
+	p.restoreState(saved)
+	return nil, false
+}
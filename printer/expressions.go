@@ -0,0 +1,359 @@
+package printer
+
+import (
+	"ProjetGo/ast"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// expression imprime une expression ; les parenthèses du source sont des
+// nœuds ParenthesizedExpression
+func (p *printer) expression(expr ast.Expression) {
+	switch e := expr.(type) {
+	case nil:
+		// Nœud que le parser n'a pas su lire
+	case *ast.Identifier:
+		p.write(e.Value)
+	case *ast.StringLiteral:
+		p.write(quote(e.Value))
+	case *ast.NumberLiteral:
+		p.write(e.Value)
+	case *ast.BooleanLiteral:
+		p.write(strconv.FormatBool(e.Value))
+	case *ast.TemplateLiteral:
+		p.write("`")
+		for _, part := range e.Parts {
+			if text, ok := part.(*ast.StringLiteral); ok {
+				p.write(text.Value)
+				continue
+			}
+			p.write("${")
+			p.expression(part)
+			p.write("}")
+		}
+		p.write("`")
+	case *ast.ArrayLiteral:
+		p.array(e)
+	case *ast.ObjectLiteral:
+		p.object(e)
+	case *ast.CallExpression:
+		p.operand(e.Function)
+		p.typeArguments(e.TypeArguments)
+		p.arguments(e.Arguments)
+	case *ast.NewExpression:
+		p.write("new ")
+		p.operand(e.Class)
+		p.typeArguments(e.TypeArguments)
+		p.arguments(e.Arguments)
+	case *ast.InfixExpression:
+		precedence := ast.Precedence(e.Operator)
+		p.binaryOperand(e.Left, precedence, false)
+		p.write(" " + e.Operator + " ")
+		p.binaryOperand(e.Right, precedence, true)
+	case *ast.ParenthesizedExpression:
+		p.write("(")
+		p.expression(e.Expression)
+		p.write(")")
+	case *ast.IndexExpression:
+		p.operand(e.Left)
+		p.write("[")
+		p.expression(e.Index)
+		p.write("]")
+	case *ast.DotExpression:
+		p.operand(e.Object)
+		p.write("." + e.Property)
+	case *ast.AssignmentExpression:
+		if e.Right == nil {
+			// x++, x--
+			p.operand(e.Left)
+			p.write(e.Operator)
+			return
+		}
+		p.expression(e.Left)
+		p.write(" " + e.Operator + " ")
+		p.expression(e.Right)
+	case *ast.SpreadElement:
+		p.write("...")
+		p.expression(e.Argument)
+	case *ast.AwaitExpression:
+		p.write("await ")
+		p.operand(e.Argument)
+	case *ast.AsExpression:
+		if e.AngleBracket {
+			p.write("<" + e.Type + ">")
+			p.operand(e.Expression)
+			return
+		}
+		p.expression(e.Expression)
+		p.write(" as " + e.Type)
+	case *ast.SatisfiesExpression:
+		p.expression(e.Expression)
+		p.write(" satisfies " + e.Type)
+	case *ast.NonNullExpression:
+		p.operand(e.Expression)
+		p.write("!")
+	case *ast.ArrowFunction:
+		p.arrow(e)
+	case *ast.ClassExpression:
+		p.class(e.Class, "")
+	case *ast.YieldExpression:
+		p.write("yield")
+		if e.Delegate {
+			p.write("*")
+		}
+		if e.Argument != nil {
+			p.write(" ")
+			p.expression(e.Argument)
+		}
+	case *ast.JSXElement, *ast.JSXFragment:
+		p.jsx(e)
+	default:
+		panic(fmt.Sprintf("printer : type d'expression inattendu %T", expr))
+	}
+}
+
+// operand imprime une expression suivie d'un accès, d'un appel ou d'un
+// opérateur postfixe ; une opération, une assertion de type ou une fonction
+// fléchée y prend des parenthèses : (value as Shape).area(), (a + b).toFixed()
+func (p *printer) operand(expr ast.Expression) {
+	switch e := expr.(type) {
+	case *ast.AssignmentExpression:
+		p.parenthesized(expr, e.Right != nil)
+	case *ast.InfixExpression, *ast.AsExpression, *ast.SatisfiesExpression, *ast.ArrowFunction,
+		*ast.YieldExpression, *ast.AwaitExpression:
+		p.parenthesized(expr, true)
+	default:
+		p.expression(expr)
+	}
+}
+
+// binaryOperand imprime l'opérande gauche ou droit d'un opérateur de priorité
+// precedence. Un arbre construit par Rewrite n'a pas forcément les nœuds
+// ParenthesizedExpression qu'il lui faut : a - (b - c) prend des parenthèses
+// à droite, a * (b + c) autour de l'opération moins prioritaire.
+func (p *printer) binaryOperand(expr ast.Expression, precedence int, right bool) {
+	switch e := expr.(type) {
+	case *ast.InfixExpression:
+		inner := ast.Precedence(e.Operator)
+		p.parenthesized(expr, inner < precedence || right && inner == precedence)
+	case *ast.AssignmentExpression:
+		p.parenthesized(expr, e.Right != nil)
+	case *ast.AsExpression, *ast.SatisfiesExpression, *ast.ArrowFunction, *ast.YieldExpression:
+		p.parenthesized(expr, true)
+	default:
+		p.expression(expr)
+	}
+}
+
+func (p *printer) parenthesized(expr ast.Expression, parenthesize bool) {
+	if !parenthesize {
+		p.expression(expr)
+		return
+	}
+	p.write("(")
+	p.expression(expr)
+	p.write(")")
+}
+
+func (p *printer) arguments(args []ast.Expression) {
+	p.write("(")
+	for i, arg := range args {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.expression(arg)
+	}
+	p.write(")")
+}
+
+func (p *printer) typeArguments(args []string) {
+	if len(args) > 0 {
+		p.write("<" + strings.Join(args, ", ") + ">")
+	}
+}
+
+func (p *printer) arrow(arrow *ast.ArrowFunction) {
+	if arrow.IsAsync {
+		p.write("async ")
+	}
+	p.parameters(arrow.Parameters)
+	p.returnType(arrow.ReturnType)
+	p.write(" => ")
+	switch body := arrow.ExpressionBody.(type) {
+	case nil:
+		p.block(arrow.Body, arrow.End)
+	case *ast.ObjectLiteral:
+		// () => ({ ... }) : sans parenthèses, l'accolade ouvrirait un bloc
+		p.write("(")
+		p.object(body)
+		p.write(")")
+	default:
+		p.expression(body)
+	}
+}
+
+// array imprime un tableau sur une ligne, ou un élément par ligne si le
+// source l'écrivait déjà sur plusieurs
+func (p *printer) array(array *ast.ArrayLiteral) {
+	spans := make([]ast.Span, len(array.Elements))
+	for i, element := range array.Elements {
+		spans[i] = ast.Position(element)
+	}
+	if !expanded(array.Span, spans) {
+		p.write("[")
+		for i, element := range array.Elements {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.expression(element)
+		}
+		p.write("]")
+		return
+	}
+	p.expandedList("[", "]", spans, array.End, func(i int) { p.expression(array.Elements[i]) })
+}
+
+// object imprime un objet littéral, sur une ligne ou une propriété par
+// ligne comme array
+func (p *printer) object(object *ast.ObjectLiteral) {
+	spans := make([]ast.Span, len(object.Properties))
+	for i, property := range object.Properties {
+		spans[i] = property.Span
+	}
+	print := func(i int) {
+		property := object.Properties[i]
+		if property.Key != "" {
+			p.write(memberName(property.Key, false) + ": ")
+		}
+		p.expression(property.Value)
+	}
+	if !expanded(object.Span, spans) {
+		if len(object.Properties) == 0 {
+			p.write("{}")
+			return
+		}
+		p.write("{ ")
+		for i := range object.Properties {
+			if i > 0 {
+				p.write(", ")
+			}
+			print(i)
+		}
+		p.write(" }")
+		return
+	}
+	p.expandedList("{", "}", spans, object.End, print)
+}
+
+// expanded indique si le premier élément d'une liste commence sur une autre
+// ligne que la liste elle-même
+func expanded(list ast.Span, spans []ast.Span) bool {
+	return len(spans) > 0 && list.Pos.IsValid() && spans[0].Pos.IsValid() && spans[0].Pos.Line > list.Pos.Line
+}
+
+// expandedList imprime un élément par ligne, chacun suivi d'une virgule
+func (p *printer) expandedList(open, close string, spans []ast.Span, end ast.Location, print func(i int)) {
+	p.write(open)
+	p.newline()
+	p.indent++
+	p.lastLine = 0
+	for i, span := range spans {
+		p.leading(span)
+		print(i)
+		p.write(",")
+		p.trailing(span)
+	}
+	if end.IsValid() {
+		p.flushComments(end.Offset)
+	}
+	p.indent--
+	p.write(close)
+}
+
+// jsx imprime un élément ou un fragment. Les enfants sans texte passent un par
+// ligne ; avec du texte, ils restent sur la ligne, où les blancs comptent.
+func (p *printer) jsx(expr ast.Expression) {
+	var name string
+	var children []ast.Expression
+	switch e := expr.(type) {
+	case *ast.JSXFragment:
+		children = e.Children
+		p.write("<>")
+	case *ast.JSXElement:
+		name, children = e.Name, e.Children
+		p.write("<" + name)
+		for _, attribute := range e.Attributes {
+			p.write(" ")
+			p.jsxAttribute(attribute)
+		}
+		if e.SelfClosing {
+			p.write(" />")
+			return
+		}
+		p.write(">")
+	}
+
+	if jsxMultiline(children) {
+		p.newline()
+		p.indent++
+		for _, child := range children {
+			p.jsxChild(child)
+			p.newline()
+		}
+		p.indent--
+	} else {
+		for _, child := range children {
+			p.jsxChild(child)
+		}
+	}
+	p.write("</" + name + ">")
+}
+
+func jsxMultiline(children []ast.Expression) bool {
+	multiline := false
+	for _, child := range children {
+		switch child.(type) {
+		case *ast.JSXText:
+			return false
+		case *ast.JSXElement, *ast.JSXFragment:
+			multiline = true
+		}
+	}
+	return multiline
+}
+
+func (p *printer) jsxChild(child ast.Expression) {
+	switch c := child.(type) {
+	case *ast.JSXText:
+		p.write(c.Value)
+	case *ast.JSXExpressionContainer:
+		p.write("{")
+		p.expression(c.Expression)
+		p.write("}")
+	default:
+		p.expression(child)
+	}
+}
+
+func (p *printer) jsxAttribute(attribute ast.JSXAttribute) {
+	if attribute.IsSpread {
+		p.write("{...")
+		p.expression(attribute.Value)
+		p.write("}")
+		return
+	}
+	p.write(attribute.Name)
+	switch value := attribute.Value.(type) {
+	case nil:
+	case *ast.StringLiteral:
+		p.write("=" + quote(value.Value))
+	case *ast.JSXExpressionContainer:
+		p.write("={")
+		p.expression(value.Expression)
+		p.write("}")
+	default:
+		p.write("=")
+		p.expression(value)
+	}
+}
@@ -0,0 +1,725 @@
+// Package printer réécrit un arbre syntaxique en source TypeScript, dans un
+// style constant : indentation de deux espaces, points-virgules, guillemets
+// doubles. Les annotations de type, les génériques et les commentaires sont
+// conservés : le source imprimé se parse en le même arbre.
+package printer
+
+import (
+	"ProjetGo/ast"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Print imprime un programme en TypeScript
+func Print(program *ast.Program) string {
+	p := &printer{comments: program.Comments, bol: true}
+	for _, directive := range program.Directives {
+		p.write("\"" + directive + "\";")
+		p.newline()
+	}
+	if len(program.Directives) > 0 && len(program.Statements) > 0 {
+		p.newline()
+	}
+	// Les commentaires qui suivent la dernière instruction terminent le fichier
+	p.statements(program.Statements, ast.Location{Offset: math.MaxInt, Line: math.MaxInt})
+	return p.sb.String()
+}
+
+type printer struct {
+	sb       strings.Builder
+	indent   int
+	bol      bool // en début de ligne : l'indentation reste à écrire
+	comments []ast.Comment
+	next     int // premier commentaire pas encore imprimé
+	lastLine int // dernière ligne du source imprimée, 0 à l'ouverture d'un bloc
+}
+
+func (p *printer) write(s string) {
+	if p.bol {
+		p.sb.WriteString(strings.Repeat("  ", p.indent))
+		p.bol = false
+	}
+	p.sb.WriteString(s)
+}
+
+func (p *printer) newline() {
+	p.sb.WriteByte('\n')
+	p.bol = true
+}
+
+// leading imprime les commentaires qui précèdent un élément du source et
+// garde une ligne vide là où le source en séparait deux
+func (p *printer) leading(span ast.Span) {
+	if !span.Pos.IsValid() {
+		return
+	}
+	p.flushComments(span.Pos.Offset)
+	p.blankLine(span.Pos.Line)
+	p.lastLine = span.Pos.Line
+}
+
+// trailing termine la ligne d'un élément. Un commentaire qui le suit sur sa
+// dernière ligne y reste ; ceux pris dans une expression imprimée sur une
+// seule ligne passent sur les lignes suivantes.
+func (p *printer) trailing(span ast.Span) {
+	if !span.End.IsValid() {
+		p.newline()
+		return
+	}
+	inner := p.next
+	for inner < len(p.comments) && p.comments[inner].Pos.Offset < span.End.Offset {
+		inner++
+	}
+	interior := p.comments[p.next:inner]
+	p.next = inner
+	if p.next < len(p.comments) && p.comments[p.next].Pos.Line == span.End.Line {
+		p.write(" " + commentText(p.comments[p.next]))
+		p.next++
+	}
+	p.newline()
+	for _, comment := range interior {
+		p.write(commentText(comment))
+		p.newline()
+	}
+	p.lastLine = span.End.Line
+}
+
+// flushComments imprime, chacun sur sa ligne, les commentaires situés avant
+// offset dans le source
+func (p *printer) flushComments(offset int) {
+	for p.next < len(p.comments) && p.comments[p.next].Pos.Offset < offset {
+		comment := p.comments[p.next]
+		p.next++
+		p.blankLine(comment.Pos.Line)
+		p.write(commentText(comment))
+		p.newline()
+		p.lastLine = comment.End.Line
+	}
+}
+
+// hasCommentsBefore indique s'il reste un commentaire avant la position end
+func (p *printer) hasCommentsBefore(end ast.Location) bool {
+	return end.IsValid() && p.next < len(p.comments) && p.comments[p.next].Pos.Offset < end.Offset
+}
+
+func (p *printer) blankLine(line int) {
+	if p.lastLine > 0 && line > p.lastLine+1 {
+		p.newline()
+	}
+}
+
+func commentText(comment ast.Comment) string {
+	return strings.TrimRight(comment.Text, " \t\r")
+}
+
+// statements imprime une instruction par ligne ; end est la fin du bloc dans
+// le source, pour y garder les commentaires qui précèdent l'accolade fermante
+func (p *printer) statements(list []ast.Statement, end ast.Location) {
+	for _, stmt := range list {
+		p.leading(statementStart(stmt))
+		p.statement(stmt)
+		p.trailing(ast.Position(stmt))
+	}
+	if end.IsValid() {
+		p.flushComments(end.Offset)
+	}
+}
+
+// statementStart renvoie l'étendue d'une instruction ; une fonction
+// surchargée commence à sa première signature
+func statementStart(stmt ast.Statement) ast.Span {
+	span := ast.Position(stmt)
+	inner := stmt
+	for {
+		switch s := inner.(type) {
+		case *ast.ExportDeclaration:
+			inner = s.Declaration
+			continue
+		case *ast.AmbientDeclaration:
+			inner = s.Declaration
+			continue
+		}
+		break
+	}
+	if fd, ok := inner.(*ast.FunctionDeclaration); ok && len(fd.Overloads) > 0 && fd.Overloads[0].Pos.IsValid() {
+		span.Pos = fd.Overloads[0].Pos
+	}
+	return span
+}
+
+// block imprime { instructions } ; un bloc vide tient sur une ligne
+func (p *printer) block(list []ast.Statement, end ast.Location) {
+	if len(list) == 0 && !p.hasCommentsBefore(end) {
+		p.write("{}")
+		return
+	}
+	p.write("{")
+	p.newline()
+	p.indent++
+	p.lastLine = 0
+	p.statements(list, end)
+	p.indent--
+	p.write("}")
+}
+
+// branch imprime le corps d'un if, d'un for ou d'un while
+func (p *printer) branch(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case nil:
+		p.write("{}")
+	case *ast.BlockStatement:
+		p.block(s.Statements, s.End)
+	default:
+		p.statement(stmt)
+	}
+}
+
+func (p *printer) statement(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case *ast.VariableDeclaration:
+		p.variable(s)
+		p.write(";")
+	case *ast.ExpressionStatement:
+		p.expression(s.Expression)
+		p.write(";")
+	case *ast.ReturnStatement:
+		p.write("return")
+		if s.Value != nil {
+			p.write(" ")
+			p.expression(s.Value)
+		}
+		p.write(";")
+	case *ast.IfStatement:
+		p.write("if (")
+		p.expression(s.Condition)
+		p.write(") ")
+		p.branch(s.ThenBranch)
+		if s.ElseBranch != nil {
+			p.write(" else ")
+			p.branch(s.ElseBranch)
+		}
+	case *ast.ForStatement:
+		p.write("for (")
+		p.forClause(s.Init)
+		p.write(";")
+		if s.Condition != nil {
+			p.write(" ")
+			p.expression(s.Condition)
+		}
+		p.write(";")
+		if s.Update != nil {
+			p.write(" ")
+			p.forClause(s.Update)
+		}
+		p.write(") ")
+		p.branch(s.Body)
+	case *ast.WhileStatement:
+		p.write("while (")
+		p.expression(s.Condition)
+		p.write(") ")
+		p.branch(s.Body)
+	case *ast.BlockStatement:
+		p.block(s.Statements, s.End)
+	case *ast.FunctionDeclaration, *ast.ClassDeclaration:
+		p.declaration(stmt, "")
+	case *ast.TypeAlias:
+		p.write("type " + s.Name)
+		p.typeParameters(s.TypeParameters)
+		if s.Type != "" {
+			p.write(" = " + s.Type)
+		}
+		p.write(";")
+	case *ast.Interface:
+		p.interfaceDeclaration(s)
+	case *ast.EnumDeclaration:
+		p.enum(s)
+	case *ast.NamespaceDeclaration:
+		p.namespace(s)
+	case *ast.ImportDeclaration:
+		p.importDeclaration(s)
+	case *ast.ExportDeclaration:
+		p.exportDeclaration(s)
+	case *ast.AmbientDeclaration:
+		p.declaration(s.Declaration, "declare ")
+	default:
+		panic(fmt.Sprintf("printer : type d'instruction inattendu %T", stmt))
+	}
+}
+
+// forClause imprime l'initialisation ou l'incrément d'un for, sans ';'
+func (p *printer) forClause(stmt ast.Statement) {
+	switch s := stmt.(type) {
+	case nil:
+	case *ast.VariableDeclaration:
+		p.variable(s)
+	case *ast.ExpressionStatement:
+		p.expression(s.Expression)
+	default:
+		p.statement(stmt)
+	}
+}
+
+func (p *printer) variable(vd *ast.VariableDeclaration) {
+	switch {
+	case vd.IsConst:
+		p.write("const ")
+	case vd.IsVar:
+		p.write("var ")
+	default:
+		p.write("let ")
+	}
+	p.write(vd.Name)
+	if vd.Type != "" {
+		p.write(": " + vd.Type)
+	}
+	if vd.Value != nil {
+		p.write(" = ")
+		p.expression(vd.Value)
+	}
+}
+
+// declaration imprime une instruction précédée de export ou declare. Les
+// décorateurs d'une classe passent devant le préfixe, que chaque signature
+// d'une fonction surchargée reprend.
+func (p *printer) declaration(stmt ast.Statement, prefix string) {
+	switch s := stmt.(type) {
+	case *ast.FunctionDeclaration:
+		p.function(s, prefix)
+	case *ast.ClassDeclaration:
+		p.class(s, prefix)
+	case *ast.AmbientDeclaration:
+		p.declaration(s.Declaration, prefix+"declare ")
+	case *ast.NamespaceDeclaration:
+		if prefix == "declare " && s.Name == "global" && !s.IsModule {
+			// declare global { ... }
+			p.write(prefix + "global ")
+			p.block(s.Body, s.End)
+			return
+		}
+		p.write(prefix)
+		p.namespace(s)
+	default:
+		p.write(prefix)
+		p.statement(stmt)
+	}
+}
+
+func (p *printer) function(fd *ast.FunctionDeclaration, prefix string) {
+	for _, overload := range fd.Overloads {
+		p.leading(overload.Span)
+		p.signature(overload, prefix)
+		p.write(";")
+		p.trailing(overload.Span)
+	}
+	if len(fd.Overloads) > 0 {
+		p.leading(fd.Span)
+	}
+	p.signature(fd, prefix)
+	if fd.Body == nil {
+		p.write(";")
+		return
+	}
+	p.write(" ")
+	p.block(fd.Body, fd.End)
+}
+
+func (p *printer) signature(fd *ast.FunctionDeclaration, prefix string) {
+	p.write(prefix)
+	if fd.IsAsync {
+		p.write("async ")
+	}
+	p.write("function")
+	if fd.IsGenerator {
+		p.write("*")
+	}
+	p.write(" " + fd.Name)
+	p.typeParameters(fd.TypeParameters)
+	p.parameters(fd.Parameters)
+	p.returnType(fd.ReturnType)
+}
+
+func (p *printer) class(class *ast.ClassDeclaration, prefix string) {
+	for _, decorator := range class.Decorators {
+		p.decorator(decorator)
+		p.newline()
+	}
+	p.write(prefix)
+	if class.IsAbstract {
+		p.write("abstract ")
+	}
+	p.write("class")
+	if class.Name != "" {
+		p.write(" " + class.Name)
+	}
+	p.typeParameters(class.TypeParameters)
+	if class.SuperClass != "" {
+		p.write(" extends " + class.SuperClass)
+	}
+	if len(class.Implements) > 0 {
+		p.write(" implements " + strings.Join(class.Implements, ", "))
+	}
+	p.write(" ")
+
+	// Champs, méthodes et blocs static reprennent leur ordre dans le source ;
+	// ceux qui n'y ont pas de position passent à la fin
+	type member struct {
+		span  ast.Span
+		print func()
+	}
+	var members []member
+	for i := range class.Fields {
+		field := &class.Fields[i]
+		members = append(members, member{field.Span, func() { p.field(field) }})
+	}
+	for i := range class.Methods {
+		method := &class.Methods[i]
+		members = append(members, member{method.Span, func() { p.method(method) }})
+	}
+	for i := range class.StaticBlocks {
+		block := &class.StaticBlocks[i]
+		members = append(members, member{block.Span, func() {
+			p.write("static ")
+			p.block(block.Body, block.End)
+		}})
+	}
+	order := func(m member) int {
+		if m.span.Pos.IsValid() {
+			return m.span.Pos.Offset
+		}
+		return math.MaxInt
+	}
+	sort.SliceStable(members, func(i, j int) bool { return order(members[i]) < order(members[j]) })
+
+	spans := make([]ast.Span, len(members))
+	for i, m := range members {
+		spans[i] = m.span
+	}
+	p.members(spans, class.End, func(i int) { members[i].print() })
+}
+
+func (p *printer) field(field *ast.ClassField) {
+	for _, decorator := range field.Decorators {
+		p.decorator(decorator)
+		p.newline()
+	}
+	if field.IsPrivate && !field.IsPrivateName {
+		p.write("private ")
+	}
+	if field.IsProtected {
+		p.write("protected ")
+	}
+	if field.IsStatic {
+		p.write("static ")
+	}
+	if field.IsReadonly {
+		p.write("readonly ")
+	}
+	p.write(memberName(field.Name, field.IsPrivateName))
+	if field.Type != "" {
+		p.write(": " + field.Type)
+	}
+	if field.HasDefault && field.Default != nil {
+		p.write(" = ")
+		p.expression(field.Default)
+	}
+	p.write(";")
+}
+
+func (p *printer) method(method *ast.ClassMethod) {
+	for _, decorator := range method.Decorators {
+		p.decorator(decorator)
+		p.newline()
+	}
+	if method.IsPrivate && !method.IsPrivateName {
+		p.write("private ")
+	}
+	if method.IsProtected {
+		p.write("protected ")
+	}
+	if method.IsStatic {
+		p.write("static ")
+	}
+	if method.IsAbstract {
+		p.write("abstract ")
+	}
+	if method.IsAsync {
+		p.write("async ")
+	}
+	switch method.Kind {
+	case ast.Getter:
+		p.write("get ")
+	case ast.Setter:
+		p.write("set ")
+	}
+	if method.IsGenerator {
+		p.write("*")
+	}
+	p.write(memberName(method.Name, method.IsPrivateName))
+	p.typeParameters(method.TypeParameters)
+	p.parameters(method.Parameters)
+	p.returnType(method.ReturnType)
+	if method.Body == nil {
+		p.write(";")
+		return
+	}
+	p.write(" ")
+	p.block(method.Body, method.End)
+}
+
+func (p *printer) interfaceDeclaration(iface *ast.Interface) {
+	p.write("interface " + iface.Name)
+	p.typeParameters(iface.TypeParameters)
+	if len(iface.Extends) > 0 {
+		p.write(" extends " + strings.Join(iface.Extends, ", "))
+	}
+	p.write(" ")
+	spans := make([]ast.Span, len(iface.Fields))
+	for i, field := range iface.Fields {
+		spans[i] = field.Span
+	}
+	p.members(spans, iface.End, func(i int) {
+		field := iface.Fields[i]
+		p.write(memberName(field.Name, false))
+		if field.Optional {
+			p.write("?")
+		}
+		if field.IsMethod {
+			p.typeParameters(field.TypeParameters)
+			p.parameters(field.Parameters)
+			p.returnType(field.ReturnType)
+		} else {
+			p.write(": " + field.Type)
+		}
+		p.write(";")
+	})
+}
+
+func (p *printer) enum(enum *ast.EnumDeclaration) {
+	if enum.IsConst {
+		p.write("const ")
+	}
+	p.write("enum " + enum.Name + " ")
+	spans := make([]ast.Span, len(enum.Members))
+	for i, member := range enum.Members {
+		spans[i] = member.Span
+	}
+	p.members(spans, enum.End, func(i int) {
+		member := enum.Members[i]
+		p.write(memberName(member.Name, false))
+		if member.Value != nil {
+			p.write(" = ")
+			p.expression(member.Value)
+		}
+		p.write(",")
+	})
+}
+
+// members imprime entre accolades, un par ligne, les membres d'une classe,
+// d'une interface, d'un enum ou d'un objet ; print imprime le i-ème, dont
+// spans[i] est l'étendue
+func (p *printer) members(spans []ast.Span, end ast.Location, print func(i int)) {
+	if len(spans) == 0 && !p.hasCommentsBefore(end) {
+		p.write("{}")
+		return
+	}
+	p.write("{")
+	p.newline()
+	p.indent++
+	p.lastLine = 0
+	for i, span := range spans {
+		p.leading(span)
+		print(i)
+		p.trailing(span)
+	}
+	if end.IsValid() {
+		p.flushComments(end.Offset)
+	}
+	p.indent--
+	p.write("}")
+}
+
+func (p *printer) namespace(ns *ast.NamespaceDeclaration) {
+	if ns.IsModule {
+		p.write("module " + quote(ns.Name))
+	} else {
+		p.write("namespace " + ns.Name)
+	}
+	if ns.Body == nil {
+		p.write(";")
+		return
+	}
+	p.write(" ")
+	p.block(ns.Body, ns.End)
+}
+
+func (p *printer) importDeclaration(decl *ast.ImportDeclaration) {
+	p.write("import ")
+	if decl.IsTypeOnly {
+		p.write("type ")
+	}
+	var clauses []string
+	if decl.Default != "" {
+		clauses = append(clauses, decl.Default)
+	}
+	if decl.Namespace != "" {
+		clauses = append(clauses, "* as "+decl.Namespace)
+	}
+	if decl.Specifiers != nil {
+		clauses = append(clauses, specifiers(decl.Specifiers))
+	}
+	if len(clauses) > 0 {
+		p.write(strings.Join(clauses, ", ") + " from ")
+	}
+	p.write(quote(decl.Source) + ";")
+}
+
+func (p *printer) exportDeclaration(decl *ast.ExportDeclaration) {
+	switch {
+	case decl.Declaration != nil && decl.IsDefault:
+		p.declaration(decl.Declaration, "export default ")
+		return
+	case decl.Declaration != nil:
+		p.declaration(decl.Declaration, "export ")
+		return
+	case decl.IsWildcard:
+		p.write("export *")
+		if decl.Namespace != "" {
+			p.write(" as " + decl.Namespace)
+		}
+	default:
+		p.write("export ")
+		if decl.IsTypeOnly {
+			p.write("type ")
+		}
+		p.write(specifiers(decl.Specifiers))
+	}
+	if decl.Source != "" {
+		p.write(" from " + quote(decl.Source))
+	}
+	p.write(";")
+}
+
+// specifiers imprime { a, b as c, type T }
+func specifiers(list []ast.ImportSpecifier) string {
+	if len(list) == 0 {
+		return "{}"
+	}
+	names := make([]string, len(list))
+	for i, spec := range list {
+		if spec.IsType {
+			names[i] = "type "
+		}
+		names[i] += spec.Name
+		if spec.Alias != "" {
+			names[i] += " as " + spec.Alias
+		}
+	}
+	return "{ " + strings.Join(names, ", ") + " }"
+}
+
+func (p *printer) decorator(decorator ast.Decorator) {
+	p.write("@" + decorator.Name)
+	if decorator.IsCall {
+		p.arguments(decorator.Arguments)
+	}
+}
+
+func (p *printer) typeParameters(params []ast.TypeParameter) {
+	if len(params) == 0 {
+		return
+	}
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.Name
+		if param.Constraint != "" {
+			names[i] += " extends " + param.Constraint
+		}
+		if param.Default != "" {
+			names[i] += " = " + param.Default
+		}
+	}
+	p.write("<" + strings.Join(names, ", ") + ">")
+}
+
+func (p *printer) parameters(params []ast.Parameter) {
+	p.write("(")
+	for i, param := range params {
+		if i > 0 {
+			p.write(", ")
+		}
+		for _, decorator := range param.Decorators {
+			p.decorator(decorator)
+			p.write(" ")
+		}
+		// public readonly se confond avec readonly, qui suffit à déclarer la
+		// propriété
+		if param.IsPrivate {
+			p.write("private ")
+		}
+		if param.IsProtected {
+			p.write("protected ")
+		}
+		if param.IsReadonly {
+			p.write("readonly ")
+		}
+		if param.IsProperty && !param.IsPrivate && !param.IsProtected && !param.IsReadonly {
+			p.write("public ")
+		}
+		if param.IsRest {
+			p.write("...")
+		}
+		p.write(param.Name)
+		if param.Optional {
+			p.write("?")
+		}
+		if param.Type != "" {
+			p.write(": " + param.Type)
+		}
+		if param.Default != nil {
+			p.write(" = ")
+			p.expression(param.Default)
+		}
+	}
+	p.write(")")
+}
+
+func (p *printer) returnType(t string) {
+	if t != "" {
+		p.write(": " + t)
+	}
+}
+
+// quote met une chaîne entre guillemets doubles, ou simples si elle en
+// contient : le lexer ne connaît pas d'échappement des guillemets
+func quote(s string) string {
+	if strings.Contains(s, "\"") {
+		return "'" + s + "'"
+	}
+	return "\"" + s + "\""
+}
+
+// memberName écrit le nom d'un membre, entre guillemets s'il n'est pas un
+// identifiant ("content-type") et précédé de # s'il est privé
+func memberName(name string, isPrivateName bool) string {
+	if isPrivateName {
+		return "#" + name
+	}
+	if !isIdentifier(name) {
+		return quote(name)
+	}
+	return name
+}
+
+func isIdentifier(name string) bool {
+	for i, ch := range name {
+		switch {
+		case ch == '_' || ch == '$' || 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z':
+		case i > 0 && '0' <= ch && ch <= '9':
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
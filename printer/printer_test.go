@@ -0,0 +1,297 @@
+package printer
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/lexer"
+	"ProjetGo/parser"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Exemples du transpilateur (mode console, interface web, fichiers de
+// résultats) et constructions que l'impression doit garder
+var examples = map[string]string{
+	"console": `
+const message: string = "Hello World";
+let count: number = 42;
+const pi: number = 3.14;
+let isActive: boolean = true;
+
+function greet(name: string): string {
+    return "Hello " + name;
+}
+
+interface User {
+    id: number;
+    name: string;
+    email: string;
+}
+
+class Calculator {
+    private value: number = 0;
+
+    add(x: number): void {
+        this.value += x;
+    }
+
+    getResult(): number {
+        return this.value;
+    }
+}
+`,
+	"web basic":    "const message: string = \"Hello World\";\nlet count: number = 42;\nconst pi: number = 3.14;\nlet isActive: boolean = true;",
+	"web function": "function greet(name: string): string {\n    return \"Hello \" + name;\n}\n\nfunction add(a: number, b: number): number {\n    return a + b;\n}\n\nconst result = add(5, 3);\nconsole.log(greet(\"Alice\"));",
+	"web class":    "interface User {\n    id: number;\n    name: string;\n    email: string;\n}\n\nclass Calculator {\n    private value: number = 0;\n    \n    add(x: number): void {\n        this.value += x;\n    }\n    \n    getResult(): number {\n        return this.value;\n    }\n}\n\nconst calc = new Calculator();\ncalc.add(10);\nconsole.log(calc.getResult());",
+	"web advanced": "const users: User[] = [\n    { id: 1, name: \"Alice\", email: \"alice@example.com\" },\n    { id: 2, name: \"Bob\", email: \"bob@example.com\" }\n];\n\nconst template = \"Hello \" + users[0].name + \"!\";\nconst numbers: number[] = [1, 2, 3, 4, 5];\n\nfor (let i = 0; i < numbers.length; i++) {\n    console.log(numbers[i]);\n}",
+	"test 4": `const nom: string = "Alice";
+let age: number = 25;
+function saluer(n: string): void {
+  console.log("Bonjour " + n);
+}
+if (age >= 18) {
+  console.log("Majeur");
+}
+`,
+	"test 6": `const nom: string = "Alice";
+let eleve = { nom: nom, age: 25 };
+`,
+	"test 7": `const nom: string = "Alice";
+let notes: number[] = [12, 15, 9];
+for (let i = 0; i < notes.length; i++) {
+  console.log("Note " + i);
+}
+`,
+	"parsing": `if (age >= 18) {
+  majeur = true;
+} else {
+  majeur = false;
+}
+
+let compteur: number = 3;
+while (compteur > 0) {
+  console.log("Compte :", compteur);
+  compteur--;
+}
+`,
+	"readonly": `class Service {
+  static readonly version: string = "1";
+  private readonly cache: Map<string, number> = new Map();
+  readonly name: string;
+
+  constructor(private readonly repo: Repo, readonly id: number, public label: string, protected count: number) {
+    this.name = "service";
+  }
+}
+`,
+	"access": `class Counter {
+  protected count: number = 0;
+  private step: number = 1;
+
+  constructor(protected readonly name: string) {}
+
+  protected bump(): void {
+    this.count += this.step;
+  }
+
+  protected get total(): number {
+    return this.count;
+  }
+}
+`,
+	"classes": `// Formes
+abstract class Shape<T extends object = {}> implements Named {
+  abstract area(): number;
+
+  describe(): string {
+    return ` + "`area ${this.area()}`" + `;
+  }
+}
+
+@sealed
+export class Square extends Shape {
+  #side: number = 1;
+
+  get side(): number {
+    return this.#side;
+  }
+
+  static of(side: number): Square {
+    const square = new Square();
+    return square;
+  }
+}
+`,
+	"comments": `/* en-tête */
+let a = 1;
+
+/**
+ * Additionne deux nombres.
+ */
+function add(x: number, y: number): number {
+  return x + y; // somme
+}
+
+class A {
+  /** valeur */
+  value: number = 0;
+}
+`,
+	"static blocks": `class Registry {
+  static {}
+
+  static items: string[] = [];
+
+  static {
+    Registry.items.push("a");
+  }
+
+  size(): number {
+    return Registry.items.length;
+  }
+}
+`,
+	"precedence": `let a = 1;
+let b = 2;
+let r = (a + b) * 2 - (a - b) / 2;
+let s = a - (b - 1) + (a * b).toString().length;
+let t = a < b && (b < 3 || a == 1);
+`,
+	"expressions": `import { readFile } from "fs";
+
+type Status = "on" | "off";
+
+async function load(path?: string, ...rest: string[]): Promise<string> {
+  const value = (await readFile(path!)) as string;
+  const pick = (x: number) => x * 2;
+  return value satisfies string;
+}
+
+export { load };
+`,
+}
+
+func parse(source string) *ast.Program {
+	return parser.New(lexer.New(source)).ParseProgram()
+}
+
+// shape renvoie l'arbre d'un programme sans ses positions ni son source, qui
+// changent forcément à l'impression
+func shape(t *testing.T, program *ast.Program) any {
+	t.Helper()
+	data, err := ast.EncodeJSON(program)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tree map[string]any
+	if err := json.Unmarshal(data, &tree); err != nil {
+		t.Fatal(err)
+	}
+	root := tree["program"].(map[string]any)
+	delete(root, "source")
+	return withoutSpans(root)
+}
+
+func withoutSpans(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		delete(v, "span")
+		for key, value := range v {
+			v[key] = withoutSpans(value)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = withoutSpans(item)
+		}
+	}
+	return v
+}
+
+// Le source imprimé se parse en le même arbre, et s'imprime à l'identique
+func TestPrintRoundTrip(t *testing.T) {
+	for name, source := range examples {
+		t.Run(name, func(t *testing.T) {
+			original := parse(source)
+			printed := Print(original)
+			reparsed := parse(printed)
+			if !reflect.DeepEqual(shape(t, original), shape(t, reparsed)) {
+				t.Errorf("l'arbre change à l'impression :\n%s", printed)
+			}
+			if again := Print(reparsed); again != printed {
+				t.Errorf("l'impression n'est pas stable :\n%s\n---\n%s", printed, again)
+			}
+		})
+	}
+}
+
+func TestPrintComments(t *testing.T) {
+	printed := Print(parse(examples["comments"]))
+	if printed != examples["comments"] {
+		t.Errorf("commentaires imprimés :\n%s", printed)
+	}
+}
+
+// Les exemples sont déjà sous leur forme imprimée : l'impression les rend
+// inchangés, blocs static vides compris
+func TestPrintIdempotent(t *testing.T) {
+	for _, name := range []string{"comments", "access", "static blocks", "precedence"} {
+		t.Run(name, func(t *testing.T) {
+			if printed := Print(parse(examples[name])); printed != examples[name] {
+				t.Errorf("impression :\n%s", printed)
+			}
+		})
+	}
+}
+
+// Un arbre construit sans ParenthesizedExpression prend les parenthèses
+// qu'exige la priorité des opérateurs
+func TestPrintRewrittenPrecedence(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{source: "let r = a - (b - c);", want: "let r = a - (b - c);"},
+		{source: "let r = (a - b) - c;", want: "let r = a - b - c;"},
+		{source: "let r = (a + b) * c;", want: "let r = (a + b) * c;"},
+		{source: "let r = a * (b + c);", want: "let r = a * (b + c);"},
+		{source: "let r = a + (b * c);", want: "let r = a + b * c;"},
+		{source: "let r = (a || b) && c;", want: "let r = (a || b) && c;"},
+		{source: "let r = (a + b).toFixed();", want: "let r = (a + b).toFixed();"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			// Retire les parenthèses du source
+			rewritten := ast.Rewrite(parse(tt.source), func(node ast.Node) ast.Node {
+				if paren, ok := node.(*ast.ParenthesizedExpression); ok {
+					return paren.Expression
+				}
+				return node
+			}).(*ast.Program)
+			printed := strings.TrimSpace(Print(rewritten))
+			if printed != tt.want {
+				t.Errorf("%s, attendu %s", printed, tt.want)
+			}
+			if again := strings.TrimSpace(Print(parse(printed))); again != printed {
+				t.Errorf("réimpression %s, attendu %s", again, printed)
+			}
+		})
+	}
+}
+
+func TestPrintReadonly(t *testing.T) {
+	printed := Print(parse(examples["readonly"]))
+	for _, want := range []string{
+		"static readonly version: string",
+		"private readonly cache",
+		"readonly name: string;",
+		"private readonly repo: Repo",
+		"readonly id: number",
+		"public label: string",
+		"protected count: number",
+	} {
+		if !strings.Contains(printed, want) {
+			t.Errorf("%q absent de :\n%s", want, printed)
+		}
+	}
+}
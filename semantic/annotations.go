@@ -0,0 +1,217 @@
+package semantic
+
+import (
+	"strconv"
+	"strings"
+)
+
+// typeReader relit la forme textuelle d'un type, telle que le parser la
+// normalise : number[], Map<string, number>, 'a' | 'b',
+// (x: number, y?: string) => void, { id: number; name?: string }... Les noms
+// de types sont résolus par name ; ce que le lecteur ne comprend pas
+// (keyof T, typeof x) devient any.
+type typeReader struct {
+	src  string
+	pos  int
+	name func(name string, args []Type) Type
+}
+
+// readType lit une annotation complète, ou renvoie any si elle ne se relit
+// pas jusqu'au bout
+func readType(src string, name func(string, []Type) Type) Type {
+	r := &typeReader{src: src, name: name}
+	t := r.union()
+	if r.skipSpaces(); r.pos < len(r.src) {
+		return Any
+	}
+	return t
+}
+
+func (r *typeReader) skipSpaces() {
+	for r.pos < len(r.src) && r.src[r.pos] == ' ' {
+		r.pos++
+	}
+}
+
+// eat passe le texte attendu s'il vient ensuite
+func (r *typeReader) eat(text string) bool {
+	r.skipSpaces()
+	if strings.HasPrefix(r.src[r.pos:], text) {
+		r.pos += len(text)
+		return true
+	}
+	return false
+}
+
+func (r *typeReader) union() Type {
+	types := []Type{r.intersection()}
+	for r.eat("|") {
+		types = append(types, r.intersection())
+	}
+	if len(types) == 1 {
+		// Un alias d'union garde son nom
+		return types[0]
+	}
+	return unionOf(types...)
+}
+
+// intersection fusionne les propriétés de A & B quand ce sont des types
+// objets ; les autres intersections ne sont pas vérifiées
+func (r *typeReader) intersection() Type {
+	t := r.array()
+	for r.eat("&") {
+		right := r.array()
+		left, ok1 := t.(*Object)
+		other, ok2 := right.(*Object)
+		if !ok1 || !ok2 {
+			t = Any
+			continue
+		}
+		merged := &Object{Fields: append([]Property{}, left.Fields...)}
+		for _, field := range other.Fields {
+			if merged.Field(field.Name) == nil {
+				merged.Fields = append(merged.Fields, field)
+			}
+		}
+		t = merged
+	}
+	return t
+}
+
+func (r *typeReader) array() Type {
+	t := r.primary()
+	for r.eat("[]") {
+		t = &Array{Elem: t}
+	}
+	return t
+}
+
+func (r *typeReader) primary() Type {
+	r.skipSpaces()
+	if r.pos >= len(r.src) {
+		return Any
+	}
+	switch c := r.src[r.pos]; {
+	case c == '\'':
+		end := strings.IndexByte(r.src[r.pos+1:], '\'')
+		if end < 0 {
+			r.pos = len(r.src)
+			return Any
+		}
+		value := r.src[r.pos+1 : r.pos+1+end]
+		r.pos += end + 2
+		return &Literal{Value: value, Base: String}
+	case c >= '0' && c <= '9' || c == '-':
+		start := r.pos
+		r.pos++
+		for r.pos < len(r.src) && isNameByte(r.src[r.pos]) {
+			r.pos++
+		}
+		return &Literal{Value: numberValue(r.src[start:r.pos]), Base: Number}
+	case c == '(':
+		return r.parenOrFunction()
+	case c == '{':
+		return r.object()
+	case isNameByte(c):
+		name := r.identifier()
+		if r.eat("<") {
+			var args []Type
+			for !r.eat(">") && r.pos < len(r.src) {
+				args = append(args, r.union())
+				r.eat(",")
+			}
+			return r.name(name, args)
+		}
+		switch name {
+		case "true", "false":
+			return &Literal{Value: name, Base: Boolean}
+		}
+		if basic := basicTypes[name]; basic != nil {
+			return basic
+		}
+		return r.name(name, nil)
+	}
+	return Any
+}
+
+// identifier lit un nom, éventuellement qualifié : React.ReactNode
+func (r *typeReader) identifier() string {
+	start := r.pos
+	for r.pos < len(r.src) && (isNameByte(r.src[r.pos]) || r.src[r.pos] == '.') {
+		r.pos++
+	}
+	return r.src[start:r.pos]
+}
+
+// parenOrFunction lit (A | B) ou (x: number, ...rest: T[]) => R
+func (r *typeReader) parenOrFunction() Type {
+	r.pos++ // passer '('
+	var params []Param
+	var inner Type
+	for !r.eat(")") && r.pos < len(r.src) {
+		param := Param{Name: "arg" + strconv.Itoa(len(params))}
+		param.Rest = r.eat("...")
+		// Un paramètre nommé est suivi de ':' ou de '?:'
+		r.skipSpaces()
+		start := r.pos
+		name := r.identifier()
+		switch {
+		case name != "" && r.eat("?:"):
+			param.Name, param.Optional = name, true
+		case name != "" && r.eat(":"):
+			param.Name = name
+		default:
+			r.pos = start
+		}
+		param.Type = r.union()
+		inner = param.Type
+		params = append(params, param)
+		r.eat(",")
+	}
+	if r.eat("=>") {
+		return &Signature{Params: params, Return: r.union()}
+	}
+	if len(params) != 1 || inner == nil {
+		return Any
+	}
+	return inner
+}
+
+// object lit { id: number; name?: string }
+func (r *typeReader) object() Type {
+	r.pos++ // passer '{'
+	object := &Object{}
+	for !r.eat("}") && r.pos < len(r.src) {
+		r.skipSpaces()
+		field := Property{Name: r.identifier()}
+		if field.Name == "" {
+			// Clé entre guillemets que le parser a gardée sans eux, ou
+			// membre qu'il n'a pas lu : le type n'est pas vérifié
+			return Any
+		}
+		if r.eat("?") {
+			field.Optional = true
+		}
+		if !r.eat(":") {
+			return Any
+		}
+		field.Type = r.union()
+		object.Fields = append(object.Fields, field)
+		r.eat(";")
+	}
+	return object
+}
+
+func isNameByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
+
+// numberValue normalise l'écriture d'un nombre, pour que 1.0 et 1 désignent
+// le même littéral
+func numberValue(text string) string {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(text, "_", ""), 64)
+	if err != nil {
+		return text
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
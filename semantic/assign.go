@@ -0,0 +1,274 @@
+package semantic
+
+// assignable indique si une valeur de type source peut être rangée là où
+// target est attendu. null et undefined sont acceptés partout, comme sans
+// strictNullChecks : l'analyse ne suit pas les rétrécissements par if, et
+// refuserait sinon if (x !== null) { const y: string = x }.
+func (c *checker) assignable(source, target Type) bool {
+	return c.assignableTo(source, target, map[[2]Type]bool{})
+}
+
+// seen retient les paires déjà en cours de comparaison : deux types
+// récursifs (interface Node { next: Node }) sont compatibles si rien d'autre
+// ne les distingue
+func (c *checker) assignableTo(source, target Type, seen map[[2]Type]bool) bool {
+	if source == target || source == Any || source == Never || source == Null || source == Undefined {
+		return true
+	}
+	switch target {
+	case Any, Unknown:
+		return true
+	case Void:
+		return false
+	}
+	pair := [2]Type{source, target}
+	if seen[pair] {
+		return true
+	}
+	seen[pair] = true
+
+	// Les types que le fichier ne déclare pas ne se comparent qu'entre eux
+	if s, ok := source.(*Reference); ok {
+		if t, ok := target.(*Reference); ok && s.Name == t.Name && len(s.Args) == len(t.Args) {
+			for i := range s.Args {
+				if !c.assignableTo(s.Args[i], t.Args[i], seen) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if _, ok := target.(*Reference); ok {
+		return true
+	}
+
+	if s, ok := source.(*Union); ok {
+		for _, member := range s.Types {
+			if !c.assignableTo(member, target, seen) {
+				return false
+			}
+		}
+		return true
+	}
+	if t, ok := target.(*Union); ok {
+		for _, member := range t.Types {
+			if c.assignableTo(source, member, seen) {
+				return true
+			}
+		}
+		// boolean est l'union true | false
+		return source == Boolean && c.assignableTo(&Literal{Value: "true", Base: Boolean}, target, seen) &&
+			c.assignableTo(&Literal{Value: "false", Base: Boolean}, target, seen)
+	}
+
+	if s, ok := source.(*TypeParam); ok {
+		if t, ok := target.(*TypeParam); ok && s.Name == t.Name {
+			return true
+		}
+		return s.Constraint != nil && c.assignableTo(s.Constraint, target, seen)
+	}
+
+	switch t := target.(type) {
+	case *TypeParam:
+		// Dans function f<T>(): T, seule une valeur de type T convient
+		return false
+
+	case *Basic:
+		switch s := source.(type) {
+		case *Basic:
+			return s.Name == t.Name
+		case *Literal:
+			return s.Base == t
+		case *EnumType:
+			return t == Number || t == String
+		}
+		// object accepte toute valeur qui n'est pas primitive
+		return t.Name == "object" && isObjectLike(source)
+
+	case *Literal:
+		s, ok := source.(*Literal)
+		return ok && s.Base == t.Base && s.Value == t.Value
+
+	case *EnumType:
+		switch s := source.(type) {
+		case *EnumType:
+			return s.Name == t.Name
+		case *Literal:
+			// Un membre d'énumération numérique vaut un nombre
+			return s.Base == Number
+		}
+		return source == Number
+
+	case *Array:
+		s, ok := source.(*Array)
+		return ok && c.assignableTo(s.Elem, t.Elem, seen)
+
+	case *Object:
+		if t.Name == "" && len(t.Fields) == 0 {
+			// {} accepte toute valeur
+			return true
+		}
+		if !isObjectLike(source) {
+			return false
+		}
+		for _, field := range t.Fields {
+			property := c.property(source, field.Name)
+			if property == nil {
+				if !field.Optional {
+					return false
+				}
+				continue
+			}
+			if !c.assignableTo(property, field.Type, seen) {
+				return false
+			}
+		}
+		return true
+
+	case *Signature:
+		s, ok := source.(*Signature)
+		if !ok {
+			return false
+		}
+		if len(s.TypeParams) > 0 || len(t.TypeParams) > 0 {
+			// Les signatures génériques ne sont pas comparées
+			return true
+		}
+		// Une fonction peut ignorer des arguments, pas en exiger davantage
+		if min, _ := s.arity(); min > len(t.Params) && !hasRest(t) {
+			return false
+		}
+		for i, param := range s.Params {
+			if i >= len(t.Params) || param.Rest || t.Params[i].Rest {
+				break
+			}
+			// Les paramètres se comparent dans les deux sens, comme tsc pour
+			// les méthodes
+			if !c.assignableTo(t.Params[i].Type, param.Type, seen) && !c.assignableTo(param.Type, t.Params[i].Type, seen) {
+				return false
+			}
+		}
+		return t.Return == Void || c.assignableTo(s.Return, t.Return, seen)
+
+	case *ClassType:
+		s, ok := source.(*ClassType)
+		return ok && s.Decl == t.Decl
+	}
+	return false
+}
+
+func isObjectLike(t Type) bool {
+	switch t.(type) {
+	case *Object, *Array, *Signature, *ClassType, *Reference:
+		return true
+	}
+	return false
+}
+
+func hasRest(f *Signature) bool {
+	return len(f.Params) > 0 && f.Params[len(f.Params)-1].Rest
+}
+
+// substitute remplace des paramètres de type dans un type : le T[] de
+// first<T> devient number[] pour first([1, 2])
+func (c *checker) substitute(t Type, bindings map[*TypeParam]Type) Type {
+	if len(bindings) == 0 {
+		return t
+	}
+	switch t := t.(type) {
+	case *TypeParam:
+		if bound, ok := bindings[t]; ok {
+			return bound
+		}
+	case *Array:
+		return &Array{Elem: c.substitute(t.Elem, bindings)}
+	case *Union:
+		members := make([]Type, len(t.Types))
+		for i, member := range t.Types {
+			members[i] = c.substitute(member, bindings)
+		}
+		return unionOf(members...)
+	case *Reference:
+		return &Reference{Name: t.Name, Args: c.substituteAll(t.Args, bindings)}
+	case *Signature:
+		fn := &Signature{TypeParams: t.TypeParams, Params: make([]Param, len(t.Params)), Return: c.substitute(t.Return, bindings)}
+		for i, param := range t.Params {
+			param.Type = c.substitute(param.Type, bindings)
+			fn.Params[i] = param
+		}
+		return fn
+	case *Object:
+		if t.origin != nil {
+			// Box<T> devient Box<number> : une nouvelle instance de la
+			// déclaration, que le cache partage avec ses références récursives
+			return c.instantiate(t.origin, c.substituteAll(t.args, bindings))
+		}
+		object := &Object{Fields: make([]Property, len(t.Fields))}
+		for i, field := range t.Fields {
+			field.Type = c.substitute(field.Type, bindings)
+			object.Fields[i] = field
+		}
+		return object
+	}
+	return t
+}
+
+func (c *checker) substituteAll(types []Type, bindings map[*TypeParam]Type) []Type {
+	if types == nil {
+		return nil
+	}
+	result := make([]Type, len(types))
+	for i, t := range types {
+		result[i] = c.substitute(t, bindings)
+	}
+	return result
+}
+
+// infer déduit les paramètres de type d'une signature en rapprochant le type
+// attendu d'un paramètre (param) de celui de l'argument reçu
+func (c *checker) infer(param, arg Type, bindings map[*TypeParam]Type) {
+	switch p := param.(type) {
+	case *TypeParam:
+		if _, ok := bindings[p]; ok && bindings[p] == nil {
+			bindings[p] = widen(arg)
+		}
+	case *Array:
+		if a, ok := arg.(*Array); ok {
+			c.infer(p.Elem, a.Elem, bindings)
+		}
+	case *Union:
+		// T | undefined : l'argument donne T
+		for _, member := range p.Types {
+			if _, ok := member.(*TypeParam); ok {
+				c.infer(member, arg, bindings)
+			}
+		}
+	case *Reference:
+		if a, ok := arg.(*Reference); ok && a.Name == p.Name && len(a.Args) == len(p.Args) {
+			for i := range p.Args {
+				c.infer(p.Args[i], a.Args[i], bindings)
+			}
+		}
+	case *Signature:
+		if a, ok := arg.(*Signature); ok {
+			for i := range p.Params {
+				if i < len(a.Params) {
+					c.infer(p.Params[i].Type, a.Params[i].Type, bindings)
+				}
+			}
+			c.infer(p.Return, a.Return, bindings)
+		}
+	case *Object:
+		if a, ok := arg.(*Object); ok && p.origin != nil && a.origin == p.origin && len(a.args) == len(p.args) {
+			for i := range p.args {
+				c.infer(p.args[i], a.args[i], bindings)
+			}
+			return
+		}
+		for _, field := range p.Fields {
+			if property := c.property(arg, field.Name); property != nil {
+				c.infer(field.Type, property, bindings)
+			}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package semantic
+
+import (
+	"ProjetGo/ast"
+	"fmt"
+	"strconv"
+)
+
+// Check vérifie les types d'un programme déjà analysé par Analyze : valeurs
+// affectées ou renvoyées, arguments des appels. Les types viennent des
+// annotations, et sinon des valeurs : let total = a + b prend le type de
+// a + b. Les diagnostics sont rendus dans l'ordre du source.
+func Check(program *ast.Program, info *Info) []Diagnostic {
+	c := newChecker(info)
+	c.locate(program)
+	c.check(program)
+	sortDiagnostics(c.diagnostics)
+	return c.diagnostics
+}
+
+type checker struct {
+	info        *Info
+	diagnostics []Diagnostic
+
+	scopes      map[ast.Node]*Scope // portée où apparaît chaque nœud
+	declared    map[ast.Node]*Symbol
+	symbols     map[*Symbol]Type // nil pendant le calcul du type
+	expressions map[ast.Expression]Type
+	signatures  map[ast.Node]*Signature
+	typeParams  map[any][]*TypeParam // par fonction, classe, interface, alias ou méthode d'interface
+	instances   map[instanceKey]Type // formes génériques et instances des types déclarés
+}
+
+func newChecker(info *Info) *checker {
+	return &checker{
+		info:        info,
+		scopes:      map[ast.Node]*Scope{},
+		declared:    map[ast.Node]*Symbol{},
+		symbols:     map[*Symbol]Type{},
+		expressions: map[ast.Expression]Type{},
+		signatures:  map[ast.Node]*Signature{},
+		typeParams:  map[any][]*TypeParam{},
+		instances:   map[instanceKey]Type{},
+	}
+}
+
+// locate relève la portée de chaque nœud et le symbole de chaque
+// déclaration : les types se calculent à la demande, dans n'importe quel
+// ordre, et doivent lire leurs annotations là où elles sont écrites
+func (c *checker) locate(program *ast.Program) {
+	stack := []*Scope{c.info.Module}
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		scope := stack[len(stack)-1]
+		c.scopes[node] = scope
+		if inner := c.info.ScopeOf(node); inner != nil {
+			scope = inner
+		}
+		stack = append(stack, scope)
+		return true
+	})
+
+	var declare func(scope *Scope)
+	declare = func(scope *Scope) {
+		for _, sym := range scope.Symbols {
+			for _, decl := range sym.Declarations {
+				c.declared[decl] = sym
+			}
+		}
+		for _, child := range scope.Children {
+			declare(child)
+		}
+	}
+	declare(c.info.Module)
+}
+
+// check parcourt l'arbre et vérifie chaque affectation, initialisation,
+// appel et return
+func (c *checker) check(program *ast.Program) {
+	var stack []ast.Node
+	var functions []ast.Node // fonctions englobantes, pour les return
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node == nil {
+			if isFunction(stack[len(stack)-1]) {
+				functions = functions[:len(functions)-1]
+			}
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, node)
+		if isFunction(node) {
+			functions = append(functions, node)
+		}
+
+		switch n := node.(type) {
+		case *ast.ArrowFunction:
+			if n.ExpressionBody != nil {
+				c.checkReturn(n, n.ExpressionBody)
+			}
+		case *ast.ReturnStatement:
+			if n.Value != nil && len(functions) > 0 {
+				c.checkReturn(functions[len(functions)-1], n.Value)
+			}
+		case *ast.VariableDeclaration:
+			c.expect(n.Value, c.resolve(n.Type, c.scopes[n], nil))
+		case *ast.ClassField:
+			c.expect(n.Default, c.resolve(n.Type, c.scopes[n], nil))
+		case *ast.Parameter:
+			c.expect(n.Default, c.resolve(n.Type, c.scopes[n], nil))
+		case *ast.AssignmentExpression:
+			if n.Operator == "=" {
+				c.expect(n.Right, c.target(n.Left))
+			}
+		case *ast.CallExpression:
+			if fn, ok := c.typeOf(n.Function).(*Signature); ok {
+				c.checkArguments(n, c.instantiateCall(fn, n.TypeArguments, n.Arguments, c.scopes[n]), n.Arguments)
+			}
+		case *ast.NewExpression:
+			if class, ok := c.typeOf(n.Class).(*ClassType); ok {
+				fn := c.instantiateCall(c.constructor(class.Decl), n.TypeArguments, n.Arguments, c.scopes[n])
+				c.checkArguments(n, fn, n.Arguments)
+			}
+		case *ast.SatisfiesExpression:
+			expected := c.resolve(n.Type, c.scopes[n], nil)
+			if t := c.typeOf(n.Expression); !c.assignable(t, expected) {
+				c.report(n.Expression, NotSatisfied, "Le type « %s » ne satisfait pas le type attendu « %s ».", shown(t, expected), expected)
+			}
+		}
+		return true
+	})
+}
+
+func isFunction(node ast.Node) bool {
+	switch node.(type) {
+	case *ast.FunctionDeclaration, *ast.ClassMethod, *ast.ArrowFunction:
+		return true
+	}
+	return false
+}
+
+// target renvoie le type déclaré de ce qui reçoit une affectation, ou nil
+// s'il n'est pas connu. Une constante n'en reçoit pas : CheckNames le signale
+// déjà.
+func (c *checker) target(left ast.Expression) Type {
+	switch l := left.(type) {
+	case *ast.Identifier:
+		if sym := c.info.SymbolOf(l); sym != nil {
+			switch sym.Kind {
+			case Var, Let, Parameter:
+				return c.symbolType(sym)
+			}
+		}
+	case *ast.DotExpression:
+		return c.property(c.typeOf(l.Object), l.Property)
+	case *ast.IndexExpression:
+		if array, ok := c.typeOf(l.Left).(*Array); ok {
+			return array.Elem
+		}
+	}
+	return nil
+}
+
+// checkReturn vérifie une valeur renvoyée contre le type de retour annoté de
+// sa fonction ; celui d'une fonction async est le T de Promise<T>
+func (c *checker) checkReturn(fn ast.Node, value ast.Expression) {
+	var returnType string
+	var isAsync, isGenerator bool
+	switch f := fn.(type) {
+	case *ast.FunctionDeclaration:
+		returnType, isAsync, isGenerator = f.ReturnType, f.IsAsync, f.IsGenerator
+	case *ast.ClassMethod:
+		returnType, isAsync, isGenerator = f.ReturnType, f.IsAsync, f.IsGenerator
+	case *ast.ArrowFunction:
+		returnType, isAsync = f.ReturnType, f.IsAsync
+	}
+	expected := c.resolve(returnType, c.declarationScope(fn), nil)
+	if expected == nil || isGenerator {
+		return
+	}
+	if isAsync {
+		promise, ok := expected.(*Reference)
+		if !ok || promise.Name != "Promise" || len(promise.Args) != 1 {
+			return
+		}
+		expected = promise.Args[0]
+	}
+	c.expect(value, expected)
+}
+
+// expect vérifie qu'une valeur convient au type attendu ; un type ou une
+// valeur absents ne sont pas vérifiés
+func (c *checker) expect(value ast.Expression, expected Type) {
+	if value == nil || expected == nil {
+		return
+	}
+	if t := c.typeOf(value); !c.assignable(t, expected) {
+		c.report(value, NotAssignable, "Le type « %s » n'est pas assignable au type « %s ».", shown(t, expected), expected)
+	}
+}
+
+// checkArguments vérifie le nombre d'arguments d'un appel, puis chacun
+// contre son paramètre. Un argument étalé (...xs) arrête la vérification.
+func (c *checker) checkArguments(call ast.Expression, fn *Signature, args []ast.Expression) {
+	for i, arg := range args {
+		if _, ok := arg.(*ast.SpreadElement); ok {
+			return
+		}
+		param := paramAt(fn, i)
+		if param == nil {
+			break
+		}
+		if t := c.typeOf(arg); !c.assignable(t, param) {
+			c.report(arg, ArgumentMismatch, "L'argument de type « %s » n'est pas assignable au paramètre de type « %s ».", shown(t, param), param)
+		}
+	}
+	if min, max := fn.arity(); len(args) < min || max >= 0 && len(args) > max {
+		c.report(call, ArgumentCount, "Nombre d'arguments attendu : %s, reçu : %d.", expectedCount(min, max), len(args))
+	}
+}
+
+// shown renvoie le type d'une valeur tel que le message le cite : comme tsc,
+// « string » plutôt que « 'hi' » quand le type attendu ne contient pas de
+// littéraux
+func shown(t, expected Type) Type {
+	if hasLiteral(expected) {
+		return t
+	}
+	return widen(t)
+}
+
+func hasLiteral(t Type) bool {
+	switch t := t.(type) {
+	case *Literal:
+		return true
+	case *Union:
+		for _, member := range t.Types {
+			if hasLiteral(member) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func expectedCount(min, max int) string {
+	switch {
+	case max < 0:
+		return "au moins " + strconv.Itoa(min)
+	case min == max:
+		return strconv.Itoa(min)
+	}
+	return fmt.Sprintf("%d à %d", min, max)
+}
+
+func (c *checker) report(node ast.Node, code, format string, args ...any) {
+	c.diagnostics = append(c.diagnostics, Diagnostic{Span: ast.Position(node), Code: code, Message: fmt.Sprintf(format, args...)})
+}
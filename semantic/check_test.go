@@ -0,0 +1,57 @@
+package semantic
+
+import (
+	"strings"
+	"testing"
+)
+
+// diagnosticCase attend d'un source exactement un diagnostic, de code et de
+// position donnés ; un code vide attend un source sans diagnostic
+type diagnosticCase struct {
+	name   string
+	source string
+	code   string
+	line   int
+	column int
+}
+
+func runDiagnosticCases(t *testing.T, tests []diagnosticCase) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics := Diagnose(parse(tt.source))
+			if tt.code == "" {
+				if len(diagnostics) > 0 {
+					t.Errorf("diagnostics inattendus : %v", diagnostics)
+				}
+				return
+			}
+			if len(diagnostics) != 1 {
+				t.Fatalf("%d diagnostics, attendu un %s : %v", len(diagnostics), tt.code, diagnostics)
+			}
+			d := diagnostics[0]
+			if d.Code != tt.code || d.Pos.Line != tt.line || d.Pos.Column != tt.column {
+				t.Errorf("%s, attendu %d:%d: %s", d, tt.line, tt.column, tt.code)
+			}
+			if strings.TrimSpace(d.Message) == "" {
+				t.Errorf("%s sans message", d.Code)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	runDiagnosticCases(t, []diagnosticCase{
+		{name: "initialisation", source: `let x: number = "hi";`, code: NotAssignable, line: 1, column: 17},
+		{name: "affectation", source: "let x: number = 1;\nx = \"hi\";", code: NotAssignable, line: 2, column: 5},
+		{name: "retour", source: "function f(x: number): string {\n  return x;\n}", code: NotAssignable, line: 2, column: 10},
+		{name: "propriété manquante", source: "interface User { name: string; }\nconst u: User = { age: 1 };", code: NotAssignable, line: 2, column: 17},
+		{name: "union", source: `let s: "on" | "off" = "maybe";`, code: NotAssignable, line: 1, column: 23},
+		{name: "argument", source: "function f(x: number) {}\nf(\"s\");", code: ArgumentMismatch, line: 2, column: 3},
+		{name: "argument générique", source: "function first<T>(items: T[], fallback: T): T { return fallback; }\nfirst([1, 2], \"a\");", code: ArgumentMismatch, line: 2, column: 15},
+		{name: "trop d'arguments", source: "function f(x: number) {}\nf(1, 2);", code: ArgumentCount, line: 2, column: 1},
+		{name: "argument manquant", source: "function f(x: number, y: number) {}\nf(1);", code: ArgumentCount, line: 2, column: 1},
+		{name: "satisfies", source: `const s = 1 satisfies string;`, code: NotSatisfied, line: 1, column: 11},
+		{name: "types compatibles", source: "interface User { name: string; }\nfunction greet(u: User, suffix?: string): string {\n  return u.name;\n}\nconst ids: number[] = [1, 2];\ngreet({ name: \"Ada\" });"},
+	})
+}
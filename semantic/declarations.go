@@ -0,0 +1,593 @@
+package semantic
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// instanceKey repère l'instance d'une déclaration générique pour des
+// arguments de type donnés : Box et "number"
+type instanceKey struct {
+	origin ast.Node
+	args   string
+}
+
+// resolve lit une annotation écrite dans une portée ; extra lie les
+// paramètres de type d'une interface ou d'un alias, qui n'ouvrent pas de
+// portée. Une annotation vide donne nil.
+func (c *checker) resolve(text string, scope *Scope, extra map[string]Type) Type {
+	if text == "" {
+		return nil
+	}
+	return readType(text, func(name string, args []Type) Type {
+		return c.named(name, args, scope, extra)
+	})
+}
+
+// named résout un nom de type : paramètre de type, déclaration du fichier, ou
+// type que le fichier ne déclare pas
+func (c *checker) named(name string, args []Type, scope *Scope, extra map[string]Type) Type {
+	if t, ok := extra[name]; ok {
+		return t
+	}
+	if tp := c.typeParamNamed(name, scope); tp != nil {
+		return tp
+	}
+	if scope != nil {
+		if sym := scope.Lookup(name); sym != nil {
+			switch decl := sym.Declaration().(type) {
+			case *ast.Interface, *ast.TypeAlias, *ast.ClassDeclaration:
+				return c.instantiate(decl, args)
+			case *ast.EnumDeclaration:
+				return &EnumType{Name: decl.Name}
+			}
+		}
+	}
+	switch name {
+	case "Array", "ReadonlyArray":
+		if len(args) == 1 {
+			return &Array{Elem: args[0]}
+		}
+	case "Function", "Object":
+		return Any
+	}
+	return &Reference{Name: name, Args: args}
+}
+
+// typeParamNamed cherche un paramètre de type dans les fonctions, méthodes et
+// classes qui englobent une portée
+func (c *checker) typeParamNamed(name string, scope *Scope) *TypeParam {
+	for s := scope; s != nil; s = s.Parent {
+		var list []ast.TypeParameter
+		switch n := s.Node.(type) {
+		case *ast.FunctionDeclaration:
+			list = n.TypeParameters
+		case *ast.ClassMethod:
+			list = n.TypeParameters
+		case *ast.ClassDeclaration:
+			list = n.TypeParameters
+		default:
+			continue
+		}
+		for i, param := range list {
+			if param.Name == name {
+				return c.typeParamsOf(s.Node, list, s, nil)[i]
+			}
+		}
+	}
+	return nil
+}
+
+// typeParamsOf crée une fois pour toutes les paramètres de type d'une
+// déclaration ; leurs contraintes se lisent ensuite, pour que
+// <T, K extends T> voie T
+func (c *checker) typeParamsOf(owner any, list []ast.TypeParameter, scope *Scope, extra map[string]Type) []*TypeParam {
+	if params, ok := c.typeParams[owner]; ok {
+		return params
+	}
+	params := make([]*TypeParam, len(list))
+	for i, param := range list {
+		params[i] = &TypeParam{Name: param.Name}
+	}
+	c.typeParams[owner] = params
+	bindings := bind(params, nil, extra)
+	for i, param := range list {
+		params[i].Constraint = c.resolve(param.Constraint, scope, bindings)
+	}
+	return params
+}
+
+// bind associe à chaque nom de paramètre son argument de type, ou le
+// paramètre lui-même sans argument, en complétant extra
+func bind(params []*TypeParam, args []Type, extra map[string]Type) map[string]Type {
+	bindings := map[string]Type{}
+	for name, t := range extra {
+		bindings[name] = t
+	}
+	for i, param := range params {
+		if i < len(args) {
+			bindings[param.Name] = args[i]
+		} else {
+			bindings[param.Name] = param
+		}
+	}
+	return bindings
+}
+
+// instantiate renvoie le type d'une interface, d'un alias ou d'une classe
+// pour des arguments de type. La forme générique, avec ses propres
+// paramètres, est construite une fois ; chaque instance y remplace les
+// paramètres par les arguments.
+func (c *checker) instantiate(origin ast.Node, args []Type) Type {
+	var name string
+	var list []ast.TypeParameter
+	switch decl := origin.(type) {
+	case *ast.Interface:
+		name, list = decl.Name, decl.TypeParameters
+	case *ast.TypeAlias:
+		name, list = decl.Name, decl.TypeParameters
+	case *ast.ClassDeclaration:
+		name, list = decl.Name, decl.TypeParameters
+	}
+	generic := c.generic(origin)
+	params := c.typeParamsOf(origin, list, c.declarationScope(origin), nil)
+	if len(params) == 0 {
+		return generic
+	}
+
+	// Les arguments manquants prennent la valeur par défaut du paramètre
+	full := make([]Type, len(params))
+	same := true
+	for i, param := range params {
+		switch {
+		case i < len(args):
+			full[i] = args[i]
+		case list[i].Default != "":
+			full[i] = c.resolve(list[i].Default, c.declarationScope(origin), bind(params, full[:i], nil))
+		default:
+			full[i] = Any
+		}
+		same = same && full[i] == param
+	}
+	if same {
+		return generic
+	}
+
+	texts := make([]string, len(full))
+	for i, arg := range full {
+		texts[i] = arg.String()
+	}
+	key := instanceKey{origin, strings.Join(texts, ", ")}
+	if t, ok := c.instances[key]; ok {
+		return t
+	}
+	bindings := map[*TypeParam]Type{}
+	for i, param := range params {
+		bindings[param] = full[i]
+	}
+	object, ok := generic.(*Object)
+	if !ok {
+		t := c.substitute(generic, bindings)
+		c.instances[key] = t
+		return t
+	}
+	instance := &Object{Name: name + "<" + key.args + ">", origin: origin, args: full}
+	c.instances[key] = instance
+	for _, field := range object.Fields {
+		field.Type = c.substitute(field.Type, bindings)
+		instance.Fields = append(instance.Fields, field)
+	}
+	return instance
+}
+
+// declarationScope renvoie la portée où se lisent les annotations d'une
+// déclaration : la sienne pour une classe, celle qui l'entoure sinon
+func (c *checker) declarationScope(origin ast.Node) *Scope {
+	if scope := c.info.ScopeOf(origin); scope != nil {
+		return scope
+	}
+	return c.scopes[origin]
+}
+
+// generic construit la forme générique d'une déclaration de type
+func (c *checker) generic(origin ast.Node) Type {
+	key := instanceKey{origin: origin}
+	if t, ok := c.instances[key]; ok {
+		if t == nil {
+			// Alias récursif : type Tree = { children: Tree[] }
+			return &Reference{Name: origin.(*ast.TypeAlias).Name}
+		}
+		return t
+	}
+	switch decl := origin.(type) {
+	case *ast.Interface:
+		return c.interfaceObject(decl, key)
+	case *ast.ClassDeclaration:
+		return c.classObject(decl, key)
+	case *ast.TypeAlias:
+		c.instances[key] = nil
+		scope := c.scopes[decl]
+		params := c.typeParamsOf(decl, decl.TypeParameters, scope, nil)
+		t := c.resolve(decl.Type, scope, bind(params, nil, nil))
+		if object, ok := t.(*Object); ok && object.Name == "" {
+			// type Point = { x: number } : les messages parlent de Point
+			t = &Object{Name: genericName(decl.Name, params), Fields: object.Fields, origin: decl, args: typesOf(params)}
+		}
+		if union, ok := t.(*Union); ok && len(params) == 0 {
+			t = &Union{Name: decl.Name, Types: union.Types}
+		}
+		c.instances[key] = t
+		return t
+	}
+	return Any
+}
+
+// genericName écrit Box<T> pour une déclaration générique
+func genericName(name string, params []*TypeParam) string {
+	if len(params) == 0 {
+		return name
+	}
+	names := make([]string, len(params))
+	for i, param := range params {
+		names[i] = param.Name
+	}
+	return name + "<" + strings.Join(names, ", ") + ">"
+}
+
+func typesOf(params []*TypeParam) []Type {
+	types := make([]Type, len(params))
+	for i, param := range params {
+		types[i] = param
+	}
+	return types
+}
+
+// interfaceObject construit une interface avec les champs de toutes ses
+// déclarations, puis ceux des interfaces qu'elle étend
+func (c *checker) interfaceObject(decl *ast.Interface, key instanceKey) *Object {
+	scope := c.scopes[decl]
+	params := c.typeParamsOf(decl, decl.TypeParameters, scope, nil)
+	object := &Object{Name: genericName(decl.Name, params), origin: decl, args: typesOf(params)}
+	c.instances[key] = object
+	bindings := bind(params, nil, nil)
+
+	declarations := []ast.Node{decl}
+	if sym := c.declared[decl]; sym != nil {
+		declarations = sym.Declarations
+	}
+	for _, node := range declarations {
+		part, ok := node.(*ast.Interface)
+		if !ok {
+			continue
+		}
+		for i := range part.Fields {
+			field := &part.Fields[i]
+			if object.Field(field.Name) != nil {
+				continue
+			}
+			t := c.resolve(field.Type, scope, bindings)
+			if field.IsMethod {
+				t = c.methodSignature(field, scope, bindings)
+			}
+			if t == nil {
+				t = Any
+			}
+			object.Fields = append(object.Fields, Property{Name: field.Name, Type: t, Optional: field.Optional})
+		}
+		for _, extends := range part.Extends {
+			c.inherit(object, c.resolve(extends, scope, bindings))
+		}
+	}
+	return object
+}
+
+// methodSignature construit le type d'une méthode d'interface :
+// find<T>(id: T): Item
+func (c *checker) methodSignature(field *ast.InterfaceField, scope *Scope, extra map[string]Type) *Signature {
+	params := c.typeParamsOf(field, field.TypeParameters, scope, extra)
+	bindings := bind(params, nil, extra)
+	fn := &Signature{TypeParams: params, Return: c.resolve(field.ReturnType, scope, bindings)}
+	if fn.Return == nil {
+		fn.Return = Any
+	}
+	for i := range field.Parameters {
+		fn.Params = append(fn.Params, c.param(&field.Parameters[i], scope, bindings))
+	}
+	return fn
+}
+
+// inherit ajoute à un objet les champs d'un type de base qu'il ne redéfinit pas
+func (c *checker) inherit(object *Object, base Type) {
+	parent, ok := base.(*Object)
+	if !ok {
+		return
+	}
+	for _, field := range parent.Fields {
+		if object.Field(field.Name) == nil {
+			object.Fields = append(object.Fields, field)
+		}
+	}
+}
+
+// classObject construit le type des instances d'une classe : champs,
+// propriétés de paramètres, méthodes et accesseurs qui ne sont pas static,
+// puis les membres hérités
+func (c *checker) classObject(decl *ast.ClassDeclaration, key instanceKey) *Object {
+	scope := c.info.ScopeOf(decl)
+	params := c.typeParamsOf(decl, decl.TypeParameters, scope, nil)
+	object := &Object{Name: genericName(decl.Name, params), origin: decl, args: typesOf(params)}
+	c.instances[key] = object
+
+	add := func(name string, t Type) {
+		if object.Field(name) == nil {
+			object.Fields = append(object.Fields, Property{Name: name, Type: t})
+		}
+	}
+	for i := range decl.Fields {
+		field := &decl.Fields[i]
+		if field.IsStatic {
+			continue
+		}
+		t := c.resolve(field.Type, scope, nil)
+		if t == nil {
+			t = c.inferred(field.Default, false)
+		}
+		add(field.Name, t)
+	}
+	for i := range decl.Methods {
+		method := &decl.Methods[i]
+		if method.IsStatic {
+			continue
+		}
+		switch method.Kind {
+		case ast.Constructor:
+			for j := range method.Parameters {
+				if param := &method.Parameters[j]; param.IsProperty {
+					add(param.Name, c.param(param, c.info.ScopeOf(method), nil).Type)
+				}
+			}
+		case ast.Getter:
+			add(method.Name, c.signature(method).Return)
+		case ast.Setter:
+			if len(method.Parameters) > 0 {
+				add(method.Name, c.param(&method.Parameters[0], c.info.ScopeOf(method), nil).Type)
+			}
+		default:
+			add(method.Name, c.signature(method))
+		}
+	}
+	if decl.SuperClass != "" {
+		c.inherit(object, c.resolve(decl.SuperClass, scope, nil))
+	}
+	return object
+}
+
+// constructor renvoie la signature de new d'une classe : celle de son
+// constructeur, ou de celui dont elle hérite. Ses paramètres de type sont
+// ceux de la classe, déduits des arguments comme pour un appel.
+func (c *checker) constructor(decl *ast.ClassDeclaration) *Signature {
+	scope := c.info.ScopeOf(decl)
+	params := c.typeParamsOf(decl, decl.TypeParameters, scope, nil)
+	fn := &Signature{TypeParams: params, Return: c.generic(decl)}
+	for i := range decl.Methods {
+		method := &decl.Methods[i]
+		if method.Kind == ast.Constructor {
+			fn.Params = c.signature(method).Params
+			return fn
+		}
+	}
+	if decl.SuperClass != "" {
+		if base, ok := c.resolve(decl.SuperClass, scope, nil).(*Object); ok {
+			if parent, ok := base.origin.(*ast.ClassDeclaration); ok {
+				fn.Params = c.constructor(parent).Params
+				return fn
+			}
+		}
+		// Classe de base inconnue : les arguments ne sont pas vérifiés
+		fn.Params = []Param{{Name: "args", Type: &Array{Elem: Any}, Rest: true}}
+	}
+	return fn
+}
+
+// enumObject est la valeur d'une énumération : Color.Red a le type Color
+func enumObject(decl *ast.EnumDeclaration) *Object {
+	object := &Object{Name: "typeof " + decl.Name}
+	for _, member := range decl.Members {
+		object.Fields = append(object.Fields, Property{Name: member.Name, Type: &EnumType{Name: decl.Name}})
+	}
+	return object
+}
+
+// symbolType renvoie le type d'un nom déclaré, calculé à la première
+// lecture. Un nom lu pendant le calcul de son propre type (une fonction
+// récursive sans type de retour) vaut any.
+func (c *checker) symbolType(sym *Symbol) Type {
+	if t, ok := c.symbols[sym]; ok {
+		if t == nil {
+			return Any
+		}
+		return t
+	}
+	c.symbols[sym] = nil
+	t := c.declarationType(sym)
+	c.symbols[sym] = t
+	return t
+}
+
+func (c *checker) declarationType(sym *Symbol) Type {
+	switch decl := sym.Declaration().(type) {
+	case *ast.VariableDeclaration:
+		if t := c.resolve(decl.Type, c.scopes[decl], nil); t != nil {
+			return t
+		}
+		return c.inferred(decl.Value, decl.IsConst)
+	case *ast.Parameter:
+		return c.param(decl, sym.Scope, nil).Type
+	case *ast.ClassField:
+		if t := c.resolve(decl.Type, c.scopes[decl], nil); t != nil {
+			return t
+		}
+		return c.inferred(decl.Default, false)
+	case *ast.FunctionDeclaration:
+		if len(decl.Overloads) > 0 {
+			// Les surcharges ne sont pas départagées
+			return Any
+		}
+		return c.signature(decl)
+	case *ast.ClassMethod:
+		return c.signature(decl)
+	case *ast.ClassDeclaration:
+		return &ClassType{Decl: decl}
+	case *ast.EnumDeclaration:
+		return enumObject(decl)
+	case *ast.NamespaceDeclaration:
+		return c.namespaceObject(decl)
+	}
+	return Any
+}
+
+// namespaceObject est la valeur d'un namespace : Geometry.area a le type de
+// la fonction area qu'il déclare
+func (c *checker) namespaceObject(decl *ast.NamespaceDeclaration) *Object {
+	object := &Object{Name: "typeof " + decl.Name}
+	if scope := c.info.Scopes[decl]; scope != nil {
+		for _, sym := range scope.Symbols {
+			object.Fields = append(object.Fields, Property{Name: sym.Name, Type: c.symbolType(sym)})
+		}
+	}
+	return object
+}
+
+// inferred renvoie le type d'une déclaration sans annotation, déduit de sa
+// valeur : let count = 0 donne number, const mode = 'dark' garde 'dark'
+func (c *checker) inferred(value ast.Expression, isConst bool) Type {
+	if value == nil {
+		return Any
+	}
+	t := c.typeOf(value)
+	if t == Null || t == Undefined {
+		return Any
+	}
+	if literal, ok := t.(*Literal); ok && isConst {
+		return &Literal{Value: literal.Value, Base: literal.Base}
+	}
+	return widen(t)
+}
+
+// param renvoie un paramètre de signature, typé par son annotation ou sa
+// valeur par défaut
+func (c *checker) param(param *ast.Parameter, scope *Scope, extra map[string]Type) Param {
+	p := Param{Name: param.Name, Optional: param.Optional || param.Default != nil, Rest: param.IsRest}
+	p.Type = c.resolve(param.Type, scope, extra)
+	switch {
+	case p.Type != nil:
+	case param.Default != nil:
+		p.Type = c.inferred(param.Default, false)
+	case param.IsRest:
+		p.Type = &Array{Elem: Any}
+	default:
+		p.Type = Any
+	}
+	return p
+}
+
+// signature renvoie le type d'une fonction, d'une méthode ou d'une fonction
+// fléchée. Sans annotation, le retour se déduit des return du corps.
+func (c *checker) signature(node ast.Node) *Signature {
+	if fn, ok := c.signatures[node]; ok {
+		return fn
+	}
+	var list []ast.TypeParameter
+	var params []ast.Parameter
+	var returnType string
+	var isAsync, isGenerator bool
+	var body []ast.Statement
+	var expression ast.Expression
+	switch n := node.(type) {
+	case *ast.FunctionDeclaration:
+		list, params, returnType, isAsync, isGenerator, body = n.TypeParameters, n.Parameters, n.ReturnType, n.IsAsync, n.IsGenerator, n.Body
+	case *ast.ClassMethod:
+		list, params, returnType, isAsync, isGenerator, body = n.TypeParameters, n.Parameters, n.ReturnType, n.IsAsync, n.IsGenerator, n.Body
+	case *ast.ArrowFunction:
+		params, returnType, isAsync, body, expression = n.Parameters, n.ReturnType, n.IsAsync, n.Body, n.ExpressionBody
+	}
+	scope := c.declarationScope(node)
+
+	fn := &Signature{TypeParams: c.typeParamsOf(node, list, scope, nil), Return: Any}
+	c.signatures[node] = fn
+	for i := range params {
+		fn.Params = append(fn.Params, c.param(&params[i], scope, nil))
+	}
+	switch {
+	case returnType != "":
+		fn.Return = c.resolve(returnType, scope, nil)
+	case isGenerator:
+		fn.Return = c.yieldsOf(body)
+	case expression != nil:
+		fn.Return = widen(c.typeOf(expression))
+	case body != nil:
+		fn.Return = c.returnsOf(body)
+	}
+	if isAsync && returnType == "" && !isGenerator {
+		fn.Return = &Reference{Name: "Promise", Args: []Type{fn.Return}}
+	}
+	return fn
+}
+
+// yieldsOf déduit le type de retour d'un générateur : Generator<T> où T est
+// l'union de ses yield, ou any si l'un d'eux ne produit rien ou délègue à un
+// itérable inconnu
+func (c *checker) yieldsOf(body []ast.Statement) Type {
+	var types []Type
+	known := true
+	for _, stmt := range body {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.FunctionDeclaration, *ast.ArrowFunction, *ast.ClassDeclaration, *ast.ClassExpression:
+				return false
+			case *ast.YieldExpression:
+				switch {
+				case n.Argument == nil:
+					known = false
+				case n.Delegate:
+					// yield* d'un autre générateur dont les valeurs sont connues
+					if inner, ok := c.typeOf(n.Argument).(*Reference); ok && inner.Name == "Generator" && len(inner.Args) == 1 {
+						types = append(types, inner.Args[0])
+					} else {
+						known = false
+					}
+				default:
+					types = append(types, widen(c.typeOf(n.Argument)))
+				}
+			}
+			return known
+		})
+	}
+	if !known || len(types) == 0 {
+		return Any
+	}
+	return &Reference{Name: "Generator", Args: []Type{unionOf(types...)}}
+}
+
+// returnsOf déduit le type de retour d'un corps : l'union de ses return,
+// hors fonctions imbriquées, ou void
+func (c *checker) returnsOf(body []ast.Statement) Type {
+	var types []Type
+	for _, stmt := range body {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.FunctionDeclaration, *ast.ArrowFunction, *ast.ClassDeclaration, *ast.ClassExpression:
+				return false
+			case *ast.ReturnStatement:
+				if n.Value != nil {
+					types = append(types, widen(c.typeOf(n.Value)))
+				}
+			}
+			return true
+		})
+	}
+	if len(types) == 0 {
+		return Void
+	}
+	return unionOf(types...)
+}
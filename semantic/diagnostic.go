@@ -0,0 +1,53 @@
+package semantic
+
+import (
+	"ProjetGo/ast"
+	"fmt"
+	"sort"
+)
+
+// Codes des diagnostics : le numéro de l'erreur équivalente de tsc, pour
+// qu'on la retrouve dans sa documentation
+const (
+	NotAssignable    = "TS2322" // valeur affectée, initialisée ou renvoyée
+	ArgumentMismatch = "TS2345" // argument d'un appel
+	ArgumentCount    = "TS2554" // nombre d'arguments d'un appel
+	NotSatisfied     = "TS1360" // expr satisfies T
+
+	CannotFindName             = "TS2304" // nom jamais déclaré
+	UsedBeforeDeclaration      = "TS2448" // let ou const lu dans sa zone morte
+	ClassUsedBeforeDeclaration = "TS2449" // classe lue avant sa déclaration
+	ConstantAssignment         = "TS2588" // const réaffectée
+	RedeclaredBlockScoped      = "TS2451" // let ou const déclaré deux fois
+	DuplicateIdentifier        = "TS2300" // classe ou paramètre en conflit avec un autre nom
+	DuplicateFunction          = "TS2393" // deux corps pour la même fonction
+	ReturnOutsideFunction      = "TS1108" // return hors d'une fonction
+)
+
+// Diagnostic est une erreur trouvée dans le source, située par l'étendue du
+// nœud en cause
+type Diagnostic struct {
+	ast.Span
+	Code    string
+	Message string
+}
+
+// String écrit le diagnostic sous la forme ligne:colonne: code message
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s %s", d.Pos.Line, d.Pos.Column, d.Code, d.Message)
+}
+
+// Diagnose analyse un programme et renvoie tous ses diagnostics, noms et
+// types, dans l'ordre du source
+func Diagnose(program *ast.Program) []Diagnostic {
+	info := Analyze(program)
+	diagnostics := append(CheckNames(program, info), Check(program, info)...)
+	sortDiagnostics(diagnostics)
+	return diagnostics
+}
+
+func sortDiagnostics(diagnostics []Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Pos.Offset < diagnostics[j].Pos.Offset
+	})
+}
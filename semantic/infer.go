@@ -0,0 +1,394 @@
+package semantic
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// Inference donne aux générateurs des langages typés le type des
+// déclarations que TypeScript laisse déduire : let total = a + b a le type de
+// a + b, une fonction sans annotation celui de ses return
+type Inference struct {
+	c         *checker
+	assigned  map[ast.Node][]*ast.AssignmentExpression // affectations d'une variable ou d'un champ après sa déclaration
+	receivers map[ast.Node][]ast.Expression            // affectations de champ et appels de méthode sur une variable
+	declared  map[declarationKey]ast.Node              // variables et champs, pour retrouver l'original d'une copie
+}
+
+// declarationKey identifie une variable ou un champ par sa position : les
+// générateurs en manipulent des copies
+type declarationKey struct {
+	ast.Span
+	name string
+}
+
+// Infer prépare l'inférence d'un programme déjà analysé par Analyze ; les
+// types sont calculés à la demande
+func Infer(program *ast.Program, info *Info) *Inference {
+	in := &Inference{c: newChecker(info), assigned: map[ast.Node][]*ast.AssignmentExpression{}, receivers: map[ast.Node][]ast.Expression{}, declared: map[declarationKey]ast.Node{}}
+	in.c.locate(program)
+	ast.Inspect(program, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.VariableDeclaration:
+			in.declared[declarationKey{n.Span, n.Name}] = n
+		case *ast.ClassField:
+			in.declared[declarationKey{n.Span, n.Name}] = n
+		case *ast.CallExpression:
+			// t.run(), t.items.push(x)
+			if callee, ok := n.Function.(*ast.DotExpression); ok {
+				in.addReceiver(callee.Object, n)
+			}
+		}
+		n, ok := node.(*ast.AssignmentExpression)
+		if !ok {
+			return true
+		}
+		switch target := n.Left.(type) {
+		case *ast.Identifier:
+			if sym := info.SymbolOf(target); sym != nil {
+				in.assigned[sym.Declaration()] = append(in.assigned[sym.Declaration()], n)
+			}
+		case *ast.DotExpression:
+			// this.total = this.total + x
+			if field, ok := in.member(target).(*ast.ClassField); ok {
+				in.assigned[field] = append(in.assigned[field], n)
+			}
+			in.addReceiver(target.Object, n)
+		case *ast.IndexExpression:
+			in.addReceiver(target.Left, n)
+		}
+		return true
+	})
+	return in
+}
+
+// scope renvoie la portée d'un nœud ; un nœud recopié par un générateur
+// (assertion retirée, classe remontée) n'en a pas et lit ses annotations au
+// niveau du module
+func (in *Inference) scope(node ast.Node) *Scope {
+	if scope := in.c.scopes[node]; scope != nil {
+		return scope
+	}
+	return in.c.info.Module
+}
+
+// Declaration renvoie le type d'une variable, d'un paramètre ou d'un champ de
+// classe : son annotation, ou celui de sa valeur
+func (in *Inference) Declaration(node ast.Node) Type {
+	switch n := node.(type) {
+	case *ast.VariableDeclaration:
+		if t := in.c.resolve(n.Type, in.scope(n), nil); t != nil {
+			return t
+		}
+		return in.c.inferred(n.Value, n.IsConst)
+	case *ast.Parameter:
+		return in.c.param(n, in.scope(n), nil).Type
+	case *ast.ClassField:
+		if t := in.c.resolve(n.Type, in.scope(n), nil); t != nil {
+			return t
+		}
+		return in.c.inferred(n.Default, false)
+	}
+	return Any
+}
+
+// Expression renvoie le type d'une expression du programme
+func (in *Inference) Expression(expr ast.Expression) Type {
+	return in.c.typeOf(expr)
+}
+
+// Return renvoie le type de retour d'une fonction, d'une méthode ou d'une
+// fonction fléchée : son annotation, ou l'union de ses return (void sans
+// return). Une fonction async renvoie une Promise.
+func (in *Inference) Return(fn ast.Node) Type {
+	return in.c.signature(fn).Return
+}
+
+// Assigned indique si une variable ou un champ reçoit une affectation après
+// sa déclaration : i++, total = total + x
+func (in *Inference) Assigned(node ast.Node) bool {
+	return len(in.assigned[in.original(node)]) > 0
+}
+
+// Nullable indique si une expression peut valoir null ou undefined : son type
+// l'inclut, ou elle lit un paramètre optionnel x?: T. Une expression de type
+// any ou unknown est supposée pouvoir l'être.
+func (in *Inference) Nullable(expr ast.Expression) bool {
+	if id, ok := expr.(*ast.Identifier); ok {
+		if sym := in.c.info.SymbolOf(id); sym != nil {
+			if param, ok := sym.Declaration().(*ast.Parameter); ok && param.Optional && param.Default == nil {
+				return true
+			}
+		}
+	}
+	switch t := in.c.typeOf(expr).(type) {
+	case *Union:
+		for _, member := range t.Types {
+			if member == Null || member == Undefined {
+				return true
+			}
+		}
+	case *Basic:
+		return t == Any || t == Unknown || t == Null || t == Undefined
+	}
+	return false
+}
+
+// Receivers renvoie les affectations d'un champ d'une variable et les appels
+// de méthode sur elle ou ses champs : t.v = 2, t.run(), t.items.push(x). Un
+// langage qui distingue les emprunts mutables y cherche ce qui la modifie.
+func (in *Inference) Receivers(node ast.Node) []ast.Expression {
+	return in.receivers[in.original(node)]
+}
+
+// addReceiver retient une modification possible de la variable dont part
+// l'objet d'un accès : t, t.items, t.items[0]
+func (in *Inference) addReceiver(object ast.Expression, use ast.Expression) {
+	for {
+		switch o := object.(type) {
+		case *ast.DotExpression:
+			object = o.Object
+			continue
+		case *ast.IndexExpression:
+			object = o.Left
+			continue
+		case *ast.Identifier:
+			if sym := in.c.info.SymbolOf(o); sym != nil {
+				in.receivers[sym.Declaration()] = append(in.receivers[sym.Declaration()], use)
+			}
+		}
+		return
+	}
+}
+
+// Member renvoie le champ ou la méthode d'une classe que lit obj.name, ou nil
+func (in *Inference) Member(de *ast.DotExpression) ast.Node {
+	return in.member(de)
+}
+
+// Integral indique si la valeur numérique d'une expression, d'une
+// déclaration ou des return d'une fonction est forcément entière : 0, i + 1,
+// items.length, ou une variable qui ne reçoit que des entiers. TypeScript ne
+// distingue pas les entiers ; une annotation number, un paramètre, une
+// division ou Math.sqrt(x) peuvent être à virgule. Les éléments d'un tableau
+// le sont aussi : [1, 2] doit pouvoir être passé à un paramètre number[].
+func (in *Inference) Integral(node ast.Node) bool {
+	return in.integral(node, map[ast.Node]bool{})
+}
+
+func (in *Inference) integral(node ast.Node, seen map[ast.Node]bool) bool {
+	if node == nil {
+		return false
+	}
+	node = in.original(node)
+	if seen[node] {
+		// Une valeur qui dépend d'elle-même, sum = sum + 1, est entière si
+		// le reste l'est
+		return true
+	}
+	seen[node] = true
+
+	switch node.(type) {
+	case *ast.VariableDeclaration, *ast.ClassField:
+		// let sum = 0 puis sum = sum + 0.5
+		for _, assignment := range in.assigned[node] {
+			if !in.integral(assignment, seen) {
+				return false
+			}
+		}
+	}
+
+	switch n := node.(type) {
+	case *ast.VariableDeclaration:
+		if n.Type != "" || n.Value == nil {
+			return false
+		}
+		return in.integral(n.Value, seen)
+	case *ast.ClassField:
+		if n.Type != "" || n.Default == nil {
+			return false
+		}
+		return in.integral(n.Default, seen)
+	case *ast.FunctionDeclaration:
+		return n.ReturnType == "" && in.returnsIntegral(n.Body, seen)
+	case *ast.ClassMethod:
+		return n.ReturnType == "" && in.returnsIntegral(n.Body, seen)
+	case *ast.ArrowFunction:
+		if n.ReturnType != "" {
+			return false
+		}
+		if n.ExpressionBody != nil {
+			return in.integral(n.ExpressionBody, seen)
+		}
+		return in.returnsIntegral(n.Body, seen)
+
+	case *ast.NumberLiteral:
+		value := strings.ToLower(n.Value)
+		return strings.HasPrefix(value, "0x") || !strings.ContainsAny(value, ".e")
+	case *ast.Identifier:
+		if sym := in.c.info.SymbolOf(n); sym != nil {
+			switch decl := sym.Declaration().(type) {
+			case *ast.VariableDeclaration, *ast.ClassField:
+				return in.integral(decl, seen)
+			}
+		}
+	case *ast.InfixExpression:
+		switch n.Operator {
+		case "+", "-", "*", "%":
+			return in.integral(n.Left, seen) && in.integral(n.Right, seen)
+		}
+	case *ast.AssignmentExpression:
+		switch n.Operator {
+		case "++", "--":
+			return true
+		case "/=":
+			return false
+		case "=":
+			return in.integral(n.Right, seen)
+		}
+		return in.integral(n.Left, seen) && in.integral(n.Right, seen)
+	case *ast.AwaitExpression:
+		return in.integral(n.Argument, seen)
+	case *ast.AsExpression:
+		return in.integral(n.Expression, seen)
+	case *ast.SatisfiesExpression:
+		return in.integral(n.Expression, seen)
+	case *ast.ParenthesizedExpression:
+		return in.integral(n.Expression, seen)
+	case *ast.NonNullExpression:
+		return in.integral(n.Expression, seen)
+	case *ast.DotExpression:
+		if n.Property == "length" && !isMath(n.Object) {
+			return true
+		}
+		return in.integral(in.member(n), seen)
+	case *ast.CallExpression:
+		// Les fonctions de Math renvoient des nombres à virgule dans les
+		// langages cibles, Math.floor(x) compris
+		switch callee := n.Function.(type) {
+		case *ast.DotExpression:
+			return in.integral(in.member(callee), seen)
+		case *ast.Identifier:
+			if sym := in.c.info.SymbolOf(callee); sym != nil {
+				return in.integral(sym.Declaration(), seen)
+			}
+		}
+	}
+	return false
+}
+
+// original renvoie la variable ou le champ du programme dont un générateur a
+// fait une copie, ou le nœud lui-même
+func (in *Inference) original(node ast.Node) ast.Node {
+	var key declarationKey
+	switch n := node.(type) {
+	case *ast.VariableDeclaration:
+		key = declarationKey{n.Span, n.Name}
+	case *ast.ClassField:
+		key = declarationKey{n.Span, n.Name}
+	default:
+		return node
+	}
+	if original := in.declared[key]; original != nil {
+		return original
+	}
+	return node
+}
+
+// returnsIntegral suit les return d'un corps, hors fonctions imbriquées : il
+// en faut au moins un, et tous entiers
+func (in *Inference) returnsIntegral(body []ast.Statement, seen map[ast.Node]bool) bool {
+	returns, integral := 0, true
+	for _, stmt := range body {
+		ast.Inspect(stmt, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.FunctionDeclaration, *ast.ArrowFunction, *ast.ClassDeclaration, *ast.ClassExpression:
+				return false
+			case *ast.ReturnStatement:
+				returns++
+				if integral && !in.integral(n.Value, seen) {
+					integral = false
+				}
+			}
+			return integral
+		})
+	}
+	return returns > 0 && integral
+}
+
+// Callee renvoie la signature qu'appelle un appel ou un new, ses paramètres
+// de type remplacés d'après les arguments ; nil si elle n'est pas connue
+func (in *Inference) Callee(node ast.Node) *Signature {
+	switch n := node.(type) {
+	case *ast.CallExpression:
+		if fn, ok := in.c.typeOf(n.Function).(*Signature); ok {
+			return in.c.instantiateCall(fn, n.TypeArguments, n.Arguments, in.scope(n))
+		}
+	case *ast.NewExpression:
+		if class, ok := in.c.typeOf(n.Class).(*ClassType); ok {
+			return in.c.instantiateCall(in.c.constructor(class.Decl), n.TypeArguments, n.Arguments, in.scope(n))
+		}
+	}
+	return nil
+}
+
+// member renvoie le champ ou la méthode d'une classe que lit obj.name, ou nil ;
+// obj.#name lit un membre privé, Box.name un membre static
+func (in *Inference) member(de *ast.DotExpression) ast.Node {
+	if this, ok := de.Object.(*ast.Identifier); ok && this.Value == "this" {
+		return in.thisMember(this, de.Property)
+	}
+	switch object := in.c.typeOf(de.Object).(type) {
+	case *Object:
+		if class, ok := object.origin.(*ast.ClassDeclaration); ok {
+			return in.classMember(class, de.Property, false)
+		}
+	case *ClassType:
+		return in.classMember(object.Decl, de.Property, true)
+	}
+	return nil
+}
+
+// thisMember renvoie le champ ou la méthode name de la classe où this
+// apparaît ; dans un bloc static, this désigne la classe et ses membres static
+func (in *Inference) thisMember(this *ast.Identifier, name string) ast.Node {
+	static := false
+	for scope := in.c.scopes[this]; scope != nil; scope = scope.Parent {
+		switch n := scope.Node.(type) {
+		case *ast.StaticBlock:
+			static = true
+		case *ast.ClassDeclaration:
+			return in.classMember(n, name, static)
+		}
+	}
+	return nil
+}
+
+// classMember renvoie le champ ou la méthode name d'une classe, d'instance ou
+// static, déclaré par elle ou hérité
+func (in *Inference) classMember(class *ast.ClassDeclaration, name string, static bool) ast.Node {
+	name = strings.TrimPrefix(name, "#")
+	for i, field := range class.Fields {
+		if field.Name == name && field.IsStatic == static {
+			return &class.Fields[i]
+		}
+	}
+	for i, method := range class.Methods {
+		if method.Name == name && method.IsStatic == static {
+			return &class.Methods[i]
+		}
+	}
+	if class.SuperClass != "" {
+		if base, ok := in.c.resolve(class.SuperClass, in.c.info.ScopeOf(class), nil).(*Object); ok {
+			if decl, ok := base.origin.(*ast.ClassDeclaration); ok && decl != class {
+				return in.classMember(decl, name, static)
+			}
+		}
+	}
+	return nil
+}
+
+func isMath(expr ast.Expression) bool {
+	id, ok := expr.(*ast.Identifier)
+	return ok && id.Value == "Math"
+}
@@ -0,0 +1,110 @@
+package semantic
+
+import (
+	"ProjetGo/ast"
+	"testing"
+)
+
+func TestIntegral(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string // déclare la variable x
+		integral bool
+	}{
+		{name: "littéral entier", source: "let x = 0;", integral: true},
+		{name: "littéral à virgule", source: "let x = 0.5;"},
+		{name: "annotation number", source: "let x: number = 2;"},
+		{name: "somme de paramètres", source: "function f(a: number, b: number) { let x = a + b; }"},
+		{name: "compteur", source: "let x = 0;\nx = x + 1;\nx++;", integral: true},
+		{name: "affectation à virgule", source: "let x = 0;\nx = x / 2;"},
+		{name: "longueur", source: "const items = [1, 2];\nconst x = items.length;", integral: true},
+		{name: "élément de tableau", source: "const items = [1, 2];\nconst x = items[0];"},
+		{name: "retour entier", source: "function next(i: number) { return 1; }\nconst x = next(2);", integral: true},
+		{name: "champ static", source: "class Box { static count = 0; }\nconst x = Box.count;", integral: true},
+		{name: "Math.sqrt", source: "const x = Math.sqrt(4);"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parse(tt.source)
+			var x *ast.VariableDeclaration
+			ast.Inspect(program, func(node ast.Node) bool {
+				if vd, ok := node.(*ast.VariableDeclaration); ok && vd.Name == "x" {
+					x = vd
+				}
+				return true
+			})
+			if x == nil {
+				t.Fatal("x non déclarée")
+			}
+			if got := Infer(program, Analyze(program)).Integral(x); got != tt.integral {
+				t.Errorf("Integral(x) = %v, attendu %v", got, tt.integral)
+			}
+		})
+	}
+}
+
+func TestNullable(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string // x! lit la valeur testée
+		nullable bool
+	}{
+		{name: "union avec undefined", source: "let v: string | undefined = \"a\";\nlet x = v!;", nullable: true},
+		{name: "union avec null", source: "let v: number | null = 1;\nlet x = v!;", nullable: true},
+		{name: "paramètre optionnel", source: "function f(v?: string) { let x = v!; }", nullable: true},
+		{name: "any", source: "let v: any = 1;\nlet x = v!;", nullable: true},
+		{name: "chaîne", source: "let v: string = \"a\";\nlet x = v!;"},
+		{name: "paramètre par défaut", source: "function f(v: number = 1) { let x = v!; }"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parse(tt.source)
+			var asserted ast.Expression
+			ast.Inspect(program, func(node ast.Node) bool {
+				if nn, ok := node.(*ast.NonNullExpression); ok {
+					asserted = nn.Expression
+				}
+				return true
+			})
+			if asserted == nil {
+				t.Fatal("pas d'assertion !")
+			}
+			if got := Infer(program, Analyze(program)).Nullable(asserted); got != tt.nullable {
+				t.Errorf("Nullable = %v, attendu %v", got, tt.nullable)
+			}
+		})
+	}
+}
+
+func TestGeneratorReturn(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string // déclare le générateur gen
+		want   string
+	}{
+		{name: "yield de nombres", source: "function* gen() { yield 1; yield 2; }", want: "Generator<number>"},
+		{name: "yield mêlés", source: "function* gen() { yield 1; yield \"a\"; }", want: "Generator<number | string>"},
+		{name: "annotation", source: "function* gen(): Generator<string> { yield \"a\"; }", want: "Generator<string>"},
+		{name: "délégation", source: "function* inner() { yield 1; }\nfunction* gen() { yield 2; yield* inner(); }", want: "Generator<number>"},
+		{name: "délégation inconnue", source: "function* gen() { yield 1; yield* other(); }", want: "any"},
+		{name: "sans yield", source: "function* gen() { }", want: "any"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parse(tt.source)
+			var gen *ast.FunctionDeclaration
+			ast.Inspect(program, func(node ast.Node) bool {
+				if fd, ok := node.(*ast.FunctionDeclaration); ok && fd.Name == "gen" {
+					gen = fd
+				}
+				return true
+			})
+			if gen == nil {
+				t.Fatal("gen non déclaré")
+			}
+			if got := Infer(program, Analyze(program)).Return(gen).String(); got != tt.want {
+				t.Errorf("Return(gen) = %s, attendu %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package semantic
+
+import "ProjetGo/ast"
+
+// CheckNames vérifie l'usage des noms d'un programme déjà analysé par
+// Analyze : nom jamais déclaré, lecture d'un let, const ou d'une classe avant
+// sa déclaration, affectation d'une constante, nom déclaré deux fois dans une
+// portée, et return hors d'une fonction
+func CheckNames(program *ast.Program, info *Info) []Diagnostic {
+	c := newChecker(info)
+	c.checkScope(info.Module)
+	for _, id := range info.Unresolved {
+		if !globals[id.Value] {
+			c.report(id, CannotFindName, "Nom « %s » introuvable.", id.Value)
+		}
+	}
+	c.checkStatements(program)
+	sortDiagnostics(c.diagnostics)
+	return c.diagnostics
+}
+
+// globals sont les noms que l'environnement fournit sans déclaration
+var globals = map[string]bool{
+	"undefined": true, "null": true, "NaN": true, "Infinity": true, "globalThis": true, "arguments": true,
+	"console": true, "Math": true, "JSON": true, "Reflect": true, "Proxy": true, "Intl": true,
+	"Object": true, "Array": true, "String": true, "Number": true, "Boolean": true, "Symbol": true, "BigInt": true,
+	"Function": true, "Date": true, "RegExp": true, "Promise": true, "Map": true, "Set": true, "WeakMap": true,
+	"WeakSet": true, "WeakRef": true, "ArrayBuffer": true, "DataView": true, "Uint8Array": true, "Int32Array": true,
+	"Float64Array": true, "Error": true, "TypeError": true, "RangeError": true, "SyntaxError": true,
+	"ReferenceError": true, "AggregateError": true,
+	"parseInt": true, "parseFloat": true, "isNaN": true, "isFinite": true, "encodeURIComponent": true,
+	"decodeURIComponent": true, "encodeURI": true, "decodeURI": true, "structuredClone": true,
+	"setTimeout": true, "clearTimeout": true, "setInterval": true, "clearInterval": true, "queueMicrotask": true,
+	"fetch": true, "window": true, "document": true, "navigator": true, "localStorage": true, "alert": true,
+	"require": true, "module": true, "exports": true, "process": true, "__dirname": true, "__filename": true,
+	"React": true,
+}
+
+// checkScope cherche les déclarations en double et les lectures trop tôt
+// dans une portée et celles qu'elle contient. Les membres d'une classe ne
+// sont pas concernés : un getter et son setter portent le même nom.
+func (c *checker) checkScope(scope *Scope) {
+	if scope.Kind != ClassScope {
+		for _, sym := range scope.Symbols {
+			c.checkDuplicates(sym)
+			if sym.Kind.IsBlockScoped() {
+				c.checkDeadZone(sym)
+			}
+		}
+	}
+	for _, child := range scope.Children {
+		c.checkScope(child)
+	}
+}
+
+// checkDuplicates signale les déclarations d'un nom qui ne peuvent pas
+// cohabiter : un let, un const ou une classe avec toute autre valeur du même
+// nom, ou deux corps de la même fonction. var x; var x;, une interface et sa
+// classe, ou les surcharges d'une fonction restent permis.
+func (c *checker) checkDuplicates(sym *Symbol) {
+	var values, implementations []ast.Node
+	blockScoped := false
+	for _, decl := range sym.Declarations {
+		switch d := decl.(type) {
+		case *ast.VariableDeclaration:
+			blockScoped = blockScoped || !d.IsVar
+		case *ast.ClassDeclaration:
+			blockScoped = true
+		case *ast.FunctionDeclaration:
+			if d.Body == nil {
+				// declare function : une signature sans corps
+				continue
+			}
+			implementations = append(implementations, d)
+		case *ast.Parameter:
+		default:
+			continue
+		}
+		values = append(values, decl)
+	}
+
+	if !blockScoped {
+		for i := 1; i < len(implementations); i++ {
+			c.report(implementations[i], DuplicateFunction, "Implémentation de fonction en double.")
+		}
+		return
+	}
+	for i := 1; i < len(values); i++ {
+		if vd, ok := values[i].(*ast.VariableDeclaration); ok && !vd.IsVar {
+			c.report(vd, RedeclaredBlockScoped, "Impossible de redéclarer la variable de portée bloc « %s ».", sym.Name)
+		} else {
+			c.report(values[i], DuplicateIdentifier, "Identificateur « %s » en double.", sym.Name)
+		}
+	}
+}
+
+// checkDeadZone signale la lecture d'un let, d'un const ou d'une classe
+// avant la fin de sa déclaration (zone morte temporelle). Une fonction ou une
+// classe qui le lit plus haut ne s'exécute qu'ensuite : elle n'est pas
+// signalée.
+func (c *checker) checkDeadZone(sym *Symbol) {
+	decl := ast.Position(sym.Declaration())
+	if !decl.Pos.IsValid() {
+		return
+	}
+	for _, ref := range sym.References {
+		if ref.Pos.Offset >= decl.End.Offset || c.deferred(ref, sym.Scope) {
+			continue
+		}
+		if sym.Kind == Class {
+			c.report(ref, ClassUsedBeforeDeclaration, "Classe « %s » utilisée avant sa déclaration.", sym.Name)
+		} else {
+			c.report(ref, UsedBeforeDeclaration, "Variable de portée bloc « %s » utilisée avant sa déclaration.", sym.Name)
+		}
+	}
+}
+
+// deferred indique si un identifiant se trouve dans une fonction ou une
+// classe imbriquée dans la portée de sa déclaration : il n'est lu qu'à son
+// appel
+func (c *checker) deferred(ref *ast.Identifier, declared *Scope) bool {
+	for scope := c.info.ScopeAt(ref); scope != nil && scope != declared; scope = scope.Parent {
+		if scope.Kind == FunctionScope || scope.Kind == ClassScope {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStatements signale les affectations de constantes et les return hors
+// d'une fonction
+func (c *checker) checkStatements(program *ast.Program) {
+	var stack []ast.Node
+	functions := 0
+	ast.Inspect(program, func(node ast.Node) bool {
+		if node == nil {
+			if isFunction(stack[len(stack)-1]) {
+				functions--
+			}
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		stack = append(stack, node)
+		if isFunction(node) {
+			functions++
+		}
+
+		switch n := node.(type) {
+		case *ast.ReturnStatement:
+			if functions == 0 {
+				c.report(n, ReturnOutsideFunction, "Une instruction return ne peut être utilisée que dans le corps d'une fonction.")
+			}
+		case *ast.AssignmentExpression:
+			// x = 1, x += 1 et x++
+			if id, ok := n.Left.(*ast.Identifier); ok {
+				if sym := c.info.SymbolOf(id); sym != nil && sym.Kind == Const {
+					c.report(id, ConstantAssignment, "Impossible d'affecter à « %s », car il s'agit d'une constante.", id.Value)
+				}
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,17 @@
+package semantic
+
+import "testing"
+
+func TestCheckNames(t *testing.T) {
+	runDiagnosticCases(t, []diagnosticCase{
+		{name: "nom introuvable", source: "console.log(missing);", code: CannotFindName, line: 1, column: 13},
+		{name: "zone morte", source: "console.log(early);\nlet early = 1;", code: UsedBeforeDeclaration, line: 1, column: 13},
+		{name: "classe avant sa déclaration", source: "new Later();\nclass Later {}", code: ClassUsedBeforeDeclaration, line: 1, column: 5},
+		{name: "const réaffectée", source: "const k = 1;\nk = 2;", code: ConstantAssignment, line: 2, column: 1},
+		{name: "let redéclaré", source: "let dup = 1;\nlet dup = 2;", code: RedeclaredBlockScoped, line: 2, column: 1},
+		{name: "classe et variable", source: "class A {}\nvar A = 1;", code: DuplicateIdentifier, line: 2, column: 1},
+		{name: "fonction en double", source: "function g() {}\nfunction g() {}", code: DuplicateFunction, line: 2, column: 1},
+		{name: "return hors d'une fonction", source: "return 5;", code: ReturnOutsideFunction, line: 1, column: 1},
+		{name: "noms corrects", source: "var v = 1;\nvar v = 2;\nfunction f(a: number) {\n  let b = a + v;\n  return b;\n}\nif (f(1) > 0) {\n  let b = 2;\n  console.log(b);\n}"},
+	})
+}
@@ -0,0 +1,274 @@
+package semantic
+
+import "ProjetGo/ast"
+
+// Info est le résultat de l'analyse d'un programme
+type Info struct {
+	Module     *Scope                      // portée du fichier, racine des autres
+	Scopes     map[ast.Node]*Scope         // portée ouverte par chaque fonction, bloc, classe, namespace...
+	Uses       map[*ast.Identifier]*Symbol // symbole désigné par chaque identifiant résolu
+	Unresolved []*ast.Identifier           // identifiants sans déclaration dans le fichier : console, Math ou nom inconnu
+
+	enclosing map[*ast.Identifier]*Scope
+}
+
+// Analyze construit les portées d'un programme et résout ses identifiants
+func Analyze(program *ast.Program) *Info {
+	info := &Info{
+		Scopes:    map[ast.Node]*Scope{},
+		Uses:      map[*ast.Identifier]*Symbol{},
+		enclosing: map[*ast.Identifier]*Scope{},
+	}
+	r := (&resolver{info: info}).open(ModuleScope, program)
+	info.Module = r.scope
+	r.declare(program.Statements)
+	ast.Walk(r, program)
+	return info
+}
+
+// SymbolOf renvoie le symbole désigné par un identifiant, ou nil pour un nom
+// qui n'est pas déclaré dans le fichier
+func (info *Info) SymbolOf(id *ast.Identifier) *Symbol {
+	return info.Uses[id]
+}
+
+// ScopeOf renvoie la portée ouverte par un nœud, ou nil
+func (info *Info) ScopeOf(node ast.Node) *Scope {
+	return info.Scopes[node]
+}
+
+// ScopeAt renvoie la portée où apparaît un identifiant
+func (info *Info) ScopeAt(id *ast.Identifier) *Scope {
+	return info.enclosing[id]
+}
+
+// IsLocal indique si un identifiant désigne une déclaration propre à une
+// fonction (paramètre, variable locale...) plutôt qu'au module
+func (info *Info) IsLocal(id *ast.Identifier) bool {
+	sym := info.Uses[id]
+	return sym != nil && sym.Scope.Function().Kind == FunctionScope
+}
+
+// resolver parcourt l'arbre avec ast.Walk ; chaque nœud qui ouvre une portée
+// renvoie un resolver pour cette portée, qui visite ses enfants
+type resolver struct {
+	info  *Info
+	scope *Scope
+}
+
+func (r *resolver) open(kind ScopeKind, node ast.Node) *resolver {
+	scope := newScope(kind, node, r.scope)
+	if node != nil {
+		r.info.Scopes[node] = scope
+	}
+	return &resolver{info: r.info, scope: scope}
+}
+
+func (r *resolver) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *ast.Identifier:
+		r.resolve(n)
+		return nil
+	case *ast.FunctionDeclaration:
+		if n.Body == nil {
+			// Signature de surcharge ou declare function : rien à résoudre
+			return nil
+		}
+		return r.function(n, n.Parameters, n.Body)
+	case *ast.ClassMethod:
+		return r.function(n, n.Parameters, n.Body)
+	case *ast.ArrowFunction:
+		return r.function(n, n.Parameters, n.Body)
+	case *ast.InterfaceField:
+		// Signature de méthode : ses paramètres ne sont que des types
+		return nil
+	case *ast.BlockStatement:
+		block := r.open(BlockScope, n)
+		block.declare(n.Statements)
+		return block
+	case *ast.ForStatement:
+		// for (let i = 0; ...) : i appartient à la boucle
+		loop := r.open(BlockScope, n)
+		loop.declare([]ast.Statement{n.Init})
+		return loop
+	case *ast.NamespaceDeclaration:
+		if isGlobalAugmentation(n) {
+			// Ses déclarations sont déjà dans la portée du module
+			return &resolver{info: r.info, scope: r.info.Module}
+		}
+		namespace := r.open(NamespaceScope, n)
+		namespace.declare(n.Body)
+		return namespace
+	case *ast.ClassDeclaration:
+		r.class(n, false)
+		return nil
+	case *ast.ClassExpression:
+		if n.Class != nil {
+			r.class(n.Class, true)
+		}
+		return nil
+	}
+	return r
+}
+
+func (r *resolver) resolve(id *ast.Identifier) {
+	if id.Value == "this" || id.Value == "super" {
+		return
+	}
+	r.info.enclosing[id] = r.scope
+	if sym := r.scope.Lookup(id.Value); sym != nil {
+		r.info.Uses[id] = sym
+		sym.References = append(sym.References, id)
+		return
+	}
+	r.info.Unresolved = append(r.info.Unresolved, id)
+}
+
+// function ouvre la portée d'une fonction, de ses paramètres et de son corps
+func (r *resolver) function(node ast.Node, params []ast.Parameter, body []ast.Statement) *resolver {
+	fn := r.open(FunctionScope, node)
+	for i := range params {
+		fn.scope.declare(params[i].Name, Parameter, &params[i])
+	}
+	fn.declare(body)
+	return fn
+}
+
+// class résout une classe : décorateurs dans la portée englobante, membres
+// dans celle de la classe. Le nom d'une expression de classe n'est visible
+// qu'à l'intérieur.
+func (r *resolver) class(class *ast.ClassDeclaration, isExpression bool) {
+	for i := range class.Decorators {
+		ast.Walk(r, &class.Decorators[i])
+	}
+
+	inner := r.open(ClassScope, class)
+	if isExpression {
+		inner.scope.declare(class.Name, Class, class)
+	}
+	for i := range class.Fields {
+		inner.scope.declare(class.Fields[i].Name, Field, &class.Fields[i])
+	}
+	for i := range class.Methods {
+		method := &class.Methods[i]
+		inner.scope.declare(method.Name, Method, method)
+		if method.Kind == ast.Constructor {
+			for j := range method.Parameters {
+				if method.Parameters[j].IsProperty {
+					inner.scope.declare(method.Parameters[j].Name, Field, &method.Parameters[j])
+				}
+			}
+		}
+	}
+
+	for i := range class.Fields {
+		ast.Walk(inner, &class.Fields[i])
+	}
+	for i := range class.Methods {
+		ast.Walk(inner, &class.Methods[i])
+	}
+	for i := range class.StaticBlocks {
+		// Un bloc static a ses propres var, comme une fonction
+		statements := class.StaticBlocks[i].Body
+		block := inner.open(FunctionScope, &class.StaticBlocks[i])
+		block.declare(statements)
+		for _, stmt := range statements {
+			if stmt != nil {
+				ast.Walk(block, stmt)
+			}
+		}
+	}
+}
+
+// declare enregistre les déclarations d'une liste d'instructions avant de la
+// parcourir : une fonction se lit plus haut que sa déclaration, et un let
+// masque dès le début du bloc le nom qu'il déclare. Les var des blocs
+// imbriqués remontent dans la fonction.
+func (r *resolver) declare(statements []ast.Statement) {
+	for _, stmt := range statements {
+		r.declareStatement(stmt)
+	}
+	if r.scope.Kind != BlockScope {
+		r.hoistVars(statements)
+	}
+}
+
+func (r *resolver) declareStatement(stmt ast.Statement) {
+	scope := r.scope
+	switch s := stmt.(type) {
+	case *ast.ExportDeclaration:
+		if s.Declaration != nil {
+			r.declareStatement(s.Declaration)
+		}
+	case *ast.AmbientDeclaration:
+		r.declareStatement(s.Declaration)
+	case *ast.VariableDeclaration:
+		switch {
+		case s.IsConst:
+			scope.declare(s.Name, Const, s)
+		case !s.IsVar:
+			scope.declare(s.Name, Let, s)
+		}
+	case *ast.FunctionDeclaration:
+		scope.declare(s.Name, Function, s)
+	case *ast.ClassDeclaration:
+		scope.declare(s.Name, Class, s)
+	case *ast.EnumDeclaration:
+		scope.declare(s.Name, Enum, s)
+	case *ast.Interface:
+		scope.declare(s.Name, Interface, s)
+	case *ast.TypeAlias:
+		scope.declare(s.Name, TypeAlias, s)
+	case *ast.NamespaceDeclaration:
+		switch {
+		case isGlobalAugmentation(s):
+			(&resolver{info: r.info, scope: r.info.Module}).declare(s.Body)
+		case !s.IsModule:
+			scope.declare(s.Name, Namespace, s)
+		}
+	case *ast.ImportDeclaration:
+		scope.declare(s.Default, Import, s)
+		scope.declare(s.Namespace, Import, s)
+		for i := range s.Specifiers {
+			spec := &s.Specifiers[i]
+			if spec.Alias != "" {
+				scope.declare(spec.Alias, Import, spec)
+			} else {
+				scope.declare(spec.Name, Import, spec)
+			}
+		}
+	}
+}
+
+// hoistVars déclare dans la portée courante, une fonction ou le module, les
+// var de ses instructions et de leurs blocs, sans entrer dans les fonctions
+func (r *resolver) hoistVars(statements []ast.Statement) {
+	for _, stmt := range statements {
+		switch s := stmt.(type) {
+		case *ast.VariableDeclaration:
+			if s.IsVar {
+				r.scope.declare(s.Name, Var, s)
+			}
+		case *ast.ExportDeclaration:
+			r.hoistVars([]ast.Statement{s.Declaration})
+		case *ast.AmbientDeclaration:
+			r.hoistVars([]ast.Statement{s.Declaration})
+		case *ast.BlockStatement:
+			r.hoistVars(s.Statements)
+		case *ast.IfStatement:
+			r.hoistVars([]ast.Statement{s.ThenBranch, s.ElseBranch})
+		case *ast.ForStatement:
+			r.hoistVars([]ast.Statement{s.Init, s.Body})
+		case *ast.WhileStatement:
+			r.hoistVars([]ast.Statement{s.Body})
+		}
+	}
+}
+
+// isGlobalAugmentation reconnaît declare global { ... }, qui complète la
+// portée du module
+func isGlobalAugmentation(ns *ast.NamespaceDeclaration) bool {
+	return ns.Name == "global" && !ns.IsModule
+}
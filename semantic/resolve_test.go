@@ -0,0 +1,90 @@
+package semantic
+
+import (
+	"ProjetGo/ast"
+	"ProjetGo/lexer"
+	"ProjetGo/parser"
+	"testing"
+)
+
+func parse(source string) *ast.Program {
+	return parser.New(lexer.New(source)).ParseProgram()
+}
+
+// uses renvoie, dans l'ordre du source, les identifiants qui lisent name
+func uses(program *ast.Program, name string) []*ast.Identifier {
+	var ids []*ast.Identifier
+	ast.Inspect(program, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Identifier); ok && id.Value == name {
+			ids = append(ids, id)
+		}
+		return true
+	})
+	return ids
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		use    string // nom lu
+		nth    int    // lecture visée, dans l'ordre du source
+		kind   SymbolKind
+		scope  ScopeKind // portée de la déclaration
+		absent bool      // le nom n'est pas déclaré dans le fichier
+	}{
+		{name: "var hissée hors du bloc", source: "function f() { if (ok) { var x = 1; } return x; }", use: "x", kind: Var, scope: FunctionScope},
+		{name: "let du bloc", source: "let x = 1; if (ok) { let x = 2; log(x); } log(x);", use: "x", kind: Let, scope: BlockScope},
+		{name: "let du module", source: "let x = 1; if (ok) { let x = 2; log(x); } log(x);", use: "x", nth: 1, kind: Let, scope: ModuleScope},
+		{name: "fonction hissée", source: "g(); function g() {}", use: "g", kind: Function, scope: ModuleScope},
+		{name: "paramètre", source: "function f(p: number) { return p; }", use: "p", kind: Parameter, scope: FunctionScope},
+		{name: "paramètre de fonction fléchée", source: "const f = (p: number) => p * 2;", use: "p", kind: Parameter, scope: FunctionScope},
+		{name: "import", source: "import { readFile } from \"fs\"; readFile(\"a\");", use: "readFile", kind: Import, scope: ModuleScope},
+		{name: "classe", source: "class A {} const a = new A();", use: "A", kind: Class, scope: ModuleScope},
+		{name: "const d'une boucle", source: "for (let i = 0; i < 3; i++) { const j = i; log(j); }", use: "j", kind: Const, scope: BlockScope},
+		{name: "membre lu sans this", source: "class A { x: number = 1; m() { return x; } }", use: "x", absent: true},
+		{name: "global", source: "console.log(1);", use: "console", absent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program := parse(tt.source)
+			info := Analyze(program)
+			ids := uses(program, tt.use)
+			if len(ids) <= tt.nth {
+				t.Fatalf("%d lectures de %s", len(ids), tt.use)
+			}
+			sym := info.SymbolOf(ids[tt.nth])
+			if tt.absent {
+				if sym != nil {
+					t.Errorf("%s résolu en %s, attendu non déclaré", tt.use, sym.Kind)
+				}
+				return
+			}
+			if sym == nil {
+				t.Fatalf("%s non résolu", tt.use)
+			}
+			if sym.Kind != tt.kind || sym.Scope.Kind != tt.scope {
+				t.Errorf("%s résolu en %s de portée %s, attendu %s de portée %s", tt.use, sym.Kind, sym.Scope.Kind, tt.kind, tt.scope)
+			}
+		})
+	}
+}
+
+func TestShadowing(t *testing.T) {
+	program := parse("let x = 1; function f() { let x = 2; return x; } log(x);")
+	info := Analyze(program)
+	ids := uses(program, "x")
+	inner, outer := info.SymbolOf(ids[0]), info.SymbolOf(ids[1])
+	if inner == nil || outer == nil || inner == outer {
+		t.Fatalf("x résolu en %v et %v", inner, outer)
+	}
+	if inner.Shadows() != outer {
+		t.Errorf("le x de f ne masque pas celui du module")
+	}
+	if !info.IsLocal(ids[0]) || info.IsLocal(ids[1]) {
+		t.Errorf("IsLocal : %v pour le x de f, %v pour celui du module", info.IsLocal(ids[0]), info.IsLocal(ids[1]))
+	}
+	if len(outer.References) != 1 || outer.References[0] != ids[1] {
+		t.Errorf("références du x du module : %v", outer.References)
+	}
+}
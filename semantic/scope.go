@@ -0,0 +1,137 @@
+// Package semantic analyse la portée des noms d'un programme : il construit
+// les portées lexicales, y enregistre les déclarations et relie chaque
+// identifiant au symbole qu'il désigne.
+package semantic
+
+import "ProjetGo/ast"
+
+// ScopeKind est la sorte d'une portée
+type ScopeKind int
+
+const (
+	ModuleScope    ScopeKind = iota // le fichier
+	NamespaceScope                  // namespace Geometry { ... }
+	FunctionScope                   // fonction, méthode, fonction fléchée ou bloc static
+	BlockScope                      // { ... } et for (let ...)
+	ClassScope                      // membres d'une classe
+)
+
+var scopeKindNames = []string{"module", "namespace", "function", "block", "class"}
+
+func (k ScopeKind) String() string { return scopeKindNames[k] }
+
+// SymbolKind est la sorte d'une déclaration
+type SymbolKind int
+
+const (
+	Var SymbolKind = iota
+	Let
+	Const
+	Function
+	Parameter
+	Class
+	Enum
+	Interface
+	TypeAlias
+	Namespace
+	Import
+	Field  // champ de classe, ou propriété de paramètre constructor(private x)
+	Method // méthode, accesseur ou constructeur
+)
+
+var symbolKindNames = []string{
+	"var", "let", "const", "function", "parameter", "class", "enum",
+	"interface", "type", "namespace", "import", "field", "method",
+}
+
+func (k SymbolKind) String() string { return symbolKindNames[k] }
+
+// IsMember indique un membre de classe : il ne se lit que par this
+func (k SymbolKind) IsMember() bool { return k == Field || k == Method }
+
+// IsBlockScoped indique une déclaration qu'on ne peut pas lire avant sa
+// ligne (zone morte temporelle) ni redéclarer dans la même portée
+func (k SymbolKind) IsBlockScoped() bool { return k == Let || k == Const || k == Class }
+
+// Symbol est un nom déclaré dans une portée
+type Symbol struct {
+	Name  string
+	Kind  SymbolKind
+	Scope *Scope
+	// Declarations garde chaque nœud qui déclare le nom, dans l'ordre du
+	// source : var x; var x; en a deux, comme un getter et son setter
+	Declarations []ast.Node
+	References   []*ast.Identifier
+}
+
+// Declaration renvoie la première déclaration du symbole
+func (s *Symbol) Declaration() ast.Node { return s.Declarations[0] }
+
+// Shadows renvoie le symbole d'une portée englobante que celui-ci masque,
+// ou nil
+func (s *Symbol) Shadows() *Symbol {
+	if s.Kind.IsMember() || s.Scope.Parent == nil {
+		return nil
+	}
+	return s.Scope.Parent.Lookup(s.Name)
+}
+
+// Scope est une portée lexicale
+type Scope struct {
+	Kind     ScopeKind
+	Node     ast.Node // nœud qui ouvre la portée
+	Parent   *Scope
+	Children []*Scope
+	Symbols  []*Symbol // dans l'ordre de déclaration
+	names    map[string]*Symbol
+}
+
+func newScope(kind ScopeKind, node ast.Node, parent *Scope) *Scope {
+	scope := &Scope{Kind: kind, Node: node, Parent: parent, names: map[string]*Symbol{}}
+	if parent != nil {
+		parent.Children = append(parent.Children, scope)
+	}
+	return scope
+}
+
+// LookupLocal cherche un nom déclaré dans cette portée seulement
+func (s *Scope) LookupLocal(name string) *Symbol {
+	return s.names[name]
+}
+
+// Lookup cherche un nom dans la portée puis dans les portées englobantes. Les
+// membres de classe n'y sont pas visibles : ils ne se lisent que par this.
+func (s *Scope) Lookup(name string) *Symbol {
+	for scope := s; scope != nil; scope = scope.Parent {
+		if sym := scope.names[name]; sym != nil && !sym.Kind.IsMember() {
+			return sym
+		}
+	}
+	return nil
+}
+
+// Function renvoie la fonction, le namespace ou le module qui contient la
+// portée : là où les var sont hissées
+func (s *Scope) Function() *Scope {
+	scope := s
+	for scope.Kind == BlockScope || scope.Kind == ClassScope {
+		scope = scope.Parent
+	}
+	return scope
+}
+
+// declare ajoute une déclaration ; un nom déjà déclaré dans la portée
+// garde son symbole, qui reçoit la nouvelle déclaration
+func (s *Scope) declare(name string, kind SymbolKind, node ast.Node) *Symbol {
+	if name == "" {
+		return nil
+	}
+	if sym := s.names[name]; sym != nil {
+		sym.Declarations = append(sym.Declarations, node)
+		return sym
+	}
+	sym := &Symbol{Name: name, Kind: kind, Scope: s, Declarations: []ast.Node{node}}
+	s.names[name] = sym
+	s.Symbols = append(s.Symbols, sym)
+	return sym
+}
@@ -0,0 +1,428 @@
+package semantic
+
+import (
+	"ProjetGo/ast"
+	"strconv"
+	"strings"
+)
+
+// typeOf renvoie le type d'une expression, calculé une fois. Ce qui échappe à
+// l'analyse (appel d'une fonction inconnue, élément JSX...) vaut any.
+func (c *checker) typeOf(expr ast.Expression) Type {
+	if expr == nil {
+		return Any
+	}
+	if t, ok := c.expressions[expr]; ok {
+		return t
+	}
+	t := c.expressionType(expr)
+	c.expressions[expr] = t
+	return t
+}
+
+func (c *checker) expressionType(expr ast.Expression) Type {
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		return c.identifierType(e)
+	case *ast.StringLiteral:
+		return &Literal{Value: e.Value, Base: String, Fresh: true}
+	case *ast.NumberLiteral:
+		return &Literal{Value: numberValue(e.Value), Base: Number, Fresh: true}
+	case *ast.BooleanLiteral:
+		return &Literal{Value: strconv.FormatBool(e.Value), Base: Boolean, Fresh: true}
+	case *ast.TemplateLiteral:
+		return String
+	case *ast.ArrayLiteral:
+		if len(e.Elements) == 0 {
+			return &Array{Elem: Any}
+		}
+		elements := make([]Type, len(e.Elements))
+		for i, element := range e.Elements {
+			elements[i] = c.typeOf(element)
+			if spread, ok := element.(*ast.SpreadElement); ok {
+				elements[i] = elementOf(c.typeOf(spread.Argument))
+			}
+		}
+		return &Array{Elem: unionOf(elements...)}
+	case *ast.ObjectLiteral:
+		object := &Object{}
+		for _, property := range e.Properties {
+			if property.Key == "" {
+				// { ...base } : l'objet reçoit les propriétés étalées
+				if base, ok := c.typeOf(property.Value).(*Object); ok {
+					for _, field := range base.Fields {
+						c.setProperty(object, field)
+					}
+				}
+				continue
+			}
+			c.setProperty(object, Property{Name: property.Key, Type: c.typeOf(property.Value)})
+		}
+		return object
+	case *ast.InfixExpression:
+		return c.infixType(e)
+	case *ast.CallExpression:
+		fn, ok := c.typeOf(e.Function).(*Signature)
+		if !ok {
+			return Any
+		}
+		return c.instantiateCall(fn, e.TypeArguments, e.Arguments, c.scopes[e]).Return
+	case *ast.NewExpression:
+		switch class := c.typeOf(e.Class).(type) {
+		case *ClassType:
+			return c.instantiateCall(c.constructor(class.Decl), e.TypeArguments, e.Arguments, c.scopes[e]).Return
+		default:
+			// new Map<string, number>() : type que le fichier ne déclare pas
+			if id, ok := e.Class.(*ast.Identifier); ok && c.info.SymbolOf(id) == nil {
+				return c.named(id.Value, c.typeArguments(e.TypeArguments, c.scopes[e]), nil, nil)
+			}
+		}
+		return Any
+	case *ast.IndexExpression:
+		switch left := c.typeOf(e.Left).(type) {
+		case *Array:
+			return left.Elem
+		case *Basic:
+			if left == String {
+				return String
+			}
+		}
+		return Any
+	case *ast.DotExpression:
+		if t := c.property(c.typeOf(e.Object), e.Property); t != nil {
+			return t
+		}
+		return Any
+	case *ast.AssignmentExpression:
+		if e.Right == nil {
+			// x++, x--
+			return Number
+		}
+		return c.typeOf(e.Right)
+	case *ast.SpreadElement:
+		return c.typeOf(e.Argument)
+	case *ast.AwaitExpression:
+		t := c.typeOf(e.Argument)
+		if promise, ok := t.(*Reference); ok && promise.Name == "Promise" && len(promise.Args) == 1 {
+			return promise.Args[0]
+		}
+		return t
+	case *ast.AsExpression:
+		if e.Type == "const" {
+			return c.typeOf(e.Expression)
+		}
+		return c.resolve(e.Type, c.scopes[e], nil)
+	case *ast.SatisfiesExpression:
+		return c.typeOf(e.Expression)
+	case *ast.ParenthesizedExpression:
+		return c.typeOf(e.Expression)
+	case *ast.NonNullExpression:
+		return withoutNullish(c.typeOf(e.Expression))
+	case *ast.ArrowFunction:
+		return c.signature(e)
+	case *ast.ClassExpression:
+		if e.Class != nil {
+			return &ClassType{Decl: e.Class}
+		}
+	}
+	return Any
+}
+
+// setProperty ajoute une propriété à un objet littéral, ou remplace celle du même
+// nom : { ...base, id: 2 }
+func (c *checker) setProperty(object *Object, field Property) {
+	if existing := object.Field(field.Name); existing != nil {
+		*existing = field
+		return
+	}
+	object.Fields = append(object.Fields, field)
+}
+
+func (c *checker) typeArguments(texts []string, scope *Scope) []Type {
+	var args []Type
+	for _, text := range texts {
+		args = append(args, c.resolve(text, scope, nil))
+	}
+	return args
+}
+
+func (c *checker) identifierType(id *ast.Identifier) Type {
+	if id.Value == "this" {
+		return c.thisType(id)
+	}
+	if sym := c.info.SymbolOf(id); sym != nil {
+		return c.symbolType(sym)
+	}
+	switch id.Value {
+	case "undefined":
+		return Undefined
+	case "null":
+		return Null
+	case "NaN", "Infinity":
+		return Number
+	case "Math":
+		return mathObject
+	}
+	return Any
+}
+
+// mathObject est l'objet global Math : ses constantes et ses fonctions
+// donnent des nombres
+var mathObject = func() *Object {
+	object := &Object{Name: "Math"}
+	for _, name := range []string{"PI", "E", "LN2", "LN10", "LOG2E", "LOG10E", "SQRT2", "SQRT1_2"} {
+		object.Fields = append(object.Fields, Property{Name: name, Type: Number})
+	}
+	for _, name := range []string{"abs", "floor", "ceil", "round", "trunc", "sign", "sqrt", "cbrt", "pow", "exp",
+		"log", "log2", "log10", "sin", "cos", "tan", "asin", "acos", "atan", "atan2", "min", "max", "random", "hypot"} {
+		object.Fields = append(object.Fields, Property{Name: name, Type: method(Number)})
+	}
+	return object
+}()
+
+// thisType renvoie le type de this : l'instance de la classe qui contient la
+// méthode ou le champ. Une fonction fléchée garde le this qui l'entoure ; une
+// fonction ordinaire ou un membre static ne sont pas suivis.
+func (c *checker) thisType(id *ast.Identifier) Type {
+	for scope := c.scopes[id]; scope != nil; scope = scope.Parent {
+		switch n := scope.Node.(type) {
+		case *ast.ClassDeclaration:
+			return c.generic(n)
+		case *ast.ClassMethod:
+			if n.IsStatic {
+				return Any
+			}
+		case *ast.StaticBlock:
+			// static { ... } : this est la classe elle-même
+			if class, ok := scope.Parent.Node.(*ast.ClassDeclaration); ok {
+				return &ClassType{Decl: class}
+			}
+			return Any
+		case *ast.FunctionDeclaration, nil:
+			return Any
+		}
+	}
+	return Any
+}
+
+// infixType type une opération binaire d'après ses deux opérandes
+func (c *checker) infixType(infix *ast.InfixExpression) Type {
+	return binaryType(infix.Operator, c.typeOf(infix.Left), c.typeOf(infix.Right))
+}
+
+func binaryType(operator string, left, right Type) Type {
+	switch operator {
+	case "+":
+		switch {
+		case left == Any || right == Any:
+			return Any
+		case isStringLike(left) || isStringLike(right):
+			return String
+		case isNumberLike(left) && isNumberLike(right):
+			return Number
+		}
+		return Any
+	case "-", "*", "/", "%", "**", "|", "^", "&", "<<", ">>", ">>>":
+		return Number
+	case "==", "!=", "===", "!==", "<", ">", "<=", ">=", "instanceof", "in":
+		return Boolean
+	case "&&":
+		return right
+	case "||", "??":
+		return unionOf(withoutNullish(left), right)
+	}
+	return Any
+}
+
+func isStringLike(t Type) bool {
+	literal, ok := t.(*Literal)
+	return t == String || ok && literal.Base == String
+}
+
+func isNumberLike(t Type) bool {
+	switch t := t.(type) {
+	case *Literal:
+		return t.Base == Number
+	case *EnumType:
+		return true
+	}
+	return t == Number
+}
+
+// elementOf renvoie le type des éléments d'un tableau, any pour le reste
+func elementOf(t Type) Type {
+	if array, ok := t.(*Array); ok {
+		return array.Elem
+	}
+	return Any
+}
+
+// property renvoie le type d'une propriété, ou nil si le type n'en a pas de
+// ce nom. Les types que l'analyse ne décrit pas ont toutes les propriétés.
+func (c *checker) property(t Type, name string) Type {
+	switch t := t.(type) {
+	case *Object:
+		if field := t.Field(name); field != nil {
+			return field.Type
+		}
+		if class, ok := t.origin.(*ast.ClassDeclaration); ok && strings.HasPrefix(name, "#") {
+			// this.#count : un membre #privé ne fait pas partie de la forme
+			// de l'instance
+			return c.classMember(class, name[1:], false)
+		}
+		return nil
+	case *ClassType:
+		// Box.count : membre static
+		if member := c.classMember(t.Decl, strings.TrimPrefix(name, "#"), true); member != nil {
+			return member
+		}
+	case *Array:
+		return arrayMember(t.Elem, name)
+	case *Literal:
+		return c.property(t.Base, name)
+	case *Basic:
+		switch t {
+		case String:
+			return stringMember(name)
+		case Number:
+			return numberMember(name)
+		}
+	case *Union:
+		var types []Type
+		for _, member := range t.Types {
+			property := c.property(member, name)
+			if property == nil {
+				return nil
+			}
+			types = append(types, property)
+		}
+		return unionOf(types...)
+	case *TypeParam:
+		if t.Constraint != nil {
+			return c.property(t.Constraint, name)
+		}
+	}
+	return Any
+}
+
+// classMember renvoie le type du champ ou de la méthode name d'une classe,
+// static ou d'instance, ou nil
+func (c *checker) classMember(decl *ast.ClassDeclaration, name string, static bool) Type {
+	scope := c.info.ScopeOf(decl)
+	for i := range decl.Fields {
+		field := &decl.Fields[i]
+		if field.Name == name && field.IsStatic == static {
+			if t := c.resolve(field.Type, scope, nil); t != nil {
+				return t
+			}
+			return c.inferred(field.Default, false)
+		}
+	}
+	for i := range decl.Methods {
+		method := &decl.Methods[i]
+		if method.Name == name && method.IsStatic == static && method.Kind == ast.RegularMethod {
+			return c.signature(method)
+		}
+	}
+	return nil
+}
+
+// method renvoie la signature d'une méthode prédéfinie dont les arguments ne
+// sont pas vérifiés
+func method(result Type) *Signature {
+	return &Signature{Params: []Param{{Name: "args", Type: &Array{Elem: Any}, Rest: true}}, Return: result}
+}
+
+func arrayMember(elem Type, name string) Type {
+	switch name {
+	case "length":
+		return Number
+	case "push", "unshift":
+		return &Signature{Params: []Param{{Name: "items", Type: &Array{Elem: elem}, Rest: true}}, Return: Number}
+	case "pop", "shift":
+		return &Signature{Return: elem}
+	case "includes":
+		return &Signature{Params: []Param{{Name: "value", Type: elem}, {Name: "from", Type: Number, Optional: true}}, Return: Boolean}
+	case "indexOf", "lastIndexOf":
+		return &Signature{Params: []Param{{Name: "value", Type: elem}, {Name: "from", Type: Number, Optional: true}}, Return: Number}
+	case "join":
+		return &Signature{Params: []Param{{Name: "separator", Type: String, Optional: true}}, Return: String}
+	case "slice", "filter", "reverse", "sort", "concat":
+		return method(&Array{Elem: elem})
+	}
+	return Any
+}
+
+func stringMember(name string) Type {
+	switch name {
+	case "length":
+		return Number
+	case "toUpperCase", "toLowerCase", "trim", "trimStart", "trimEnd", "charAt", "substring",
+		"slice", "padStart", "padEnd", "repeat", "replace", "replaceAll", "concat":
+		return method(String)
+	case "includes", "startsWith", "endsWith":
+		return method(Boolean)
+	case "indexOf", "lastIndexOf", "charCodeAt":
+		return method(Number)
+	case "split":
+		return method(&Array{Elem: String})
+	}
+	return Any
+}
+
+func numberMember(name string) Type {
+	switch name {
+	case "toFixed", "toString", "toPrecision":
+		return method(String)
+	}
+	return Any
+}
+
+// instantiateCall remplace les paramètres de type d'une signature générique,
+// par les arguments de type écrits (identity<number>(5)) ou déduits des
+// arguments (identity(5)). Un paramètre qu'on ne déduit pas vaut any.
+func (c *checker) instantiateCall(fn *Signature, typeArgs []string, args []ast.Expression, scope *Scope) *Signature {
+	if len(fn.TypeParams) == 0 {
+		return fn
+	}
+	bindings := map[*TypeParam]Type{}
+	for _, param := range fn.TypeParams {
+		bindings[param] = nil
+	}
+	if len(typeArgs) > 0 {
+		for i, t := range c.typeArguments(typeArgs, scope) {
+			if i < len(fn.TypeParams) {
+				bindings[fn.TypeParams[i]] = t
+			}
+		}
+	} else {
+		for i, arg := range args {
+			if _, ok := arg.(*ast.SpreadElement); ok {
+				break
+			}
+			if param := paramAt(fn, i); param != nil {
+				c.infer(param, c.typeOf(arg), bindings)
+			}
+		}
+	}
+	for param, t := range bindings {
+		if t == nil {
+			bindings[param] = Any
+		}
+	}
+	instance := c.substitute(fn, bindings).(*Signature)
+	instance.TypeParams = nil
+	return instance
+}
+
+// paramAt renvoie le type attendu pour le i-ième argument d'un appel, ou nil
+// au-delà des paramètres
+func paramAt(fn *Signature, i int) Type {
+	if hasRest(fn) && i >= len(fn.Params)-1 {
+		return elementOf(fn.Params[len(fn.Params)-1].Type)
+	}
+	if i < len(fn.Params) {
+		return fn.Params[i].Type
+	}
+	return nil
+}
@@ -0,0 +1,313 @@
+package semantic
+
+import (
+	"ProjetGo/ast"
+	"strings"
+)
+
+// Type est un type TypeScript, tel que le vérificateur le comprend
+type Type interface {
+	String() string
+}
+
+// Basic est un type primitif, ou l'un des types spéciaux any, unknown, void
+// et never
+type Basic struct{ Name string }
+
+var (
+	Any       = &Basic{"any"}
+	Unknown   = &Basic{"unknown"}
+	Never     = &Basic{"never"}
+	Void      = &Basic{"void"}
+	Null      = &Basic{"null"}
+	Undefined = &Basic{"undefined"}
+	Number    = &Basic{"number"}
+	String    = &Basic{"string"}
+	Boolean   = &Basic{"boolean"}
+)
+
+var basicTypes = map[string]*Basic{
+	"any": Any, "unknown": Unknown, "never": Never, "void": Void,
+	"null": Null, "undefined": Undefined, "number": Number, "string": String,
+	"boolean": Boolean, "object": {"object"}, "bigint": {"bigint"}, "symbol": {"symbol"},
+}
+
+func (b *Basic) String() string { return b.Name }
+
+// Literal est le type d'une valeur précise : 'done', 42, true. Un littéral
+// écrit dans une expression est frais : let l'élargit à son type de base.
+type Literal struct {
+	Value string
+	Base  *Basic // String, Number ou Boolean
+	Fresh bool
+}
+
+func (l *Literal) String() string {
+	if l.Base == String {
+		return "'" + l.Value + "'"
+	}
+	return l.Value
+}
+
+// Array est un tableau : T[] ou Array<T>
+type Array struct{ Elem Type }
+
+func (a *Array) String() string {
+	switch elem := a.Elem.(type) {
+	case *Union:
+		if elem.Name == "" {
+			return "(" + a.Elem.String() + ")[]"
+		}
+	case *Signature:
+		return "(" + a.Elem.String() + ")[]"
+	}
+	return a.Elem.String() + "[]"
+}
+
+// Union est un type A | B ; unionOf la construit à plat et sans doublons.
+// Name est l'alias qui la déclare : type Status = 'on' | 'off'
+type Union struct {
+	Name  string
+	Types []Type
+}
+
+func (u *Union) String() string {
+	if u.Name != "" {
+		return u.Name
+	}
+	parts := make([]string, len(u.Types))
+	for i, t := range u.Types {
+		parts[i] = t.String()
+		if _, ok := t.(*Signature); ok {
+			parts[i] = "(" + parts[i] + ")"
+		}
+	}
+	return strings.Join(parts, " | ")
+}
+
+// Property est une propriété d'un type objet
+type Property struct {
+	Name     string
+	Type     Type
+	Optional bool
+}
+
+// Object est un type objet, comparé par sa structure : type littéral
+// { id: number }, interface, ou instance de classe. Un type nommé garde la
+// déclaration et les arguments dont il vient, pour être instancié à nouveau
+// quand ses paramètres de type sont remplacés.
+type Object struct {
+	Name   string // vide pour un type littéral
+	Fields []Property
+
+	origin ast.Node
+	args   []Type
+}
+
+func (o *Object) String() string {
+	if o.Name != "" {
+		return o.Name
+	}
+	if len(o.Fields) == 0 {
+		return "{}"
+	}
+	parts := make([]string, len(o.Fields))
+	for i, field := range o.Fields {
+		parts[i] = field.Name
+		if field.Optional {
+			parts[i] += "?"
+		}
+		parts[i] += ": " + field.Type.String()
+	}
+	return "{ " + strings.Join(parts, "; ") + " }"
+}
+
+// Field renvoie la propriété d'un nom, ou nil
+func (o *Object) Field(name string) *Property {
+	for i := range o.Fields {
+		if o.Fields[i].Name == name {
+			return &o.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Param est un paramètre d'un type fonction
+type Param struct {
+	Name     string
+	Type     Type
+	Optional bool // x?: T, ou valeur par défaut
+	Rest     bool // ...xs: T[]
+}
+
+// Signature est le type d'une fonction, d'une méthode ou d'une fonction fléchée
+type Signature struct {
+	TypeParams []*TypeParam // <T> d'une fonction générique
+	Params     []Param
+	Return     Type
+}
+
+func (f *Signature) String() string {
+	var sb strings.Builder
+	if len(f.TypeParams) > 0 {
+		names := make([]string, len(f.TypeParams))
+		for i, tp := range f.TypeParams {
+			names[i] = tp.Name
+		}
+		sb.WriteString("<" + strings.Join(names, ", ") + ">")
+	}
+	sb.WriteString("(")
+	for i, param := range f.Params {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if param.Rest {
+			sb.WriteString("...")
+		}
+		sb.WriteString(param.Name)
+		if param.Optional {
+			sb.WriteString("?")
+		}
+		sb.WriteString(": " + param.Type.String())
+	}
+	sb.WriteString(") => " + f.Return.String())
+	return sb.String()
+}
+
+// arity renvoie le nombre d'arguments obligatoires, et le nombre maximal, -1
+// avec un paramètre ...rest
+func (f *Signature) arity() (min, max int) {
+	for _, param := range f.Params {
+		if param.Rest {
+			return min, -1
+		}
+		if !param.Optional {
+			min = max + 1
+		}
+		max++
+	}
+	return min, max
+}
+
+// TypeParam est un paramètre de type : le T de function first<T>(xs: T[]): T
+type TypeParam struct {
+	Name       string
+	Constraint Type // type après extends, nil si absent
+}
+
+func (tp *TypeParam) String() string { return tp.Name }
+
+// EnumType est le type des membres d'une énumération
+type EnumType struct{ Name string }
+
+func (e *EnumType) String() string { return e.Name }
+
+// ClassType est la valeur d'une classe, qu'on instancie avec new
+type ClassType struct {
+	Decl *ast.ClassDeclaration
+}
+
+func (c *ClassType) String() string { return "typeof " + c.Decl.Name }
+
+// Reference est un type nommé que le fichier ne déclare pas : Promise<T>,
+// Map<K, V>, React.ReactNode... Il est compatible avec tout type, sauf une
+// autre instance du même nom dont les arguments diffèrent.
+type Reference struct {
+	Name string
+	Args []Type
+}
+
+func (r *Reference) String() string {
+	if len(r.Args) == 0 {
+		return r.Name
+	}
+	args := make([]string, len(r.Args))
+	for i, arg := range r.Args {
+		args[i] = arg.String()
+	}
+	return r.Name + "<" + strings.Join(args, ", ") + ">"
+}
+
+// unionOf construit l'union de types : les unions imbriquées sont mises à
+// plat, les doublons et never retirés, et any absorbe le reste
+func unionOf(types ...Type) Type {
+	var members []Type
+	seen := map[string]bool{}
+	var add func(t Type)
+	add = func(t Type) {
+		switch t := t.(type) {
+		case nil:
+		case *Union:
+			for _, member := range t.Types {
+				add(member)
+			}
+		default:
+			if t == Never || seen[t.String()] {
+				return
+			}
+			seen[t.String()] = true
+			members = append(members, t)
+		}
+	}
+	for _, t := range types {
+		add(t)
+		if t == Any {
+			return Any
+		}
+	}
+	switch len(members) {
+	case 0:
+		return Never
+	case 1:
+		return members[0]
+	}
+	return &Union{Types: members}
+}
+
+// widen élargit les littéraux frais d'un type à leur type de base, comme
+// let count = 0 donne number, jusque dans les tableaux et objets littéraux
+func widen(t Type) Type {
+	switch t := t.(type) {
+	case *Literal:
+		if t.Fresh {
+			return t.Base
+		}
+	case *Union:
+		if t.Name != "" {
+			return t
+		}
+		members := make([]Type, len(t.Types))
+		for i, member := range t.Types {
+			members[i] = widen(member)
+		}
+		return unionOf(members...)
+	case *Array:
+		return &Array{Elem: widen(t.Elem)}
+	case *Object:
+		if t.Name != "" {
+			return t
+		}
+		object := &Object{Fields: make([]Property, len(t.Fields))}
+		for i, field := range t.Fields {
+			field.Type = widen(field.Type)
+			object.Fields[i] = field
+		}
+		return object
+	}
+	return t
+}
+
+// withoutNullish retire null et undefined d'un type : x! ou x ?? y
+func withoutNullish(t Type) Type {
+	union, ok := t.(*Union)
+	if !ok {
+		return t
+	}
+	var members []Type
+	for _, member := range union.Types {
+		if member != Null && member != Undefined {
+			members = append(members, member)
+		}
+	}
+	return unionOf(members...)
+}
@@ -4,11 +4,13 @@ import (
 	"ProjetGo/generator"
 	"ProjetGo/lexer"
 	"ProjetGo/parser"
+	"ProjetGo/semantic"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"log"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -624,7 +626,7 @@ func handleHome(w http.ResponseWriter, r *http.Request) {
 			result.ParseTime = elapsed.String()
 
 			// Check for parsing errors
-			if len(program) == 0 {
+			if len(program.Statements) == 0 {
 				result.ErrorMessage = "Aucun code valide détecté. Vérifiez la syntaxe de votre code source."
 			} else {
 				// Génération dans tous les langages
@@ -651,8 +653,9 @@ func handleTranspile(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var request struct {
-		Code   string `json:"code"`
-		Target string `json:"target"`
+		Code     string `json:"code"`
+		Target   string `json:"target"`
+		FileName string `json:"fileName"` // facultatif ; un .tsx active le JSX
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
@@ -663,19 +666,24 @@ func handleTranspile(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
 
 	l := lexer.New(request.Code)
+	if strings.HasSuffix(request.FileName, ".tsx") {
+		l = lexer.NewJSX(request.Code)
+	}
 	p := parser.New(l)
 	program := p.ParseProgram()
+	program.FileName = request.FileName
 
 	elapsed := time.Since(start)
 
 	response := map[string]interface{}{
-		"success":   len(program) > 0,
+		"success":   len(program.Statements) > 0,
 		"parseTime": elapsed.String(),
 	}
 
-	if len(program) == 0 {
+	if len(program.Statements) == 0 {
 		response["error"] = "Aucun code valide détecté. Vérifiez la syntaxe."
 	} else {
+		response["diagnostics"] = diagnosticsJSON(semantic.Diagnose(program))
 		response["javascript"] = generator.Generate(program, generator.JavaScript)
 		response["java"] = generator.Generate(program, generator.Java)
 		response["python"] = generator.Generate(program, generator.Python)
@@ -684,12 +692,54 @@ func handleTranspile(w http.ResponseWriter, r *http.Request) {
 		response["rust"] = generator.Generate(program, generator.Rust)
 		response["swift"] = generator.Generate(program, generator.Swift)
 		response["php"] = generator.Generate(program, generator.PHP)
+
+		// Constructions qu'une cible n'exprime pas, par cible
+		targetDiagnostics := map[string]interface{}{}
+		for _, target := range []generator.TargetLanguage{generator.Java, generator.Python, generator.CSharp, generator.Go, generator.Rust, generator.Swift, generator.PHP} {
+			if diagnostics := generator.Diagnose(program, target); len(diagnostics) > 0 {
+				targetDiagnostics[string(target)] = targetDiagnosticsJSON(diagnostics)
+			}
+		}
+		response["targetDiagnostics"] = targetDiagnostics
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// diagnosticsJSON met les diagnostics sous la forme rendue par /transpile :
+// code, message, et position de début et de fin
+func diagnosticsJSON(diagnostics []semantic.Diagnostic) []map[string]interface{} {
+	list := []map[string]interface{}{}
+	for _, d := range diagnostics {
+		list = append(list, map[string]interface{}{
+			"code":      d.Code,
+			"message":   d.Message,
+			"line":      d.Pos.Line,
+			"column":    d.Pos.Column,
+			"endLine":   d.End.Line,
+			"endColumn": d.End.Column,
+		})
+	}
+	return list
+}
+
+// targetDiagnosticsJSON met les diagnostics d'une cible sous la même forme,
+// sans code
+func targetDiagnosticsJSON(diagnostics []generator.Diagnostic) []map[string]interface{} {
+	list := []map[string]interface{}{}
+	for _, d := range diagnostics {
+		list = append(list, map[string]interface{}{
+			"message":   d.Message,
+			"line":      d.Pos.Line,
+			"column":    d.Pos.Column,
+			"endLine":   d.End.Line,
+			"endColumn": d.End.Column,
+		})
+	}
+	return list
+}
+
 func StartWebServer() {
 	http.HandleFunc("/", handleHome)
 	http.HandleFunc("/transpile", handleTranspile)